@@ -9,21 +9,28 @@ import (
 
 // Update represents an incoming update.At most one of the optional parameters can be present in any given update.
 type Update struct {
-	UpdateId           int64               `json:"update_id"`                      // The update's unique identifier. Update identifiers start from a certain positive number and increase sequentially. This ID becomes especially handy if you're using webhooks, since it allows you to ignore repeated updates or to restore the correct update sequence, should they get out of order. If there are no new updates for at least a week, then identifier of the next update will be chosen randomly instead of sequentially.
-	Message            *Message            `json:"message,omitempty"`              // Optional. New incoming message of any kind - text, photo, sticker, etc.
-	EditedMessage      *Message            `json:"edited_message,omitempty"`       // Optional. New version of a message that is known to the bot and was edited
-	ChannelPost        *Message            `json:"channel_post,omitempty"`         // Optional. New incoming channel post of any kind - text, photo, sticker, etc.
-	EditedChannelPost  *Message            `json:"edited_channel_post,omitempty"`  // Optional. New version of a channel post that is known to the bot and was edited
-	InlineQuery        *InlineQuery        `json:"inline_query,omitempty"`         // Optional. New incoming inline query
-	ChosenInlineResult *ChosenInlineResult `json:"chosen_inline_result,omitempty"` // Optional. The result of an inline query that was chosen by a user and sent to their chat partner. Please see our documentation on the feedback collecting for details on how to enable these updates for your bot.
-	CallbackQuery      *CallbackQuery      `json:"callback_query,omitempty"`       // Optional. New incoming callback query
-	ShippingQuery      *ShippingQuery      `json:"shipping_query,omitempty"`       // Optional. New incoming shipping query. Only for invoices with flexible price
-	PreCheckoutQuery   *PreCheckoutQuery   `json:"pre_checkout_query,omitempty"`   // Optional. New incoming pre-checkout query. Contains full information about checkout
-	Poll               *Poll               `json:"poll,omitempty"`                 // Optional. New poll state. Bots receive only updates about stopped polls and polls, which are sent by the bot
-	PollAnswer         *PollAnswer         `json:"poll_answer,omitempty"`          // Optional. A user changed their answer in a non-anonymous poll. Bots receive new votes only in polls that were sent by the bot itself.
-	MyChatMember       *ChatMemberUpdated  `json:"my_chat_member,omitempty"`       // Optional. The bot's chat member status was updated in a chat. For private chats, this update is received only when the bot is blocked or unblocked by the user.
-	ChatMember         *ChatMemberUpdated  `json:"chat_member,omitempty"`          // Optional. A chat member's status was updated in a chat. The bot must be an administrator in the chat and must explicitly specify "chat_member" in the list of allowed_updates to receive these updates.
-	ChatJoinRequest    *ChatJoinRequest    `json:"chat_join_request,omitempty"`    // Optional. A request to join the chat has been sent. The bot must have the can_invite_users administrator right in the chat to receive these updates.
+	UpdateId                int64                        `json:"update_id"`                           // The update's unique identifier. Update identifiers start from a certain positive number and increase sequentially. This ID becomes especially handy if you're using webhooks, since it allows you to ignore repeated updates or to restore the correct update sequence, should they get out of order. If there are no new updates for at least a week, then identifier of the next update will be chosen randomly instead of sequentially.
+	Message                 *Message                     `json:"message,omitempty"`                   // Optional. New incoming message of any kind - text, photo, sticker, etc.
+	EditedMessage           *Message                     `json:"edited_message,omitempty"`            // Optional. New version of a message that is known to the bot and was edited
+	ChannelPost             *Message                     `json:"channel_post,omitempty"`              // Optional. New incoming channel post of any kind - text, photo, sticker, etc.
+	EditedChannelPost       *Message                     `json:"edited_channel_post,omitempty"`       // Optional. New version of a channel post that is known to the bot and was edited
+	BusinessConnection      *BusinessConnection          `json:"business_connection,omitempty"`       // Optional. The bot was connected to or disconnected from a business account, or a user edited an existing connection with the bot
+	BusinessMessage         *Message                     `json:"business_message,omitempty"`          // Optional. New message from a connected business account
+	EditedBusinessMessage   *Message                     `json:"edited_business_message,omitempty"`   // Optional. New version of a message from a connected business account
+	DeletedBusinessMessages *BusinessMessagesDeleted     `json:"deleted_business_messages,omitempty"` // Optional. Messages were deleted from a connected business account
+	MessageReaction         *MessageReactionUpdated      `json:"message_reaction,omitempty"`          // Optional. A reaction to a message was changed by a user. The bot must be an administrator in the chat and must explicitly specify "message_reaction" in the list of allowed_updates to receive these updates. The update isn't received for reactions set by bots.
+	MessageReactionCount    *MessageReactionCountUpdated `json:"message_reaction_count,omitempty"`    // Optional. Reactions to a message with anonymous reactions were changed. The bot must be an administrator in the chat and must explicitly specify "message_reaction_count" in the list of allowed_updates to receive these updates. The updates are grouped and can be sent with delay up to a few minutes.
+	InlineQuery             *InlineQuery                 `json:"inline_query,omitempty"`              // Optional. New incoming inline query
+	ChosenInlineResult      *ChosenInlineResult          `json:"chosen_inline_result,omitempty"`      // Optional. The result of an inline query that was chosen by a user and sent to their chat partner. Please see our documentation on the feedback collecting for details on how to enable these updates for your bot.
+	CallbackQuery           *CallbackQuery               `json:"callback_query,omitempty"`            // Optional. New incoming callback query
+	ShippingQuery           *ShippingQuery               `json:"shipping_query,omitempty"`            // Optional. New incoming shipping query. Only for invoices with flexible price
+	PreCheckoutQuery        *PreCheckoutQuery            `json:"pre_checkout_query,omitempty"`        // Optional. New incoming pre-checkout query. Contains full information about checkout
+	PurchasedPaidMedia      *PaidMediaPurchased          `json:"purchased_paid_media,omitempty"`      // Optional. A user purchased paid media with a non-empty payload sent by the bot in a non-channel chat
+	Poll                    *Poll                        `json:"poll,omitempty"`                      // Optional. New poll state. Bots receive only updates about stopped polls and polls, which are sent by the bot
+	PollAnswer              *PollAnswer                  `json:"poll_answer,omitempty"`               // Optional. A user changed their answer in a non-anonymous poll. Bots receive new votes only in polls that were sent by the bot itself.
+	MyChatMember            *ChatMemberUpdated           `json:"my_chat_member,omitempty"`            // Optional. The bot's chat member status was updated in a chat. For private chats, this update is received only when the bot is blocked or unblocked by the user.
+	ChatMember              *ChatMemberUpdated           `json:"chat_member,omitempty"`               // Optional. A chat member's status was updated in a chat. The bot must be an administrator in the chat and must explicitly specify "chat_member" in the list of allowed_updates to receive these updates.
+	ChatJoinRequest         *ChatJoinRequest             `json:"chat_join_request,omitempty"`         // Optional. A request to join the chat has been sent. The bot must have the can_invite_users administrator right in the chat to receive these updates.
 }
 
 // getUpdates is used to receive incoming updates using long polling (wiki). Returns an Array of Update objects.
@@ -161,36 +168,65 @@ type User struct {
 }
 
 // Chat represents a chat.
+
 type Chat struct {
-	Id                                 int64            `json:"id"`                                                // Unique identifier for this chat. This number may have more than 32 significant bits and some programming languages may have difficulty/silent defects in interpreting it. But it has at most 52 significant bits, so a signed 64-bit integer or double-precision float type are safe for storing this identifier.
-	Type                               string           `json:"type"`                                              // Type of chat, can be either “private”, “group”, “supergroup” or “channel”
-	Title                              string           `json:"title,omitempty"`                                   // Optional. Title, for supergroups, channels and group chats
-	Username                           string           `json:"username,omitempty"`                                // Optional. Username, for private chats, supergroups and channels if available
-	FirstName                          string           `json:"first_name,omitempty"`                              // Optional. First name of the other party in a private chat
-	LastName                           string           `json:"last_name,omitempty"`                               // Optional. Last name of the other party in a private chat
-	IsForum                            bool             `json:"is_forum,omitempty"`                                // Optional. True, if the supergroup chat is a forum (has topics enabled)
-	Photo                              *ChatPhoto       `json:"photo,omitempty"`                                   // Optional. Chat photo. Returned only in getChat.
-	ActiveUsernames                    []string         `json:"active_usernames,omitempty"`                        // Optional. If non-empty, the list of all active chat usernames; for private chats, supergroups and channels. Returned only in getChat.
-	EmojiStatusCustomEmojiId           string           `json:"emoji_status_custom_emoji_id,omitempty"`            // Optional. Custom emoji identifier of emoji status of the other party in a private chat. Returned only in getChat.
-	EmojiStatusExpirationDate          int64            `json:"emoji_status_expiration_date,omitempty"`            // Optional. Expiration date of the emoji status of the other party in a private chat in Unix time, if any. Returned only in getChat.
-	Bio                                string           `json:"bio,omitempty"`                                     // Optional. Bio of the other party in a private chat. Returned only in getChat.
-	HasPrivateForwards                 bool             `json:"has_private_forwards,omitempty"`                    // Optional. True, if privacy settings of the other party in the private chat allows to use tg://user?id=<user_id> links only in chats with the user. Returned only in getChat.
-	HasRestrictedVoiceAndVideoMessages bool             `json:"has_restricted_voice_and_video_messages,omitempty"` // Optional. True, if the privacy settings of the other party restrict sending voice and video note messages in the private chat. Returned only in getChat.
-	JoinToSendMessages                 bool             `json:"join_to_send_messages,omitempty"`                   // Optional. True, if users need to join the supergroup before they can send messages. Returned only in getChat.
-	JoinByRequest                      bool             `json:"join_by_request,omitempty"`                         // Optional. True, if all users directly joining the supergroup need to be approved by supergroup administrators. Returned only in getChat.
-	Description                        string           `json:"description,omitempty"`                             // Optional. Description, for groups, supergroups and channel chats. Returned only in getChat.
-	InviteLink                         string           `json:"invite_link,omitempty"`                             // Optional. Primary invite link, for groups, supergroups and channel chats. Returned only in getChat.
-	PinnedMessage                      *Message         `json:"pinned_message,omitempty"`                          // Optional. The most recent pinned message (by sending date). Returned only in getChat.
-	Permissions                        *ChatPermissions `json:"permissions,omitempty"`                             // Optional. Default chat member permissions, for groups and supergroups. Returned only in getChat.
-	SlowModeDelay                      int64            `json:"slow_mode_delay,omitempty"`                         // Optional. For supergroups, the minimum allowed delay between consecutive messages sent by each unpriviledged user; in seconds. Returned only in getChat.
-	MessageAutoDeleteTime              int64            `json:"message_auto_delete_time,omitempty"`                // Optional. The time after which all messages sent to the chat will be automatically deleted; in seconds. Returned only in getChat.
-	HasAggressiveAntiSpamEnabled       bool             `json:"has_aggressive_anti_spam_enabled,omitempty"`        // Optional. True, if aggressive anti-spam checks are enabled in the supergroup. The field is only available to chat administrators. Returned only in getChat.
-	HasHiddenMembers                   bool             `json:"has_hidden_members,omitempty"`                      // Optional. True, if non-administrators can only get the list of bots and administrators in the chat. Returned only in getChat.
-	HasProtectedContent                bool             `json:"has_protected_content,omitempty"`                   // Optional. True, if messages from the chat can't be forwarded to other chats. Returned only in getChat.
-	StickerSetName                     string           `json:"sticker_set_name,omitempty"`                        // Optional. For supergroups, name of group sticker set. Returned only in getChat.
-	CanSetStickerSet                   bool             `json:"can_set_sticker_set,omitempty"`                     // Optional. True, if the bot can change the group sticker set. Returned only in getChat.
-	LinkedChatId                       int64            `json:"linked_chat_id,omitempty"`                          // Optional. Unique identifier for the linked chat, i.e. the discussion group identifier for a channel and vice versa; for supergroups and channel chats. This identifier may be greater than 32 bits and some programming languages may have difficulty/silent defects in interpreting it. But it is smaller than 52 bits, so a signed 64 bit integer or double-precision float type are safe for storing this identifier. Returned only in getChat.
-	Location                           *ChatLocation    `json:"location,omitempty"`                                // Optional. For supergroups, the location to which the supergroup is connected. Returned only in getChat.
+	Id                                 int64                 `json:"id"`                                                // Unique identifier for this chat. This number may have more than 32 significant bits and some programming languages may have difficulty/silent defects in interpreting it. But it has at most 52 significant bits, so a signed 64-bit integer or double-precision float type are safe for storing this identifier.
+	Type                               string                `json:"type"`                                              // Type of chat, can be either “private”, “group”, “supergroup” or “channel”
+	Title                              string                `json:"title,omitempty"`                                   // Optional. Title, for supergroups, channels and group chats
+	Username                           string                `json:"username,omitempty"`                                // Optional. Username, for private chats, supergroups and channels if available
+	FirstName                          string                `json:"first_name,omitempty"`                              // Optional. First name of the other party in a private chat
+	LastName                           string                `json:"last_name,omitempty"`                               // Optional. Last name of the other party in a private chat
+	IsForum                            bool                  `json:"is_forum,omitempty"`                                // Optional. True, if the supergroup chat is a forum (has topics enabled)
+	Photo                              *ChatPhoto            `json:"photo,omitempty"`                                   // Optional. Chat photo. Returned only in getChat.
+	ActiveUsernames                    []string              `json:"active_usernames,omitempty"`                        // Optional. If non-empty, the list of all active chat usernames; for private chats, supergroups and channels. Returned only in getChat.
+	BusinessIntro                      *BusinessIntro        `json:"business_intro,omitempty"`                          // Optional. For private chats with business accounts, the intro of the business. Returned only in getChat.
+	BusinessLocation                   *BusinessLocation     `json:"business_location,omitempty"`                       // Optional. For private chats with business accounts, the location of the business. Returned only in getChat.
+	BusinessOpeningHours               *BusinessOpeningHours `json:"business_opening_hours,omitempty"`                  // Optional. For private chats with business accounts, the opening hours of the business. Returned only in getChat.
+	EmojiStatusCustomEmojiId           string                `json:"emoji_status_custom_emoji_id,omitempty"`            // Optional. Custom emoji identifier of emoji status of the other party in a private chat. Returned only in getChat.
+	EmojiStatusExpirationDate          int64                 `json:"emoji_status_expiration_date,omitempty"`            // Optional. Expiration date of the emoji status of the other party in a private chat in Unix time, if any. Returned only in getChat.
+	Bio                                string                `json:"bio,omitempty"`                                     // Optional. Bio of the other party in a private chat. Returned only in getChat.
+	HasPrivateForwards                 bool                  `json:"has_private_forwards,omitempty"`                    // Optional. True, if privacy settings of the other party in the private chat allows to use tg://user?id=<user_id> links only in chats with the user. Returned only in getChat.
+	HasRestrictedVoiceAndVideoMessages bool                  `json:"has_restricted_voice_and_video_messages,omitempty"` // Optional. True, if the privacy settings of the other party restrict sending voice and video note messages in the private chat. Returned only in getChat.
+	JoinToSendMessages                 bool                  `json:"join_to_send_messages,omitempty"`                   // Optional. True, if users need to join the supergroup before they can send messages. Returned only in getChat.
+	JoinByRequest                      bool                  `json:"join_by_request,omitempty"`                         // Optional. True, if all users directly joining the supergroup need to be approved by supergroup administrators. Returned only in getChat.
+	Description                        string                `json:"description,omitempty"`                             // Optional. Description, for groups, supergroups and channel chats. Returned only in getChat.
+	InviteLink                         string                `json:"invite_link,omitempty"`                             // Optional. Primary invite link, for groups, supergroups and channel chats. Returned only in getChat.
+	PinnedMessage                      *Message              `json:"pinned_message,omitempty"`                          // Optional. The most recent pinned message (by sending date). Returned only in getChat.
+	Permissions                        *ChatPermissions      `json:"permissions,omitempty"`                             // Optional. Default chat member permissions, for groups and supergroups. Returned only in getChat.
+	SlowModeDelay                      int64                 `json:"slow_mode_delay,omitempty"`                         // Optional. For supergroups, the minimum allowed delay between consecutive messages sent by each unpriviledged user; in seconds. Returned only in getChat.
+	MessageAutoDeleteTime              int64                 `json:"message_auto_delete_time,omitempty"`                // Optional. The time after which all messages sent to the chat will be automatically deleted; in seconds. Returned only in getChat.
+	HasAggressiveAntiSpamEnabled       bool                  `json:"has_aggressive_anti_spam_enabled,omitempty"`        // Optional. True, if aggressive anti-spam checks are enabled in the supergroup. The field is only available to chat administrators. Returned only in getChat.
+	HasHiddenMembers                   bool                  `json:"has_hidden_members,omitempty"`                      // Optional. True, if non-administrators can only get the list of bots and administrators in the chat. Returned only in getChat.
+	HasProtectedContent                bool                  `json:"has_protected_content,omitempty"`                   // Optional. True, if messages from the chat can't be forwarded to other chats. Returned only in getChat.
+	StickerSetName                     string                `json:"sticker_set_name,omitempty"`                        // Optional. For supergroups, name of group sticker set. Returned only in getChat.
+	CanSetStickerSet                   bool                  `json:"can_set_sticker_set,omitempty"`                     // Optional. True, if the bot can change the group sticker set. Returned only in getChat.
+	LinkedChatId                       int64                 `json:"linked_chat_id,omitempty"`                          // Optional. Unique identifier for the linked chat, i.e. the discussion group identifier for a channel and vice versa; for supergroups and channel chats. This identifier may be greater than 32 bits and some programming languages may have difficulty/silent defects in interpreting it. But it is smaller than 52 bits, so a signed 64 bit integer or double-precision float type are safe for storing this identifier. Returned only in getChat.
+	Location                           *ChatLocation         `json:"location,omitempty"`                                // Optional. For supergroups, the location to which the supergroup is connected. Returned only in getChat.
+}
+
+// BusinessIntro contains information about the start page settings of a Telegram Business account.
+type BusinessIntro struct {
+	Title   string   `json:"title,omitempty"`   // Optional. Title text of the business intro
+	Message string   `json:"message,omitempty"` // Optional. Message text of the business intro
+	Sticker *Sticker `json:"sticker,omitempty"` // Optional. Sticker of the business intro
+}
+
+// BusinessLocation contains information about the location of a Telegram Business account.
+type BusinessLocation struct {
+	Address  string    `json:"address"`            // Address of the business
+	Location *Location `json:"location,omitempty"` // Optional. Location of the business
+}
+
+// BusinessOpeningHoursInterval describes an interval of time during which a business is open.
+type BusinessOpeningHoursInterval struct {
+	OpeningMinute int64 `json:"opening_minute"` // The minute's sequence number in a week, starting on Monday, marking the start of the time interval during which the business is open; 0 - 7 * 24 * 60
+	ClosingMinute int64 `json:"closing_minute"` // The minute's sequence number in a week, starting on Monday, marking the end of the time interval during which the business is open; 0 - 8 * 24 * 60
+}
+
+// BusinessOpeningHours describes the opening hours of a business.
+type BusinessOpeningHours struct {
+	TimeZoneName string                          `json:"time_zone_name"` // Unique name of the time zone for which the opening hours are defined
+	OpeningHours []*BusinessOpeningHoursInterval `json:"opening_hours"`  // List of time intervals describing business opening hours
 }
 
 // Message represents a message.
@@ -199,7 +235,9 @@ type Message struct {
 	MessageThreadId               int64                          `json:"message_thread_id,omitempty"`                 // Optional. Unique identifier of a message thread to which the message belongs; for supergroups only
 	From                          *User                          `json:"from,omitempty"`                              // Optional. Sender of the message; empty for messages sent to channels. For backward compatibility, the field contains a fake sender user in non-channel chats, if the message was sent on behalf of a chat.
 	SenderChat                    *Chat                          `json:"sender_chat,omitempty"`                       // Optional. Sender of the message, sent on behalf of a chat. For example, the channel itself for channel posts, the supergroup itself for messages from anonymous group administrators, the linked channel for messages automatically forwarded to the discussion group. For backward compatibility, the field from contains a fake sender user in non-channel chats, if the message was sent on behalf of a chat.
+	SenderBusinessBot             *User                          `json:"sender_business_bot,omitempty"`               // Optional. The bot that actually sent the message on behalf of the business account. Available only for outgoing messages sent on behalf of the connected business account.
 	Date                          int64                          `json:"date"`                                        // Date the message was sent in Unix time
+	BusinessConnectionId          string                         `json:"business_connection_id,omitempty"`            // Optional. Unique identifier of the business connection from which the message was received. If non-empty, the message belongs to a chat of the corresponding business account that is independent from any potential bot chat which might share the same identifier.
 	Chat                          Chat                           `json:"chat"`                                        // Conversation the message belongs to
 	ForwardFrom                   *User                          `json:"forward_from,omitempty"`                      // Optional. For forwarded messages, sender of the original message
 	ForwardFromChat               *Chat                          `json:"forward_from_chat,omitempty"`                 // Optional. For messages forwarded from channels or from anonymous administrators, information about the original sender chat
@@ -210,6 +248,8 @@ type Message struct {
 	IsTopicMessage                bool                           `json:"is_topic_message,omitempty"`                  // Optional. True, if the message is sent to a forum topic
 	IsAutomaticForward            bool                           `json:"is_automatic_forward,omitempty"`              // Optional. True, if the message is a channel post that was automatically forwarded to the connected discussion group
 	ReplyToMessage                *Message                       `json:"reply_to_message,omitempty"`                  // Optional. For replies, the original message. Note that the Message object in this field will not contain further reply_to_message fields even if it itself is a reply.
+	ExternalReply                 *ExternalReplyInfo             `json:"external_reply,omitempty"`                    // Optional. Information about the message that is being replied to, which may come from another chat or forum topic
+	Quote                         *TextQuote                     `json:"quote,omitempty"`                             // Optional. For replies that quote part of the original message, the quoted part of the message
 	ViaBot                        *User                          `json:"via_bot,omitempty"`                           // Optional. Bot through which the message was sent
 	EditDate                      int64                          `json:"edit_date,omitempty"`                         // Optional. Date the message was last edited in Unix time
 	HasProtectedContent           bool                           `json:"has_protected_content,omitempty"`             // Optional. True, if the message can't be forwarded
@@ -217,12 +257,15 @@ type Message struct {
 	AuthorSignature               string                         `json:"author_signature,omitempty"`                  // Optional. Signature of the post author for messages in channels, or the custom title of an anonymous group administrator
 	Text                          string                         `json:"text,omitempty"`                              // Optional. For text messages, the actual UTF-8 text of the message
 	Entities                      []*MessageEntity               `json:"entities,omitempty"`                          // Optional. For text messages, special entities like usernames, URLs, bot commands, etc. that appear in the text
+	LinkPreviewOptions            *LinkPreviewOptions            `json:"link_preview_options,omitempty"`              // Optional. Options used for link preview generation for the message, if it is a text message and link preview options were changed
+	EffectId                      string                         `json:"effect_id,omitempty"`                         // Optional. Unique identifier of the message effect added to the message
 	Animation                     *Animation                     `json:"animation,omitempty"`                         // Optional. Message is an animation, information about the animation. For backward compatibility, when this field is set, the document field will also be set
 	Audio                         *Audio                         `json:"audio,omitempty"`                             // Optional. Message is an audio file, information about the file
 	Document                      *Document                      `json:"document,omitempty"`                          // Optional. Message is a general file, information about the file
 	Photo                         []*PhotoSize                   `json:"photo,omitempty"`                             // Optional. Message is a photo, available sizes of the photo
 	Sticker                       *Sticker                       `json:"sticker,omitempty"`                           // Optional. Message is a sticker, information about the sticker
 	Story                         *Story                         `json:"story,omitempty"`                             // Optional. Message is a forwarded story
+	PaidMedia                     *PaidMediaInfo                 `json:"paid_media,omitempty"`                        // Optional. Message contains paid media; information about the paid media
 	Video                         *Video                         `json:"video,omitempty"`                             // Optional. Message is a video, information about the video
 	VideoNote                     *VideoNote                     `json:"video_note,omitempty"`                        // Optional. Message is a video note, information about the video message
 	Voice                         *Voice                         `json:"voice,omitempty"`                             // Optional. Message is a voice message, information about the file
@@ -269,11 +312,284 @@ type Message struct {
 	ReplyMarkup                   *InlineKeyboardMarkup          `json:"reply_markup,omitempty"`                      // Optional. Inline keyboard attached to the message. login_url buttons are represented as ordinary url buttons.
 }
 
+// TextQuote contains information about the quoted part of a message that is replied to by the given message.
+type TextQuote struct {
+	Text     string           `json:"text"`                // Text of the quoted part of a message that is replied to by the given message
+	Entities []*MessageEntity `json:"entities,omitempty"`  // Optional. Special entities that appear in the quote. Currently, only bold, italic, underline, strikethrough, spoiler, and custom_emoji entities are kept in quotes.
+	Position int64            `json:"position"`            // Approximate quote position in the original message in UTF-16 code units as specified by the sender
+	IsManual bool             `json:"is_manual,omitempty"` // Optional. True, if the quote was chosen manually by the message sender. Otherwise, the quote was added automatically by the server.
+}
+
+// ExternalReplyInfo contains information about a message that is being replied to, which may come from another chat or forum topic.
+type ExternalReplyInfo struct {
+	Origin          MessageOrigin `json:"origin"`                      // Origin of the message replied to by the given message
+	Chat            *Chat         `json:"chat,omitempty"`              // Optional. Chat the original message belongs to. Available only if the chat is a supergroup or a channel.
+	MessageId       int64         `json:"message_id,omitempty"`        // Optional. Unique message identifier inside the original chat. Available only if the original chat is a supergroup or a channel.
+	Animation       *Animation    `json:"animation,omitempty"`         // Optional. Message is an animation, information about the animation
+	Audio           *Audio        `json:"audio,omitempty"`             // Optional. Message is an audio file, information about the file
+	Document        *Document     `json:"document,omitempty"`          // Optional. Message is a general file, information about the file
+	Photo           []*PhotoSize  `json:"photo,omitempty"`             // Optional. Message is a photo, available sizes of the photo
+	Sticker         *Sticker      `json:"sticker,omitempty"`           // Optional. Message is a sticker, information about the sticker
+	Story           *Story        `json:"story,omitempty"`             // Optional. Message is a forwarded story
+	Video           *Video        `json:"video,omitempty"`             // Optional. Message is a video, information about the video
+	VideoNote       *VideoNote    `json:"video_note,omitempty"`        // Optional. Message is a video note, information about the video message
+	Voice           *Voice        `json:"voice,omitempty"`             // Optional. Message is a voice message, information about the file
+	HasMediaSpoiler bool          `json:"has_media_spoiler,omitempty"` // Optional. True, if the message media is covered by a spoiler animation
+	Contact         *Contact      `json:"contact,omitempty"`           // Optional. Message is a shared contact, information about the contact
+	Dice            *Dice         `json:"dice,omitempty"`              // Optional. Message is a dice with random value
+	Game            *Game         `json:"game,omitempty"`              // Optional. Message is a game, information about the game. More about games »
+	Invoice         *Invoice      `json:"invoice,omitempty"`           // Optional. Message is an invoice for a payment, information about the invoice. More about payments »
+	Location        *Location     `json:"location,omitempty"`          // Optional. Message is a shared location, information about the location
+	Poll            *Poll         `json:"poll,omitempty"`              // Optional. Message is a native poll, information about the poll
+	Venue           *Venue        `json:"venue,omitempty"`             // Optional. Message is a venue, information about the venue
+}
+
+func (x *ExternalReplyInfo) UnmarshalJSON(rawBytes []byte) (err error) {
+	if len(rawBytes) == 0 {
+		return nil
+	}
+
+	type temp struct {
+		Origin          json.RawMessage `json:"origin"`                      // Origin of the message replied to by the given message
+		Chat            *Chat           `json:"chat,omitempty"`              // Optional. Chat the original message belongs to. Available only if the chat is a supergroup or a channel.
+		MessageId       int64           `json:"message_id,omitempty"`        // Optional. Unique message identifier inside the original chat. Available only if the original chat is a supergroup or a channel.
+		Animation       *Animation      `json:"animation,omitempty"`         // Optional. Message is an animation, information about the animation
+		Audio           *Audio          `json:"audio,omitempty"`             // Optional. Message is an audio file, information about the file
+		Document        *Document       `json:"document,omitempty"`          // Optional. Message is a general file, information about the file
+		Photo           []*PhotoSize    `json:"photo,omitempty"`             // Optional. Message is a photo, available sizes of the photo
+		Sticker         *Sticker        `json:"sticker,omitempty"`           // Optional. Message is a sticker, information about the sticker
+		Story           *Story          `json:"story,omitempty"`             // Optional. Message is a forwarded story
+		Video           *Video          `json:"video,omitempty"`             // Optional. Message is a video, information about the video
+		VideoNote       *VideoNote      `json:"video_note,omitempty"`        // Optional. Message is a video note, information about the video message
+		Voice           *Voice          `json:"voice,omitempty"`             // Optional. Message is a voice message, information about the file
+		HasMediaSpoiler bool            `json:"has_media_spoiler,omitempty"` // Optional. True, if the message media is covered by a spoiler animation
+		Contact         *Contact        `json:"contact,omitempty"`           // Optional. Message is a shared contact, information about the contact
+		Dice            *Dice           `json:"dice,omitempty"`              // Optional. Message is a dice with random value
+		Game            *Game           `json:"game,omitempty"`              // Optional. Message is a game, information about the game. More about games »
+		Invoice         *Invoice        `json:"invoice,omitempty"`           // Optional. Message is an invoice for a payment, information about the invoice. More about payments »
+		Location        *Location       `json:"location,omitempty"`          // Optional. Message is a shared location, information about the location
+		Poll            *Poll           `json:"poll,omitempty"`              // Optional. Message is a native poll, information about the poll
+		Venue           *Venue          `json:"venue,omitempty"`             // Optional. Message is a venue, information about the venue
+	}
+	raw := &temp{}
+
+	if err = json.Unmarshal(rawBytes, raw); err != nil {
+		return err
+	}
+
+	if data, err := unmarshalMessageOrigin(raw.Origin); err != nil {
+		return err
+	} else {
+		x.Origin = data
+	}
+
+	x.Chat = raw.Chat
+	x.MessageId = raw.MessageId
+	x.Animation = raw.Animation
+	x.Audio = raw.Audio
+	x.Document = raw.Document
+	x.Photo = raw.Photo
+	x.Sticker = raw.Sticker
+	x.Story = raw.Story
+	x.Video = raw.Video
+	x.VideoNote = raw.VideoNote
+	x.Voice = raw.Voice
+	x.HasMediaSpoiler = raw.HasMediaSpoiler
+	x.Contact = raw.Contact
+	x.Dice = raw.Dice
+	x.Game = raw.Game
+	x.Invoice = raw.Invoice
+	x.Location = raw.Location
+	x.Poll = raw.Poll
+	x.Venue = raw.Venue
+	return nil
+}
+
+// MessageOrigin describes the origin of a message. It can be one of
+//   - MessageOriginUser
+//   - MessageOriginHiddenUser
+//   - MessageOriginChat
+//   - MessageOriginChannel
+type MessageOrigin interface {
+	// IsMessageOrigin does nothing and is only used to enforce type-safety
+	IsMessageOrigin()
+}
+
+// The message was originally sent by a known user.
+type MessageOriginUser struct {
+	Type       string `json:"type"`        // Type of the message origin, always “user”
+	Date       int64  `json:"date"`        // Date the message was sent originally in Unix time
+	SenderUser User   `json:"sender_user"` // User that sent the message originally
+}
+
+func (MessageOriginUser) IsMessageOrigin() {}
+
+// The message was originally sent by an unknown user.
+type MessageOriginHiddenUser struct {
+	Type           string `json:"type"`             // Type of the message origin, always “hidden_user”
+	Date           int64  `json:"date"`             // Date the message was sent originally in Unix time
+	SenderUserName string `json:"sender_user_name"` // Name of the user that sent the message originally
+}
+
+func (MessageOriginHiddenUser) IsMessageOrigin() {}
+
+// The message was originally sent on behalf of a chat to a group chat.
+type MessageOriginChat struct {
+	Type            string `json:"type"`                       // Type of the message origin, always “chat”
+	Date            int64  `json:"date"`                       // Date the message was sent originally in Unix time
+	SenderChat      Chat   `json:"sender_chat"`                // Chat that sent the message originally
+	AuthorSignature string `json:"author_signature,omitempty"` // Optional. For messages originally sent by an anonymous chat administrator, original message author signature
+}
+
+func (MessageOriginChat) IsMessageOrigin() {}
+
+// The message was originally sent to a channel chat.
+type MessageOriginChannel struct {
+	Type            string `json:"type"`                       // Type of the message origin, always “channel”
+	Date            int64  `json:"date"`                       // Date the message was sent originally in Unix time
+	Chat            Chat   `json:"chat"`                       // Channel chat to which the message was originally sent
+	MessageId       int64  `json:"message_id"`                 // Unique message identifier inside the chat
+	AuthorSignature string `json:"author_signature,omitempty"` // Optional. Signature of the original post author
+}
+
+func (MessageOriginChannel) IsMessageOrigin() {}
+
+// ReactionType describes the type of a reaction. It can be one of
+//   - ReactionTypeEmoji
+//   - ReactionTypeCustomEmoji
+//   - ReactionTypePaid
+type ReactionType interface {
+	// IsReactionType does nothing and is only used to enforce type-safety
+	IsReactionType()
+}
+
+// The reaction is based on an emoji.
+type ReactionTypeEmoji struct {
+	Type  string `json:"type"`  // Type of the reaction, always “emoji”
+	Emoji string `json:"emoji"` // Reaction emoji. Currently, it can be one of "👍", "👎", "❤", "🔥", "🥰", "👏", "😁", "🤔", "🤯", "😱", "🤬", "😢", "🎉", "🤩", "🤮", "💩", "🙏", "👌", "🕊", "🤡", "🥱", "🥴", "😍", "🐳", "❤‍🔥", "🌚", "🌭", "💯", "🤣", "⚡", "🍌", "🏆", "💔", "🤨", "😐", "🍓", "🍾", "💋", "🖕", "😈", "😴", "😭", "🤓", "👻", "👨‍💻", "👀", "🎃", "🙈", "😇", "😨", "🤝", "✍", "🤗", "🫡", "🎅", "🎄", "☃", "💅", "🤪", "🗿", "🆒", "💘", "🙉", "🦄", "😘", "💊", "🙊", "😎", "👾", "🤷‍♂", "🤷", "🤷‍♀", "😡"
+}
+
+func (ReactionTypeEmoji) IsReactionType() {}
+
+// The reaction is based on a custom emoji.
+type ReactionTypeCustomEmoji struct {
+	Type          string `json:"type"`            // Type of the reaction, always “custom_emoji”
+	CustomEmojiId string `json:"custom_emoji_id"` // Custom emoji identifier
+}
+
+func (ReactionTypeCustomEmoji) IsReactionType() {}
+
+// The reaction is paid.
+type ReactionTypePaid struct {
+	Type string `json:"type"` // Type of the reaction, always “paid”
+}
+
+func (ReactionTypePaid) IsReactionType() {}
+
+// Represents a reaction added to a message along with the number of times it was added.
+type ReactionCount struct {
+	Type       ReactionType `json:"type"`        // Type of the reaction
+	TotalCount int64        `json:"total_count"` // Number of times the reaction was added
+}
+
+func (x *ReactionCount) UnmarshalJSON(rawBytes []byte) (err error) {
+	if len(rawBytes) == 0 {
+		return nil
+	}
+
+	type temp struct {
+		Type       json.RawMessage `json:"type"`        // Type of the reaction
+		TotalCount int64           `json:"total_count"` // Number of times the reaction was added
+	}
+	raw := &temp{}
+
+	if err = json.Unmarshal(rawBytes, raw); err != nil {
+		return err
+	}
+
+	if data, err := unmarshalReactionType(raw.Type); err != nil {
+		return err
+	} else {
+		x.Type = data
+	}
+
+	x.TotalCount = raw.TotalCount
+	return nil
+}
+
+// MessageReactionUpdated represents a change of a reaction on a message performed by a user.
+type MessageReactionUpdated struct {
+	Chat        Chat           `json:"chat"`                 // The chat containing the message the user reacted to
+	MessageId   int64          `json:"message_id"`           // Unique identifier of the message inside the chat
+	User        *User          `json:"user,omitempty"`       // Optional. The user that changed the reaction, if the user isn't anonymous
+	ActorChat   *Chat          `json:"actor_chat,omitempty"` // Optional. The chat on behalf of which the reaction was changed, if the user is anonymous
+	Date        int64          `json:"date"`                 // Date of the change in Unix time
+	OldReaction []ReactionType `json:"old_reaction"`         // Previous list of reaction types that were set by the user
+	NewReaction []ReactionType `json:"new_reaction"`         // New list of reaction types that have been set by the user
+}
+
+func (x *MessageReactionUpdated) UnmarshalJSON(rawBytes []byte) (err error) {
+	if len(rawBytes) == 0 {
+		return nil
+	}
+
+	type temp struct {
+		Chat        Chat              `json:"chat"`                 // The chat containing the message the user reacted to
+		MessageId   int64             `json:"message_id"`           // Unique identifier of the message inside the chat
+		User        *User             `json:"user,omitempty"`       // Optional. The user that changed the reaction, if the user isn't anonymous
+		ActorChat   *Chat             `json:"actor_chat,omitempty"` // Optional. The chat on behalf of which the reaction was changed, if the user is anonymous
+		Date        int64             `json:"date"`                 // Date of the change in Unix time
+		OldReaction []json.RawMessage `json:"old_reaction"`         // Previous list of reaction types that were set by the user
+		NewReaction []json.RawMessage `json:"new_reaction"`         // New list of reaction types that have been set by the user
+	}
+	raw := &temp{}
+
+	if err = json.Unmarshal(rawBytes, raw); err != nil {
+		return err
+	}
+
+	if data, err := unmarshalArray(raw.OldReaction, unmarshalReactionType); err != nil {
+		return err
+	} else {
+		x.OldReaction = data
+	}
+
+	if data, err := unmarshalArray(raw.NewReaction, unmarshalReactionType); err != nil {
+		return err
+	} else {
+		x.NewReaction = data
+	}
+
+	x.Chat = raw.Chat
+	x.MessageId = raw.MessageId
+	x.User = raw.User
+	x.ActorChat = raw.ActorChat
+	x.Date = raw.Date
+	return nil
+}
+
+// MessageReactionCountUpdated represents reaction changes on a message with anonymous reactions.
+type MessageReactionCountUpdated struct {
+	Chat      Chat             `json:"chat"`       // The chat containing the message
+	MessageId int64            `json:"message_id"` // Unique message identifier inside the chat
+	Date      int64            `json:"date"`       // Date of the change in Unix time
+	Reactions []*ReactionCount `json:"reactions"`  // List of reactions that are present on the message
+}
+
 // MessageId represents a unique message identifier.
 type MessageId struct {
 	MessageId int64 `json:"message_id"` // Unique message identifier
 }
 
+// LinkPreviewOptions describes the options used for link preview generation.
+type LinkPreviewOptions struct {
+	IsDisabled       bool   `json:"is_disabled,omitempty"`        // Optional. True, if the link preview is disabled
+	Url              string `json:"url,omitempty"`                // Optional. URL to use for the link preview. If empty, then the first URL found in the message text will be used
+	PreferSmallMedia bool   `json:"prefer_small_media,omitempty"` // Optional. True, if the media in the link preview is supposed to be shrunk; ignored if the URL isn't explicitly specified or media size change isn't supported for the preview
+	PreferLargeMedia bool   `json:"prefer_large_media,omitempty"` // Optional. True, if the media in the link preview is supposed to be enlarged; ignored if the URL isn't explicitly specified or media size change isn't supported for the preview
+	ShowAboveText    bool   `json:"show_above_text,omitempty"`    // Optional. True, if the link preview must be shown above the message text; otherwise, the link preview will be shown below the message text
+}
+
 // MessageEntity represents one special entity in a text message. For example, hashtags, usernames, URLs, etc.
 type MessageEntity struct {
 	Type          string `json:"type"`                      // Type of the entity. Currently, can be “mention” (@username), “hashtag” (#hashtag), “cashtag” ($USD), “bot_command” (/start@jobs_bot), “url” (https://telegram.org), “email” (do-not-reply@telegram.org), “phone_number” (+1-212-555-0123), “bold” (bold text), “italic” (italic text), “underline” (underlined text), “strikethrough” (strikethrough text), “spoiler” (spoiler message), “code” (monowidth string), “pre” (monowidth block), “text_link” (for clickable text URLs), “text_mention” (for users without usernames), “custom_emoji” (for inline custom emoji stickers)
@@ -330,8 +646,77 @@ type Document struct {
 	FileSize     int64      `json:"file_size,omitempty"` // Optional. File size in bytes. It can be bigger than 2^31 and some programming languages may have difficulty/silent defects in interpreting it. But it has at most 52 significant bits, so a signed 64-bit integer or double-precision float type are safe for storing this value.
 }
 
-// Story represents a message about a forwarded story in the chat. Currently holds no information.
-type Story struct{}
+// Story represents a story.
+type Story struct {
+	Chat Chat  `json:"chat"` // Chat that posted the story
+	Id   int64 `json:"id"`   // Unique identifier for the story in the chat
+}
+
+// Describes the paid media added to a message.
+type PaidMediaInfo struct {
+	StarCount int64       `json:"star_count"` // The number of Telegram Stars that must be paid to buy access to the media
+	PaidMedia []PaidMedia `json:"paid_media"` // Information about the paid media
+}
+
+func (x *PaidMediaInfo) UnmarshalJSON(rawBytes []byte) (err error) {
+	if len(rawBytes) == 0 {
+		return nil
+	}
+
+	type temp struct {
+		StarCount int64             `json:"star_count"` // The number of Telegram Stars that must be paid to buy access to the media
+		PaidMedia []json.RawMessage `json:"paid_media"` // Information about the paid media
+	}
+	raw := &temp{}
+
+	if err = json.Unmarshal(rawBytes, raw); err != nil {
+		return err
+	}
+
+	if data, err := unmarshalArray(raw.PaidMedia, unmarshalPaidMedia); err != nil {
+		return err
+	} else {
+		x.PaidMedia = data
+	}
+
+	x.StarCount = raw.StarCount
+	return nil
+}
+
+// PaidMedia describes paid media. Currently, it can be one of
+//   - PaidMediaPreview
+//   - PaidMediaPhoto
+//   - PaidMediaVideo
+type PaidMedia interface {
+	// IsPaidMedia does nothing and is only used to enforce type-safety
+	IsPaidMedia()
+}
+
+// The paid media isn't available before the payment.
+type PaidMediaPreview struct {
+	Type     string `json:"type"`               // Type of the paid media, always “preview”
+	Width    int64  `json:"width,omitempty"`    // Optional. Media width as defined by the sender
+	Height   int64  `json:"height,omitempty"`   // Optional. Media height as defined by the sender
+	Duration int64  `json:"duration,omitempty"` // Optional. Duration of the media in seconds as defined by the sender
+}
+
+func (PaidMediaPreview) IsPaidMedia() {}
+
+// The paid media is a photo.
+type PaidMediaPhoto struct {
+	Type  string       `json:"type"`  // Type of the paid media, always “photo”
+	Photo []*PhotoSize `json:"photo"` // The photo
+}
+
+func (PaidMediaPhoto) IsPaidMedia() {}
+
+// The paid media is a video.
+type PaidMediaVideo struct {
+	Type  string `json:"type"`  // Type of the paid media, always “video”
+	Video Video  `json:"video"` // The video
+}
+
+func (PaidMediaVideo) IsPaidMedia() {}
 
 // Video represents a video file.
 type Video struct {
@@ -846,6 +1231,23 @@ func (x *ChatMemberUpdated) UnmarshalJSON(rawBytes []byte) (err error) {
 	return nil
 }
 
+// Describes the connection of the bot with a business account.
+type BusinessConnection struct {
+	Id         string `json:"id"`           // Unique identifier of the business connection
+	User       User   `json:"user"`         // Business account user that created the business connection
+	UserChatId int64  `json:"user_chat_id"` // Identifier of a private chat with the user who created the business connection. This number may have more than 32 significant bits and some programming languages may have difficulty/silent defects in interpreting it. But it has at most 52 significant bits, so a 64-bit integer or double-precision float type are safe for storing this identifier.
+	Date       int64  `json:"date"`         // Date the connection was established in Unix time
+	CanReply   bool   `json:"can_reply"`    // True, if the bot can act on behalf of the business account in chats that were active in the last 24 hours
+	IsEnabled  bool   `json:"is_enabled"`   // True, if the connection is active
+}
+
+// BusinessMessagesDeleted is received when messages are deleted from a connected business account.
+type BusinessMessagesDeleted struct {
+	BusinessConnectionId string  `json:"business_connection_id"` // Unique identifier of the business connection
+	Chat                 Chat    `json:"chat"`                   // Information about a chat in the business account. The bot may not have access to the chat or the corresponding user.
+	MessageIds           []int64 `json:"message_ids"`            // The list of identifiers of deleted messages in the chat of the business account
+}
+
 // Represents a join request sent to a chat.
 type ChatJoinRequest struct {
 	Chat       Chat            `json:"chat"`                  // Chat to which the request was sent
@@ -1245,6 +1647,119 @@ func (x *InputMediaDocument) getFiles() map[string]*InputFile {
 	return media
 }
 
+// InputPaidMedia describes the paid media to be sent. Currently, it can be one of
+//   - InputPaidMediaPhoto
+//   - InputPaidMediaVideo
+type InputPaidMedia interface {
+	// IsInputPaidMedia does nothing and is only used to enforce type-safety
+	IsInputPaidMedia()
+
+	getFiles() map[string]*InputFile
+}
+
+// The paid media to send is a photo.
+type InputPaidMediaPhoto struct {
+	Type  string     `json:"type"`  // Type of the media, must be photo
+	Media *InputFile `json:"media"` // File to send. Pass a file_id to send a file that exists on the Telegram servers (recommended), pass an HTTP URL for Telegram to get a file from the Internet, or pass “attach://<file_attach_name>” to upload a new one using multipart/form-data under <file_attach_name> name. More information on Sending Files »
+}
+
+func (InputPaidMediaPhoto) IsInputPaidMedia() {}
+
+func (x *InputPaidMediaPhoto) getFiles() map[string]*InputFile {
+	media := map[string]*InputFile{}
+
+	if x.Media != nil {
+		if x.Media.IsUploadable() {
+			media[x.Media.Value] = x.Media
+		}
+	}
+
+	return media
+}
+
+// The paid media to send is a video.
+type InputPaidMediaVideo struct {
+	Type              string     `json:"type"`                         // Type of the media, must be video
+	Media             *InputFile `json:"media"`                        // File to send. Pass a file_id to send a file that exists on the Telegram servers (recommended), pass an HTTP URL for Telegram to get a file from the Internet, or pass “attach://<file_attach_name>” to upload a new one using multipart/form-data under <file_attach_name> name. More information on Sending Files »
+	Thumbnail         *InputFile `json:"thumbnail,omitempty"`          // Optional. Thumbnail of the file sent; can be ignored if thumbnail generation for the file is supported server-side. The thumbnail should be in JPEG format and less than 200 kB in size. A thumbnail's width and height should not exceed 320.
+	Width             int64      `json:"width,omitempty"`              // Optional. Video width
+	Height            int64      `json:"height,omitempty"`             // Optional. Video height
+	Duration          int64      `json:"duration,omitempty"`           // Optional. Video duration in seconds
+	SupportsStreaming bool       `json:"supports_streaming,omitempty"` // Optional. Pass True if the uploaded video is suitable for streaming
+}
+
+func (InputPaidMediaVideo) IsInputPaidMedia() {}
+
+func (x *InputPaidMediaVideo) getFiles() map[string]*InputFile {
+	media := map[string]*InputFile{}
+
+	if x.Media != nil {
+		if x.Media.IsUploadable() {
+			media[x.Media.Value] = x.Media
+		}
+	}
+	if x.Thumbnail != nil {
+		if x.Thumbnail.IsUploadable() {
+			media[x.Thumbnail.Value] = x.Thumbnail
+		}
+	}
+
+	return media
+}
+
+// InputStoryContent describes the content of a story to post. Currently, it can be one of
+//   - InputStoryContentPhoto
+//   - InputStoryContentVideo
+type InputStoryContent interface {
+	// IsInputStoryContent does nothing and is only used to enforce type-safety
+	IsInputStoryContent()
+
+	getFiles() map[string]*InputFile
+}
+
+// Describes a photo to post as a story.
+type InputStoryContentPhoto struct {
+	Type  string     `json:"type"`  // Type of the content, must be photo
+	Photo *InputFile `json:"photo"` // The photo to post as a story. The photo must be of the size 1080x1920 and must not exceed 10 MB. The photo can't be reused and can only be uploaded as a new file, so you can pass “attach://<file_attach_name>” if the photo was uploaded using multipart/form-data under <file_attach_name>. More information on Sending Files »
+}
+
+func (InputStoryContentPhoto) IsInputStoryContent() {}
+
+func (x *InputStoryContentPhoto) getFiles() map[string]*InputFile {
+	media := map[string]*InputFile{}
+
+	if x.Photo != nil {
+		if x.Photo.IsUploadable() {
+			media[x.Photo.Value] = x.Photo
+		}
+	}
+
+	return media
+}
+
+// Describes a video to post as a story.
+type InputStoryContentVideo struct {
+	Type                string     `json:"type"`                            // Type of the content, must be video
+	Video               *InputFile `json:"video"`                           // The video to post as a story. The video must be of the size 720x1280, streamable, encoded with H.265 codec, with key frames added each second in the MPEG4 format, and must not exceed 30 MB. The video can't be reused and can only be uploaded as a new file, so you can pass “attach://<file_attach_name>” if the video was uploaded using multipart/form-data under <file_attach_name>. More information on Sending Files »
+	Duration            float64    `json:"duration,omitempty"`              // Optional. Precise duration of the video in seconds; 0-60
+	CoverFrameTimestamp float64    `json:"cover_frame_timestamp,omitempty"` // Optional. Timestamp in seconds of the frame that will be used as the static cover for the story. Defaults to 0.0.
+	IsAnimation         bool       `json:"is_animation,omitempty"`          // Optional. Pass True if the video has no sound
+}
+
+func (InputStoryContentVideo) IsInputStoryContent() {}
+
+func (x *InputStoryContentVideo) getFiles() map[string]*InputFile {
+	media := map[string]*InputFile{}
+
+	if x.Video != nil {
+		if x.Video.IsUploadable() {
+			media[x.Video.Value] = x.Video
+		}
+	}
+
+	return media
+}
+
 // A simple method for testing your bot's authentication token. Requires no parameters. Returns basic information about the bot in form of a User object.
 func (api *API) GetMe() (*User, error) {
 	return callJson[*User](api, "getMe", nil)
@@ -1261,18 +1776,22 @@ func (api *API) Close() (bool, error) {
 }
 
 // sendMessage is used to send text messages. On success, the sent Message is returned.
+
+
 type SendMessage struct {
-	ChatId                   ChatID           `json:"chat_id"`                               // Unique identifier for the target chat or username of the target channel (in the format @channelusername)
-	MessageThreadId          int64            `json:"message_thread_id,omitempty"`           // Unique identifier for the target message thread (topic) of the forum; for forum supergroups only
-	Text                     string           `json:"text"`                                  // Text of the message to be sent, 1-4096 characters after entities parsing
-	ParseMode                ParseMode        `json:"parse_mode,omitempty"`                  // Mode for parsing entities in the message text. See formatting options for more details.
-	Entities                 []*MessageEntity `json:"entities,omitempty"`                    // A JSON-serialized list of special entities that appear in message text, which can be specified instead of parse_mode
-	DisableWebPagePreview    bool             `json:"disable_web_page_preview,omitempty"`    // Disables link previews for links in this message
-	DisableNotification      bool             `json:"disable_notification,omitempty"`        // Sends the message silently. Users will receive a notification with no sound.
-	ProtectContent           bool             `json:"protect_content,omitempty"`             // Protects the contents of the sent message from forwarding and saving
-	ReplyToMessageId         int64            `json:"reply_to_message_id,omitempty"`         // If the message is a reply, ID of the original message
-	AllowSendingWithoutReply bool             `json:"allow_sending_without_reply,omitempty"` // Pass True if the message should be sent even if the specified replied-to message is not found
-	ReplyMarkup              ReplyMarkup      `json:"reply_markup,omitempty"`                // Additional interface options. A JSON-serialized object for an inline keyboard, custom reply keyboard, instructions to remove reply keyboard or to force a reply from the user.
+	ChatId                   ChatID              `json:"chat_id"`                               // Unique identifier for the target chat or username of the target channel (in the format @channelusername)
+	MessageThreadId          int64               `json:"message_thread_id,omitempty"`           // Unique identifier for the target message thread (topic) of the forum; for forum supergroups only
+	Text                     string              `json:"text"`                                  // Text of the message to be sent, 1-4096 characters after entities parsing
+	ParseMode                ParseMode           `json:"parse_mode,omitempty"`                  // Mode for parsing entities in the message text. See formatting options for more details.
+	Entities                 []*MessageEntity    `json:"entities,omitempty"`                    // A JSON-serialized list of special entities that appear in message text, which can be specified instead of parse_mode
+	DisableWebPagePreview    bool                `json:"disable_web_page_preview,omitempty"`    // Disables link previews for links in this message
+	LinkPreviewOptions       *LinkPreviewOptions `json:"link_preview_options,omitempty"`        // Link preview generation options for the message
+	DisableNotification      bool                `json:"disable_notification,omitempty"`        // Sends the message silently. Users will receive a notification with no sound.
+	ProtectContent           bool                `json:"protect_content,omitempty"`             // Protects the contents of the sent message from forwarding and saving
+	MessageEffectId          string              `json:"message_effect_id,omitempty"`           // Unique identifier of the message effect to be added to the message; for private chats only
+	ReplyToMessageId         int64               `json:"reply_to_message_id,omitempty"`         // If the message is a reply, ID of the original message
+	AllowSendingWithoutReply bool                `json:"allow_sending_without_reply,omitempty"` // Pass True if the message should be sent even if the specified replied-to message is not found
+	ReplyMarkup              ReplyMarkup         `json:"reply_markup,omitempty"`                // Additional interface options. A JSON-serialized object for an inline keyboard, custom reply keyboard, instructions to remove reply keyboard or to force a reply from the user.
 }
 
 // sendMessage is used to send text messages. On success, the sent Message is returned.
@@ -1316,7 +1835,39 @@ func (api *API) CopyMessage(payload *CopyMessage) (*MessageId, error) {
 	return callJson[*MessageId](api, "copyMessage", payload)
 }
 
+// forwardMessages is used to forward multiple messages of any kind. If some of the specified messages can't be found or forwarded, they are skipped. Service messages and messages with protected content can't be forwarded. Album grouping is kept for forwarded messages. On success, an array of MessageId of the sent messages is returned.
+type ForwardMessages struct {
+	ChatId              ChatID  `json:"chat_id"`                        // Unique identifier for the target chat or username of the target channel (in the format @channelusername)
+	MessageThreadId     int64   `json:"message_thread_id,omitempty"`    // Unique identifier for the target message thread (topic) of the forum; for forum supergroups only
+	FromChatId          ChatID  `json:"from_chat_id"`                   // Unique identifier for the chat where the original messages were sent (or channel username in the format @channelusername)
+	MessageIds          []int64 `json:"message_ids"`                    // A JSON-serialized list of 1-100 identifiers of messages in the chat from_chat_id to forward. The identifiers must be specified in a strictly increasing order.
+	DisableNotification bool    `json:"disable_notification,omitempty"` // Sends the messages silently. Users will receive a notification with no sound.
+	ProtectContent      bool    `json:"protect_content,omitempty"`      // Protects the contents of the forwarded messages from forwarding and saving
+}
+
+// forwardMessages is used to forward multiple messages of any kind. If some of the specified messages can't be found or forwarded, they are skipped. Service messages and messages with protected content can't be forwarded. Album grouping is kept for forwarded messages. On success, an array of MessageId of the sent messages is returned.
+func (api *API) ForwardMessages(payload *ForwardMessages) ([]*MessageId, error) {
+	return callJson[[]*MessageId](api, "forwardMessages", payload)
+}
+
+// copyMessages is used to copy messages of any kind. If some of the specified messages can't be found or copied, they are skipped. Service messages, giveaway messages, giveaway winners messages, and invoice messages can't be copied. A quiz poll can be copied only if the value of the field correct_option_id is known to the bot. The method is analogous to the method forwardMessages, but the copied messages don't have a link to the original message. Album grouping is kept for copied messages. On success, an array of MessageId of the sent messages is returned.
+type CopyMessages struct {
+	ChatId              ChatID  `json:"chat_id"`                        // Unique identifier for the target chat or username of the target channel (in the format @channelusername)
+	MessageThreadId     int64   `json:"message_thread_id,omitempty"`    // Unique identifier for the target message thread (topic) of the forum; for forum supergroups only
+	FromChatId          ChatID  `json:"from_chat_id"`                   // Unique identifier for the chat where the original messages were sent (or channel username in the format @channelusername)
+	MessageIds          []int64 `json:"message_ids"`                    // A JSON-serialized list of 1-100 identifiers of messages in the chat from_chat_id to copy. The identifiers must be specified in a strictly increasing order.
+	DisableNotification bool    `json:"disable_notification,omitempty"` // Sends the messages silently. Users will receive a notification with no sound.
+	ProtectContent      bool    `json:"protect_content,omitempty"`      // Protects the contents of the sent messages from forwarding and saving
+	RemoveCaption       bool    `json:"remove_caption,omitempty"`       // Pass True to copy the messages without their captions
+}
+
+// copyMessages is used to copy messages of any kind. If some of the specified messages can't be found or copied, they are skipped. Service messages, giveaway messages, giveaway winners messages, and invoice messages can't be copied. A quiz poll can be copied only if the value of the field correct_option_id is known to the bot. The method is analogous to the method forwardMessages, but the copied messages don't have a link to the original message. Album grouping is kept for copied messages. On success, an array of MessageId of the sent messages is returned.
+func (api *API) CopyMessages(payload *CopyMessages) ([]*MessageId, error) {
+	return callJson[[]*MessageId](api, "copyMessages", payload)
+}
+
 // sendPhoto is used to send photos. On success, the sent Message is returned.
+
 type SendPhoto struct {
 	ChatId                   ChatID           `json:"chat_id"`                               // Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	MessageThreadId          int64            `json:"message_thread_id,omitempty"`           // Unique identifier for the target message thread (topic) of the forum; for forum supergroups only
@@ -1327,6 +1878,7 @@ type SendPhoto struct {
 	HasSpoiler               bool             `json:"has_spoiler,omitempty"`                 // Pass True if the photo needs to be covered with a spoiler animation
 	DisableNotification      bool             `json:"disable_notification,omitempty"`        // Sends the message silently. Users will receive a notification with no sound.
 	ProtectContent           bool             `json:"protect_content,omitempty"`             // Protects the contents of the sent message from forwarding and saving
+	MessageEffectId          string           `json:"message_effect_id,omitempty"`           // Unique identifier of the message effect to be added to the message; for private chats only
 	ReplyToMessageId         int64            `json:"reply_to_message_id,omitempty"`         // If the message is a reply, ID of the original message
 	AllowSendingWithoutReply bool             `json:"allow_sending_without_reply,omitempty"` // Pass True if the message should be sent even if the specified replied-to message is not found
 	ReplyMarkup              ReplyMarkup      `json:"reply_markup,omitempty"`                // Additional interface options. A JSON-serialized object for an inline keyboard, custom reply keyboard, instructions to remove reply keyboard or to force a reply from the user.
@@ -1377,6 +1929,9 @@ func (x *SendPhoto) getParams() (map[string]string, error) {
 	if x.ProtectContent {
 		payload["protect_content"] = strconv.FormatBool(x.ProtectContent)
 	}
+	if x.MessageEffectId != "" {
+		payload["message_effect_id"] = x.MessageEffectId
+	}
 	if x.ReplyToMessageId != 0 {
 		payload["reply_to_message_id"] = strconv.FormatInt(x.ReplyToMessageId, 10)
 	}
@@ -1408,6 +1963,7 @@ func (api *API) SendPhoto(payload *SendPhoto) (*Message, error) {
 
 // sendAudio is used to send audio files, if you want Telegram clients to display them in the music player. Your audio must be in the .MP3 or .M4A format. On success, the sent Message is returned. Bots can currently send audio files of up to 50 MB in size, this limit may be changed in the future.
 // For sending voice messages, use the sendVoice method instead.
+
 type SendAudio struct {
 	ChatId                   ChatID           `json:"chat_id"`                               // Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	MessageThreadId          int64            `json:"message_thread_id,omitempty"`           // Unique identifier for the target message thread (topic) of the forum; for forum supergroups only
@@ -1421,6 +1977,7 @@ type SendAudio struct {
 	Thumbnail                *InputFile       `json:"thumbnail,omitempty"`                   // Thumbnail of the file sent; can be ignored if thumbnail generation for the file is supported server-side. The thumbnail should be in JPEG format and less than 200 kB in size. A thumbnail's width and height should not exceed 320. Ignored if the file is not uploaded using multipart/form-data. Thumbnails can't be reused and can be only uploaded as a new file, so you can pass “attach://<file_attach_name>” if the thumbnail was uploaded using multipart/form-data under <file_attach_name>. More information on Sending Files »
 	DisableNotification      bool             `json:"disable_notification,omitempty"`        // Sends the message silently. Users will receive a notification with no sound.
 	ProtectContent           bool             `json:"protect_content,omitempty"`             // Protects the contents of the sent message from forwarding and saving
+	MessageEffectId          string           `json:"message_effect_id,omitempty"`           // Unique identifier of the message effect to be added to the message; for private chats only
 	ReplyToMessageId         int64            `json:"reply_to_message_id,omitempty"`         // If the message is a reply, ID of the original message
 	AllowSendingWithoutReply bool             `json:"allow_sending_without_reply,omitempty"` // Pass True if the message should be sent even if the specified replied-to message is not found
 	ReplyMarkup              ReplyMarkup      `json:"reply_markup,omitempty"`                // Additional interface options. A JSON-serialized object for an inline keyboard, custom reply keyboard, instructions to remove reply keyboard or to force a reply from the user.
@@ -1482,6 +2039,9 @@ func (x *SendAudio) getParams() (map[string]string, error) {
 	if x.ProtectContent {
 		payload["protect_content"] = strconv.FormatBool(x.ProtectContent)
 	}
+	if x.MessageEffectId != "" {
+		payload["message_effect_id"] = x.MessageEffectId
+	}
 	if x.ReplyToMessageId != 0 {
 		payload["reply_to_message_id"] = strconv.FormatInt(x.ReplyToMessageId, 10)
 	}
@@ -1513,6 +2073,7 @@ func (api *API) SendAudio(payload *SendAudio) (*Message, error) {
 }
 
 // sendDocument is used to send general files. On success, the sent Message is returned. Bots can currently send files of any type of up to 50 MB in size, this limit may be changed in the future.
+
 type SendDocument struct {
 	ChatId                      ChatID           `json:"chat_id"`                                  // Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	MessageThreadId             int64            `json:"message_thread_id,omitempty"`              // Unique identifier for the target message thread (topic) of the forum; for forum supergroups only
@@ -1524,6 +2085,7 @@ type SendDocument struct {
 	DisableContentTypeDetection bool             `json:"disable_content_type_detection,omitempty"` // Disables automatic server-side content type detection for files uploaded using multipart/form-data
 	DisableNotification         bool             `json:"disable_notification,omitempty"`           // Sends the message silently. Users will receive a notification with no sound.
 	ProtectContent              bool             `json:"protect_content,omitempty"`                // Protects the contents of the sent message from forwarding and saving
+	MessageEffectId             string           `json:"message_effect_id,omitempty"`              // Unique identifier of the message effect to be added to the message; for private chats only
 	ReplyToMessageId            int64            `json:"reply_to_message_id,omitempty"`            // If the message is a reply, ID of the original message
 	AllowSendingWithoutReply    bool             `json:"allow_sending_without_reply,omitempty"`    // Pass True if the message should be sent even if the specified replied-to message is not found
 	ReplyMarkup                 ReplyMarkup      `json:"reply_markup,omitempty"`                   // Additional interface options. A JSON-serialized object for an inline keyboard, custom reply keyboard, instructions to remove reply keyboard or to force a reply from the user.
@@ -1579,6 +2141,9 @@ func (x *SendDocument) getParams() (map[string]string, error) {
 	if x.ProtectContent {
 		payload["protect_content"] = strconv.FormatBool(x.ProtectContent)
 	}
+	if x.MessageEffectId != "" {
+		payload["message_effect_id"] = x.MessageEffectId
+	}
 	if x.ReplyToMessageId != 0 {
 		payload["reply_to_message_id"] = strconv.FormatInt(x.ReplyToMessageId, 10)
 	}
@@ -1609,6 +2174,7 @@ func (api *API) SendDocument(payload *SendDocument) (*Message, error) {
 }
 
 // sendVideo is used to send video files, Telegram clients support MPEG4 videos (other formats may be sent as Document). On success, the sent Message is returned. Bots can currently send video files of up to 50 MB in size, this limit may be changed in the future.
+
 type SendVideo struct {
 	ChatId                   ChatID           `json:"chat_id"`                               // Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	MessageThreadId          int64            `json:"message_thread_id,omitempty"`           // Unique identifier for the target message thread (topic) of the forum; for forum supergroups only
@@ -1624,6 +2190,7 @@ type SendVideo struct {
 	SupportsStreaming        bool             `json:"supports_streaming,omitempty"`          // Pass True if the uploaded video is suitable for streaming
 	DisableNotification      bool             `json:"disable_notification,omitempty"`        // Sends the message silently. Users will receive a notification with no sound.
 	ProtectContent           bool             `json:"protect_content,omitempty"`             // Protects the contents of the sent message from forwarding and saving
+	MessageEffectId          string           `json:"message_effect_id,omitempty"`           // Unique identifier of the message effect to be added to the message; for private chats only
 	ReplyToMessageId         int64            `json:"reply_to_message_id,omitempty"`         // If the message is a reply, ID of the original message
 	AllowSendingWithoutReply bool             `json:"allow_sending_without_reply,omitempty"` // Pass True if the message should be sent even if the specified replied-to message is not found
 	ReplyMarkup              ReplyMarkup      `json:"reply_markup,omitempty"`                // Additional interface options. A JSON-serialized object for an inline keyboard, custom reply keyboard, instructions to remove reply keyboard or to force a reply from the user.
@@ -1691,6 +2258,9 @@ func (x *SendVideo) getParams() (map[string]string, error) {
 	if x.ProtectContent {
 		payload["protect_content"] = strconv.FormatBool(x.ProtectContent)
 	}
+	if x.MessageEffectId != "" {
+		payload["message_effect_id"] = x.MessageEffectId
+	}
 	if x.ReplyToMessageId != 0 {
 		payload["reply_to_message_id"] = strconv.FormatInt(x.ReplyToMessageId, 10)
 	}
@@ -1721,6 +2291,7 @@ func (api *API) SendVideo(payload *SendVideo) (*Message, error) {
 }
 
 // sendAnimation is used to send animation files (GIF or H.264/MPEG-4 AVC video without sound). On success, the sent Message is returned. Bots can currently send animation files of up to 50 MB in size, this limit may be changed in the future.
+
 type SendAnimation struct {
 	ChatId                   ChatID           `json:"chat_id"`                               // Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	MessageThreadId          int64            `json:"message_thread_id,omitempty"`           // Unique identifier for the target message thread (topic) of the forum; for forum supergroups only
@@ -1735,6 +2306,7 @@ type SendAnimation struct {
 	HasSpoiler               bool             `json:"has_spoiler,omitempty"`                 // Pass True if the animation needs to be covered with a spoiler animation
 	DisableNotification      bool             `json:"disable_notification,omitempty"`        // Sends the message silently. Users will receive a notification with no sound.
 	ProtectContent           bool             `json:"protect_content,omitempty"`             // Protects the contents of the sent message from forwarding and saving
+	MessageEffectId          string           `json:"message_effect_id,omitempty"`           // Unique identifier of the message effect to be added to the message; for private chats only
 	ReplyToMessageId         int64            `json:"reply_to_message_id,omitempty"`         // If the message is a reply, ID of the original message
 	AllowSendingWithoutReply bool             `json:"allow_sending_without_reply,omitempty"` // Pass True if the message should be sent even if the specified replied-to message is not found
 	ReplyMarkup              ReplyMarkup      `json:"reply_markup,omitempty"`                // Additional interface options. A JSON-serialized object for an inline keyboard, custom reply keyboard, instructions to remove reply keyboard or to force a reply from the user.
@@ -1799,6 +2371,9 @@ func (x *SendAnimation) getParams() (map[string]string, error) {
 	if x.ProtectContent {
 		payload["protect_content"] = strconv.FormatBool(x.ProtectContent)
 	}
+	if x.MessageEffectId != "" {
+		payload["message_effect_id"] = x.MessageEffectId
+	}
 	if x.ReplyToMessageId != 0 {
 		payload["reply_to_message_id"] = strconv.FormatInt(x.ReplyToMessageId, 10)
 	}
@@ -1829,6 +2404,7 @@ func (api *API) SendAnimation(payload *SendAnimation) (*Message, error) {
 }
 
 // sendVoice is used to send audio files, if you want Telegram clients to display the file as a playable voice message. For this to work, your audio must be in an .OGG file encoded with OPUS (other formats may be sent as Audio or Document). On success, the sent Message is returned. Bots can currently send voice messages of up to 50 MB in size, this limit may be changed in the future.
+
 type SendVoice struct {
 	ChatId                   ChatID           `json:"chat_id"`                               // Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	MessageThreadId          int64            `json:"message_thread_id,omitempty"`           // Unique identifier for the target message thread (topic) of the forum; for forum supergroups only
@@ -1839,6 +2415,7 @@ type SendVoice struct {
 	Duration                 int64            `json:"duration,omitempty"`                    // Duration of the voice message in seconds
 	DisableNotification      bool             `json:"disable_notification,omitempty"`        // Sends the message silently. Users will receive a notification with no sound.
 	ProtectContent           bool             `json:"protect_content,omitempty"`             // Protects the contents of the sent message from forwarding and saving
+	MessageEffectId          string           `json:"message_effect_id,omitempty"`           // Unique identifier of the message effect to be added to the message; for private chats only
 	ReplyToMessageId         int64            `json:"reply_to_message_id,omitempty"`         // If the message is a reply, ID of the original message
 	AllowSendingWithoutReply bool             `json:"allow_sending_without_reply,omitempty"` // Pass True if the message should be sent even if the specified replied-to message is not found
 	ReplyMarkup              ReplyMarkup      `json:"reply_markup,omitempty"`                // Additional interface options. A JSON-serialized object for an inline keyboard, custom reply keyboard, instructions to remove reply keyboard or to force a reply from the user.
@@ -1889,6 +2466,9 @@ func (x *SendVoice) getParams() (map[string]string, error) {
 	if x.ProtectContent {
 		payload["protect_content"] = strconv.FormatBool(x.ProtectContent)
 	}
+	if x.MessageEffectId != "" {
+		payload["message_effect_id"] = x.MessageEffectId
+	}
 	if x.ReplyToMessageId != 0 {
 		payload["reply_to_message_id"] = strconv.FormatInt(x.ReplyToMessageId, 10)
 	}
@@ -1919,6 +2499,7 @@ func (api *API) SendVoice(payload *SendVoice) (*Message, error) {
 }
 
 // As of v.4.0, Telegram clients support rounded square MPEG4 videos of up to 1 minute long. sendVideoNote is used to send video messages. On success, the sent Message is returned.
+
 type SendVideoNote struct {
 	ChatId                   ChatID      `json:"chat_id"`                               // Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	MessageThreadId          int64       `json:"message_thread_id,omitempty"`           // Unique identifier for the target message thread (topic) of the forum; for forum supergroups only
@@ -1928,6 +2509,7 @@ type SendVideoNote struct {
 	Thumbnail                *InputFile  `json:"thumbnail,omitempty"`                   // Thumbnail of the file sent; can be ignored if thumbnail generation for the file is supported server-side. The thumbnail should be in JPEG format and less than 200 kB in size. A thumbnail's width and height should not exceed 320. Ignored if the file is not uploaded using multipart/form-data. Thumbnails can't be reused and can be only uploaded as a new file, so you can pass “attach://<file_attach_name>” if the thumbnail was uploaded using multipart/form-data under <file_attach_name>. More information on Sending Files »
 	DisableNotification      bool        `json:"disable_notification,omitempty"`        // Sends the message silently. Users will receive a notification with no sound.
 	ProtectContent           bool        `json:"protect_content,omitempty"`             // Protects the contents of the sent message from forwarding and saving
+	MessageEffectId          string      `json:"message_effect_id,omitempty"`           // Unique identifier of the message effect to be added to the message; for private chats only
 	ReplyToMessageId         int64       `json:"reply_to_message_id,omitempty"`         // If the message is a reply, ID of the original message
 	AllowSendingWithoutReply bool        `json:"allow_sending_without_reply,omitempty"` // Pass True if the message should be sent even if the specified replied-to message is not found
 	ReplyMarkup              ReplyMarkup `json:"reply_markup,omitempty"`                // Additional interface options. A JSON-serialized object for an inline keyboard, custom reply keyboard, instructions to remove reply keyboard or to force a reply from the user.
@@ -1973,6 +2555,9 @@ func (x *SendVideoNote) getParams() (map[string]string, error) {
 	if x.ProtectContent {
 		payload["protect_content"] = strconv.FormatBool(x.ProtectContent)
 	}
+	if x.MessageEffectId != "" {
+		payload["message_effect_id"] = x.MessageEffectId
+	}
 	if x.ReplyToMessageId != 0 {
 		payload["reply_to_message_id"] = strconv.FormatInt(x.ReplyToMessageId, 10)
 	}
@@ -2002,13 +2587,116 @@ func (api *API) SendVideoNote(payload *SendVideoNote) (*Message, error) {
 	return callJson[*Message](api, "sendVideoNote", payload)
 }
 
+// sendPaidMedia is used to send paid media. On success, the sent Message is returned.
+type SendPaidMedia struct {
+	BusinessConnectionId     string           `json:"business_connection_id,omitempty"`      // Unique identifier of the business connection on behalf of which the message will be sent
+	ChatId                   ChatID           `json:"chat_id"`                               // Unique identifier for the target chat or username of the target channel (in the format @channelusername). If the chat is a channel, all Telegram Star proceeds from this media will be credited to the chat's balance. Otherwise, they will be credited to the bot's balance.
+	StarCount                int64            `json:"star_count"`                            // The number of Telegram Stars that must be paid to buy access to the media; 1-10000
+	Media                    []InputPaidMedia `json:"media"`                                 // A JSON-serialized array describing the media to be sent; up to 10 items
+	Payload                  string           `json:"payload,omitempty"`                     // Bot-defined paid media payload, 0-128 bytes. This will not be displayed to the user, use it for your internal processes.
+	Caption                  string           `json:"caption,omitempty"`                     // Media caption, 0-1024 characters after entities parsing
+	ParseMode                ParseMode        `json:"parse_mode,omitempty"`                  // Mode for parsing entities in the media caption. See formatting options for more details.
+	CaptionEntities          []*MessageEntity `json:"caption_entities,omitempty"`            // A JSON-serialized list of special entities that appear in the caption, which can be specified instead of parse_mode
+	ShowCaptionAboveMedia    bool             `json:"show_caption_above_media,omitempty"`    // Pass True, if the caption must be shown above the message media
+	DisableNotification      bool             `json:"disable_notification,omitempty"`        // Sends the message silently. Users will receive a notification with no sound.
+	ProtectContent           bool             `json:"protect_content,omitempty"`             // Protects the contents of the sent message from forwarding and saving
+	ReplyToMessageId         int64            `json:"reply_to_message_id,omitempty"`         // If the message is a reply, ID of the original message
+	AllowSendingWithoutReply bool             `json:"allow_sending_without_reply,omitempty"` // Pass True if the message should be sent even if the specified replied-to message is not found
+	ReplyMarkup              ReplyMarkup      `json:"reply_markup,omitempty"`                // Additional interface options. A JSON-serialized object for an inline keyboard, custom reply keyboard, instructions to remove reply keyboard or to force a reply from the user.
+}
+
+func (x *SendPaidMedia) getFiles() map[string]*InputFile {
+	media := map[string]*InputFile{}
+
+	for _, m := range x.Media {
+		for key, value := range m.getFiles() {
+			media[key] = value
+		}
+	}
+
+	return media
+}
+
+func (x *SendPaidMedia) getParams() (map[string]string, error) {
+	payload := map[string]string{}
+
+	if x.BusinessConnectionId != "" {
+		payload["business_connection_id"] = x.BusinessConnectionId
+	}
+	if bb, err := json.Marshal(x.ChatId); err != nil {
+		return nil, err
+	} else {
+		payload["chat_id"] = string(bb)
+	}
+	payload["star_count"] = strconv.FormatInt(x.StarCount, 10)
+	if bb, err := json.Marshal(x.Media); err != nil {
+		return nil, err
+	} else {
+		payload["media"] = string(bb)
+	}
+	if x.Payload != "" {
+		payload["payload"] = x.Payload
+	}
+	if x.Caption != "" {
+		payload["caption"] = x.Caption
+	}
+	if x.ParseMode != ParseModeNone {
+		payload["parse_mode"] = string(x.ParseMode)
+	}
+	if x.CaptionEntities != nil {
+		if bb, err := json.Marshal(x.CaptionEntities); err != nil {
+			return nil, err
+		} else {
+			payload["caption_entities"] = string(bb)
+		}
+	}
+	if x.ShowCaptionAboveMedia {
+		payload["show_caption_above_media"] = strconv.FormatBool(x.ShowCaptionAboveMedia)
+	}
+	if x.DisableNotification {
+		payload["disable_notification"] = strconv.FormatBool(x.DisableNotification)
+	}
+	if x.ProtectContent {
+		payload["protect_content"] = strconv.FormatBool(x.ProtectContent)
+	}
+	if x.ReplyToMessageId != 0 {
+		payload["reply_to_message_id"] = strconv.FormatInt(x.ReplyToMessageId, 10)
+	}
+	if x.AllowSendingWithoutReply {
+		payload["allow_sending_without_reply"] = strconv.FormatBool(x.AllowSendingWithoutReply)
+	}
+	if x.ReplyMarkup != nil {
+		if bb, err := json.Marshal(x.ReplyMarkup); err != nil {
+			return nil, err
+		} else {
+			payload["reply_markup"] = string(bb)
+		}
+	}
+
+	return payload, nil
+}
+
+// sendPaidMedia is used to send paid media. On success, the sent Message is returned.
+func (api *API) SendPaidMedia(payload *SendPaidMedia) (*Message, error) {
+	if files := payload.getFiles(); len(files) != 0 {
+		params, err := payload.getParams()
+		if err != nil {
+			return nil, err
+		}
+		return callMultipart[*Message](api, "sendPaidMedia", params, files)
+	}
+	return callJson[*Message](api, "sendPaidMedia", payload)
+}
+
 // sendMediaGroup is used to send a group of photos, videos, documents or audios as an album. Documents and audio files can be only grouped in an album with messages of the same type. On success, an array of Messages that were sent is returned.
+
 type SendMediaGroup struct {
 	ChatId                   ChatID       `json:"chat_id"`                               // Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	MessageThreadId          int64        `json:"message_thread_id,omitempty"`           // Unique identifier for the target message thread (topic) of the forum; for forum supergroups only
 	Media                    []InputMedia `json:"media"`                                 // A JSON-serialized array describing messages to be sent, must include 2-10 items
 	DisableNotification      bool         `json:"disable_notification,omitempty"`        // Sends messages silently. Users will receive a notification with no sound.
 	ProtectContent           bool         `json:"protect_content,omitempty"`             // Protects the contents of the sent messages from forwarding and saving
+	MessageEffectId          string       `json:"message_effect_id,omitempty"`           // Unique identifier of the message effect to be added to the message; for private chats only
 	ReplyToMessageId         int64        `json:"reply_to_message_id,omitempty"`         // If the messages are a reply, ID of the original message
 	AllowSendingWithoutReply bool         `json:"allow_sending_without_reply,omitempty"` // Pass True if the message should be sent even if the specified replied-to message is not found
 }
@@ -2047,6 +2735,9 @@ func (x *SendMediaGroup) getParams() (map[string]string, error) {
 	if x.ProtectContent {
 		payload["protect_content"] = strconv.FormatBool(x.ProtectContent)
 	}
+	if x.MessageEffectId != "" {
+		payload["message_effect_id"] = x.MessageEffectId
+	}
 	if x.ReplyToMessageId != 0 {
 		payload["reply_to_message_id"] = strconv.FormatInt(x.ReplyToMessageId, 10)
 	}
@@ -2070,6 +2761,7 @@ func (api *API) SendMediaGroup(payload *SendMediaGroup) ([]*Message, error) {
 }
 
 // sendLocation is used to send point on the map. On success, the sent Message is returned.
+
 type SendLocation struct {
 	ChatId                   ChatID      `json:"chat_id"`                               // Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	MessageThreadId          int64       `json:"message_thread_id,omitempty"`           // Unique identifier for the target message thread (topic) of the forum; for forum supergroups only
@@ -2081,6 +2773,7 @@ type SendLocation struct {
 	ProximityAlertRadius     int64       `json:"proximity_alert_radius,omitempty"`      // For live locations, a maximum distance for proximity alerts about approaching another chat member, in meters. Must be between 1 and 100000 if specified.
 	DisableNotification      bool        `json:"disable_notification,omitempty"`        // Sends the message silently. Users will receive a notification with no sound.
 	ProtectContent           bool        `json:"protect_content,omitempty"`             // Protects the contents of the sent message from forwarding and saving
+	MessageEffectId          string      `json:"message_effect_id,omitempty"`           // Unique identifier of the message effect to be added to the message; for private chats only
 	ReplyToMessageId         int64       `json:"reply_to_message_id,omitempty"`         // If the message is a reply, ID of the original message
 	AllowSendingWithoutReply bool        `json:"allow_sending_without_reply,omitempty"` // Pass True if the message should be sent even if the specified replied-to message is not found
 	ReplyMarkup              ReplyMarkup `json:"reply_markup,omitempty"`                // Additional interface options. A JSON-serialized object for an inline keyboard, custom reply keyboard, instructions to remove reply keyboard or to force a reply from the user.
@@ -2092,6 +2785,7 @@ func (api *API) SendLocation(payload *SendLocation) (*Message, error) {
 }
 
 // sendVenue is used to send information about a venue. On success, the sent Message is returned.
+
 type SendVenue struct {
 	ChatId                   ChatID      `json:"chat_id"`                               // Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	MessageThreadId          int64       `json:"message_thread_id,omitempty"`           // Unique identifier for the target message thread (topic) of the forum; for forum supergroups only
@@ -2105,6 +2799,7 @@ type SendVenue struct {
 	GooglePlaceType          string      `json:"google_place_type,omitempty"`           // Google Places type of the venue. (See supported types.)
 	DisableNotification      bool        `json:"disable_notification,omitempty"`        // Sends the message silently. Users will receive a notification with no sound.
 	ProtectContent           bool        `json:"protect_content,omitempty"`             // Protects the contents of the sent message from forwarding and saving
+	MessageEffectId          string      `json:"message_effect_id,omitempty"`           // Unique identifier of the message effect to be added to the message; for private chats only
 	ReplyToMessageId         int64       `json:"reply_to_message_id,omitempty"`         // If the message is a reply, ID of the original message
 	AllowSendingWithoutReply bool        `json:"allow_sending_without_reply,omitempty"` // Pass True if the message should be sent even if the specified replied-to message is not found
 	ReplyMarkup              ReplyMarkup `json:"reply_markup,omitempty"`                // Additional interface options. A JSON-serialized object for an inline keyboard, custom reply keyboard, instructions to remove reply keyboard or to force a reply from the user.
@@ -2116,6 +2811,7 @@ func (api *API) SendVenue(payload *SendVenue) (*Message, error) {
 }
 
 // sendContact is used to send phone contacts. On success, the sent Message is returned.
+
 type SendContact struct {
 	ChatId                   ChatID      `json:"chat_id"`                               // Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	MessageThreadId          int64       `json:"message_thread_id,omitempty"`           // Unique identifier for the target message thread (topic) of the forum; for forum supergroups only
@@ -2125,6 +2821,7 @@ type SendContact struct {
 	Vcard                    string      `json:"vcard,omitempty"`                       // Additional data about the contact in the form of a vCard, 0-2048 bytes
 	DisableNotification      bool        `json:"disable_notification,omitempty"`        // Sends the message silently. Users will receive a notification with no sound.
 	ProtectContent           bool        `json:"protect_content,omitempty"`             // Protects the contents of the sent message from forwarding and saving
+	MessageEffectId          string      `json:"message_effect_id,omitempty"`           // Unique identifier of the message effect to be added to the message; for private chats only
 	ReplyToMessageId         int64       `json:"reply_to_message_id,omitempty"`         // If the message is a reply, ID of the original message
 	AllowSendingWithoutReply bool        `json:"allow_sending_without_reply,omitempty"` // Pass True if the message should be sent even if the specified replied-to message is not found
 	ReplyMarkup              ReplyMarkup `json:"reply_markup,omitempty"`                // Additional interface options. A JSON-serialized object for an inline keyboard, custom reply keyboard, instructions to remove reply keyboard or to force a reply from the user.
@@ -2136,6 +2833,7 @@ func (api *API) SendContact(payload *SendContact) (*Message, error) {
 }
 
 // sendPoll is used to send a native poll. On success, the sent Message is returned.
+
 type SendPoll struct {
 	ChatId                   ChatID           `json:"chat_id"`                               // Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	MessageThreadId          int64            `json:"message_thread_id,omitempty"`           // Unique identifier for the target message thread (topic) of the forum; for forum supergroups only
@@ -2153,6 +2851,7 @@ type SendPoll struct {
 	IsClosed                 bool             `json:"is_closed,omitempty"`                   // Pass True if the poll needs to be immediately closed. This can be useful for poll preview.
 	DisableNotification      bool             `json:"disable_notification,omitempty"`        // Sends the message silently. Users will receive a notification with no sound.
 	ProtectContent           bool             `json:"protect_content,omitempty"`             // Protects the contents of the sent message from forwarding and saving
+	MessageEffectId          string           `json:"message_effect_id,omitempty"`           // Unique identifier of the message effect to be added to the message; for private chats only
 	ReplyToMessageId         int64            `json:"reply_to_message_id,omitempty"`         // If the message is a reply, ID of the original message
 	AllowSendingWithoutReply bool             `json:"allow_sending_without_reply,omitempty"` // Pass True if the message should be sent even if the specified replied-to message is not found
 	ReplyMarkup              ReplyMarkup      `json:"reply_markup,omitempty"`                // Additional interface options. A JSON-serialized object for an inline keyboard, custom reply keyboard, instructions to remove reply keyboard or to force a reply from the user.
@@ -2164,12 +2863,14 @@ func (api *API) SendPoll(payload *SendPoll) (*Message, error) {
 }
 
 // sendDice is used to send an animated emoji that will display a random value. On success, the sent Message is returned.
+
 type SendDice struct {
 	ChatId                   ChatID      `json:"chat_id"`                               // Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	MessageThreadId          int64       `json:"message_thread_id,omitempty"`           // Unique identifier for the target message thread (topic) of the forum; for forum supergroups only
 	Emoji                    string      `json:"emoji,omitempty"`                       // Emoji on which the dice throw animation is based. Currently, must be one of “”, “”, “”, “”, “”, or “”. Dice can have values 1-6 for “”, “” and “”, values 1-5 for “” and “”, and values 1-64 for “”. Defaults to “”
 	DisableNotification      bool        `json:"disable_notification,omitempty"`        // Sends the message silently. Users will receive a notification with no sound.
 	ProtectContent           bool        `json:"protect_content,omitempty"`             // Protects the contents of the sent message from forwarding
+	MessageEffectId          string      `json:"message_effect_id,omitempty"`           // Unique identifier of the message effect to be added to the message; for private chats only
 	ReplyToMessageId         int64       `json:"reply_to_message_id,omitempty"`         // If the message is a reply, ID of the original message
 	AllowSendingWithoutReply bool        `json:"allow_sending_without_reply,omitempty"` // Pass True if the message should be sent even if the specified replied-to message is not found
 	ReplyMarkup              ReplyMarkup `json:"reply_markup,omitempty"`                // Additional interface options. A JSON-serialized object for an inline keyboard, custom reply keyboard, instructions to remove reply keyboard or to force a reply from the user.
@@ -2200,6 +2901,19 @@ func (api *API) SendChatAction(payload *SendChatAction) (bool, error) {
 	return callJson[bool](api, "sendChatAction", payload)
 }
 
+// setMessageReaction is used to change the chosen reactions on a message. Service messages of some types can't be reacted to. Automatically forwarded messages from a channel to its discussion group have the same available reactions as messages in the channel. Bots can't use paid reactions. Returns True on success.
+type SetMessageReaction struct {
+	ChatId    ChatID         `json:"chat_id"`            // Unique identifier for the target chat or username of the target channel (in the format @channelusername)
+	MessageId int64          `json:"message_id"`         // Identifier of the target message. If the message belongs to a media group, the reaction is set to the first non-deleted message in the group instead.
+	Reaction  []ReactionType `json:"reaction,omitempty"` // Optional. A JSON-serialized list of reaction types to set on the message. Currently, as non-premium users, bots can set up to one reaction per message. A custom emoji reaction can be used if it is either already present on the message or explicitly allowed by chat administrators. Paid reactions can't be used by bots.
+	IsBig     bool           `json:"is_big,omitempty"`   // Optional. Pass True to set the reaction with a big animation
+}
+
+// setMessageReaction is used to change the chosen reactions on a message. Service messages of some types can't be reacted to. Automatically forwarded messages from a channel to its discussion group have the same available reactions as messages in the channel. Bots can't use paid reactions. Returns True on success.
+func (api *API) SetMessageReaction(payload *SetMessageReaction) (bool, error) {
+	return callJson[bool](api, "setMessageReaction", payload)
+}
+
 // getUserProfilePhotos is used to get a list of profile pictures for a user. Returns a UserProfilePhotos object.
 type GetUserProfilePhotos struct {
 	UserId int64 `json:"user_id"`          // Unique identifier of the target user
@@ -2540,6 +3254,16 @@ func (api *API) GetChat(payload *GetChat) (*Chat, error) {
 	return callJson[*Chat](api, "getChat", payload)
 }
 
+// getBusinessConnection is used to get information about the connection of the bot with a business account. Returns a BusinessConnection object on success.
+type GetBusinessConnection struct {
+	BusinessConnectionId string `json:"business_connection_id"` // Unique identifier of the business connection
+}
+
+// getBusinessConnection is used to get information about the connection of the bot with a business account. Returns a BusinessConnection object on success.
+func (api *API) GetBusinessConnection(payload *GetBusinessConnection) (*BusinessConnection, error) {
+	return callJson[*BusinessConnection](api, "getBusinessConnection", payload)
+}
+
 // getChatAdministrators is used to get a list of administrators in a chat, which aren't bots. Returns an Array of ChatMember objects.
 type GetChatAdministrators struct {
 	ChatId ChatID `json:"chat_id"` // Unique identifier for the target chat or username of the target supergroup or channel (in the format @channelusername)
@@ -2547,7 +3271,11 @@ type GetChatAdministrators struct {
 
 // getChatAdministrators is used to get a list of administrators in a chat, which aren't bots. Returns an Array of ChatMember objects.
 func (api *API) GetChatAdministrators(payload *GetChatAdministrators) ([]ChatMember, error) {
-	return callJson[[]ChatMember](api, "getChatAdministrators", payload)
+	resp, err := callJson[[]json.RawMessage](api, "getChatAdministrators", payload)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalArray(resp, unmarshalChatMember)
 }
 
 // getChatMemberCount is used to get the number of members in a chat. Returns Int on success.
@@ -2904,6 +3632,7 @@ type EditMessageText struct {
 	ParseMode             ParseMode             `json:"parse_mode,omitempty"`               // Mode for parsing entities in the message text. See formatting options for more details.
 	Entities              []*MessageEntity      `json:"entities,omitempty"`                 // A JSON-serialized list of special entities that appear in message text, which can be specified instead of parse_mode
 	DisableWebPagePreview bool                  `json:"disable_web_page_preview,omitempty"` // Disables link previews for links in this message
+	LinkPreviewOptions    *LinkPreviewOptions   `json:"link_preview_options,omitempty"`     // Link preview generation options for the message
 	ReplyMarkup           *InlineKeyboardMarkup `json:"reply_markup,omitempty"`             // A JSON-serialized object for an inline keyboard.
 }
 
@@ -3058,6 +3787,17 @@ func (api *API) DeleteMessage(payload *DeleteMessage) (bool, error) {
 	return callJson[bool](api, "deleteMessage", payload)
 }
 
+// deleteMessages is used to delete multiple messages simultaneously. If some of the specified messages can't be found, they are skipped. Returns True on success.
+type DeleteMessages struct {
+	ChatId     ChatID  `json:"chat_id"`     // Unique identifier for the target chat or username of the target channel (in the format @channelusername)
+	MessageIds []int64 `json:"message_ids"` // A JSON-serialized list of 1-100 identifiers of messages to delete. See deleteMessage for limitations on which messages can be deleted
+}
+
+// deleteMessages is used to delete multiple messages simultaneously. If some of the specified messages can't be found, they are skipped. Returns True on success.
+func (api *API) DeleteMessages(payload *DeleteMessages) (bool, error) {
+	return callJson[bool](api, "deleteMessages", payload)
+}
+
 // Sticker represents a sticker.
 type Sticker struct {
 	FileId           string        `json:"file_id"`                     // Identifier for this file, which can be used to download or reuse the file
@@ -3117,6 +3857,7 @@ func (x *InputSticker) getFiles() map[string]*InputFile {
 }
 
 // sendSticker is used to send static .WEBP, animated .TGS, or video .WEBM stickers. On success, the sent Message is returned.
+
 type SendSticker struct {
 	ChatId                   ChatID      `json:"chat_id"`                               // Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	MessageThreadId          int64       `json:"message_thread_id,omitempty"`           // Unique identifier for the target message thread (topic) of the forum; for forum supergroups only
@@ -3124,6 +3865,7 @@ type SendSticker struct {
 	Emoji                    string      `json:"emoji,omitempty"`                       // Emoji associated with the sticker; only for just uploaded stickers
 	DisableNotification      bool        `json:"disable_notification,omitempty"`        // Sends the message silently. Users will receive a notification with no sound.
 	ProtectContent           bool        `json:"protect_content,omitempty"`             // Protects the contents of the sent message from forwarding and saving
+	MessageEffectId          string      `json:"message_effect_id,omitempty"`           // Unique identifier of the message effect to be added to the message; for private chats only
 	ReplyToMessageId         int64       `json:"reply_to_message_id,omitempty"`         // If the message is a reply, ID of the original message
 	AllowSendingWithoutReply bool        `json:"allow_sending_without_reply,omitempty"` // Pass True if the message should be sent even if the specified replied-to message is not found
 	ReplyMarkup              ReplyMarkup `json:"reply_markup,omitempty"`                // Additional interface options. A JSON-serialized object for an inline keyboard, custom reply keyboard, instructions to remove reply keyboard or to force a reply from the user.
@@ -3161,6 +3903,9 @@ func (x *SendSticker) getParams() (map[string]string, error) {
 	if x.ProtectContent {
 		payload["protect_content"] = strconv.FormatBool(x.ProtectContent)
 	}
+	if x.MessageEffectId != "" {
+		payload["message_effect_id"] = x.MessageEffectId
+	}
 	if x.ReplyToMessageId != 0 {
 		payload["reply_to_message_id"] = strconv.FormatInt(x.ReplyToMessageId, 10)
 	}
@@ -4697,11 +5442,13 @@ type InputMessageContent interface {
 }
 
 // Represents the content of a text message to be sent as the result of an inline query.
+
 type InputTextMessageContent struct {
-	MessageText           string           `json:"message_text"`                       // Text of the message to be sent, 1-4096 characters
-	ParseMode             ParseMode        `json:"parse_mode,omitempty"`               // Optional. Mode for parsing entities in the message text. See formatting options for more details.
-	Entities              []*MessageEntity `json:"entities,omitempty"`                 // Optional. List of special entities that appear in message text, which can be specified instead of parse_mode
-	DisableWebPagePreview bool             `json:"disable_web_page_preview,omitempty"` // Optional. Disables link previews for links in the sent message
+	MessageText           string              `json:"message_text"`                       // Text of the message to be sent, 1-4096 characters
+	ParseMode             ParseMode           `json:"parse_mode,omitempty"`               // Optional. Mode for parsing entities in the message text. See formatting options for more details.
+	Entities              []*MessageEntity    `json:"entities,omitempty"`                 // Optional. List of special entities that appear in message text, which can be specified instead of parse_mode
+	DisableWebPagePreview bool                `json:"disable_web_page_preview,omitempty"` // Optional. Disables link previews for links in the sent message
+	LinkPreviewOptions    *LinkPreviewOptions `json:"link_preview_options,omitempty"`     // Optional. Link preview generation options for the message
 }
 
 func (InputTextMessageContent) IsInputMessageContent() {}
@@ -4795,6 +5542,7 @@ type SentWebAppMessage struct {
 }
 
 // sendInvoice is used to send invoices. On success, the sent Message is returned.
+
 type SendInvoice struct {
 	ChatId                    ChatID                `json:"chat_id"`                                 // Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	MessageThreadId           int64                 `json:"message_thread_id,omitempty"`             // Unique identifier for the target message thread (topic) of the forum; for forum supergroups only
@@ -4821,6 +5569,7 @@ type SendInvoice struct {
 	IsFlexible                bool                  `json:"is_flexible,omitempty"`                   // Pass True if the final price depends on the shipping method
 	DisableNotification       bool                  `json:"disable_notification,omitempty"`          // Sends the message silently. Users will receive a notification with no sound.
 	ProtectContent            bool                  `json:"protect_content,omitempty"`               // Protects the contents of the sent message from forwarding and saving
+	MessageEffectId           string                `json:"message_effect_id,omitempty"`             // Unique identifier of the message effect to be added to the message; for private chats only
 	ReplyToMessageId          int64                 `json:"reply_to_message_id,omitempty"`           // If the message is a reply, ID of the original message
 	AllowSendingWithoutReply  bool                  `json:"allow_sending_without_reply,omitempty"`   // Pass True if the message should be sent even if the specified replied-to message is not found
 	ReplyMarkup               *InlineKeyboardMarkup `json:"reply_markup,omitempty"`                  // A JSON-serialized object for an inline keyboard. If empty, one 'Pay total price' button will be shown. If not empty, the first button must be a Pay button.
@@ -4885,6 +5634,28 @@ func (api *API) AnswerPreCheckoutQuery(payload *AnswerPreCheckoutQuery) (bool, e
 	return callJson[bool](api, "answerPreCheckoutQuery", payload)
 }
 
+// Returns the bot's Telegram Star transactions in chronological order. On success, returns a StarTransactions object.
+type GetStarTransactions struct {
+	Offset int64 `json:"offset,omitempty"` // Number of transactions to skip in the response
+	Limit  int64 `json:"limit,omitempty"`  // The maximum number of transactions to be retrieved. Values between 1-100 are accepted. Defaults to 100.
+}
+
+// Returns the bot's Telegram Star transactions in chronological order. On success, returns a StarTransactions object.
+func (api *API) GetStarTransactions(payload *GetStarTransactions) (*StarTransactions, error) {
+	return callJson[*StarTransactions](api, "getStarTransactions", payload)
+}
+
+// Refunds a successful payment in Telegram Stars. Returns True on success.
+type RefundStarPayment struct {
+	UserId                  int64  `json:"user_id"`                    // Identifier of the user whose payment will be refunded
+	TelegramPaymentChargeId string `json:"telegram_payment_charge_id"` // Telegram payment identifier
+}
+
+// Refunds a successful payment in Telegram Stars. Returns True on success.
+func (api *API) RefundStarPayment(payload *RefundStarPayment) (bool, error) {
+	return callJson[bool](api, "refundStarPayment", payload)
+}
+
 // LabeledPrice represents a portion of the price for goods or services.
 type LabeledPrice struct {
 	Label  string `json:"label"`  // Portion label
@@ -4955,6 +5726,269 @@ type PreCheckoutQuery struct {
 	OrderInfo        *OrderInfo `json:"order_info,omitempty"`         // Optional. Order information provided by the user
 }
 
+// Gift represents a gift that can be sent by the bot.
+type Gift struct {
+	Id               string  `json:"id"`                           // Unique identifier of the gift
+	Sticker          Sticker `json:"sticker"`                      // The sticker that represents the gift
+	StarCount        int64   `json:"star_count"`                   // The number of Telegram Stars that must be paid to send the sticker
+	UpgradeStarCount int64   `json:"upgrade_star_count,omitempty"` // Optional. The number of Telegram Stars that must be paid to upgrade the gift to a unique one
+	TotalCount       int64   `json:"total_count,omitempty"`        // Optional. The total number of the gifts of this type that can be sent; for limited gifts only
+	RemainingCount   int64   `json:"remaining_count,omitempty"`    // Optional. The number of remaining gifts of this type that can be sent; for limited gifts only
+}
+
+// Gifts represent a list of gifts.
+type Gifts struct {
+	Gifts []*Gift `json:"gifts"` // The list of gifts
+}
+
+// Returns the list of gifts that can be sent by the bot to users and channel chats. Requires no parameters. Returns a Gifts object.
+func (api *API) GetAvailableGifts() (*Gifts, error) {
+	return callJson[*Gifts](api, "getAvailableGifts", nil)
+}
+
+// Sends a gift to the given user or channel chat. The gift can't be converted to Telegram Stars by the receiver. Returns True on success.
+type SendGift struct {
+	UserId        int64            `json:"user_id,omitempty"`         // Required if chat_id is not specified. Unique identifier of the target user who will receive the gift.
+	ChatId        ChatID           `json:"chat_id,omitempty"`         // Required if user_id is not specified. Unique identifier for the chat or username of the channel (in the format @channelusername) that will receive the gift.
+	GiftId        string           `json:"gift_id"`                   // Identifier of the gift
+	PayForUpgrade bool             `json:"pay_for_upgrade,omitempty"` // Pass True to pay for the gift upgrade from the bot's balance, thereby making the upgrade free for the receiver
+	Text          string           `json:"text,omitempty"`            // Text that will be shown along with the gift; 0-128 characters
+	TextParseMode ParseMode        `json:"text_parse_mode,omitempty"` // Mode for parsing entities in the text. See formatting options for more details. Entities other than “bold”, “italic”, “underline”, “strikethrough”, “spoiler”, and “custom_emoji” are ignored.
+	TextEntities  []*MessageEntity `json:"text_entities,omitempty"`   // A JSON-serialized list of special entities that appear in the gift text. It can be specified instead of text_parse_mode. Entities other than “bold”, “italic”, “underline”, “strikethrough”, “spoiler”, and “custom_emoji” are ignored.
+}
+
+// Sends a gift to the given user or channel chat. The gift can't be converted to Telegram Stars by the receiver. Returns True on success.
+func (api *API) SendGift(payload *SendGift) (bool, error) {
+	return callJson[bool](api, "sendGift", payload)
+}
+
+// Posts a story on behalf of a managed business account. Requires the can_manage_stories business bot right. Returns Story on success.
+type PostStory struct {
+	BusinessConnectionId string            `json:"business_connection_id"`      // Unique identifier of the business connection
+	Content              InputStoryContent `json:"content"`                     // Content of the story
+	ActivePeriod         int64             `json:"active_period"`               // Period after which the story is moved to the archive, in seconds; must be one of 6 * 3600, 12 * 3600, 86400, or 2 * 86400
+	Caption              string            `json:"caption,omitempty"`           // Caption of the story, 0-2048 characters after entities parsing
+	ParseMode            ParseMode         `json:"parse_mode,omitempty"`        // Mode for parsing entities in the story caption. See formatting options for more details.
+	CaptionEntities      []*MessageEntity  `json:"caption_entities,omitempty"`  // A JSON-serialized list of special entities that appear in the caption, which can be specified instead of parse_mode
+	PostToChatPage       bool              `json:"post_to_chat_page,omitempty"` // Pass True to keep the story accessible after it expires
+	ProtectContent       bool              `json:"protect_content,omitempty"`   // Pass True if the content of the story must be protected from forwarding and screenshotting
+}
+
+func (x *PostStory) getFiles() map[string]*InputFile {
+	media := map[string]*InputFile{}
+
+	if x.Content != nil {
+		for key, value := range x.Content.getFiles() {
+			media[key] = value
+		}
+	}
+
+	return media
+}
+
+func (x *PostStory) getParams() (map[string]string, error) {
+	payload := map[string]string{}
+
+	payload["business_connection_id"] = x.BusinessConnectionId
+	if bb, err := json.Marshal(x.Content); err != nil {
+		return nil, err
+	} else {
+		payload["content"] = string(bb)
+	}
+	payload["active_period"] = strconv.FormatInt(x.ActivePeriod, 10)
+	if x.Caption != "" {
+		payload["caption"] = x.Caption
+	}
+	if x.ParseMode != ParseModeNone {
+		payload["parse_mode"] = string(x.ParseMode)
+	}
+	if x.CaptionEntities != nil {
+		if bb, err := json.Marshal(x.CaptionEntities); err != nil {
+			return nil, err
+		} else {
+			payload["caption_entities"] = string(bb)
+		}
+	}
+	if x.PostToChatPage {
+		payload["post_to_chat_page"] = strconv.FormatBool(x.PostToChatPage)
+	}
+	if x.ProtectContent {
+		payload["protect_content"] = strconv.FormatBool(x.ProtectContent)
+	}
+
+	return payload, nil
+}
+
+// Posts a story on behalf of a managed business account. Requires the can_manage_stories business bot right. Returns Story on success.
+func (api *API) PostStory(payload *PostStory) (*Story, error) {
+	if files := payload.getFiles(); len(files) != 0 {
+		params, err := payload.getParams()
+		if err != nil {
+			return nil, err
+		}
+		return callMultipart[*Story](api, "postStory", params, files)
+	}
+	return callJson[*Story](api, "postStory", payload)
+}
+
+// PaidMediaPurchased contains information about a paid media purchase.
+type PaidMediaPurchased struct {
+	From             User   `json:"from"`               // User who purchased the media
+	PaidMediaPayload string `json:"paid_media_payload"` // Bot-specified paid media payload
+}
+
+// RevenueWithdrawalState describes the state of a revenue withdrawal operation. Currently, it can be one of
+// RevenueWithdrawalStatePending, RevenueWithdrawalStateSucceeded, RevenueWithdrawalStateFailed
+type RevenueWithdrawalState interface {
+	// IsRevenueWithdrawalState does nothing and is only used to enforce type-safety
+	IsRevenueWithdrawalState()
+}
+
+// The withdrawal is in progress.
+type RevenueWithdrawalStatePending struct {
+	Type string `json:"type"` // Type of the state, always “pending”
+}
+
+func (RevenueWithdrawalStatePending) IsRevenueWithdrawalState() {}
+
+// The withdrawal succeeded.
+type RevenueWithdrawalStateSucceeded struct {
+	Type string `json:"type"` // Type of the state, always “succeeded”
+	Date int64  `json:"date"` // Date the withdrawal was completed in Unix time
+	Url  string `json:"url"`  // An HTTPS URL that can be used to see transaction details
+}
+
+func (RevenueWithdrawalStateSucceeded) IsRevenueWithdrawalState() {}
+
+// The withdrawal failed and the transaction was refunded.
+type RevenueWithdrawalStateFailed struct {
+	Type string `json:"type"` // Type of the state, always “failed”
+}
+
+func (RevenueWithdrawalStateFailed) IsRevenueWithdrawalState() {}
+
+// TransactionPartner describes the source of a transaction, or its recipient for outgoing transactions. Currently, it can be one of
+// TransactionPartnerUser, TransactionPartnerFragment, TransactionPartnerTelegramAds, TransactionPartnerTelegramApi, TransactionPartnerOther
+type TransactionPartner interface {
+	// IsTransactionPartner does nothing and is only used to enforce type-safety
+	IsTransactionPartner()
+}
+
+// Describes a transaction with a user.
+type TransactionPartnerUser struct {
+	Type             string `json:"type"`                         // Type of the transaction partner, always “user”
+	User             User   `json:"user"`                         // Information about the user
+	InvoicePayload   string `json:"invoice_payload,omitempty"`    // Optional. Bot-specified invoice payload
+	PaidMediaPayload string `json:"paid_media_payload,omitempty"` // Optional. Bot-specified paid media payload
+}
+
+func (TransactionPartnerUser) IsTransactionPartner() {}
+
+// Describes a withdrawal transaction with Fragment.
+type TransactionPartnerFragment struct {
+	Type            string                 `json:"type"`                       // Type of the transaction partner, always “fragment”
+	WithdrawalState RevenueWithdrawalState `json:"withdrawal_state,omitempty"` // Optional. State of the transaction if the transaction is outgoing
+}
+
+func (TransactionPartnerFragment) IsTransactionPartner() {}
+
+func (x *TransactionPartnerFragment) UnmarshalJSON(rawBytes []byte) (err error) {
+	if len(rawBytes) == 0 {
+		return nil
+	}
+
+	type temp struct {
+		Type            string          `json:"type"`                       // Type of the transaction partner, always “fragment”
+		WithdrawalState json.RawMessage `json:"withdrawal_state,omitempty"` // Optional. State of the transaction if the transaction is outgoing
+	}
+	raw := &temp{}
+
+	if err = json.Unmarshal(rawBytes, raw); err != nil {
+		return err
+	}
+
+	if data, err := unmarshalRevenueWithdrawalState(raw.WithdrawalState); err != nil {
+		return err
+	} else {
+		x.WithdrawalState = data
+	}
+	x.Type = raw.Type
+
+	return nil
+}
+
+// Describes a withdrawal transaction to the Telegram Ads platform.
+type TransactionPartnerTelegramAds struct {
+	Type string `json:"type"` // Type of the transaction partner, always “telegram_ads”
+}
+
+func (TransactionPartnerTelegramAds) IsTransactionPartner() {}
+
+// Describes a transaction with payment for paid broadcasting.
+type TransactionPartnerTelegramApi struct {
+	Type         string `json:"type"`          // Type of the transaction partner, always “telegram_api”
+	RequestCount int64  `json:"request_count"` // The number of successful requests that exceeded regular limits and were therefore billed
+}
+
+func (TransactionPartnerTelegramApi) IsTransactionPartner() {}
+
+// Describes a transaction with an unknown source or recipient.
+type TransactionPartnerOther struct {
+	Type string `json:"type"` // Type of the transaction partner, always “other”
+}
+
+func (TransactionPartnerOther) IsTransactionPartner() {}
+
+// StarTransaction describes a Telegram Star transaction.
+type StarTransaction struct {
+	Id       string             `json:"id"`                 // Unique identifier of the transaction. Coincides with the identifier of the original transaction for refund transactions. Coincides with SuccessfulPayment.telegram_payment_charge_id for successful incoming payments from users.
+	Amount   int64              `json:"amount"`             // Number of Telegram Stars transferred by the transaction
+	Date     int64              `json:"date"`               // Date the transaction was created in Unix time
+	Source   TransactionPartner `json:"source,omitempty"`   // Optional. Source of an incoming transaction (e.g., a user purchasing goods or services, Fragment refunding a failed withdrawal). Only for incoming transactions
+	Receiver TransactionPartner `json:"receiver,omitempty"` // Optional. Receiver of an outgoing transaction (e.g., a user for a purchase refund, Fragment for a withdrawal). Only for outgoing transactions
+}
+
+func (x *StarTransaction) UnmarshalJSON(rawBytes []byte) (err error) {
+	if len(rawBytes) == 0 {
+		return nil
+	}
+
+	type temp struct {
+		Id       string          `json:"id"`                 // Unique identifier of the transaction. Coincides with the identifier of the original transaction for refund transactions. Coincides with SuccessfulPayment.telegram_payment_charge_id for successful incoming payments from users.
+		Amount   int64           `json:"amount"`             // Number of Telegram Stars transferred by the transaction
+		Date     int64           `json:"date"`               // Date the transaction was created in Unix time
+		Source   json.RawMessage `json:"source,omitempty"`   // Optional. Source of an incoming transaction (e.g., a user purchasing goods or services, Fragment refunding a failed withdrawal). Only for incoming transactions
+		Receiver json.RawMessage `json:"receiver,omitempty"` // Optional. Receiver of an outgoing transaction (e.g., a user for a purchase refund, Fragment for a withdrawal). Only for outgoing transactions
+	}
+	raw := &temp{}
+
+	if err = json.Unmarshal(rawBytes, raw); err != nil {
+		return err
+	}
+
+	if data, err := unmarshalTransactionPartner(raw.Source); err != nil {
+		return err
+	} else {
+		x.Source = data
+	}
+
+	if data, err := unmarshalTransactionPartner(raw.Receiver); err != nil {
+		return err
+	} else {
+		x.Receiver = data
+	}
+	x.Id = raw.Id
+	x.Amount = raw.Amount
+	x.Date = raw.Date
+
+	return nil
+}
+
+// StarTransactions contains a list of Telegram Star transactions.
+type StarTransactions struct {
+	Transactions []*StarTransaction `json:"transactions"` // The list of transactions
+}
+
 // Describes Telegram Passport data shared with the bot by the user.
 type PassportData struct {
 	Data        []*EncryptedPassportElement `json:"data"`        // Array with information about documents and other Telegram Passport elements that was shared with the bot
@@ -5102,12 +6136,14 @@ type PassportElementErrorUnspecified struct {
 func (PassportElementErrorUnspecified) IsPassportElementError() {}
 
 // sendGame is used to send a game. On success, the sent Message is returned.
+
 type SendGame struct {
 	ChatId                   int64                 `json:"chat_id"`                               // Unique identifier for the target chat
 	MessageThreadId          int64                 `json:"message_thread_id,omitempty"`           // Unique identifier for the target message thread (topic) of the forum; for forum supergroups only
 	GameShortName            string                `json:"game_short_name"`                       // Short name of the game, serves as the unique identifier for the game. Set up your games via @BotFather.
 	DisableNotification      bool                  `json:"disable_notification,omitempty"`        // Sends the message silently. Users will receive a notification with no sound.
 	ProtectContent           bool                  `json:"protect_content,omitempty"`             // Protects the contents of the sent message from forwarding and saving
+	MessageEffectId          string                `json:"message_effect_id,omitempty"`           // Unique identifier of the message effect to be added to the message; for private chats only
 	ReplyToMessageId         int64                 `json:"reply_to_message_id,omitempty"`         // If the message is a reply, ID of the original message
 	AllowSendingWithoutReply bool                  `json:"allow_sending_without_reply,omitempty"` // Pass True if the message should be sent even if the specified replied-to message is not found
 	ReplyMarkup              *InlineKeyboardMarkup `json:"reply_markup,omitempty"`                // A JSON-serialized object for an inline keyboard. If empty, one 'Play game_title' button will be shown. If not empty, the first button must launch the game.
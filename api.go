@@ -2,13 +2,16 @@ package tgo
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"mime/multipart"
 	"net/http"
+	"strings"
+	"time"
 )
 
-//go:generate go run ./cmd
+//go:generate go run ./cmd/genapi
 
 const TelegramHost = "https://api.telegram.org"
 
@@ -20,9 +23,28 @@ type httpResponse[T any] struct {
 
 // API is a telegram bot API client instance.
 type API struct {
-	host   string
-	token  string
-	client *http.Client
+	host    string
+	token   string
+	client  *http.Client
+	breaker *Breaker
+	slowLog *SlowLog
+	budget  *callBudget
+	hedger  *Hedger
+	logger  Logger
+	metrics Metrics
+	tracer  Tracer
+	limiter *RateLimiter
+	ctx     context.Context
+	testEnv bool
+	dryRun  *dryRunState
+
+	// muteCheck, if not nil, fails the messages to the chats in which the bot is muted; see bot.MuteChat.
+	muteCheck func(chatID ChatID) error
+
+	interceptors       []Interceptor
+	mediaPipeline      []MediaTransformer
+	thumbnails         ThumbnailProvider
+	businessConnection string
 }
 
 // NewAPI creates a new instance of the Telegram API client.
@@ -42,6 +64,7 @@ func NewAPI(token, host string, client *http.Client) *API {
 		host:   host,
 		token:  token,
 		client: client,
+		logger: stdLogger{},
 	}
 }
 
@@ -49,37 +72,55 @@ func NewAPI(token, host string, client *http.Client) *API {
 // It takes the filePath obtained from GetFile and returns an http.Response
 // and an error. Please note that the filePath is not the same as the fileID.
 func (api *API) Download(filePath string) (*http.Response, error) {
-	return api.client.Get(api.host + "/file/bot" + api.token + "/" + filePath)
+	return api.client.Get(api.host + "/file" + api.botPath() + "/" + filePath)
 }
 
-func callJson[T any](a *API, method string, rawData any) (T, error) {
-	var response httpResponse[T]
+// TestEnvironment reports whether the api calls telegram's test environment; see Options.TestEnvironment.
+func (api *API) TestEnvironment() bool { return api.testEnv }
 
-	body := bytes.NewBuffer(nil)
-	if err := json.NewEncoder(body).Encode(rawData); err != nil {
-		return response.Result, err
+// botPath returns the path of the bot's methods and files.
+func (api *API) botPath() string {
+	if api.testEnv {
+		return "/bot" + api.token + "/test"
 	}
+	return "/bot" + api.token
+}
 
-	resp, err := a.client.Post(a.host+"/bot"+a.token+"/"+method, "application/json", body)
-	if err != nil {
-		return response.Result, err
+func callJson[T any](a *API, method string, rawData any) (result T, err error) {
+	end := a.traceCall(method, func() string { return payloadChatID(rawData) })
+	defer func() { end(err) }()
+
+	return intercept(a, method, rawData, func() (T, error) { return postJson[T](a, method, rawData) })
+}
+
+// postJson encodes the data and calls the method with it.
+func postJson[T any](a *API, method string, rawData any) (result T, err error) {
+	body := bytes.NewBuffer(nil)
+	if err = json.NewEncoder(body).Encode(rawData); err != nil {
+		return result, err
 	}
-	defer resp.Body.Close()
 
-	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return response.Result, err
-	} else if !response.OK {
-		return response.Result, response.Error
+	if body, err = a.businessJSON(body); err != nil {
+		return result, err
 	}
 
-	return response.Result, nil
+	return call[T](a, method, "application/json", body)
 }
 
-func callMultipart[T any](a *API, method string, params map[string]string, files map[string]*InputFile) (T, error) {
+func callMultipart[T any](a *API, method string, params map[string]string, files map[string]*InputFile) (result T, err error) {
+	end := a.traceCall(method, func() string { return strings.Trim(params["chat_id"], `"`) })
+	defer func() { end(err) }()
+
+	return intercept(a, method, params, func() (T, error) { return postMultipart[T](a, method, params, files) })
+}
+
+// postMultipart streams the params and the files as a multipart body, and calls the method with it.
+func postMultipart[T any](a *API, method string, params map[string]string, files map[string]*InputFile) (result T, err error) {
 	r, w := io.Pipe()
 	defer r.Close()
 
 	m := multipart.NewWriter(w)
+	params = a.businessParams(params)
 
 	go func() {
 		defer w.Close()
@@ -90,29 +131,93 @@ func callMultipart[T any](a *API, method string, params map[string]string, files
 		}
 
 		for key, file := range files {
+			info := MediaInfo{Method: method, Field: key, Name: file.Value}
+
+			content, err := a.trackUpload(info, file.Reader)
+			if err == nil {
+				content, err = a.transformMedia(info, content)
+			}
+			if err != nil {
+				w.CloseWithError(err)
+				return
+			}
+
 			ww, err := m.CreateFormFile(key, file.Value)
 			if err != nil {
 				w.CloseWithError(err)
 				return
-			} else if _, err = io.Copy(ww, file.Reader); err != nil {
+			} else if _, err = io.Copy(ww, content); err != nil {
 				w.CloseWithError(err)
 				return
 			}
 		}
 	}()
 
-	var response httpResponse[T]
+	return call[T](a, method, m.FormDataContentType(), r)
+}
+
+// call sends the request body to the method and returns its decoded result.
+func call[T any](a *API, method, contentType string, body io.Reader) (result T, err error) {
+	defer func(start time.Time) {
+		took := time.Since(start)
+		a.logCall(method, err, "took", took)
+		if a.metrics != nil {
+			a.metrics.APICall(method, took, err)
+		}
+	}(time.Now())
+
+	if a.budget != nil {
+		if err = a.budget.take(a, method); err != nil {
+			return result, err
+		}
+	}
+
+	if a.breaker != nil {
+		if err = a.breaker.allow(method); err != nil {
+			return result, err
+		}
+		defer func() { a.breaker.done(method, err) }()
+	}
+
+	if a.slowLog != nil {
+		defer func(start time.Time) { a.slowLog.call(method, time.Since(start)) }(time.Now())
+	}
 
-	resp, err := a.client.Post(a.host+"/bot"+a.token+"/"+method, m.FormDataContentType(), r)
+	if a.limiter != nil && method != "getUpdates" {
+		if err = a.limiter.Wait(a.Context()); err != nil {
+			return result, err
+		}
+	}
+
+	if buf, ok := body.(*bytes.Buffer); ok && a.hedger != nil && a.hedger.covers(method) {
+		payload := buf.Bytes()
+		return hedge(a, method, func(ctx context.Context) (T, error) {
+			return post[T](ctx, a, method, contentType, bytes.NewReader(payload))
+		})
+	}
+
+	return post[T](a.Context(), a, method, contentType, body)
+}
+
+// post sends a single request of the call and decodes its result.
+func post[T any](ctx context.Context, a *API, method, contentType string, body io.Reader) (result T, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.host+a.botPath()+"/"+method, body)
 	if err != nil {
-		return response.Result, nil
+		return result, err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return result, err
 	}
 	defer resp.Body.Close()
 
+	var response httpResponse[T]
 	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return response.Result, err
+		return result, err
 	} else if !response.OK {
-		return response.Result, response.Error
+		return result, response.Error
 	}
 
 	return response.Result, nil
@@ -5,6 +5,20 @@ import (
 	"errors"
 )
 
+// unmarshalArray unmarshals each of the raw items using the unmarshaler of their interface.
+func unmarshalArray[T any](rawItems []json.RawMessage, unmarshal func(json.RawMessage) (T, error)) ([]T, error) {
+	items := make([]T, len(rawItems))
+	for i, raw := range rawItems {
+		item, err := unmarshal(raw)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = item
+	}
+
+	return items, nil
+}
+
 func unmarshalChatMember(rawBytes json.RawMessage) (data ChatMember, err error) {
 	var temp struct {
 		Status string `json:"status"`
@@ -88,3 +102,144 @@ func unmarshalInputMessageContent(rawBytes json.RawMessage) (data InputMessageCo
 	err = json.Unmarshal(rawBytes, data)
 	return data, err
 }
+
+func unmarshalTransactionPartner(rawBytes json.RawMessage) (data TransactionPartner, err error) {
+	// both source and receiver of the star transactions are optional.
+	if len(rawBytes) == 0 {
+		return nil, nil
+	}
+
+	var temp struct {
+		Type string `json:"type"`
+	}
+	if err = json.Unmarshal(rawBytes, &temp); err != nil {
+		return nil, err
+	}
+
+	switch temp.Type {
+	case "user":
+		data = &TransactionPartnerUser{}
+	case "fragment":
+		data = &TransactionPartnerFragment{}
+	case "telegram_ads":
+		data = &TransactionPartnerTelegramAds{}
+	case "telegram_api":
+		data = &TransactionPartnerTelegramApi{}
+	default:
+		// telegram keeps adding new partners; they're better to be known as "other" than failing the whole transaction list.
+		data = &TransactionPartnerOther{}
+	}
+
+	err = json.Unmarshal(rawBytes, data)
+	return data, err
+}
+
+func unmarshalRevenueWithdrawalState(rawBytes json.RawMessage) (data RevenueWithdrawalState, err error) {
+	if len(rawBytes) == 0 {
+		return nil, nil
+	}
+
+	var temp struct {
+		Type string `json:"type"`
+	}
+	if err = json.Unmarshal(rawBytes, &temp); err != nil {
+		return nil, err
+	}
+
+	switch temp.Type {
+	case "pending":
+		data = &RevenueWithdrawalStatePending{}
+	case "succeeded":
+		data = &RevenueWithdrawalStateSucceeded{}
+	case "failed":
+		data = &RevenueWithdrawalStateFailed{}
+	default:
+		return nil, errors.New("unknown type")
+	}
+
+	err = json.Unmarshal(rawBytes, data)
+	return data, err
+}
+
+func unmarshalMessageOrigin(rawBytes json.RawMessage) (data MessageOrigin, err error) {
+	if len(rawBytes) == 0 {
+		return nil, nil
+	}
+
+	var temp struct {
+		Type string `json:"type"`
+	}
+	if err = json.Unmarshal(rawBytes, &temp); err != nil {
+		return nil, err
+	}
+
+	switch temp.Type {
+	case "user":
+		data = &MessageOriginUser{}
+	case "hidden_user":
+		data = &MessageOriginHiddenUser{}
+	case "chat":
+		data = &MessageOriginChat{}
+	case "channel":
+		data = &MessageOriginChannel{}
+	default:
+		return nil, errors.New("unknown type")
+	}
+
+	err = json.Unmarshal(rawBytes, data)
+	return data, err
+}
+
+func unmarshalReactionType(rawBytes json.RawMessage) (data ReactionType, err error) {
+	if len(rawBytes) == 0 {
+		return nil, nil
+	}
+
+	var temp struct {
+		Type string `json:"type"`
+	}
+	if err = json.Unmarshal(rawBytes, &temp); err != nil {
+		return nil, err
+	}
+
+	switch temp.Type {
+	case "emoji":
+		data = &ReactionTypeEmoji{}
+	case "custom_emoji":
+		data = &ReactionTypeCustomEmoji{}
+	case "paid":
+		data = &ReactionTypePaid{}
+	default:
+		return nil, errors.New("unknown type")
+	}
+
+	err = json.Unmarshal(rawBytes, data)
+	return data, err
+}
+
+func unmarshalPaidMedia(rawBytes json.RawMessage) (data PaidMedia, err error) {
+	if len(rawBytes) == 0 {
+		return nil, nil
+	}
+
+	var temp struct {
+		Type string `json:"type"`
+	}
+	if err = json.Unmarshal(rawBytes, &temp); err != nil {
+		return nil, err
+	}
+
+	switch temp.Type {
+	case "preview":
+		data = &PaidMediaPreview{}
+	case "photo":
+		data = &PaidMediaPhoto{}
+	case "video":
+		data = &PaidMediaVideo{}
+	default:
+		return nil, errors.New("unknown type")
+	}
+
+	err = json.Unmarshal(rawBytes, data)
+	return data, err
+}
@@ -26,48 +26,70 @@ func GetAskUID(chatID, senderID int64) string {
 	return fmt.Sprintf("ask:%d:%d", chatID, senderID)
 }
 
-// waitForAnswer waits for an answer from the given UID within the specified timeout duration.
-// It returns the received answer message or an error if the timeout is exceeded.
-func (bot *Bot) waitForAnswer(uid string, timeout time.Duration) (*Message, error) {
+// registerAsk registers a wait for an answer from the given UID, taking the answers over from
+// an older one. Register it before sending the question, so a quick answer isn't missed.
+func (bot *Bot) registerAsk(uid string) chan *Message {
 	waiter := make(chan *Message, 1)
 
 	bot.askMut.Lock()
 	bot.asks[uid] = waiter
 	bot.askMut.Unlock()
 
-	defer func() {
-		bot.askMut.Lock()
-		delete(bot.asks, uid)
-		bot.askMut.Unlock()
-
-		close(waiter)
-	}()
+	return waiter
+}
 
-	aCtx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
+// unregisterAsk removes the wait, unless it's taken over by a newer one.
+func (bot *Bot) unregisterAsk(uid string, waiter chan *Message) {
+	bot.askMut.Lock()
+	if bot.asks[uid] == (chan<- *Message)(waiter) {
+		delete(bot.asks, uid)
+	}
+	bot.askMut.Unlock()
+}
 
+// awaitAnswer waits for the registered waiter's answer until the context is done.
+func (bot *Bot) awaitAnswer(ctx context.Context, uid string, waiter chan *Message) (*Message, error) {
 	select {
 	case answer := <-waiter:
+		// it's removed by sendAnswerIfAsked.
 		return answer, nil
 
-	case <-aCtx.Done():
-		return nil, aCtx.Err()
+	case <-ctx.Done():
+		bot.unregisterAsk(uid, waiter)
+
+		// the answer may be sent right before it's removed.
+		select {
+		case answer := <-waiter:
+			return answer, nil
+		default:
+			return nil, ctx.Err()
+		}
 	}
 }
 
 // sendAnswerIfAsked sends the message into the asks channel if it was a response to an ask.
 // It returns true if the messsage was the response to an ask or false otherwise.
 func (bot *Bot) sendAnswerIfAsked(msg *Message) (sent bool) {
+	uid := GetAskUID(GetChatAndSenderID(msg))
+
 	bot.askMut.RLock()
-	receiver, ok := bot.asks[GetAskUID(GetChatAndSenderID(msg))]
+	_, ok := bot.asks[uid]
 	bot.askMut.RUnlock()
 
+	if !ok {
+		return false
+	}
+
+	bot.askMut.Lock()
+	defer bot.askMut.Unlock()
+
+	// each wait gets a single answer, so it's removed as it's answered; the channel has room for it.
+	receiver, ok := bot.asks[uid]
 	if ok {
+		delete(bot.asks, uid)
 		receiver <- msg
-		return true
 	}
-
-	return false
+	return ok
 }
 
 // Ask sends a question message to the specified chat and waits for an answer within the given timeout duration.
@@ -76,11 +98,19 @@ func (bot *Bot) Ask(chatId, userId int64, msg Sendable, timeout time.Duration) (
 	if msg.GetChatID() == nil {
 		msg.SetChatID(chatId)
 	}
+
+	uid := GetAskUID(chatId, userId)
+	waiter := bot.registerAsk(uid)
+
 	question, err = bot.Send(msg)
 	if err != nil {
+		bot.unregisterAsk(uid, waiter)
 		return nil, nil, err
 	}
 
-	answer, err = bot.waitForAnswer(GetAskUID(chatId, userId), timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	answer, err = bot.awaitAnswer(ctx, uid, waiter)
 	return question, answer, err
 }
@@ -0,0 +1,103 @@
+// Package auth verifies the data which telegram passes to the websites in the name of the users.
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMissingHash = errors.New("auth: login data has no hash")
+	ErrInvalidHash = errors.New("auth: login data hash is invalid")
+	ErrExpired     = errors.New("auth: login data is expired")
+)
+
+// DefaultMaxAuthAge is the maximum age of the login data accepted by VerifyLoginWidget and ParseLoginWidget.
+const DefaultMaxAuthAge = 24 * time.Hour
+
+// LoginData contains the user data passed by the Telegram Login Widget.
+type LoginData struct {
+	Id        int64
+	FirstName string
+	LastName  string
+	Username  string
+	PhotoUrl  string
+	AuthDate  time.Time
+}
+
+// VerifyLoginWidget checks the hash of the data received from the Telegram Login Widget
+// with the bot token, and that its auth_date is not older than DefaultMaxAuthAge.
+//
+// see https://core.telegram.org/widgets/login#checking-authorization
+func VerifyLoginWidget(data map[string]string, botToken string) error {
+	return VerifyLoginWidgetMaxAge(data, botToken, DefaultMaxAuthAge)
+}
+
+// VerifyLoginWidgetMaxAge works like VerifyLoginWidget, but returns ErrExpired if the data is
+// older than maxAge, if maxAge is not zero.
+func VerifyLoginWidgetMaxAge(data map[string]string, botToken string, maxAge time.Duration) error {
+	hash := data["hash"]
+	if hash == "" {
+		return ErrMissingHash
+	}
+
+	pairs := make([]string, 0, len(data))
+	for key, value := range data {
+		if key != "hash" {
+			pairs = append(pairs, key+"="+value)
+		}
+	}
+	sort.Strings(pairs)
+
+	secretKey := sha256.Sum256([]byte(botToken))
+	h := hmac.New(sha256.New, secretKey[:])
+	h.Write([]byte(strings.Join(pairs, "\n")))
+
+	if !hmac.Equal([]byte(hex.EncodeToString(h.Sum(nil))), []byte(hash)) {
+		return ErrInvalidHash
+	}
+
+	if maxAge != 0 {
+		authDate, err := strconv.ParseInt(data["auth_date"], 10, 64)
+		if err != nil || time.Since(time.Unix(authDate, 0)) > maxAge {
+			return ErrExpired
+		}
+	}
+
+	return nil
+}
+
+// ParseLoginWidget verifies the data using VerifyLoginWidget and returns the parsed result.
+func ParseLoginWidget(data map[string]string, botToken string) (*LoginData, error) {
+	return ParseLoginWidgetMaxAge(data, botToken, DefaultMaxAuthAge)
+}
+
+// ParseLoginWidgetMaxAge verifies the data using VerifyLoginWidgetMaxAge and returns the parsed result.
+func ParseLoginWidgetMaxAge(data map[string]string, botToken string, maxAge time.Duration) (*LoginData, error) {
+	if err := VerifyLoginWidgetMaxAge(data, botToken, maxAge); err != nil {
+		return nil, err
+	}
+
+	id, err := strconv.ParseInt(data["id"], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	// auth_date is already validated if maxAge is set, but it may be missing otherwise.
+	authDate, _ := strconv.ParseInt(data["auth_date"], 10, 64)
+
+	return &LoginData{
+		Id:        id,
+		FirstName: data["first_name"],
+		LastName:  data["last_name"],
+		Username:  data["username"],
+		PhotoUrl:  data["photo_url"],
+		AuthDate:  time.Unix(authDate, 0),
+	}, nil
+}
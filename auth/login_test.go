@@ -0,0 +1,37 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestVerifyLoginWidget(t *testing.T) {
+	data := map[string]string{
+		"id":         "42",
+		"first_name": "Ali",
+		"username":   "ali",
+		"auth_date":  "1700000000",
+		"hash":       "68c3c002ff9a1116f8d3187c54bd631a068ecf313b80a2930369ad45a8e4a8b7",
+	}
+
+	if err := VerifyLoginWidget(data, "123456:TEST"); !errors.Is(err, ErrExpired) {
+		t.Errorf("verified the expired data: %v", err)
+	}
+
+	login, err := ParseLoginWidgetMaxAge(data, "123456:TEST", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if login.Id != 42 || login.Username != "ali" || login.AuthDate.Unix() != 1700000000 {
+		t.Errorf("unexpected login data: %+v", login)
+	}
+
+	if err = VerifyLoginWidgetMaxAge(data, "654321:TEST", 0); !errors.Is(err, ErrInvalidHash) {
+		t.Errorf("verified with the wrong token: %v", err)
+	}
+
+	data["username"] = "someone_else"
+	if err = VerifyLoginWidgetMaxAge(data, "123456:TEST", 0); !errors.Is(err, ErrInvalidHash) {
+		t.Errorf("verified the tampered data: %v", err)
+	}
+}
@@ -0,0 +1,27 @@
+package tgo
+
+import "time"
+
+// AutoDeleteTime returns the new auto-delete time of the chat's messages, or zero if it's disabled.
+func (x *MessageAutoDeleteTimerChanged) AutoDeleteTime() time.Duration {
+	return time.Duration(x.MessageAutoDeleteTime) * time.Second
+}
+
+// AutoDeleteTime returns the time after which all messages sent to the chat will be automatically deleted,
+// or zero if it's disabled. It's only filled in the chats returned by getChat.
+func (x *Chat) AutoDeleteTime() time.Duration {
+	return time.Duration(x.MessageAutoDeleteTime) * time.Second
+}
+
+// GetAutoDeleteTime fetches the chat and returns its auto-delete time, or zero if it's disabled.
+//
+// Note: bots can't change the auto-delete time; keep track of the MessageAutoDeleteTimerChanged
+// service messages to stay up-to-date without fetching the chat every time.
+func (api *API) GetAutoDeleteTime(chatID ChatID) (time.Duration, error) {
+	chat, err := api.GetChat(&GetChat{ChatId: chatID})
+	if err != nil {
+		return 0, err
+	}
+
+	return chat.AutoDeleteTime(), nil
+}
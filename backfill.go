@@ -0,0 +1,141 @@
+package tgo
+
+import (
+	"context"
+	"encoding/json"
+	"runtime"
+	"sync"
+)
+
+// BackfillOptions configures bot.Backfill. The zero value is valid and uses the defaults.
+type BackfillOptions struct {
+	// Offset is the identifier of the first update to fetch; zero starts from the oldest pending one.
+	Offset int64
+
+	// Types, if not empty, are the update types to handle, such as "message" and "callback_query";
+	// the others are discarded by their keys, without decoding them.
+	Types []string
+
+	// Workers is the number of the goroutines handling the updates; it defaults to the number of CPUs.
+	// The updates of a batch are handled concurrently, so they may be handled out of order.
+	Workers int
+
+	// Handler handles the updates; it defaults to bot.HandleUpdate.
+	Handler func(update *Update)
+
+	// OnProgress, if not nil, is called after each batch of the updates is handled.
+	OnProgress func(stats BackfillStats)
+}
+
+// BackfillStats is the progress of a backfill.
+type BackfillStats struct {
+	Fetched   int   // number of the updates received from telegram
+	Handled   int   // number of the updates passed to the handler
+	Discarded int   // number of the updates discarded by their type
+	Offset    int64 // the offset to continue polling from
+}
+
+// Backfill drains the pending updates as fast as possible, such as after a long downtime, and
+// returns once there's none left, or the context is done. Pass the returned Offset to the poller.
+//
+// The offset only moves past a batch once all of its updates are handled, so aborting it loses nothing.
+func (bot *Bot) Backfill(ctx context.Context, opts BackfillOptions) (BackfillStats, error) {
+	if opts.Workers <= 0 {
+		opts.Workers = runtime.NumCPU()
+	}
+	if opts.Handler == nil {
+		opts.Handler = bot.HandleUpdate
+	}
+
+	types := make(map[string]bool, len(opts.Types))
+	for _, typ := range opts.Types {
+		types[typ] = true
+	}
+
+	stats := BackfillStats{Offset: opts.Offset}
+	for {
+		if err := ctx.Err(); err != nil {
+			return stats, err
+		}
+
+		batch, err := callJson[[]json.RawMessage](bot.API, "getUpdates", &GetUpdates{Offset: stats.Offset, Limit: 100})
+		if err != nil {
+			return stats, err
+		} else if len(batch) == 0 {
+			return stats, nil
+		}
+		stats.Fetched += len(batch)
+
+		updates := make(chan *Update)
+		var wg sync.WaitGroup
+		wg.Add(opts.Workers)
+		for i := 0; i < opts.Workers; i++ {
+			go func() {
+				defer wg.Done()
+				for update := range updates {
+					opts.Handler(update)
+				}
+			}()
+		}
+
+		var next int64
+		for _, raw := range batch {
+			update, id, err := decodeBackfillUpdate(raw, types)
+			if err != nil {
+				close(updates)
+				wg.Wait()
+				return stats, err
+			}
+
+			next = id + 1
+			if update == nil {
+				stats.Discarded++
+				continue
+			}
+
+			updates <- update
+			stats.Handled++
+		}
+
+		close(updates)
+		wg.Wait()
+
+		stats.Offset = next
+		if opts.OnProgress != nil {
+			opts.OnProgress(stats)
+		}
+	}
+}
+
+// decodeBackfillUpdate returns the update's id, and the decoded update if its type is one of the types.
+func decodeBackfillUpdate(raw json.RawMessage, types map[string]bool) (*Update, int64, error) {
+	if len(types) != 0 {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return nil, 0, err
+		}
+
+		var id int64
+		if err := json.Unmarshal(fields["update_id"], &id); err != nil {
+			return nil, 0, err
+		}
+
+		wanted := false
+		for key := range fields {
+			if types[key] {
+				wanted = true
+				break
+			}
+		}
+		if !wanted {
+			return nil, id, nil
+		}
+	}
+
+	update := &Update{}
+	if err := json.Unmarshal(raw, update); err != nil {
+		return nil, 0, err
+	}
+
+	return update, update.UpdateId, nil
+}
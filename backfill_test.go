@@ -0,0 +1,42 @@
+package tgo_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestBackfill(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	pending := []any{
+		map[string]any{"update_id": 1, "message": map[string]any{"message_id": 1, "date": 1, "chat": map[string]any{"id": 1, "type": "private"}}},
+		map[string]any{"update_id": 2, "poll": map[string]any{"id": "p"}},
+		map[string]any{"update_id": 3, "message": map[string]any{"message_id": 2, "date": 1, "chat": map[string]any{"id": 1, "type": "private"}}},
+	}
+	server.Handle("getUpdates", func(call tgotest.Call) (any, *tgo.Error) {
+		if call.Params["offset"] == nil {
+			return pending, nil
+		}
+		return []any{}, nil
+	})
+
+	bot := server.Bot(tgo.Options{})
+
+	var handled int32
+	stats, err := bot.Backfill(context.Background(), tgo.BackfillOptions{
+		Types:   []string{"message"},
+		Handler: func(update *tgo.Update) { atomic.AddInt32(&handled, 1) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stats != (tgo.BackfillStats{Fetched: 3, Handled: 2, Discarded: 1, Offset: 4}) || handled != 2 {
+		t.Errorf("unexpected stats %+v, handled %d", stats, handled)
+	}
+}
@@ -0,0 +1,62 @@
+package tgo
+
+import (
+	"errors"
+	"sync"
+)
+
+// BlockStore persists the users who have blocked the bot, tracked from the my_chat_member updates of
+// their private chats. Implement it to keep the statuses in your own database.
+type BlockStore interface {
+	SetBlocked(userID int64, blocked bool) error
+	IsBlocked(userID int64) (bool, error)
+}
+
+// MemoryBlockStore is an in-memory BlockStore; it's used by the bots by default.
+type MemoryBlockStore struct{ users sync.Map }
+
+// SetBlocked implements the BlockStore interface.
+func (s *MemoryBlockStore) SetBlocked(userID int64, blocked bool) error {
+	if blocked {
+		s.users.Store(userID, struct{}{})
+	} else {
+		s.users.Delete(userID)
+	}
+	return nil
+}
+
+// IsBlocked implements the BlockStore interface.
+func (s *MemoryBlockStore) IsBlocked(userID int64) (bool, error) {
+	_, blocked := s.users.Load(userID)
+	return blocked, nil
+}
+
+// IsBlockedBy returns true if the user has blocked the bot, as far as the bot has seen.
+// The store's errors are treated as not being blocked.
+func (bot *Bot) IsBlockedBy(userID int64) bool {
+	blocked, err := bot.blockStore.IsBlocked(userID)
+	return err == nil && blocked
+}
+
+// trackBlock updates the block store if the update is about a user blocking or unblocking the bot.
+func (bot *Bot) trackBlock(update *Update) {
+	x := update.MyChatMember
+	if x == nil || !(x.BlockedBot() || x.UnblockedBot()) {
+		return
+	}
+
+	if err := bot.blockStore.SetBlocked(x.Chat.Id, x.BlockedBot()); err != nil {
+		bot.log(LevelError, "failed to store the block status", "user_id", x.Chat.Id, "error", err)
+	}
+}
+
+// SetBlockedBy stores whether the user has blocked the bot, such as when sending them a message
+// fails with ErrBotBlockedByUser. The my_chat_member updates are tracked automatically.
+func (bot *Bot) SetBlockedBy(userID int64, blocked bool) error {
+	return bot.blockStore.SetBlocked(userID, blocked)
+}
+
+// IsBlockedErr returns true if the error is telegram telling that the user has blocked the bot.
+func IsBlockedErr(err error) bool {
+	return errors.Is(err, ErrBlockedByUser)
+}
@@ -0,0 +1,29 @@
+package tgo_test
+
+import (
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestBlockTracking(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	bot := server.Bot(tgo.Options{})
+
+	memberUpdate := func(old, new tgo.ChatMember) *tgo.Update {
+		return &tgo.Update{MyChatMember: &tgo.ChatMemberUpdated{Chat: tgo.Chat{Id: 42, Type: "private"}, OldChatMember: old, NewChatMember: new}}
+	}
+
+	bot.HandleUpdate(memberUpdate(&tgo.ChatMemberMember{Status: "member"}, &tgo.ChatMemberBanned{Status: "kicked"}))
+	if !bot.IsBlockedBy(42) {
+		t.Fatal("user is not tracked as blocked")
+	}
+
+	bot.HandleUpdate(memberUpdate(&tgo.ChatMemberBanned{Status: "kicked"}, &tgo.ChatMemberMember{Status: "member"}))
+	if bot.IsBlockedBy(42) {
+		t.Fatal("user is still tracked as blocked")
+	}
+}
@@ -1,8 +1,12 @@
 package tgo
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 type Filter interface{ Check(update *Update) bool }
@@ -17,29 +21,219 @@ type Bot struct {
 
 	DefaultParseMode ParseMode
 
+	// DefaultLinkPreview, if not nil, is the link preview options of the sent and edited text messages
+	// which don't have their own, such as LinkPreviewDisabled().
+	DefaultLinkPreview *LinkPreviewOptions
+
+	// trigger is the update which the bot is handling, in the copies passed to the routers.
+	trigger *Update
+
+	// botState is shared by the bot and its copies, such as the ones made by WithCallBudget.
+	*botState
+}
+
+type botState struct {
 	asks   map[string]chan<- *Message
 	askMut sync.RWMutex
 
-	routers []Router
+	routers     []Router
+	dispatchers []*dispatcher
 
 	// contains user-ids with their session
 	sessions sync.Map
+
+	translator Translator
+
+	me    *User
+	meMut sync.Mutex
+
+	owners     []int64
+	lastUpdate atomic.Int64
+
+	blockStore BlockStore
+	muteStore  MuteStore
+
+	callBudget int
+
+	callbackStore CallbackStore
+
+	chatCache *ChatCache
+	usernames *ChatCache // resolves the usernames of the muted chats; it's the chatCache if there's one
+
+	dedupe   DedupeStore
+	recorder *Recorder
+
+	commands     *Commands
+	commandsOnce sync.Once
+
+	scheduler    *Scheduler
+	schedulerMut sync.RWMutex
+
+	outbox    *Outbox
+	outboxMut sync.RWMutex
+
+	// polling is canceled by bot.Shutdown to stop the pollers.
+	polling      context.Context
+	stopPolling  context.CancelFunc
+	polledOffset atomic.Int64
+	inflight     inflight
+	pool         *WorkerPool
+
+	shutdownHooks []func(ctx context.Context) error
+	shutdownMut   sync.Mutex
 }
 
 type Options struct {
 	Host             string
 	Client           *http.Client
 	DefaultParseMode ParseMode
+
+	// DefaultLinkPreview, if not nil, is the link preview options of the text messages which don't
+	// have their own; see Bot.DefaultLinkPreview.
+	DefaultLinkPreview *LinkPreviewOptions
+
+	// Transport, if not nil, sends the requests of the Client, or of a new one; it may be a custom
+	// RoundTripper, such as one tuning the TLS configuration, or a proxy one by ProxyTransport.
+	Transport http.RoundTripper
+
+	// TestEnvironment makes the bot call telegram's test environment, whose bots are created by
+	// @BotFather of the test accounts; it's for the integration tests and the staging bots.
+	TestEnvironment bool
+
+	// DryRun makes the bot log its changing API calls, such as sending, editing, and deleting the
+	// messages, instead of sending them, and return their synthesized results; the interceptors see
+	// them as if they were sent. The getting calls, such as getUpdates, are sent anyway, so it may
+	// run against the production data, such as for testing the broadcasts or staging the bot.
+	DryRun bool
+
+	// Breaker, if not nil, short-circuits the non-critical API calls when telegram is having issues.
+	Breaker *Breaker
+
+	// Hedger, if not nil, sends a second attempt of the latency-sensitive calls which are taking too long.
+	Hedger *Hedger
+
+	// Logger, if not nil, receives the structured events of the bot, such as the received updates
+	// and the API calls. The warnings and errors are printed by the standard log package by default.
+	Logger Logger
+
+	// Metrics, if not nil, receives the measurements of the updates and the API calls.
+	Metrics Metrics
+
+	// Tracer, if not nil, traces the handling of the updates and the API calls made by the routers.
+	Tracer Tracer
+
+	// RateLimiter, if not nil, limits the rate of the API calls; it may be shared by many bots.
+	RateLimiter *RateLimiter
+
+	// WorkerPool, if not nil, runs the handlers of the polled and webhook updates, instead of a new
+	// goroutine for each; it may be shared by many bots.
+	WorkerPool *WorkerPool
+
+	// SlowLog, if not nil, logs the handlers and API calls which are slower than its thresholds.
+	SlowLog *SlowLog
+
+	// Translator, if not nil, is used to translate the texts by bot.Translate and the contexts' T methods.
+	Translator Translator
+
+	// BlockStore, if not nil, is used to persist the users who have blocked the bot; it's in-memory by default.
+	BlockStore BlockStore
+
+	// MuteStore, if not nil, is used to persist the chats in which the bot is muted; it's in-memory by default.
+	MuteStore MuteStore
+
+	// Owners are the user ids which bot.NotifyOwner sends the texts to, such as the watchdog's alerts.
+	Owners []int64
+
+	// Interceptors hook into all the API calls, in order; see bot.WithInterceptors.
+	Interceptors []Interceptor
+
+	// MediaPipeline transforms the content of all the uploaded files, in order; see bot.WithMediaPipeline.
+	MediaPipeline []MediaTransformer
+
+	// Thumbnails, if not nil, generates the thumbnails of the uploaded videos, animations, and documents
+	// sent without one; see bot.WithThumbnails.
+	Thumbnails ThumbnailProvider
+
+	// CallbackStore, if not nil, keeps the callback data longer than 64 bytes; see bot.ShrinkCallbackData.
+	CallbackStore CallbackStore
+
+	// ChatCache, if not nil, caches the chats and their members for bot.CachedChat and bot.CachedChatMember,
+	// which the permission checks use.
+	ChatCache *ChatCache
+
+	// Dedupe, if not nil, remembers the received updates, so the ones delivered twice are dropped before
+	// they're handled, such as NewMemoryDedupeStore(0, 0).
+	Dedupe DedupeStore
+
+	// Recorder, if not nil, records all the received updates, including the duplicate ones, to be
+	// replayed by Replay.
+	Recorder *Recorder
+
+	// Commands, if not nil, is the bot's registry of the commands; see bot.Commands and bot.SyncCommands.
+	Commands *Commands
+
+	// CallBudget, if not zero, is the maximum number of the API calls which the routers may make
+	// while handling a single update; the calls beyond it fail with a *BudgetExceededError.
+	CallBudget int
 }
 
 func NewBot(token string, opts Options) (bot *Bot) {
-	api := NewAPI(token, opts.Host, opts.Client)
+	api := NewAPI(token, opts.Host, newClient(opts.Client, opts.Transport))
+	api.testEnv = opts.TestEnvironment
+	if opts.DryRun {
+		api.dryRun = &dryRunState{}
+	}
+	api.breaker = opts.Breaker
+	api.slowLog = opts.SlowLog
+	api.hedger = opts.Hedger
+	api.metrics = opts.Metrics
+	api.tracer = opts.Tracer
+	api.limiter = opts.RateLimiter
+	if opts.Logger != nil {
+		api.logger = opts.Logger
+	}
+	api.interceptors = opts.Interceptors
+	api.mediaPipeline = opts.MediaPipeline
+	api.thumbnails = opts.Thumbnails
 
-	return &Bot{
-		API:              api,
-		DefaultParseMode: opts.DefaultParseMode,
-		asks:             make(map[string]chan<- *Message),
+	if opts.BlockStore == nil {
+		opts.BlockStore = &MemoryBlockStore{}
 	}
+	if opts.MuteStore == nil {
+		opts.MuteStore = &MemoryMuteStore{}
+	}
+	usernames := opts.ChatCache
+	if usernames == nil {
+		usernames = NewChatCache(ChatCacheOptions{})
+	}
+
+	polling, stopPolling := context.WithCancel(context.Background())
+
+	bot = &Bot{
+		API:                api,
+		DefaultParseMode:   opts.DefaultParseMode,
+		DefaultLinkPreview: opts.DefaultLinkPreview,
+		botState: &botState{
+			polling:       polling,
+			pool:          opts.WorkerPool,
+			stopPolling:   stopPolling,
+			asks:          make(map[string]chan<- *Message),
+			translator:    opts.Translator,
+			owners:        opts.Owners,
+			blockStore:    opts.BlockStore,
+			muteStore:     opts.MuteStore,
+			callBudget:    opts.CallBudget,
+			callbackStore: opts.CallbackStore,
+			chatCache:     opts.ChatCache,
+			usernames:     usernames,
+			dedupe:        opts.Dedupe,
+			recorder:      opts.Recorder,
+			commands:      opts.Commands,
+		},
+	}
+	api.muteCheck = bot.checkMute
+
+	return bot
 }
 
 // GetSession returns the stored session as a sync.Map.
@@ -50,9 +244,9 @@ func (bot *Bot) GetSession(sessionID int64) *sync.Map {
 		return result.(*sync.Map)
 	}
 
-	session := &sync.Map{}
-	bot.sessions.Store(sessionID, session)
-	return session
+	// the concurrent calls should get the same session.
+	result, _ = bot.sessions.LoadOrStore(sessionID, &sync.Map{})
+	return result.(*sync.Map)
 }
 
 func (bot *Bot) AddRouter(router Router) error {
@@ -63,3 +257,69 @@ func (bot *Bot) AddRouter(router Router) error {
 	bot.routers = append(bot.routers, router)
 	return nil
 }
+
+// HandleUpdate passes the update to the dispatchers, then to the waiting asks, and then to the
+// routers in the order they were added; it stops as soon as one of them uses the update. The updates
+// which the bot's DedupeStore has already seen are dropped.
+//
+// It's called by the pollers and webhook handlers, but you may call it yourself if you're
+// receiving the updates in some other way.
+func (bot *Bot) HandleUpdate(update *Update) {
+	bot.inflight.hold()
+	defer bot.inflight.done()
+
+	holdUpdate(update)
+	defer releaseUpdate(update)
+	bot.record(update)
+	if bot.isDuplicate(update) {
+		bot.log(LevelDebug, "duplicate update dropped", "update_id", update.UpdateId)
+		return
+	}
+
+	bot.lastUpdate.Store(time.Now().UnixNano())
+	bot.log(LevelDebug, "update received", "update_id", update.UpdateId, "update", updateSummary{update})
+	bot.trackBlock(update)
+	bot.resolveCallbackData(update)
+	if bot.chatCache != nil {
+		bot.chatCache.observe(update)
+	}
+
+	if bot.slowLog != nil {
+		defer func(start time.Time) { bot.slowLog.handler(update, time.Since(start)) }(time.Now())
+	}
+
+	if bot.metrics != nil {
+		updateType := update.Type()
+		bot.metrics.UpdateReceived(updateType)
+		defer func(start time.Time) { bot.metrics.UpdateHandled(updateType, time.Since(start)) }(time.Now())
+	}
+
+	// the routers get a copy of the bot which knows the update, so the muted chats let the
+	// messages triggered by their administrators through.
+	handler := &Bot{DefaultParseMode: bot.DefaultParseMode, DefaultLinkPreview: bot.DefaultLinkPreview, trigger: update, botState: bot.botState}
+	handler.API = bot.API.withMuteCheck(handler.checkMute)
+	if bot.tracer != nil {
+		ctx, end := bot.tracer.StartUpdate(bot.Context(), update)
+		defer end()
+		handler.API = handler.API.WithContext(ctx)
+	}
+	if bot.callBudget > 0 {
+		handler = handler.WithCallBudget(bot.callBudget)
+	}
+
+	for _, ds := range bot.dispatchers {
+		ds.dispatch(update)
+	}
+
+	if update.Message != nil && bot.sendAnswerIfAsked(update.Message) {
+		bot.log(LevelDebug, "update handled", "update_id", update.UpdateId, "by", "ask")
+		return
+	}
+
+	for _, router := range bot.routers {
+		if used := router.HandleUpdate(handler, update); used {
+			bot.log(LevelDebug, "update handled", "update_id", update.UpdateId, "by", fmt.Sprintf("%T", router))
+			return
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package tgo
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by the non-critical API calls which are short-circuited by the Breaker.
+var ErrCircuitOpen = errors.New("tgo: circuit breaker is open")
+
+// BreakerState is the state of a method family's circuit.
+type BreakerState int
+
+const (
+	// BreakerClosed lets all calls pass; it's the healthy state.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen short-circuits the non-critical calls until the cooldown passes.
+	BreakerOpen
+	// BreakerHalfOpen lets a single non-critical call pass as a probe to decide the next state.
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// BreakerOptions configures a Breaker. The zero value is valid and uses the defaults.
+type BreakerOptions struct {
+	// Window is the duration which the error rate is calculated over. Defaults to 30 seconds.
+	Window time.Duration
+
+	// MinRequests is the minimum number of calls in the Window before the circuit can open. Defaults to 10.
+	MinRequests int
+
+	// ErrorRate is the failure ratio (0 to 1) in the Window which opens the circuit. Defaults to 0.5.
+	ErrorRate float64
+
+	// Cooldown is how long the circuit stays open before probing. Defaults to 15 seconds.
+	Cooldown time.Duration
+
+	// NonCritical is the list of method families which gets short-circuited when their circuit is open.
+	// Calls of other families are always sent, but their failures are still tracked.
+	// Defaults to "chat_action" and "reaction".
+	NonCritical []string
+}
+
+// BreakerStats contains the state and total counters of a method family's circuit.
+type BreakerStats struct {
+	State    BreakerState
+	Requests uint64 // calls passed to telegram
+	Failures uint64 // calls failed by network or server side errors
+	Rejected uint64 // calls short-circuited without being sent
+}
+
+// Breaker is a circuit breaker which keeps a separate circuit for each method family.
+// When the error rate of a family spikes, its non-critical calls get rejected with ErrCircuitOpen
+// to keep the bot responsive during telegram's partial outages.
+type Breaker struct {
+	opts BreakerOptions
+
+	mut      sync.Mutex
+	circuits map[string]*circuit
+}
+
+type circuit struct {
+	stats BreakerStats
+
+	windowStart        time.Time
+	requests, failures int
+
+	openedAt time.Time
+	probing  bool
+}
+
+// NewBreaker returns a new Breaker; pass it to the Options to use it.
+func NewBreaker(opts BreakerOptions) *Breaker {
+	if opts.Window <= 0 {
+		opts.Window = 30 * time.Second
+	}
+	if opts.MinRequests <= 0 {
+		opts.MinRequests = 10
+	}
+	if opts.ErrorRate <= 0 {
+		opts.ErrorRate = 0.5
+	}
+	if opts.Cooldown <= 0 {
+		opts.Cooldown = 15 * time.Second
+	}
+	if opts.NonCritical == nil {
+		opts.NonCritical = []string{"chat_action", "reaction"}
+	}
+
+	return &Breaker{opts: opts, circuits: make(map[string]*circuit)}
+}
+
+// MethodFamily returns the family of the API method which the Breaker groups the calls by.
+func MethodFamily(method string) string {
+	switch {
+	case method == "sendChatAction":
+		return "chat_action"
+	case method == "setMessageReaction":
+		return "reaction"
+	case strings.HasPrefix(method, "send"), strings.HasPrefix(method, "copyMessage"), strings.HasPrefix(method, "forwardMessage"):
+		return "send"
+	case strings.HasPrefix(method, "edit"), strings.HasPrefix(method, "stop"):
+		return "edit"
+	case strings.HasPrefix(method, "answer"):
+		return "answer"
+	case strings.HasPrefix(method, "get"):
+		return "get"
+	}
+	return "other"
+}
+
+// Stats returns the stats of all method families which have been called at least once.
+func (b *Breaker) Stats() map[string]BreakerStats {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	stats := make(map[string]BreakerStats, len(b.circuits))
+	for family, c := range b.circuits {
+		stats[family] = c.stats
+	}
+	return stats
+}
+
+func (b *Breaker) isCritical(family string) bool {
+	for _, nonCritical := range b.opts.NonCritical {
+		if family == nonCritical {
+			return false
+		}
+	}
+	return true
+}
+
+// circuit returns the family's circuit. b.mut must be held.
+func (b *Breaker) circuit(family string) *circuit {
+	c, ok := b.circuits[family]
+	if !ok {
+		c = &circuit{windowStart: time.Now()}
+		b.circuits[family] = c
+	}
+	return c
+}
+
+// allow returns ErrCircuitOpen if the method's call should be short-circuited.
+func (b *Breaker) allow(method string) error {
+	family := MethodFamily(method)
+
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	c := b.circuit(family)
+	if c.stats.State == BreakerOpen && time.Since(c.openedAt) >= b.opts.Cooldown {
+		c.stats.State = BreakerHalfOpen
+		c.probing = false
+	}
+
+	if !b.isCritical(family) {
+		switch {
+		case c.stats.State == BreakerOpen, c.stats.State == BreakerHalfOpen && c.probing:
+			c.stats.Rejected++
+			return ErrCircuitOpen
+		case c.stats.State == BreakerHalfOpen:
+			c.probing = true
+		}
+	}
+
+	c.stats.Requests++
+	return nil
+}
+
+// done records the result of a call which was allowed before.
+func (b *Breaker) done(method string, err error) {
+	failed := isServerSideErr(err)
+
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	c := b.circuit(MethodFamily(method))
+	if failed {
+		c.stats.Failures++
+	}
+
+	switch c.stats.State {
+	case BreakerHalfOpen:
+		if failed {
+			c.open()
+		} else {
+			c.close()
+		}
+
+	case BreakerClosed:
+		if time.Since(c.windowStart) > b.opts.Window {
+			c.windowStart, c.requests, c.failures = time.Now(), 0, 0
+		}
+
+		c.requests++
+		if failed {
+			c.failures++
+		}
+
+		if c.requests >= b.opts.MinRequests && float64(c.failures)/float64(c.requests) >= b.opts.ErrorRate {
+			c.open()
+		}
+	}
+}
+
+func (c *circuit) open() {
+	c.stats.State = BreakerOpen
+	c.openedAt = time.Now()
+	c.probing = false
+}
+
+func (c *circuit) close() {
+	c.stats.State = BreakerClosed
+	c.windowStart, c.requests, c.failures = time.Now(), 0, 0
+	c.probing = false
+}
+
+// isServerSideErr returns true if the err is not caused by the request itself;
+// such as network failures, rate limits, and internal server errors.
+func isServerSideErr(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var tgErr *Error
+	if errors.As(err, &tgErr) {
+		return tgErr.ErrorCode == 429 || tgErr.ErrorCode >= 500
+	}
+
+	return true
+}
@@ -0,0 +1,49 @@
+package tgo
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBreaker(t *testing.T) {
+	b := NewBreaker(BreakerOptions{MinRequests: 2, Cooldown: time.Millisecond})
+	serverErr := &Error{ErrorCode: 502, Description: "Bad Gateway"}
+
+	for i := 0; i < 2; i++ {
+		if err := b.allow("sendChatAction"); err != nil {
+			t.Fatalf("call %d rejected in closed state: %v", i, err)
+		}
+		b.done("sendChatAction", serverErr)
+	}
+
+	if err := b.allow("sendChatAction"); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("non-critical call passed in open state: %v", err)
+	}
+
+	// critical calls still flow, even if their own circuit is open.
+	for i := 0; i < 3; i++ {
+		if err := b.allow("sendMessage"); err != nil {
+			t.Fatalf("critical call rejected: %v", err)
+		}
+		b.done("sendMessage", serverErr)
+	}
+
+	time.Sleep(2 * time.Millisecond)
+
+	if err := b.allow("sendChatAction"); err != nil {
+		t.Fatalf("probe rejected in half-open state: %v", err)
+	}
+	if err := b.allow("sendChatAction"); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("second call passed while probing: %v", err)
+	}
+	b.done("sendChatAction", nil)
+
+	stats := b.Stats()["chat_action"]
+	if stats.State != BreakerClosed {
+		t.Fatalf("circuit is %s after a successful probe", stats.State)
+	}
+	if stats.Requests != 3 || stats.Failures != 2 || stats.Rejected != 2 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
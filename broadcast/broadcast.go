@@ -0,0 +1,140 @@
+// Package broadcast sends a message to many chats, within the rate limits of telegram.
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/haashemi/tgo"
+)
+
+// DefaultRate is the number of the messages sent per second by default, a bit under telegram's limit of 30.
+const DefaultRate = 25
+
+// Options configures a broadcast. The zero value is valid and uses the defaults.
+type Options struct {
+	// Rate is the number of the messages sent per second; it defaults to DefaultRate.
+	Rate int
+
+	// Checkpoint is the number of the chats, from the beginning, which are already done; pass the
+	// Done of the last progress of an interrupted broadcast to resume it.
+	Checkpoint int
+
+	// ProgressEvery is the number of the chats between two OnProgress calls; it defaults to 100.
+	ProgressEvery int
+
+	// OnProgress, if not nil, is called periodically and once at the end, such as to store the checkpoint.
+	OnProgress func(progress Progress)
+
+	// OnFailed, if not nil, is called with the chats which the message couldn't be sent to, except
+	// for the users who have blocked the bot.
+	OnFailed func(chatID int64, err error)
+}
+
+// Progress is the state of a broadcast.
+type Progress struct {
+	Total   int // number of the chats
+	Done    int // number of the chats handled, including the checkpoint; it's the next checkpoint
+	Sent    int // number of the messages sent
+	Blocked int // number of the users who have blocked the bot, including the skipped ones
+	Failed  int // number of the chats which the message couldn't be sent to
+}
+
+// Result is the outcome of a broadcast.
+type Result struct {
+	Progress
+
+	// BlockedIDs are the users who have blocked the bot; they're skipped, or recorded in the bot's block store.
+	BlockedIDs []int64
+
+	// FailedIDs are the chats which the message couldn't be sent to, with the reasons.
+	FailedIDs map[int64]error
+}
+
+// Broadcast sends the messages made by the factory to the chats, in order, one at a time within the rate.
+// The users known to have blocked the bot are skipped, and the ones who turn out to have blocked
+// it are recorded in the bot's block store. The rate-limited messages are retried after the delay
+// which telegram asks for.
+//
+// It stops when the context is done, and returns the result so far with the context's error;
+// its Done can be passed as the Checkpoint to resume the broadcast later.
+func Broadcast(ctx context.Context, bot *tgo.Bot, chatIDs []int64, factory func(chatID int64) tgo.Sendable, opts Options) (*Result, error) {
+	if opts.Rate <= 0 {
+		opts.Rate = DefaultRate
+	}
+	if opts.ProgressEvery <= 0 {
+		opts.ProgressEvery = 100
+	}
+	if opts.Checkpoint > len(chatIDs) {
+		opts.Checkpoint = len(chatIDs)
+	}
+
+	result := &Result{Progress: Progress{Total: len(chatIDs), Done: opts.Checkpoint}, FailedIDs: make(map[int64]error)}
+	report := func() {
+		if opts.OnProgress != nil {
+			opts.OnProgress(result.Progress)
+		}
+	}
+	defer report()
+
+	ticker := time.NewTicker(time.Second / time.Duration(opts.Rate))
+	defer ticker.Stop()
+
+	for _, chatID := range chatIDs[opts.Checkpoint:] {
+		if bot.IsBlockedBy(chatID) {
+			result.Blocked++
+			result.BlockedIDs = append(result.BlockedIDs, chatID)
+		} else if err := send(ctx, bot, ticker, chatID, factory); err == nil {
+			result.Sent++
+		} else if errors.Is(err, tgo.ErrBlockedByUser) {
+			result.Blocked++
+			result.BlockedIDs = append(result.BlockedIDs, chatID)
+			bot.SetBlockedBy(chatID, true)
+		} else if ctx.Err() != nil {
+			return result, ctx.Err()
+		} else {
+			result.Failed++
+			result.FailedIDs[chatID] = err
+			if opts.OnFailed != nil {
+				opts.OnFailed(chatID, err)
+			}
+		}
+
+		result.Done++
+		if (result.Done-opts.Checkpoint)%opts.ProgressEvery == 0 {
+			report()
+		}
+	}
+
+	return result, nil
+}
+
+// send sends the message to the chat on the next tick, and retries it while it's rate limited.
+func send(ctx context.Context, bot *tgo.Bot, ticker *time.Ticker, chatID int64, factory func(chatID int64) tgo.Sendable) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		msg := factory(chatID)
+		if msg.GetChatID() == nil {
+			msg.SetChatID(chatID)
+		}
+
+		_, err := bot.Send(msg)
+
+		var flood *tgo.FloodError
+		if !errors.As(err, &flood) || flood.RetryAfter <= 0 {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(flood.RetryAfter):
+		}
+	}
+}
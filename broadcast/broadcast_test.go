@@ -0,0 +1,83 @@
+package broadcast_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/broadcast"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestBroadcast(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	server.Handle("sendMessage", func(call tgotest.Call) (any, *tgo.Error) {
+		switch call.Params["chat_id"] {
+		case float64(3):
+			return nil, tgo.ErrBotBlockedByUser
+		case float64(4):
+			return nil, tgo.ErrChatNotFound
+		}
+		return map[string]any{"message_id": 1, "date": 1, "chat": map[string]any{"id": call.Params["chat_id"], "type": "private"}}, nil
+	})
+
+	bot := server.Bot(tgo.Options{})
+	bot.SetBlockedBy(2, true)
+
+	factory := func(chatID int64) tgo.Sendable { return &tgo.SendMessage{Text: "news"} }
+
+	// the first chat is already done by an interrupted broadcast.
+	result, err := broadcast.Broadcast(context.Background(), bot, []int64{1, 2, 3, 4, 5}, factory, broadcast.Options{Rate: 1000, Checkpoint: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Done != 5 || result.Sent != 1 || result.Blocked != 2 || result.Failed != 1 || result.FailedIDs[4] == nil {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if !bot.IsBlockedBy(3) {
+		t.Error("user 3 is not recorded as blocked")
+	}
+	if calls := len(server.Calls()); calls != 3 {
+		t.Errorf("got %d calls, want 3", calls)
+	}
+}
+
+func TestBroadcastWrappedErrors(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	flooded := false
+	server.Handle("sendMessage", func(call tgotest.Call) (any, *tgo.Error) {
+		if call.Params["chat_id"] == float64(2) {
+			return nil, tgo.ErrBotBlockedByUser
+		} else if !flooded {
+			flooded = true
+			return nil, &tgo.Error{ErrorCode: 429, Description: "Too Many Requests: retry after 1", Parameters: &tgo.ResponseParameters{RetryAfter: 1}}
+		}
+		return map[string]any{"message_id": 1, "date": 1, "chat": map[string]any{"id": call.Params["chat_id"], "type": "private"}}, nil
+	})
+
+	// the interceptor wraps the errors, which are still told apart by errors.Is and errors.As.
+	bot := server.Bot(tgo.Options{Interceptors: []tgo.Interceptor{{
+		AfterResponse: func(method string, result any, err error) error {
+			if err != nil {
+				return fmt.Errorf("%s: %w", method, err)
+			}
+			return nil
+		},
+	}}})
+
+	factory := func(chatID int64) tgo.Sendable { return &tgo.SendMessage{Text: "news"} }
+	result, err := broadcast.Broadcast(context.Background(), bot, []int64{1, 2}, factory, broadcast.Options{Rate: 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Sent != 1 || result.Blocked != 1 || result.Failed != 0 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
@@ -0,0 +1,59 @@
+package tgo
+
+import (
+	"fmt"
+	"sync"
+)
+
+// BudgetExceededError is returned by the API calls made beyond the call budget.
+type BudgetExceededError struct {
+	Method string // the rejected method
+	Budget int    // the number of the calls allowed
+}
+
+func (e *BudgetExceededError) Error() string {
+	return fmt.Sprintf("tgo: call budget of %d exceeded by %s", e.Budget, e.Method)
+}
+
+type callBudget struct {
+	mut    sync.Mutex
+	limit  int
+	used   int
+	logged bool
+}
+
+// take uses one call of the budget, or returns a *BudgetExceededError if there's none left.
+// The first rejection is logged with the stack of the handler which made the call.
+func (b *callBudget) take(api *API, method string) error {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	if b.used < b.limit {
+		b.used++
+		return nil
+	}
+
+	if !b.logged {
+		b.logged = true
+		api.log(LevelWarn, "call budget exceeded", "method", method, "budget", b.limit, "stack", callerStack())
+	}
+
+	return &BudgetExceededError{Method: method, Budget: b.limit}
+}
+
+// WithCallBudget returns a copy of the api which may make up to n calls; the ones beyond it
+// fail with a *BudgetExceededError. It's an escape hatch to give a handler a budget of its own,
+// as the handlers get a fresh copy of the bot with Options.CallBudget for every update.
+func (api *API) WithCallBudget(n int) *API {
+	clone := *api
+	clone.budget = &callBudget{limit: n}
+	return &clone
+}
+
+// WithCallBudget returns a copy of the bot whose API may make up to n calls.
+// The copy shares everything else, such as the routers and sessions, with the bot.
+func (bot *Bot) WithCallBudget(n int) *Bot {
+	clone := *bot
+	clone.API = bot.API.WithCallBudget(n)
+	return &clone
+}
@@ -0,0 +1,43 @@
+package tgo_test
+
+import (
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+type budgetRouter struct{ errs []error }
+
+func (r *budgetRouter) Setup(bot *tgo.Bot) error { return nil }
+
+func (r *budgetRouter) HandleUpdate(bot *tgo.Bot, upd *tgo.Update) bool {
+	for i := 0; i < 3; i++ {
+		_, err := bot.SendMessage(&tgo.SendMessage{ChatId: tgo.ID(1), Text: "loop"})
+		r.errs = append(r.errs, err)
+	}
+	return true
+}
+
+func TestCallBudget(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	bot := server.Bot(tgo.Options{CallBudget: 2})
+
+	router := &budgetRouter{}
+	bot.AddRouter(router)
+
+	// every update gets a budget of its own.
+	bot.HandleUpdate(&tgo.Update{UpdateId: 1})
+	bot.HandleUpdate(&tgo.Update{UpdateId: 2})
+
+	for i, err := range router.errs {
+		if _, exceeded := err.(*tgo.BudgetExceededError); exceeded != (i%3 == 2) {
+			t.Errorf("call %d: unexpected error %v", i, err)
+		}
+	}
+	if calls := len(server.Calls()); calls != 4 {
+		t.Errorf("got %d calls, want 4", calls)
+	}
+}
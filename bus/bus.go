@@ -0,0 +1,246 @@
+// Package bus passes typed events between the bots running in the same process, so a handler of
+// one bot can trigger the actions performed by another one, such as a logging bot posting what
+// the main bot saw.
+//
+// The events are routed by their Go type. Each subscription has a queue of its own, drained by
+// a goroutine of its own; when a queue is full, the publishers wait for it, which is the backpressure
+// from the slow subscribers, unless the subscription prefers to drop the events instead.
+package bus
+
+import (
+	"context"
+	"errors"
+	"log"
+	"reflect"
+	"sync"
+
+	"github.com/haashemi/tgo"
+)
+
+// DefaultQueueSize is the number of the events queued for each subscription by default.
+const DefaultQueueSize = 64
+
+var (
+	ErrClosed = errors.New("bus: closed")
+	ErrFull   = errors.New("bus: queue of a subscription is full")
+)
+
+// Options configures a bus. The zero value is valid.
+type Options struct {
+	// OnPanic, if not nil, is called when a subscriber panics on an event; the panic is logged otherwise.
+	OnPanic func(recovered, event any)
+}
+
+// SubscribeOptions configures a subscription. The zero value is valid and uses the defaults.
+type SubscribeOptions struct {
+	// QueueSize is the number of the events queued for the subscriber; it defaults to DefaultQueueSize.
+	QueueSize int
+
+	// DropWhenFull drops the events which don't fit in the queue instead of making the publishers wait.
+	DropWhenFull bool
+
+	// OnDrop, if not nil, is called with the events dropped by DropWhenFull.
+	OnDrop func(event any)
+}
+
+// Bus is an in-process event bus between the bots.
+type Bus struct {
+	opts Options
+
+	mut    sync.RWMutex
+	subs   map[reflect.Type][]*Subscription
+	closed bool
+}
+
+// New returns an empty bus.
+func New(opts Options) *Bus {
+	return &Bus{opts: opts, subs: make(map[reflect.Type][]*Subscription)}
+}
+
+// Subscription is a subscriber of an event type, which handles the events one at a time and in order.
+type Subscription struct {
+	bus  *Bus
+	typ  reflect.Type
+	opts SubscribeOptions
+
+	queue    chan any
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// Subscribe subscribes the handler to the events of type T, such as a struct of the event's details.
+// The handler is called with the bot which performs the actions, which is the subscriber's bot
+// rather than the publisher's.
+func Subscribe[T any](b *Bus, bot *tgo.Bot, handler func(bot *tgo.Bot, event T), opts SubscribeOptions) (*Subscription, error) {
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = DefaultQueueSize
+	}
+
+	sub := &Subscription{
+		bus:   b,
+		typ:   reflect.TypeOf((*T)(nil)).Elem(),
+		opts:  opts,
+		queue: make(chan any, opts.QueueSize),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	if b.closed {
+		return nil, ErrClosed
+	}
+	b.subs[sub.typ] = append(b.subs[sub.typ], sub)
+
+	go sub.work(func(event any) { handler(bot, event.(T)) })
+	return sub, nil
+}
+
+// Publish passes the event to the subscribers of its type, waiting for the ones whose queue is full
+// until the context is done. It returns the context's error if an event could not be queued in time;
+// the subscribers which accepted it still handle it.
+func Publish[T any](ctx context.Context, b *Bus, event T) error {
+	subs, err := b.subscribers(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return err
+	}
+
+	for _, sub := range subs {
+		if sub.opts.DropWhenFull {
+			sub.offer(event)
+			continue
+		}
+
+		select {
+		case sub.queue <- event:
+		case <-sub.stop:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// TryPublish is like Publish but never waits; it returns ErrFull if the queue of a subscriber which
+// doesn't drop the events is full.
+func TryPublish[T any](b *Bus, event T) error {
+	subs, err := b.subscribers(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return err
+	}
+
+	for _, sub := range subs {
+		if sub.opts.DropWhenFull {
+			sub.offer(event)
+			continue
+		}
+
+		select {
+		case sub.queue <- event:
+		case <-sub.stop:
+		default:
+			err = ErrFull
+		}
+	}
+
+	return err
+}
+
+func (b *Bus) subscribers(typ reflect.Type) ([]*Subscription, error) {
+	b.mut.RLock()
+	defer b.mut.RUnlock()
+
+	if b.closed {
+		return nil, ErrClosed
+	}
+	return b.subs[typ], nil
+}
+
+// Close closes the bus and all of its subscriptions, waiting for the events being handled.
+// The queued events which are not yet handled are dropped.
+func (b *Bus) Close() {
+	b.mut.Lock()
+	b.closed = true
+	subs := b.subs
+	b.subs = make(map[reflect.Type][]*Subscription)
+	b.mut.Unlock()
+
+	for _, typeSubs := range subs {
+		for _, sub := range typeSubs {
+			sub.halt()
+		}
+	}
+}
+
+// Len returns the number of the events queued for the subscriber.
+func (s *Subscription) Len() int { return len(s.queue) }
+
+// Close unsubscribes the subscriber, waiting for the event being handled. The queued events are dropped.
+// It must not be called by the subscriber's own handler, which would wait for itself.
+func (s *Subscription) Close() {
+	s.bus.mut.Lock()
+	subs := s.bus.subs[s.typ]
+	for i, sub := range subs {
+		if sub == s {
+			s.bus.subs[s.typ] = append(subs[:i:i], subs[i+1:]...)
+			break
+		}
+	}
+	s.bus.mut.Unlock()
+
+	s.halt()
+}
+
+func (s *Subscription) halt() {
+	s.stopOnce.Do(func() { close(s.stop) })
+	<-s.done
+}
+
+// offer queues the event if there's room for it, or drops it.
+func (s *Subscription) offer(event any) {
+	select {
+	case s.queue <- event:
+	case <-s.stop:
+	default:
+		if s.opts.OnDrop != nil {
+			s.opts.OnDrop(event)
+		}
+	}
+}
+
+func (s *Subscription) work(handle func(event any)) {
+	defer close(s.done)
+
+	for {
+		// prefer stopping over the queued events.
+		select {
+		case <-s.stop:
+			return
+		default:
+		}
+
+		select {
+		case <-s.stop:
+			return
+		case event := <-s.queue:
+			s.handle(handle, event)
+		}
+	}
+}
+
+func (s *Subscription) handle(handle func(event any), event any) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			if s.bus.opts.OnPanic != nil {
+				s.bus.opts.OnPanic(recovered, event)
+			} else {
+				log.Printf("bus: subscriber of %s panicked: %v", s.typ, recovered)
+			}
+		}
+	}()
+
+	handle(event)
+}
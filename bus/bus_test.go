@@ -0,0 +1,115 @@
+package bus_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/bus"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+type sawMessage struct {
+	ChatID int64
+	Text   string
+}
+
+func TestBus(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	logger := server.Bot(tgo.Options{})
+
+	b := bus.New(bus.Options{})
+	defer b.Close()
+
+	handled := make(chan sawMessage)
+	_, err := bus.Subscribe(b, logger, func(bot *tgo.Bot, event sawMessage) {
+		if _, err := bot.Send(&tgo.SendMessage{ChatId: tgo.ID(-100), Text: event.Text}); err != nil {
+			t.Error(err)
+		}
+		handled <- event
+	}, bus.SubscribeOptions{QueueSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the events of the other types don't reach the subscriber.
+	if err := bus.Publish(context.Background(), b, "unrelated"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, text := range []string{"first", "second"} {
+		if err := bus.Publish(context.Background(), b, sawMessage{ChatID: 1, Text: text}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the first one is being handled and the second one is queued, so there's no room for the third one.
+	if err := bus.TryPublish(b, sawMessage{Text: "third"}); err != bus.ErrFull {
+		t.Errorf("got %v, want ErrFull", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := bus.Publish(ctx, b, sawMessage{Text: "third"}); err != context.DeadlineExceeded {
+		t.Errorf("got %v, want the context's error", err)
+	}
+
+	for _, want := range []string{"first", "second"} {
+		select {
+		case event := <-handled:
+			if event.Text != want {
+				t.Errorf("got %q, want %q", event.Text, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%q is not handled", want)
+		}
+	}
+
+	if calls := server.Calls(); len(calls) != 2 || calls[0].Params["text"] != "first" {
+		t.Errorf("unexpected calls: %+v", calls)
+	}
+}
+
+func TestBusDrop(t *testing.T) {
+	b := bus.New(bus.Options{})
+
+	release := make(chan struct{})
+	dropped := make(chan any, 1)
+	sub, err := bus.Subscribe(b, nil, func(bot *tgo.Bot, event int) { <-release }, bus.SubscribeOptions{
+		QueueSize:    1,
+		DropWhenFull: true,
+		OnDrop:       func(event any) { dropped <- event },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// one is being handled, one is queued, and the last one is dropped.
+	for i := 1; i <= 3; i++ {
+		for i == 2 && sub.Len() != 0 {
+			time.Sleep(time.Millisecond)
+		}
+		if err := bus.TryPublish(b, i); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	select {
+	case event := <-dropped:
+		if event != 3 {
+			t.Errorf("dropped %v, want 3", event)
+		}
+	case <-time.After(time.Second):
+		t.Error("nothing is dropped")
+	}
+
+	close(release)
+	b.Close()
+
+	if err := bus.TryPublish(b, 4); err != bus.ErrClosed {
+		t.Errorf("got %v, want ErrClosed", err)
+	}
+}
@@ -0,0 +1,99 @@
+package tgo
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+// businessConnectionKey is the parameter which the calls on behalf of a business account carry.
+const businessConnectionKey = "business_connection_id"
+
+// WithBusinessConnection returns a copy of the api whose calls are made on behalf of the business
+// account of the connection, by carrying its business_connection_id. The parameters which already
+// have a business_connection_id are left as is.
+func (api *API) WithBusinessConnection(connectionID string) *API {
+	clone := *api
+	clone.businessConnection = connectionID
+	return &clone
+}
+
+// WithBusinessConnection returns a copy of the bot whose API acts on behalf of the business account
+// of the connection. The copy shares everything else, such as the routers and sessions, with the bot.
+func (bot *Bot) WithBusinessConnection(connectionID string) *Bot {
+	clone := *bot
+	clone.API = bot.API.WithBusinessConnection(connectionID)
+	return &clone
+}
+
+// businessJSON adds the api's business connection to the encoded json object, if it doesn't have one.
+func (api *API) businessJSON(body *bytes.Buffer) (*bytes.Buffer, error) {
+	if api.businessConnection == "" {
+		return body, nil
+	}
+
+	var params map[string]json.RawMessage
+	if err := json.Unmarshal(body.Bytes(), &params); err != nil || params == nil {
+		// it's not an object, so there's nothing to carry the connection in.
+		return body, nil
+	} else if _, ok := params[businessConnectionKey]; ok {
+		return body, nil
+	}
+
+	params[businessConnectionKey], _ = json.Marshal(api.businessConnection)
+
+	out := bytes.NewBuffer(nil)
+	return out, json.NewEncoder(out).Encode(params)
+}
+
+// businessParams returns the multipart params with the api's business connection, if they don't have one.
+func (api *API) businessParams(params map[string]string) map[string]string {
+	if api.businessConnection == "" {
+		return params
+	} else if _, ok := params[businessConnectionKey]; ok {
+		return params
+	}
+
+	withConnection := make(map[string]string, len(params)+1)
+	for key, val := range params {
+		withConnection[key] = val
+	}
+	withConnection[businessConnectionKey] = api.businessConnection
+
+	return withConnection
+}
+
+// IsBusiness returns true if the message belongs to a chat of a connected business account.
+func (m *Message) IsBusiness() bool { return m.BusinessConnectionId != "" }
+
+// StoryPhoto returns the photo to be posted as a story; it must be uploaded by FileFromReader.
+func StoryPhoto(photo *InputFile) *InputStoryContentPhoto {
+	return &InputStoryContentPhoto{Type: "photo", Photo: photo}
+}
+
+// StoryVideo returns the video to be posted as a story; it must be uploaded by FileFromReader.
+func StoryVideo(video *InputFile) *InputStoryContentVideo {
+	return &InputStoryContentVideo{Type: "video", Video: video}
+}
+
+// IsOpen reports whether the business is open at the time, in the time zone of its opening hours.
+func (h *BusinessOpeningHours) IsOpen(t time.Time) (bool, error) {
+	location, err := time.LoadLocation(h.TimeZoneName)
+	if err != nil {
+		return false, err
+	}
+
+	// the minutes of the week start on Monday; the intervals may go past the week's end, until
+	// the next Monday's end.
+	t = t.In(location)
+	minute := int64((int(t.Weekday())+6)%7*24*60 + t.Hour()*60 + t.Minute())
+
+	for _, interval := range h.OpeningHours {
+		for _, m := range []int64{minute, minute + 7*24*60} {
+			if m >= interval.OpeningMinute && m < interval.ClosingMinute {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,34 @@
+package tgo_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/haashemi/tgo"
+)
+
+func TestBusinessOpeningHours(t *testing.T) {
+	const day = 24 * 60
+
+	hours := &tgo.BusinessOpeningHours{TimeZoneName: "UTC", OpeningHours: []*tgo.BusinessOpeningHoursInterval{
+		{OpeningMinute: 9 * 60, ClosingMinute: 17 * 60},           // Monday, 9 to 17
+		{OpeningMinute: 6*day + 22*60, ClosingMinute: 7*day + 60}, // Sunday, 22 to Monday's 1
+	}}
+
+	tests := []struct {
+		at   time.Time
+		want bool
+	}{
+		{time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC), true},   // Monday
+		{time.Date(2024, 4, 1, 17, 0, 0, 0, time.UTC), false}, // Monday's closing
+		{time.Date(2024, 4, 2, 10, 0, 0, 0, time.UTC), false}, // Tuesday
+		{time.Date(2024, 4, 7, 23, 0, 0, 0, time.UTC), true},  // Sunday's night
+		{time.Date(2024, 4, 8, 0, 30, 0, 0, time.UTC), true},  // the next Monday's night
+	}
+
+	for _, test := range tests {
+		if got, err := hours.IsOpen(test.at); err != nil || got != test.want {
+			t.Errorf("IsOpen(%s) = %v, %v, want %v", test.at, got, err, test.want)
+		}
+	}
+}
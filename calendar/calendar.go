@@ -0,0 +1,198 @@
+// Package calendar contains a date picker widget, which shows the days of a month in an inline
+// keyboard with the buttons to move between the months.
+package calendar
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/filters"
+	"github.com/haashemi/tgo/routers/callback"
+)
+
+// the formats of the dates in the callback data.
+const (
+	monthFormat = "2006-01"
+	dayFormat   = "2006-01-02"
+)
+
+// Options configures a Calendar. The zero value is valid and uses the defaults.
+type Options struct {
+	// Prefix is the prefix of the calendar's callback data; it defaults to "cal", and it must be unique
+	// among the callback data of the bot's keyboards.
+	Prefix string
+
+	// Min and Max, if not zero, are the first and the last days which can be chosen. The months
+	// out of them can't be navigated to.
+	Min, Max time.Time
+
+	// Location is the time zone of the chosen dates; it defaults to UTC.
+	Location *time.Location
+
+	// Locales are the locales by the users' language codes, such as "en" or "pt-br", in addition to
+	// the built-in ones; see BuiltinLocale. The languages without a locale are rendered in English.
+	Locales map[string]Locale
+
+	// OnSelect is called with the chosen day, at the midnight of the Location. The callback query
+	// is answered after it returns, unless it's answered by OnSelect itself.
+	OnSelect func(ctx *callback.Context, date time.Time)
+}
+
+// Calendar is a date picker widget. Register it on a callback router, and send it by Send;
+// its months are navigated in place, and it keeps no state.
+type Calendar struct {
+	opts Options
+}
+
+// New returns a new Calendar.
+func New(opts Options) *Calendar {
+	if opts.Prefix == "" {
+		opts.Prefix = "cal"
+	}
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+	if !opts.Min.IsZero() {
+		opts.Min = day(opts.Min.In(opts.Location))
+	}
+	if !opts.Max.IsZero() {
+		opts.Max = day(opts.Max.In(opts.Location))
+	}
+
+	return &Calendar{opts: opts}
+}
+
+// Register adds the route handling the calendar's buttons to the router, with the middlewares.
+func (c *Calendar) Register(router *callback.Router, middlewares ...callback.Middleware) {
+	router.Handle(filters.WithPrefix(c.opts.Prefix+":"), c.handle, middlewares...)
+}
+
+// Send sends the text with the calendar of the month to the chat, in the locale of the language.
+func (c *Calendar) Send(bot *tgo.Bot, chatID tgo.ChatID, text string, month time.Time, language string) (*tgo.Message, error) {
+	return bot.Send(&tgo.SendMessage{ChatId: chatID, Text: text, ReplyMarkup: c.Render(month, language)})
+}
+
+// Locale returns the locale of the language.
+func (c *Calendar) Locale(language string) Locale {
+	base, _, _ := strings.Cut(strings.ToLower(language), "-")
+	if locale, ok := c.opts.Locales[language]; ok {
+		return locale
+	} else if locale, ok = c.opts.Locales[base]; ok {
+		return locale
+	} else if locale, ok = BuiltinLocale(language); ok {
+		return locale
+	}
+
+	locale, _ := BuiltinLocale("en")
+	return locale
+}
+
+// Render returns the keyboard of the month in the locale of the language. The months out of
+// Min and Max are rendered as their closest month.
+func (c *Calendar) Render(month time.Time, language string) *tgo.InlineKeyboardMarkup {
+	locale := c.Locale(language)
+
+	first := firstOfMonth(month.In(c.opts.Location))
+	if !c.opts.Min.IsZero() && first.Before(firstOfMonth(c.opts.Min)) {
+		first = firstOfMonth(c.opts.Min)
+	} else if !c.opts.Max.IsZero() && first.After(c.opts.Max) {
+		first = firstOfMonth(c.opts.Max)
+	}
+	next := first.AddDate(0, 1, 0)
+
+	prevButton, nextButton := c.noop(" "), c.noop(" ")
+	if c.opts.Min.IsZero() || first.After(c.opts.Min) {
+		prevButton = &tgo.InlineKeyboardButton{Text: "«", CallbackData: c.opts.Prefix + ":m:" + first.AddDate(0, -1, 0).Format(monthFormat)}
+	}
+	if c.opts.Max.IsZero() || !next.After(c.opts.Max) {
+		nextButton = &tgo.InlineKeyboardButton{Text: "»", CallbackData: c.opts.Prefix + ":m:" + next.Format(monthFormat)}
+	}
+
+	title := locale.Months[first.Month()-1] + " " + strconv.Itoa(first.Year())
+	rows := [][]*tgo.InlineKeyboardButton{{prevButton, c.noop(title), nextButton}}
+
+	weekdays := make([]*tgo.InlineKeyboardButton, 7)
+	for i := range weekdays {
+		weekdays[i] = c.noop(locale.Weekdays[(int(locale.FirstDay)+i)%7])
+	}
+	rows = append(rows, weekdays)
+
+	// the week of the first day starts from the locale's first day.
+	offset := (int(first.Weekday()) - int(locale.FirstDay) + 7) % 7
+
+	var week []*tgo.InlineKeyboardButton
+	for i := 0; i < offset; i++ {
+		week = append(week, c.noop(" "))
+	}
+
+	for date := first; date.Before(next); date = date.AddDate(0, 0, 1) {
+		label := strconv.Itoa(date.Day())
+		if (!c.opts.Min.IsZero() && date.Before(c.opts.Min)) || (!c.opts.Max.IsZero() && date.After(c.opts.Max)) {
+			week = append(week, c.noop("·"))
+		} else {
+			week = append(week, &tgo.InlineKeyboardButton{Text: label, CallbackData: c.opts.Prefix + ":d:" + date.Format(dayFormat)})
+		}
+
+		if len(week) == 7 {
+			rows = append(rows, week)
+			week = nil
+		}
+	}
+
+	if len(week) != 0 {
+		for len(week) < 7 {
+			week = append(week, c.noop(" "))
+		}
+		rows = append(rows, week)
+	}
+
+	return &tgo.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+func (c *Calendar) noop(text string) *tgo.InlineKeyboardButton {
+	return &tgo.InlineKeyboardButton{Text: text, CallbackData: c.opts.Prefix + ":n"}
+}
+
+func (c *Calendar) handle(ctx *callback.Context) {
+	action, arg, _ := strings.Cut(strings.TrimPrefix(ctx.Data, c.opts.Prefix+":"), ":")
+
+	switch action {
+	case "m":
+		month, err := time.ParseInLocation(monthFormat, arg, c.opts.Location)
+		if err != nil {
+			break
+		}
+
+		edit := &tgo.EditMessageReplyMarkup{InlineMessageId: ctx.InlineMessageId, ReplyMarkup: c.Render(month, ctx.From.LanguageCode)}
+		if ctx.Message != nil {
+			edit.ChatId, edit.MessageId = tgo.ID(ctx.Message.Chat.Id), ctx.Message.MessageId
+		}
+		if _, err = ctx.Bot.EditMessageReplyMarkup(edit); err != nil && !tgo.IsMessageNotModifiedErr(err) {
+			ctx.Bot.Logger().Log(tgo.LevelError, "failed to render the month", "prefix", c.opts.Prefix, "month", arg, "error", err)
+		}
+
+	case "d":
+		date, err := time.ParseInLocation(dayFormat, arg, c.opts.Location)
+		if err != nil || (!c.opts.Min.IsZero() && date.Before(c.opts.Min)) || (!c.opts.Max.IsZero() && date.After(c.opts.Max)) {
+			break
+		}
+
+		if c.opts.OnSelect != nil {
+			c.opts.OnSelect(ctx, date)
+		}
+	}
+
+	ctx.Answer(&tgo.AnswerCallbackQuery{})
+}
+
+// day returns the midnight of the day of t.
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// firstOfMonth returns the midnight of the first day of the month of t.
+func firstOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,61 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/routers/callback"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestRender(t *testing.T) {
+	c := New(Options{
+		Min: time.Date(2024, time.February, 10, 15, 0, 0, 0, time.UTC),
+		Max: time.Date(2024, time.March, 20, 0, 0, 0, 0, time.UTC),
+	})
+
+	rows := c.Render(time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC), "de").InlineKeyboard
+	if title := rows[0][1].Text; title != "Februar 2024" {
+		t.Errorf("unexpected title %q", title)
+	} else if rows[0][0].CallbackData != "cal:n" || rows[0][2].CallbackData != "cal:m:2024-03" {
+		t.Errorf("expected only the next month to be navigable: %+v %+v", rows[0][0], rows[0][2])
+	}
+
+	if rows[1][0].Text != "Mo" {
+		t.Errorf("expected the weeks to start from Monday, got %q", rows[1][0].Text)
+	}
+
+	// February 1st of 2024 is a Thursday.
+	firstWeek := rows[2]
+	if firstWeek[2].Text != " " || firstWeek[3].Text != "·" {
+		t.Errorf("unexpected first week: %q %q", firstWeek[2].Text, firstWeek[3].Text)
+	}
+	if day10 := rows[3][5]; day10.Text != "10" || day10.CallbackData != "cal:d:2024-02-10" {
+		t.Errorf("unexpected 10th day: %+v", day10)
+	}
+
+	// the months after Max are rendered as its month.
+	rows = c.Render(time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), "xx").InlineKeyboard
+	if title := rows[0][1].Text; title != "March 2024" {
+		t.Errorf("unexpected title %q", title)
+	}
+}
+
+func TestSelect(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	var chosen time.Time
+	c := New(Options{OnSelect: func(ctx *callback.Context, date time.Time) { chosen = date }})
+
+	router := callback.NewRouter()
+	c.Register(router)
+
+	query := &tgo.CallbackQuery{Id: "1", Data: "cal:d:2024-05-17", Message: &tgo.Message{MessageId: 5, Chat: tgo.Chat{Id: 1}}}
+	router.HandleUpdate(server.Bot(tgo.Options{}), &tgo.Update{CallbackQuery: query})
+
+	if want := time.Date(2024, time.May, 17, 0, 0, 0, 0, time.UTC); !chosen.Equal(want) {
+		t.Errorf("expected %v to be chosen, got %v", want, chosen)
+	}
+}
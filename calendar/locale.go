@@ -0,0 +1,70 @@
+package calendar
+
+import (
+	"strings"
+	"time"
+)
+
+// Locale is the names which a calendar is rendered with in a language.
+type Locale struct {
+	// Months are the names of the months, from January.
+	Months [12]string
+
+	// Weekdays are the short names of the days of the week, from Sunday like time.Weekday.
+	Weekdays [7]string
+
+	// FirstDay is the day which the weeks start from.
+	FirstDay time.Weekday
+}
+
+// builtinLocales are the locales which the calendars have by default, by the language codes.
+var builtinLocales = map[string]Locale{
+	"en": {
+		Months:   [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		Weekdays: [7]string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"},
+		FirstDay: time.Monday,
+	},
+	"de": {
+		Months:   [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		Weekdays: [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+		FirstDay: time.Monday,
+	},
+	"es": {
+		Months:   [12]string{"Enero", "Febrero", "Marzo", "Abril", "Mayo", "Junio", "Julio", "Agosto", "Septiembre", "Octubre", "Noviembre", "Diciembre"},
+		Weekdays: [7]string{"Do", "Lu", "Ma", "Mi", "Ju", "Vi", "Sá"},
+		FirstDay: time.Monday,
+	},
+	"fr": {
+		Months:   [12]string{"Janvier", "Février", "Mars", "Avril", "Mai", "Juin", "Juillet", "Août", "Septembre", "Octobre", "Novembre", "Décembre"},
+		Weekdays: [7]string{"Di", "Lu", "Ma", "Me", "Je", "Ve", "Sa"},
+		FirstDay: time.Monday,
+	},
+	"ru": {
+		Months:   [12]string{"Январь", "Февраль", "Март", "Апрель", "Май", "Июнь", "Июль", "Август", "Сентябрь", "Октябрь", "Ноябрь", "Декабрь"},
+		Weekdays: [7]string{"Вс", "Пн", "Вт", "Ср", "Чт", "Пт", "Сб"},
+		FirstDay: time.Monday,
+	},
+	"tr": {
+		Months:   [12]string{"Ocak", "Şubat", "Mart", "Nisan", "Mayıs", "Haziran", "Temmuz", "Ağustos", "Eylül", "Ekim", "Kasım", "Aralık"},
+		Weekdays: [7]string{"Pz", "Pt", "Sa", "Ça", "Pe", "Cu", "Ct"},
+		FirstDay: time.Monday,
+	},
+	"fa": {
+		Months:   [12]string{"ژانویه", "فوریه", "مارس", "آوریل", "مه", "ژوئن", "ژوئیه", "اوت", "سپتامبر", "اکتبر", "نوامبر", "دسامبر"},
+		Weekdays: [7]string{"ی", "د", "س", "چ", "پ", "ج", "ش"},
+		FirstDay: time.Saturday,
+	},
+}
+
+// BuiltinLocale returns the built-in locale of the language code, such as "de" or "pt-br", and false
+// if there's none. The built-in languages are English, German, Spanish, French, Russian, Turkish, and Persian.
+func BuiltinLocale(language string) (Locale, bool) {
+	language = strings.ToLower(language)
+
+	locale, ok := builtinLocales[language]
+	if !ok {
+		base, _, _ := strings.Cut(language, "-")
+		locale, ok = builtinLocales[base]
+	}
+	return locale, ok
+}
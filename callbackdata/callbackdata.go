@@ -0,0 +1,261 @@
+// Package callbackdata marshals small structs into the compact and optionally signed callback data
+// of the inline keyboard buttons, and unmarshals them back in the callback query handlers.
+//
+// The data is the codec's prefix followed by the struct's exported fields, in order, separated by
+// colons; the fields tagged `callback:"-"` are skipped. Only the strings, booleans, integers, and
+// floats are supported. The signed integers tagged `callback:"id"` are encoded by the codec's IDCodec,
+// if it has one, so the internal ids aren't exposed as is in the buttons.
+package callbackdata
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/filters"
+)
+
+// MaxLength is the maximum length of the callback data allowed by telegram.
+const MaxLength = 64
+
+// signatureSize is the number of the HMAC bytes kept in the signed data.
+const signatureSize = 8
+
+var (
+	ErrTooLong          = errors.New("callbackdata: data is longer than 64 bytes")
+	ErrPrefixMismatch   = errors.New("callbackdata: data doesn't have the codec's prefix")
+	ErrInvalidSignature = errors.New("callbackdata: invalid signature")
+	ErrFieldsMismatch   = errors.New("callbackdata: number of the fields doesn't match")
+)
+
+const separator = ":"
+
+// escaper escapes the separator in the string fields.
+var (
+	escaper   = strings.NewReplacer("%", "%25", separator, "%3A")
+	unescaper = strings.NewReplacer("%3A", separator, "%25", "%")
+)
+
+// Codec marshals and unmarshals T, which must be a struct, as callback data.
+type Codec[T any] struct {
+	prefix string
+	key    []byte
+	fields []int
+
+	ids      tgo.IDCodec
+	idFields map[int]bool
+}
+
+// New returns a codec of T with the prefix, which must be unique among the bot's codecs and have no colons.
+// If the key is not empty, the data is signed with HMAC-SHA256 so it can't be tampered with.
+//
+// It panics if T is not a struct or has a field of an unsupported type.
+func New[T any](prefix string, key []byte) *Codec[T] {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if typ.Kind() != reflect.Struct {
+		panic("callbackdata: " + typ.String() + " is not a struct")
+	}
+
+	c := &Codec[T]{prefix: prefix, key: key, idFields: make(map[int]bool)}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() || field.Tag.Get("callback") == "-" {
+			continue
+		}
+
+		if field.Tag.Get("callback") == "id" {
+			switch field.Type.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				c.idFields[i] = true
+			default:
+				panic("callbackdata: id field " + field.Name + " is not a signed integer: " + field.Type.String())
+			}
+		}
+
+		switch field.Type.Kind() {
+		case reflect.String, reflect.Bool,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+		default:
+			panic("callbackdata: unsupported type of field " + field.Name + ": " + field.Type.String())
+		}
+
+		c.fields = append(c.fields, i)
+	}
+
+	return c
+}
+
+// WithIDCodec sets the codec which encodes the fields tagged `callback:"id"`, and returns the codec.
+// Keep in mind that the encoded ids are longer than the plain ones, such as 14 characters for tgo.HashIDs.
+func (c *Codec[T]) WithIDCodec(ids tgo.IDCodec) *Codec[T] {
+	c.ids = ids
+	return c
+}
+
+// Marshal encodes the value as callback data.
+func (c *Codec[T]) Marshal(value T) (string, error) {
+	v := reflect.ValueOf(value)
+
+	parts := []string{c.prefix}
+	for _, i := range c.fields {
+		field := v.Field(i)
+
+		if c.ids != nil && c.idFields[i] {
+			parts = append(parts, c.ids.EncodeID(field.Int()))
+			continue
+		}
+
+		switch field.Kind() {
+		case reflect.String:
+			parts = append(parts, escaper.Replace(field.String()))
+		case reflect.Bool:
+			if field.Bool() {
+				parts = append(parts, "1")
+			} else {
+				parts = append(parts, "0")
+			}
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			parts = append(parts, strconv.FormatInt(field.Int(), 36))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			parts = append(parts, strconv.FormatUint(field.Uint(), 36))
+		case reflect.Float32, reflect.Float64:
+			parts = append(parts, strconv.FormatFloat(field.Float(), 'g', -1, field.Type().Bits()))
+		}
+	}
+
+	data := strings.Join(parts, separator)
+	if len(c.key) != 0 {
+		data += separator + c.sign(data)
+	}
+
+	if len(data) > MaxLength {
+		return "", ErrTooLong
+	}
+
+	return data, nil
+}
+
+// MustMarshal is like Marshal but panics if the value can't be marshaled.
+func (c *Codec[T]) MustMarshal(value T) string {
+	data, err := c.Marshal(value)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// Unmarshal decodes the callback data made by Marshal, after verifying its signature if the codec has a key.
+func (c *Codec[T]) Unmarshal(data string) (value T, err error) {
+	if !c.hasPrefix(data) {
+		return value, ErrPrefixMismatch
+	}
+
+	if len(c.key) != 0 {
+		cut := strings.LastIndex(data, separator)
+		if !hmac.Equal([]byte(data[cut+1:]), []byte(c.sign(data[:cut]))) {
+			return value, ErrInvalidSignature
+		}
+		data = data[:cut]
+	}
+
+	parts := strings.Split(data, separator)[1:]
+	if len(parts) != len(c.fields) {
+		return value, ErrFieldsMismatch
+	}
+
+	v := reflect.ValueOf(&value).Elem()
+	for index, i := range c.fields {
+		field, part := v.Field(i), parts[index]
+
+		if c.ids != nil && c.idFields[i] {
+			var id int64
+			if id, err = c.ids.DecodeID(part); err == nil && field.OverflowInt(id) {
+				err = tgo.ErrInvalidID
+			} else if err == nil {
+				field.SetInt(id)
+			}
+		} else {
+			err = parseField(field, part)
+		}
+
+		if err != nil {
+			return value, fmt.Errorf("callbackdata: invalid field %s: %w", v.Type().Field(i).Name, err)
+		}
+	}
+
+	return value, nil
+}
+
+// parseField sets the field to the value parsed from its part of the data.
+func parseField(field reflect.Value, part string) (err error) {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(unescaper.Replace(part))
+	case reflect.Bool:
+		field.SetBool(part == "1")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		if n, err = strconv.ParseInt(part, 36, field.Type().Bits()); err == nil {
+			field.SetInt(n)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var n uint64
+		if n, err = strconv.ParseUint(part, 36, field.Type().Bits()); err == nil {
+			field.SetUint(n)
+		}
+	case reflect.Float32, reflect.Float64:
+		var n float64
+		if n, err = strconv.ParseFloat(part, field.Type().Bits()); err == nil {
+			field.SetFloat(n)
+		}
+	}
+
+	return err
+}
+
+// Filter passes the callback queries whose data is successfully unmarshaled by the codec, and stores
+// the value for the update, to be retrieved by the codec's Value method in the handlers.
+func (c *Codec[T]) Filter() tgo.Filter {
+	return filters.NewFilter(func(update *tgo.Update) bool {
+		if update.CallbackQuery == nil || !c.hasPrefix(update.CallbackQuery.Data) {
+			return false
+		}
+
+		value, err := c.Unmarshal(update.CallbackQuery.Data)
+		if err != nil {
+			return false
+		}
+
+		tgo.SetUpdateValue(update, c.valueKey(), value)
+		return true
+	})
+}
+
+// Value returns the value unmarshaled by the codec's filter for the update, such as ctx.Update in the handlers.
+func (c *Codec[T]) Value(update *tgo.Update) (value T, ok bool) {
+	stored, ok := tgo.GetUpdateValue(update, c.valueKey())
+	if !ok {
+		return value, false
+	}
+	return stored.(T), true
+}
+
+func (c *Codec[T]) hasPrefix(data string) bool {
+	return data == c.prefix || strings.HasPrefix(data, c.prefix+separator)
+}
+
+func (c *Codec[T]) valueKey() string { return "callbackdata." + c.prefix }
+
+func (c *Codec[T]) sign(data string) string {
+	mac := hmac.New(sha256.New, c.key)
+	mac.Write([]byte(data))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:signatureSize])
+}
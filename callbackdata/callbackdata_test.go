@@ -0,0 +1,69 @@
+package callbackdata_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/callbackdata"
+)
+
+type vote struct {
+	PollID  int64
+	Option  string
+	Up      bool
+	Comment string `callback:"-"`
+}
+
+func TestCodec(t *testing.T) {
+	codec := callbackdata.New[vote]("v", []byte("secret"))
+
+	data, err := codec.Marshal(vote{PollID: 123456789, Option: "a:b%c", Up: true, Comment: "ignored"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	update := &tgo.Update{CallbackQuery: &tgo.CallbackQuery{Data: data}}
+	defer tgo.ForgetUpdate(update)
+
+	if !codec.Filter().Check(update) {
+		t.Fatalf("filter didn't pass %q", data)
+	}
+	if got, _ := codec.Value(update); got != (vote{PollID: 123456789, Option: "a:b%c", Up: true}) {
+		t.Errorf("got %+v", got)
+	}
+
+	tampered := strings.Replace(data, ":1:", ":0:", 1)
+	if _, err = codec.Unmarshal(tampered); err != callbackdata.ErrInvalidSignature {
+		t.Errorf("got %v for the tampered data %q", err, tampered)
+	}
+
+	if _, err = codec.Marshal(vote{Option: strings.Repeat("x", 64)}); err != callbackdata.ErrTooLong {
+		t.Errorf("got %v for the long data", err)
+	}
+}
+
+type item struct {
+	ItemID int64 `callback:"id"`
+	Page   int
+}
+
+func TestCodecIDs(t *testing.T) {
+	ids := tgo.NewHashIDs([]byte("ids"))
+	codec := callbackdata.New[item]("i", nil).WithIDCodec(ids)
+
+	data, err := codec.Marshal(item{ItemID: 1234, Page: 2})
+	if err != nil {
+		t.Fatal(err)
+	} else if want := "i:" + ids.EncodeID(1234) + ":2"; data != want {
+		t.Errorf("got %q, want %q", data, want)
+	}
+
+	if value, err := codec.Unmarshal(data); err != nil || value != (item{ItemID: 1234, Page: 2}) {
+		t.Errorf("unmarshaled %+v, %v", value, err)
+	}
+
+	if _, err := codec.Unmarshal("i:" + strings.Repeat("a", 14) + ":2"); err == nil {
+		t.Error("made-up id is unmarshaled")
+	}
+}
@@ -0,0 +1,99 @@
+package tgo
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// MaxCallbackDataLength is the maximum length of the inline keyboard buttons' callback data allowed by telegram.
+const MaxCallbackDataLength = 64
+
+// overflowPrefix marks the callback data which is a key of the bot's CallbackStore.
+const overflowPrefix = "~tgo:"
+
+// CallbackStore keeps the callback data longer than MaxCallbackDataLength under short keys, so the
+// buttons can carry them anyway. Implement it to share the payloads between the bot's instances.
+type CallbackStore interface {
+	Put(key, data string) error
+	Get(key string) (data string, ok bool, err error)
+}
+
+// MemoryCallbackStore is an in-memory CallbackStore. The keys are derived from the payloads,
+// so it only grows by the number of the distinct long payloads.
+type MemoryCallbackStore struct{ payloads sync.Map }
+
+// Put implements the CallbackStore interface.
+func (s *MemoryCallbackStore) Put(key, data string) error {
+	s.payloads.Store(key, data)
+	return nil
+}
+
+// Get implements the CallbackStore interface.
+func (s *MemoryCallbackStore) Get(key string) (string, bool, error) {
+	data, ok := s.payloads.Load(key)
+	if !ok {
+		return "", false, nil
+	}
+	return data.(string), true, nil
+}
+
+// ShrinkCallbackData replaces the callback data of the markup's buttons which are longer than
+// MaxCallbackDataLength with keys of the bot's CallbackStore, in place. They're resolved back
+// to the original data when their callback queries arrive, before any of the handlers see them.
+//
+// bot.Send does it automatically; call it yourself for the markups passed to the other methods,
+// such as editMessageReplyMarkup. It does nothing if Options.CallbackStore is not set.
+func (bot *Bot) ShrinkCallbackData(markup *InlineKeyboardMarkup) error {
+	if bot.callbackStore == nil || markup == nil {
+		return nil
+	}
+
+	for _, row := range markup.InlineKeyboard {
+		for _, button := range row {
+			if button == nil || len(button.CallbackData) <= MaxCallbackDataLength {
+				continue
+			}
+
+			hash := sha256.Sum256([]byte(button.CallbackData))
+			key := base64.RawURLEncoding.EncodeToString(hash[:18])
+
+			if err := bot.callbackStore.Put(key, button.CallbackData); err != nil {
+				return err
+			}
+			button.CallbackData = overflowPrefix + key
+		}
+	}
+
+	return nil
+}
+
+// shrinkSendable shrinks the callback data of the sendable's inline keyboard, if it has any.
+func (bot *Bot) shrinkSendable(msg Sendable) error {
+	v := reflect.ValueOf(msg)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	field := v.Elem().FieldByName("ReplyMarkup")
+	if !field.IsValid() || field.IsNil() {
+		return nil
+	}
+
+	markup, _ := field.Interface().(*InlineKeyboardMarkup)
+	return bot.ShrinkCallbackData(markup)
+}
+
+// resolveCallbackData replaces the shrunk callback data of the update with the original one.
+// It's left as is if the payload is not found, such as when the store has lost it.
+func (bot *Bot) resolveCallbackData(update *Update) {
+	if bot.callbackStore == nil || update.CallbackQuery == nil || !strings.HasPrefix(update.CallbackQuery.Data, overflowPrefix) {
+		return
+	}
+
+	if data, ok, err := bot.callbackStore.Get(strings.TrimPrefix(update.CallbackQuery.Data, overflowPrefix)); err == nil && ok {
+		update.CallbackQuery.Data = data
+	}
+}
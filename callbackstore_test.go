@@ -0,0 +1,45 @@
+package tgo_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+type callbackRouter struct{ data string }
+
+func (r *callbackRouter) Setup(bot *tgo.Bot) error { return nil }
+
+func (r *callbackRouter) HandleUpdate(bot *tgo.Bot, upd *tgo.Update) bool {
+	r.data = upd.CallbackQuery.Data
+	return true
+}
+
+func TestCallbackStore(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	bot := server.Bot(tgo.Options{CallbackStore: &tgo.MemoryCallbackStore{}})
+
+	router := &callbackRouter{}
+	bot.AddRouter(router)
+
+	long := strings.Repeat("x", 100)
+	markup := &tgo.InlineKeyboardMarkup{InlineKeyboard: [][]*tgo.InlineKeyboardButton{{{Text: "short", CallbackData: "short"}, {Text: "long", CallbackData: long}}}}
+
+	if _, err := bot.Send(&tgo.SendMessage{ChatId: tgo.ID(1), Text: "hi", ReplyMarkup: markup}); err != nil {
+		t.Fatal(err)
+	}
+
+	sent := markup.InlineKeyboard[0]
+	if sent[0].CallbackData != "short" || len(sent[1].CallbackData) > tgo.MaxCallbackDataLength {
+		t.Fatalf("unexpected callback data %q and %q", sent[0].CallbackData, sent[1].CallbackData)
+	}
+
+	bot.HandleUpdate(&tgo.Update{CallbackQuery: &tgo.CallbackQuery{Data: sent[1].CallbackData}})
+	if router.data != long {
+		t.Errorf("got callback data %q", router.data)
+	}
+}
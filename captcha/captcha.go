@@ -0,0 +1,412 @@
+// Package captcha protects the groups from the spam bots by muting their new members until they
+// answer a challenge, such as tapping a button or picking an emoji, and kicking them if they don't.
+package captcha
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/keyboard"
+)
+
+// DefaultTimeout is how long the new members have to answer their challenge by default.
+const DefaultTimeout = 2 * time.Minute
+
+// Options configures a Captcha. The zero value is valid and uses the defaults.
+type Options struct {
+	// Prefix is the prefix of the challenges' callback data; it defaults to "captcha", and it must be unique
+	// among the callback data of the bot's keyboards.
+	Prefix string
+
+	// Challenge makes the questions; it defaults to a ButtonChallenge.
+	Challenge Challenge
+
+	// Timeout is how long the new members have to answer; it defaults to DefaultTimeout.
+	Timeout time.Duration
+
+	// MaxAttempts is the number of the wrong answers after which the member is kicked; it defaults to 1.
+	MaxAttempts int
+
+	// Store keeps the pending verifications; it defaults to a MemoryStore.
+	Store Store
+
+	// SweepInterval is how often the expired verifications are looked for; it defaults to 5 seconds.
+	SweepInterval time.Duration
+
+	// IncludeBots challenges the bots added to the groups as well; they're let in by default,
+	// as only the administrators can add them.
+	IncludeBots bool
+
+	// NotYours and Wrong are the answers to the taps on someone else's challenge, and to the wrong
+	// answers which don't kick the member yet. They default to English texts, and are translated by
+	// bot.Translate if there's a translator.
+	NotYours, Wrong string
+
+	// OnVerified, if not nil, is called after a member answers their challenge and is unrestricted.
+	OnVerified func(bot *tgo.Bot, p *Pending)
+
+	// OnFailed, if not nil, is called after a member is kicked, for either the timeout or the wrong answers.
+	OnFailed func(bot *tgo.Bot, p *Pending)
+}
+
+// Captcha is a router which mutes the new members of the groups, asks them a challenge, and lifts their
+// restrictions once they answer it, or kicks them if they don't answer it in time:
+//
+//	bot.AddRouter(captcha.New(captcha.Options{Challenge: captcha.EmojiChallenge{}}))
+//
+// The bot must be an administrator of the groups, with the right to restrict and ban the members.
+// It sees the joins in both the chat_member updates and the service messages, so it's enough
+// for either of them to be among the bot's allowed updates.
+type Captcha struct {
+	opts Options
+	me   *tgo.User
+
+	bot *tgo.Bot
+
+	// locks serialize the changes of each member's verification, such as a join seen in both
+	// an update and a message, or an answer racing the timeout, without holding up the other members.
+	locks    map[pendingKey]*memberLock
+	locksMut sync.Mutex
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// New returns a Captcha router.
+func New(opts Options) *Captcha {
+	if opts.Prefix == "" {
+		opts.Prefix = "captcha"
+	}
+	if opts.Challenge == nil {
+		opts.Challenge = ButtonChallenge{}
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 1
+	}
+	if opts.Store == nil {
+		opts.Store = &MemoryStore{}
+	}
+	if opts.SweepInterval <= 0 {
+		opts.SweepInterval = 5 * time.Second
+	}
+	if opts.NotYours == "" {
+		opts.NotYours = "This challenge is not for you."
+	}
+	if opts.Wrong == "" {
+		opts.Wrong = "Wrong answer, try again."
+	}
+
+	return &Captcha{opts: opts, locks: make(map[pendingKey]*memberLock), stop: make(chan struct{})}
+}
+
+// maxKickFailures is the number of the failed kicks after which an expired verification is dropped,
+// such as when the bot is no longer an administrator of the chat.
+const maxKickFailures = 5
+
+type memberLock struct {
+	sync.Mutex
+	refs int
+}
+
+// lock locks the verification of the user in the chat, and returns its unlock function.
+func (c *Captcha) lock(chatID, userID int64) (unlock func()) {
+	key := pendingKey{chatID, userID}
+
+	c.locksMut.Lock()
+	l, ok := c.locks[key]
+	if !ok {
+		l = &memberLock{}
+		c.locks[key] = l
+	}
+	l.refs++
+	c.locksMut.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+
+		c.locksMut.Lock()
+		if l.refs--; l.refs == 0 {
+			delete(c.locks, key)
+		}
+		c.locksMut.Unlock()
+	}
+}
+
+// Setup implements the tgo.Router interface. It starts kicking the members whose verification
+// is expired, until the Captcha is stopped or the bot is shut down.
+func (c *Captcha) Setup(bot *tgo.Bot) error {
+	me, err := bot.Me()
+	if err != nil {
+		return err
+	}
+
+	c.me, c.bot = me, bot
+	bot.OnShutdown(func(ctx context.Context) error { c.Stop(); return nil })
+
+	go c.sweep()
+	return nil
+}
+
+// Stop stops kicking the members whose verification is expired; they're kicked once it's set up again.
+func (c *Captcha) Stop() { c.stopOnce.Do(func() { close(c.stop) }) }
+
+// UpdateTypes implements the tgo.UpdateTypesRouter interface.
+func (c *Captcha) UpdateTypes() []string { return []string{"chat_member", "message", "callback_query"} }
+
+// HandleUpdate implements the tgo.Router interface. The joins and leaves are still passed to the
+// other routers, so they can welcome the members; only the answers to the challenges are used.
+func (c *Captcha) HandleUpdate(bot *tgo.Bot, update *tgo.Update) bool {
+	switch {
+	case update.CallbackQuery != nil:
+		return c.handleAnswer(bot, update.CallbackQuery)
+
+	case update.ChatMember != nil:
+		member := update.ChatMember
+		if user := tgo.ChatMemberUser(member.NewChatMember); user != nil {
+			if member.JustJoined() {
+				c.challenge(bot, member.Chat.Id, user)
+			} else if member.JustLeft() {
+				c.forget(bot, member.Chat.Id, user.Id)
+			}
+		}
+
+	case update.Message != nil:
+		msg := update.Message
+		for _, user := range msg.NewChatMembers {
+			c.challenge(bot, msg.Chat.Id, user)
+		}
+		if msg.LeftChatMember != nil {
+			c.forget(bot, msg.Chat.Id, msg.LeftChatMember.Id)
+		}
+	}
+
+	return false
+}
+
+// challenge mutes the new member and sends them a challenge, unless they're already challenged.
+func (c *Captcha) challenge(bot *tgo.Bot, chatID int64, user *tgo.User) {
+	if user.Id == c.me.Id || (user.IsBot && !c.opts.IncludeBots) {
+		return
+	}
+
+	defer c.lock(chatID, user.Id)()
+
+	if p, err := c.opts.Store.Get(chatID, user.Id); err != nil {
+		bot.Logger().Log(tgo.LevelError, "failed to get the pending verification", "chat_id", chatID, "user_id", user.Id, "error", err)
+		return
+	} else if p != nil {
+		return
+	}
+
+	if err := bot.MuteUser(tgo.ID(chatID), user.Id, 0); err != nil {
+		bot.Logger().Log(tgo.LevelError, "failed to mute the new member", "chat_id", chatID, "user_id", user.Id, "error", err)
+		return
+	}
+
+	text, labels, answer := c.opts.Challenge.New(user)
+
+	buttons := make([]*tgo.InlineKeyboardButton, len(labels))
+	for i, label := range labels {
+		buttons[i] = &tgo.InlineKeyboardButton{Text: label, CallbackData: fmt.Sprintf("%s:%d:%d", c.opts.Prefix, user.Id, i)}
+	}
+
+	// the member is mentioned by an entity rather than the parse mode, so their name needs no escaping.
+	name := user.FirstName
+	msg, err := bot.Send(&tgo.SendMessage{
+		ChatId:      tgo.ID(chatID),
+		Text:        name + ", " + text,
+		Entities:    []*tgo.MessageEntity{{Type: "text_mention", Length: int64(tgo.UTF16Len(name)), User: user}},
+		ReplyMarkup: &tgo.InlineKeyboardMarkup{InlineKeyboard: keyboard.Balance(buttons, keyboard.BalanceOptions{})},
+	})
+	if err != nil {
+		bot.Logger().Log(tgo.LevelError, "failed to send the challenge", "chat_id", chatID, "user_id", user.Id, "error", err)
+
+		// the member can't answer a challenge they haven't got, so they shouldn't be kept muted.
+		if err = bot.UnrestrictUser(tgo.ID(chatID), user.Id); err != nil {
+			bot.Logger().Log(tgo.LevelError, "failed to unmute the new member", "chat_id", chatID, "user_id", user.Id, "error", err)
+		}
+		return
+	}
+
+	p := &Pending{ChatID: chatID, UserID: user.Id, MessageID: msg.MessageId, Answer: answer, Deadline: time.Now().Add(c.opts.Timeout)}
+	if err = c.opts.Store.Save(p); err != nil {
+		bot.Logger().Log(tgo.LevelError, "failed to save the pending verification", "chat_id", chatID, "user_id", user.Id, "error", err)
+	}
+}
+
+// handleAnswer handles the taps on the challenges' buttons.
+func (c *Captcha) handleAnswer(bot *tgo.Bot, query *tgo.CallbackQuery) bool {
+	if !strings.HasPrefix(query.Data, c.opts.Prefix+":") || query.Message == nil {
+		return false
+	}
+
+	rawUserID, rawIndex, _ := strings.Cut(strings.TrimPrefix(query.Data, c.opts.Prefix+":"), ":")
+	userID, err := strconv.ParseInt(rawUserID, 10, 64)
+	if err != nil {
+		return false
+	}
+	index, err := strconv.Atoi(rawIndex)
+	if err != nil {
+		return false
+	}
+
+	if query.From.Id != userID {
+		c.answer(bot, query, bot.Translate(query.From.LanguageCode, c.opts.NotYours))
+		return true
+	}
+
+	chatID := query.Message.Chat.Id
+	defer c.lock(chatID, userID)()
+
+	p, err := c.opts.Store.Get(chatID, userID)
+	if err != nil {
+		bot.Logger().Log(tgo.LevelError, "failed to get the pending verification", "chat_id", chatID, "user_id", userID, "error", err)
+		c.answer(bot, query, "")
+		return true
+	} else if p == nil {
+		// the verification is already over, such as by the timeout; the stale challenge is removed.
+		c.answer(bot, query, "")
+		c.deleteMessage(bot, chatID, query.Message.MessageId)
+		return true
+	}
+
+	if index == p.Answer {
+		c.answer(bot, query, "")
+		c.verify(bot, p)
+		return true
+	}
+
+	p.Attempts++
+	if p.Attempts >= c.opts.MaxAttempts {
+		c.answer(bot, query, "")
+		c.kick(bot, p)
+		return true
+	}
+
+	if err = c.opts.Store.Save(p); err != nil {
+		bot.Logger().Log(tgo.LevelError, "failed to save the pending verification", "chat_id", chatID, "user_id", userID, "error", err)
+	}
+	c.answer(bot, query, bot.Translate(query.From.LanguageCode, c.opts.Wrong))
+	return true
+}
+
+// verify lifts the member's restrictions and ends their verification.
+func (c *Captcha) verify(bot *tgo.Bot, p *Pending) {
+	if err := bot.UnrestrictUser(tgo.ID(p.ChatID), p.UserID); err != nil {
+		bot.Logger().Log(tgo.LevelError, "failed to unmute the verified member", "chat_id", p.ChatID, "user_id", p.UserID, "error", err)
+		return
+	}
+
+	c.end(bot, p)
+	if c.opts.OnVerified != nil {
+		c.opts.OnVerified(bot, p)
+	}
+}
+
+// kick removes the member from the chat, without banning them, and ends their verification.
+// It reports whether the member is removed.
+func (c *Captcha) kick(bot *tgo.Bot, p *Pending) bool {
+	if err := bot.BanUser(tgo.ID(p.ChatID), p.UserID, 0); err != nil {
+		bot.Logger().Log(tgo.LevelError, "failed to kick the unverified member", "chat_id", p.ChatID, "user_id", p.UserID, "error", err)
+		return false
+	}
+	if err := bot.UnbanUser(tgo.ID(p.ChatID), p.UserID); err != nil {
+		bot.Logger().Log(tgo.LevelError, "failed to unban the kicked member", "chat_id", p.ChatID, "user_id", p.UserID, "error", err)
+	}
+
+	c.end(bot, p)
+	if c.opts.OnFailed != nil {
+		c.opts.OnFailed(bot, p)
+	}
+	return true
+}
+
+// forget ends the verification of a member who left the chat.
+func (c *Captcha) forget(bot *tgo.Bot, chatID, userID int64) {
+	defer c.lock(chatID, userID)()
+
+	p, err := c.opts.Store.Get(chatID, userID)
+	if err != nil {
+		bot.Logger().Log(tgo.LevelError, "failed to get the pending verification", "chat_id", chatID, "user_id", userID, "error", err)
+	} else if p != nil {
+		c.end(bot, p)
+	}
+}
+
+// end deletes the verification and its challenge.
+func (c *Captcha) end(bot *tgo.Bot, p *Pending) {
+	if err := c.opts.Store.Delete(p.ChatID, p.UserID); err != nil {
+		bot.Logger().Log(tgo.LevelError, "failed to delete the pending verification", "chat_id", p.ChatID, "user_id", p.UserID, "error", err)
+	}
+	c.deleteMessage(bot, p.ChatID, p.MessageID)
+}
+
+// sweep kicks the members whose verification is expired, every SweepInterval.
+func (c *Captcha) sweep() {
+	ticker := time.NewTicker(c.opts.SweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		case now := <-ticker.C:
+			expired, err := c.opts.Store.Expired(now)
+			if err != nil {
+				c.bot.Logger().Log(tgo.LevelError, "failed to get the expired verifications", "error", err)
+				continue
+			}
+
+			for _, p := range expired {
+				c.kickExpired(p, now)
+			}
+		}
+	}
+}
+
+// kickExpired kicks the member of the expired verification, unless it's ended meanwhile. A failed kick
+// is retried with a doubling delay, and the verification is dropped after maxKickFailures of them.
+func (c *Captcha) kickExpired(expired *Pending, now time.Time) {
+	defer c.lock(expired.ChatID, expired.UserID)()
+
+	p, err := c.opts.Store.Get(expired.ChatID, expired.UserID)
+	if err != nil {
+		c.bot.Logger().Log(tgo.LevelError, "failed to get the pending verification", "chat_id", expired.ChatID, "user_id", expired.UserID, "error", err)
+		return
+	} else if p == nil || !now.After(p.Deadline) || c.kick(c.bot, p) {
+		return
+	}
+
+	p.KickFailures++
+	if p.KickFailures >= maxKickFailures {
+		c.bot.Logger().Log(tgo.LevelError, "gave up kicking the unverified member", "chat_id", p.ChatID, "user_id", p.UserID, "failures", p.KickFailures)
+		c.end(c.bot, p)
+		return
+	}
+
+	p.Deadline = now.Add(c.opts.SweepInterval << p.KickFailures)
+	if err = c.opts.Store.Save(p); err != nil {
+		c.bot.Logger().Log(tgo.LevelError, "failed to save the pending verification", "chat_id", p.ChatID, "user_id", p.UserID, "error", err)
+	}
+}
+
+func (c *Captcha) answer(bot *tgo.Bot, query *tgo.CallbackQuery, text string) {
+	if _, err := bot.AnswerCallbackQuery(&tgo.AnswerCallbackQuery{CallbackQueryId: query.Id, Text: text}); err != nil {
+		bot.Logger().Log(tgo.LevelError, "failed to answer the callback query", "error", err)
+	}
+}
+
+func (c *Captcha) deleteMessage(bot *tgo.Bot, chatID, messageID int64) {
+	if _, err := bot.DeleteMessage(&tgo.DeleteMessage{ChatId: tgo.ID(chatID), MessageId: messageID}); err != nil {
+		bot.Logger().Log(tgo.LevelError, "failed to delete the challenge", "chat_id", chatID, "error", err)
+	}
+}
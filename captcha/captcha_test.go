@@ -0,0 +1,148 @@
+package captcha
+
+import (
+	"testing"
+	"time"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+var TestUpdateTypesRouter tgo.UpdateTypesRouter = &Captcha{}
+
+func joinUpdate(chatID, userID int64) *tgo.Update {
+	return &tgo.Update{Message: &tgo.Message{
+		MessageId:      1,
+		Chat:           tgo.Chat{Id: chatID, Type: "supergroup"},
+		NewChatMembers: []*tgo.User{{Id: userID, FirstName: "Gopher"}},
+	}}
+}
+
+func tapUpdate(chatID, userID int64, data string) *tgo.Update {
+	return &tgo.Update{CallbackQuery: &tgo.CallbackQuery{
+		Id:      "1",
+		From:    tgo.User{Id: userID},
+		Data:    data,
+		Message: &tgo.Message{MessageId: 2, Chat: tgo.Chat{Id: chatID, Type: "supergroup"}},
+	}}
+}
+
+func methods(calls []tgotest.Call) (names []string) {
+	for _, call := range calls {
+		names = append(names, call.Method)
+	}
+	return names
+}
+
+func TestVerify(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	bot := server.Bot(tgo.Options{})
+
+	var verified *Pending
+	c := New(Options{OnVerified: func(bot *tgo.Bot, p *Pending) { verified = p }})
+	if err := bot.AddRouter(c); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Stop()
+
+	server.Reset()
+	bot.HandleUpdate(joinUpdate(-1, 70))
+	bot.HandleUpdate(joinUpdate(-1, 70)) // the same join, seen in a chat_member update as well
+
+	if got := methods(server.Calls()); len(got) != 2 || got[0] != "restrictChatMember" || got[1] != "sendMessage" {
+		t.Fatalf("expected the member to be muted and challenged once, got %v", got)
+	}
+
+	server.Reset()
+	bot.HandleUpdate(tapUpdate(-1, 71, "captcha:70:0"))
+	if calls := server.Calls(); len(calls) != 1 || calls[0].Params["text"] != "This challenge is not for you." {
+		t.Fatalf("expected the others' taps to be rejected, got %+v", calls)
+	}
+
+	server.Reset()
+	bot.HandleUpdate(tapUpdate(-1, 70, "captcha:70:0"))
+	if got := methods(server.Calls()); len(got) != 3 || got[1] != "restrictChatMember" || got[2] != "deleteMessage" {
+		t.Fatalf("expected the member to be unmuted and the challenge to be deleted, got %v", got)
+	}
+	if verified == nil || verified.UserID != 70 {
+		t.Errorf("expected OnVerified to be called for the user 70, got %+v", verified)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	bot := server.Bot(tgo.Options{})
+
+	failed := make(chan *Pending, 1)
+	c := New(Options{
+		Timeout:       time.Millisecond,
+		SweepInterval: 5 * time.Millisecond,
+		OnFailed:      func(bot *tgo.Bot, p *Pending) { failed <- p },
+	})
+	if err := bot.AddRouter(c); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Stop()
+
+	bot.HandleUpdate(joinUpdate(-1, 70))
+
+	select {
+	case p := <-failed:
+		if p.UserID != 70 {
+			t.Errorf("expected the user 70 to be kicked, got %d", p.UserID)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("the member is not kicked after the timeout")
+	}
+
+	var banned, unbanned bool
+	for _, call := range server.Calls() {
+		banned = banned || call.Method == "banChatMember"
+		unbanned = unbanned || call.Method == "unbanChatMember"
+	}
+	if !banned || !unbanned {
+		t.Error("expected the member to be kicked without being banned")
+	}
+}
+
+func TestKickFailures(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	server.Handle("banChatMember", func(call tgotest.Call) (any, *tgo.Error) {
+		return nil, &tgo.Error{ErrorCode: 400, Description: "Bad Request: not enough rights to restrict/unrestrict chat member"}
+	})
+
+	bot := server.Bot(tgo.Options{})
+
+	c := New(Options{SweepInterval: time.Hour})
+	if err := bot.AddRouter(c); err != nil {
+		t.Fatal(err)
+	}
+	c.Stop()
+
+	now := time.Now()
+	c.opts.Store.Save(&Pending{ChatID: -1, UserID: 70, MessageID: 2, Deadline: now})
+
+	for i := 1; i < maxKickFailures; i++ {
+		now = now.Add(time.Hour << i)
+		c.kickExpired(&Pending{ChatID: -1, UserID: 70}, now)
+
+		p, _ := c.opts.Store.Get(-1, 70)
+		if p == nil || p.KickFailures != i || !p.Deadline.After(now) {
+			t.Fatalf("expected the kick to be retried later after %d failures, got %+v", i, p)
+		}
+	}
+
+	c.kickExpired(&Pending{ChatID: -1, UserID: 70}, now.Add(time.Hour<<maxKickFailures))
+	if p, _ := c.opts.Store.Get(-1, 70); p != nil {
+		t.Errorf("expected the verification to be dropped after %d failures, got %+v", maxKickFailures, p)
+	}
+	if len(c.locks) != 0 {
+		t.Errorf("expected the member locks to be released, got %d", len(c.locks))
+	}
+}
@@ -0,0 +1,107 @@
+package captcha
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	"github.com/haashemi/tgo"
+)
+
+// Challenge makes the questions asked from the new members.
+type Challenge interface {
+	// New returns the text of a new question for the user, the labels of its buttons,
+	// and the index of the right one.
+	New(user *tgo.User) (text string, buttons []string, answer int)
+}
+
+// ButtonChallenge asks the new members to tap a button; it stops the bots which can't press buttons.
+type ButtonChallenge struct {
+	// Text is the question; it defaults to "please tap the button below to verify you're human."
+	Text string
+
+	// Button is the label of the button; it defaults to "I'm not a robot".
+	Button string
+}
+
+// New implements the Challenge interface.
+func (c ButtonChallenge) New(user *tgo.User) (string, []string, int) {
+	text, button := c.Text, c.Button
+	if text == "" {
+		text = "please tap the button below to verify you're human."
+	}
+	if button == "" {
+		button = "I'm not a robot"
+	}
+	return text, []string{button}, 0
+}
+
+// EmojiChallenge asks the new members to pick an emoji among the others.
+type EmojiChallenge struct {
+	// Emojis are the emojis which the choices are picked from, with their names shown in the question;
+	// it defaults to a set of the animals.
+	Emojis map[string]string
+
+	// Choices is the number of the emojis shown; it defaults to 6.
+	Choices int
+}
+
+// defaultEmojis are the emojis of the EmojiChallenge by default.
+var defaultEmojis = map[string]string{
+	"🐶": "dog", "🐱": "cat", "🐭": "mouse", "🐰": "rabbit", "🦊": "fox", "🐻": "bear",
+	"🐼": "panda", "🐨": "koala", "🐯": "tiger", "🦁": "lion", "🐮": "cow", "🐷": "pig",
+}
+
+// New implements the Challenge interface.
+func (c EmojiChallenge) New(user *tgo.User) (string, []string, int) {
+	emojis := c.Emojis
+	if len(emojis) == 0 {
+		emojis = defaultEmojis
+	}
+
+	all := make([]string, 0, len(emojis))
+	for emoji := range emojis {
+		all = append(all, emoji)
+	}
+
+	choices := c.Choices
+	if choices <= 0 {
+		choices = 6
+	}
+	if choices > len(all) {
+		choices = len(all)
+	}
+
+	// a partial Fisher-Yates shuffle picks the choices.
+	for i := 0; i < choices; i++ {
+		j := i + randInt(len(all)-i)
+		all[i], all[j] = all[j], all[i]
+	}
+
+	answer := randInt(choices)
+	return fmt.Sprintf("please tap the %s to verify you're human.", emojis[all[answer]]), all[:choices], answer
+}
+
+// MathChallenge asks the new members to solve the sum of two numbers.
+type MathChallenge struct{}
+
+// New implements the Challenge interface.
+func (MathChallenge) New(user *tgo.User) (string, []string, int) {
+	a, b := 1+randInt(9), 1+randInt(9)
+
+	answer := randInt(4)
+	buttons := make([]string, 4)
+	for i := range buttons {
+		// the wrong answers are around the right one, so they can't be told apart by their size.
+		buttons[i] = fmt.Sprint(a + b + i - answer)
+	}
+	return fmt.Sprintf("please tap the result of %d + %d to verify you're human.", a, b), buttons, answer
+}
+
+func randInt(n int) int {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		panic(err)
+	}
+	return int(i.Int64())
+}
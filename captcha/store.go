@@ -0,0 +1,94 @@
+package captcha
+
+import (
+	"sync"
+	"time"
+)
+
+// Pending is a verification which is waiting for the new member's answer.
+type Pending struct {
+	ChatID    int64     `json:"chat_id"`
+	UserID    int64     `json:"user_id"`
+	MessageID int64     `json:"message_id"` // the message of the question
+	Answer    int       `json:"answer"`     // the index of the right button
+	Attempts  int       `json:"attempts"`   // the number of the wrong answers so far
+	Deadline  time.Time `json:"deadline"`   // when the member is kicked if they've not answered
+
+	KickFailures int `json:"kick_failures"` // the number of the failed kicks after the deadline
+}
+
+// Store persists the pending verifications. Implement it to keep them across the restarts,
+// so the members who join before a restart are still verified or kicked.
+type Store interface {
+	// Save adds the verification, or replaces the one of the same chat and user.
+	Save(p *Pending) error
+
+	// Get returns the verification of the user in the chat, or nil if there's none.
+	Get(chatID, userID int64) (*Pending, error)
+
+	// Delete removes the verification; it's not an error if it doesn't exist.
+	Delete(chatID, userID int64) error
+
+	// Expired returns the verifications whose deadline is passed at now.
+	Expired(now time.Time) ([]*Pending, error)
+}
+
+type pendingKey struct{ chatID, userID int64 }
+
+// MemoryStore is an in-memory Store; the pending verifications are lost when the program exits.
+type MemoryStore struct {
+	mut     sync.Mutex
+	pending map[pendingKey]*Pending
+}
+
+// Save implements the Store interface.
+func (s *MemoryStore) Save(p *Pending) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if s.pending == nil {
+		s.pending = make(map[pendingKey]*Pending)
+	}
+
+	copied := *p
+	s.pending[pendingKey{p.ChatID, p.UserID}] = &copied
+	return nil
+}
+
+// Get implements the Store interface.
+func (s *MemoryStore) Get(chatID, userID int64) (*Pending, error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	p, ok := s.pending[pendingKey{chatID, userID}]
+	if !ok {
+		return nil, nil
+	}
+
+	copied := *p
+	return &copied, nil
+}
+
+// Delete implements the Store interface.
+func (s *MemoryStore) Delete(chatID, userID int64) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	delete(s.pending, pendingKey{chatID, userID})
+	return nil
+}
+
+// Expired implements the Store interface.
+func (s *MemoryStore) Expired(now time.Time) ([]*Pending, error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	var expired []*Pending
+	for _, p := range s.pending {
+		if now.After(p.Deadline) {
+			copied := *p
+			expired = append(expired, &copied)
+		}
+	}
+	return expired, nil
+}
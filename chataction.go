@@ -0,0 +1,63 @@
+package tgo
+
+import "time"
+
+// The actions of sendChatAction, shown to the users while the bot prepares what it's about to send.
+const (
+	ChatActionTyping          = "typing"
+	ChatActionUploadPhoto     = "upload_photo"
+	ChatActionRecordVideo     = "record_video"
+	ChatActionUploadVideo     = "upload_video"
+	ChatActionRecordVoice     = "record_voice"
+	ChatActionUploadVoice     = "upload_voice"
+	ChatActionUploadDocument  = "upload_document"
+	ChatActionChooseSticker   = "choose_sticker"
+	ChatActionFindLocation    = "find_location"
+	ChatActionRecordVideoNote = "record_video_note"
+	ChatActionUploadVideoNote = "upload_video_note"
+)
+
+// ChatActionInterval is how often WithChatAction sends its action; telegram shows each one for 5 seconds.
+const ChatActionInterval = 4 * time.Second
+
+// WithChatAction shows the chat action in the chat, or in its forum topic if threadID is not zero,
+// for as long as the work runs; and returns the work's error. The action is sent as the work starts
+// and every ChatActionInterval after that, and never after the work is done:
+//
+//	err := bot.WithChatAction(tgo.ID(chatID), 0, tgo.ChatActionUploadPhoto, func() error {
+//		_, err := bot.Send(&tgo.SendPhoto{ChatId: tgo.ID(chatID), Photo: renderChart()})
+//		return err
+//	})
+//
+// The failures of sending the action are only logged, as they don't concern the work.
+func (bot *Bot) WithChatAction(chatID ChatID, threadID int64, action string, work func() error) error {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(ChatActionInterval)
+		defer ticker.Stop()
+
+		for {
+			_, err := bot.SendChatAction(&SendChatAction{ChatId: chatID, MessageThreadId: threadID, Action: action})
+			if err != nil {
+				bot.log(LevelDebug, "failed to send the chat action", "action", action, "error", err)
+			}
+
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	defer func() {
+		close(done)
+		<-stopped
+	}()
+
+	return work()
+}
@@ -0,0 +1,45 @@
+package tgo_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestWithChatAction(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	sent := make(chan string, 10)
+	server.Handle("sendChatAction", func(call tgotest.Call) (any, *tgo.Error) {
+		sent <- call.Params["action"].(string)
+		return true, nil
+	})
+
+	bot := server.Bot(tgo.Options{})
+	errWork := errors.New("work failed")
+
+	err := bot.WithChatAction(tgo.ID(42), 0, tgo.ChatActionUploadPhoto, func() error {
+		select {
+		case action := <-sent:
+			if action != tgo.ChatActionUploadPhoto {
+				t.Errorf("sent the %q action", action)
+			}
+		case <-time.After(5 * time.Second):
+			t.Error("the chat action isn't sent while the work runs")
+		}
+		return errWork
+	})
+	if !errors.Is(err, errWork) {
+		t.Fatalf("got %v, want the work's error", err)
+	}
+
+	calls := len(server.Calls())
+	time.Sleep(10 * time.Millisecond)
+	if len(server.Calls()) != calls {
+		t.Fatal("the chat action is sent after the work is done")
+	}
+}
@@ -0,0 +1,288 @@
+package tgo
+
+import (
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultChatCacheTTL is how long the chats and their members are cached by default.
+const DefaultChatCacheTTL = time.Minute
+
+// CacheBackend stores the cached values of a ChatCache. Implement it to share the cache between
+// the bot's instances, such as in redis; the values are JSON-encoded.
+type CacheBackend interface {
+	// Get returns the value of the key, and false if it's not cached or it's expired.
+	Get(key string) (value []byte, ok bool, err error)
+
+	// Set caches the value of the key for the ttl.
+	Set(key string, value []byte, ttl time.Duration) error
+
+	// Delete removes the keys from the cache; it's not an error if they don't exist.
+	Delete(keys ...string) error
+}
+
+// MemoryCacheBackend is an in-memory CacheBackend, which evicts the least recently used values
+// beyond its maximum number of entries.
+type MemoryCacheBackend struct {
+	max     int
+	mut     sync.Mutex
+	order   *list.List // of *memoryCacheEntry, the most recently used first
+	entries map[string]*list.Element
+}
+
+type memoryCacheEntry struct {
+	key    string
+	value  []byte
+	expiry time.Time
+}
+
+// NewMemoryCacheBackend returns a MemoryCacheBackend of at most maxEntries values; it defaults to 10000.
+func NewMemoryCacheBackend(maxEntries int) *MemoryCacheBackend {
+	if maxEntries <= 0 {
+		maxEntries = 10000
+	}
+	return &MemoryCacheBackend{max: maxEntries, order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+// Get implements the CacheBackend interface.
+func (b *MemoryCacheBackend) Get(key string) ([]byte, bool, error) {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	element, ok := b.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	entry := element.Value.(*memoryCacheEntry)
+	if time.Now().After(entry.expiry) {
+		b.order.Remove(element)
+		delete(b.entries, key)
+		return nil, false, nil
+	}
+
+	b.order.MoveToFront(element)
+	return entry.value, true, nil
+}
+
+// Set implements the CacheBackend interface.
+func (b *MemoryCacheBackend) Set(key string, value []byte, ttl time.Duration) error {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	entry := &memoryCacheEntry{key: key, value: value, expiry: time.Now().Add(ttl)}
+	if element, ok := b.entries[key]; ok {
+		element.Value = entry
+		b.order.MoveToFront(element)
+		return nil
+	}
+
+	b.entries[key] = b.order.PushFront(entry)
+	for b.order.Len() > b.max {
+		oldest := b.order.Back()
+		b.order.Remove(oldest)
+		delete(b.entries, oldest.Value.(*memoryCacheEntry).key)
+	}
+	return nil
+}
+
+// Delete implements the CacheBackend interface.
+func (b *MemoryCacheBackend) Delete(keys ...string) error {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	for _, key := range keys {
+		if element, ok := b.entries[key]; ok {
+			b.order.Remove(element)
+			delete(b.entries, key)
+		}
+	}
+	return nil
+}
+
+// Len returns the number of the cached values, including the expired ones which aren't evicted yet.
+func (b *MemoryCacheBackend) Len() int {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+	return b.order.Len()
+}
+
+// ChatCacheOptions configures a ChatCache. The zero value is valid and uses the defaults.
+type ChatCacheOptions struct {
+	// TTL is how long the chats and their members are cached; it defaults to DefaultChatCacheTTL.
+	TTL time.Duration
+
+	// Backend stores the cached values; it defaults to a MemoryCacheBackend of 10000 entries.
+	Backend CacheBackend
+}
+
+// ChatCache caches the results of getChat and getChatMember, which the permission checks call for
+// most of the updates. The concurrent lookups of the same chat or member share a single call, and
+// the cached ones are invalidated by the updates changing them, such as the chat_member updates.
+// Pass it to the Options to use it by bot.CachedChat and bot.CachedChatMember.
+type ChatCache struct {
+	opts ChatCacheOptions
+
+	mut   sync.Mutex
+	calls map[string]*cacheCall
+}
+
+// cacheCall is an in-flight lookup, whose result is ready once done is closed.
+type cacheCall struct {
+	done        chan struct{}
+	value       []byte
+	err         error
+	invalidated bool
+}
+
+// NewChatCache returns a ChatCache of the options.
+func NewChatCache(opts ChatCacheOptions) *ChatCache {
+	if opts.TTL <= 0 {
+		opts.TTL = DefaultChatCacheTTL
+	}
+	if opts.Backend == nil {
+		opts.Backend = NewMemoryCacheBackend(0)
+	}
+
+	return &ChatCache{opts: opts, calls: make(map[string]*cacheCall)}
+}
+
+func chatCacheKey(chatID int64) string { return fmt.Sprintf("tgo:chat:%d", chatID) }
+func memberCacheKey(chatID, userID int64) string {
+	return fmt.Sprintf("tgo:member:%d:%d", chatID, userID)
+}
+func usernameCacheKey(username string) string { return "tgo:username:" + username }
+
+// Invalidate removes the cached chat.
+func (c *ChatCache) Invalidate(chatID int64) error { return c.invalidate(chatCacheKey(chatID)) }
+
+// InvalidateMember removes the cached member of the chat.
+func (c *ChatCache) InvalidateMember(chatID, userID int64) error {
+	return c.invalidate(memberCacheKey(chatID, userID))
+}
+
+func (c *ChatCache) invalidate(keys ...string) error {
+	// the in-flight lookups may have already got the old values, so they're not cached.
+	c.mut.Lock()
+	for _, key := range keys {
+		if call, ok := c.calls[key]; ok {
+			call.invalidated = true
+		}
+	}
+	c.mut.Unlock()
+
+	return c.opts.Backend.Delete(keys...)
+}
+
+// lookup returns the cached value of the key, or fetches and caches it.
+func (c *ChatCache) lookup(key string, fetch func() (any, error)) ([]byte, error) {
+	if value, ok, err := c.opts.Backend.Get(key); err != nil {
+		return nil, err
+	} else if ok {
+		return value, nil
+	}
+
+	c.mut.Lock()
+	if call, ok := c.calls[key]; ok {
+		c.mut.Unlock()
+
+		<-call.done
+		return call.value, call.err
+	}
+
+	call := &cacheCall{done: make(chan struct{})}
+	c.calls[key] = call
+	c.mut.Unlock()
+
+	defer close(call.done)
+
+	result, err := fetch()
+	if err == nil {
+		call.value, err = json.Marshal(result)
+	}
+
+	c.mut.Lock()
+	delete(c.calls, key)
+	invalidated := call.invalidated
+	c.mut.Unlock()
+
+	if call.err = err; err == nil && !invalidated {
+		// the failure to cache the value doesn't fail the lookup, which is done anyway.
+		c.opts.Backend.Set(key, call.value, c.opts.TTL)
+	}
+	return call.value, call.err
+}
+
+// observe invalidates the chats and the members changed by the update.
+func (c *ChatCache) observe(update *Update) {
+	var keys []string
+
+	for _, member := range []*ChatMemberUpdated{update.ChatMember, update.MyChatMember} {
+		if member == nil {
+			continue
+		}
+		if user := ChatMemberUser(member.NewChatMember); user != nil {
+			keys = append(keys, memberCacheKey(member.Chat.Id, user.Id))
+		}
+		// the bot's own rights change what the chat shows to it, such as its invite link.
+		if member == update.MyChatMember {
+			keys = append(keys, chatCacheKey(member.Chat.Id))
+		}
+	}
+
+	if msg := update.Message; msg != nil {
+		for _, user := range msg.NewChatMembers {
+			keys = append(keys, memberCacheKey(msg.Chat.Id, user.Id))
+		}
+		if msg.LeftChatMember != nil {
+			keys = append(keys, memberCacheKey(msg.Chat.Id, msg.LeftChatMember.Id))
+		}
+		if msg.NewChatTitle != "" || len(msg.NewChatPhoto) != 0 || msg.DeleteChatPhoto || msg.PinnedMessage != nil || msg.MigrateToChatId != 0 {
+			keys = append(keys, chatCacheKey(msg.Chat.Id))
+		}
+	}
+
+	if len(keys) != 0 {
+		c.invalidate(keys...)
+	}
+}
+
+// CachedChat returns the chat, from the bot's ChatCache if it has one.
+func (bot *Bot) CachedChat(chatID int64) (*Chat, error) {
+	if bot.chatCache == nil {
+		return bot.GetChat(&GetChat{ChatId: ID(chatID)})
+	}
+
+	raw, err := bot.chatCache.lookup(chatCacheKey(chatID), func() (any, error) {
+		return bot.GetChat(&GetChat{ChatId: ID(chatID)})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// each caller gets its own copy, so they can't change the others'.
+	var chat *Chat
+	err = json.Unmarshal(raw, &chat)
+	return chat, err
+}
+
+// CachedChatMember returns the user's membership of the chat, from the bot's ChatCache if it has one.
+func (bot *Bot) CachedChatMember(chatID, userID int64) (ChatMember, error) {
+	if bot.chatCache == nil {
+		return bot.GetChatMember(&GetChatMember{ChatId: ID(chatID), UserId: userID})
+	}
+
+	raw, err := bot.chatCache.lookup(memberCacheKey(chatID, userID), func() (any, error) {
+		return bot.GetChatMember(&GetChatMember{ChatId: ID(chatID), UserId: userID})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalChatMember(raw)
+}
+
+// ChatCache returns the bot's ChatCache, or nil if it has none.
+func (bot *Bot) ChatCache() *ChatCache { return bot.chatCache }
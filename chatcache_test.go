@@ -0,0 +1,77 @@
+package tgo_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestChatCache(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	var lookups int
+	var lookupsMut sync.Mutex
+	server.Handle("getChatMember", func(call tgotest.Call) (any, *tgo.Error) {
+		lookupsMut.Lock()
+		lookups++
+		lookupsMut.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+		return map[string]any{"status": "administrator", "user": map[string]any{"id": 7}}, nil
+	})
+
+	bot := server.Bot(tgo.Options{ChatCache: tgo.NewChatCache(tgo.ChatCacheOptions{})})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if member, err := bot.CachedChatMember(-1, 7); err != nil || !tgo.IsChatMemberAdmin(member) {
+				t.Errorf("unexpected member %+v: %v", member, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if lookups != 1 {
+		t.Errorf("expected the concurrent lookups to share a call, got %d calls", lookups)
+	}
+
+	// the member's update invalidates it.
+	bot.HandleUpdate(&tgo.Update{ChatMember: &tgo.ChatMemberUpdated{
+		Chat:          tgo.Chat{Id: -1},
+		OldChatMember: &tgo.ChatMemberAdministrator{Status: "administrator", User: tgo.User{Id: 7}},
+		NewChatMember: &tgo.ChatMemberMember{Status: "member", User: tgo.User{Id: 7}},
+	}})
+
+	bot.CachedChatMember(-1, 7)
+	if lookups != 2 {
+		t.Errorf("expected the member to be looked up again after the update, got %d calls", lookups)
+	}
+}
+
+func TestMemoryCacheBackend(t *testing.T) {
+	backend := tgo.NewMemoryCacheBackend(2)
+
+	backend.Set("a", []byte("1"), time.Minute)
+	backend.Set("b", []byte("2"), time.Minute)
+	backend.Get("a")
+	backend.Set("c", []byte("3"), time.Minute)
+
+	if _, ok, _ := backend.Get("b"); ok {
+		t.Error("expected the least recently used value to be evicted")
+	}
+	if _, ok, _ := backend.Get("a"); !ok {
+		t.Error("expected the recently used value to be kept")
+	}
+
+	backend.Set("d", []byte("4"), -time.Second)
+	if _, ok, _ := backend.Get("d"); ok {
+		t.Error("expected the expired value to be missing")
+	}
+}
@@ -0,0 +1,103 @@
+package tgo
+
+// ChatMemberStatus returns the member's status, which is one of "creator", "administrator",
+// "member", "restricted", "left", or "kicked"; it returns an empty string for a nil member.
+func ChatMemberStatus(member ChatMember) string {
+	switch m := member.(type) {
+	case *ChatMemberOwner:
+		return m.Status
+	case *ChatMemberAdministrator:
+		return m.Status
+	case *ChatMemberMember:
+		return m.Status
+	case *ChatMemberRestricted:
+		return m.Status
+	case *ChatMemberLeft:
+		return m.Status
+	case *ChatMemberBanned:
+		return m.Status
+	}
+
+	return ""
+}
+
+// ChatMemberUser returns the user of the member, or nil for a nil member.
+func ChatMemberUser(member ChatMember) *User {
+	switch m := member.(type) {
+	case *ChatMemberOwner:
+		return &m.User
+	case *ChatMemberAdministrator:
+		return &m.User
+	case *ChatMemberMember:
+		return &m.User
+	case *ChatMemberRestricted:
+		return &m.User
+	case *ChatMemberLeft:
+		return &m.User
+	case *ChatMemberBanned:
+		return &m.User
+	}
+
+	return nil
+}
+
+// IsChatMemberPresent returns true if the member is currently in the chat, including the restricted members.
+func IsChatMemberPresent(member ChatMember) bool {
+	switch m := member.(type) {
+	case *ChatMemberOwner, *ChatMemberAdministrator, *ChatMemberMember:
+		return true
+	case *ChatMemberRestricted:
+		return m.IsMember
+	}
+
+	return false
+}
+
+// IsChatMemberAdmin returns true if the member is the chat's owner or one of its administrators.
+func IsChatMemberAdmin(member ChatMember) bool {
+	switch member.(type) {
+	case *ChatMemberOwner, *ChatMemberAdministrator:
+		return true
+	}
+
+	return false
+}
+
+// WasPromoted returns true if the user has just become an administrator of the chat.
+func (x *ChatMemberUpdated) WasPromoted() bool {
+	return !IsChatMemberAdmin(x.OldChatMember) && IsChatMemberAdmin(x.NewChatMember)
+}
+
+// WasDemoted returns true if the user is no longer an administrator of the chat.
+func (x *ChatMemberUpdated) WasDemoted() bool {
+	return IsChatMemberAdmin(x.OldChatMember) && !IsChatMemberAdmin(x.NewChatMember)
+}
+
+// WasBanned returns true if the user has just been banned from the chat.
+func (x *ChatMemberUpdated) WasBanned() bool {
+	_, wasBanned := x.OldChatMember.(*ChatMemberBanned)
+	_, isBanned := x.NewChatMember.(*ChatMemberBanned)
+	return !wasBanned && isBanned
+}
+
+// JustJoined returns true if the user has just joined (or been added to) the chat.
+func (x *ChatMemberUpdated) JustJoined() bool {
+	return !IsChatMemberPresent(x.OldChatMember) && IsChatMemberPresent(x.NewChatMember)
+}
+
+// JustLeft returns true if the user has just left (or been removed from) the chat, including being banned.
+func (x *ChatMemberUpdated) JustLeft() bool {
+	return IsChatMemberPresent(x.OldChatMember) && !IsChatMemberPresent(x.NewChatMember)
+}
+
+// BlockedBot returns true if the update is about the user blocking the bot in their private chat.
+func (x *ChatMemberUpdated) BlockedBot() bool {
+	_, isBanned := x.NewChatMember.(*ChatMemberBanned)
+	return x.Chat.Type == "private" && isBanned
+}
+
+// UnblockedBot returns true if the update is about the user unblocking (or starting) the bot in their private chat.
+func (x *ChatMemberUpdated) UnblockedBot() bool {
+	_, wasBanned := x.OldChatMember.(*ChatMemberBanned)
+	return x.Chat.Type == "private" && wasBanned && IsChatMemberPresent(x.NewChatMember)
+}
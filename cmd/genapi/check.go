@@ -0,0 +1,162 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"sort"
+	"strings"
+)
+
+// Check compares the generated source with the package in dir, and returns the types, methods, and
+// struct fields which are documented but are missing from the package, along with the interfaces
+// which need a hand-written unmarshaler. It's meant for keeping the hand-maintained API current
+// when regenerating it as a whole isn't desired.
+func Check(generated []byte, dir string) (missing []string, err error) {
+	fset := token.NewFileSet()
+
+	gen, err := parser.ParseFile(fset, "api.gen.go", generated, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	pkgs, err := parser.ParseDir(fset, dir, func(info fs.FileInfo) bool { return !strings.HasSuffix(info.Name(), "_test.go") }, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	have := newDecls()
+	for _, pkg := range pkgs {
+		if pkg.Name != gen.Name.Name {
+			continue
+		}
+		for _, file := range pkg.Files {
+			have.add(file)
+		}
+	}
+
+	want := newDecls()
+	want.add(gen)
+
+	for name, fields := range want.types {
+		haveFields, ok := have.types[name]
+		if !ok {
+			missing = append(missing, "type "+name)
+			continue
+		}
+
+		for field := range fields {
+			if !haveFields[field] {
+				missing = append(missing, "field "+name+"."+field)
+			}
+		}
+	}
+
+	for name := range want.methods {
+		if !have.methods[name] {
+			missing = append(missing, "method "+name)
+		}
+	}
+
+	for name := range want.interfaces {
+		if !have.interfaces[name] {
+			missing = append(missing, "interface "+name)
+		} else if !have.funcs["unmarshal"+name] && !handWrittenInterfaces[name] && want.isReceived(name) {
+			missing = append(missing, "unmarshaler "+name)
+		}
+	}
+
+	sort.Strings(missing)
+	return missing, nil
+}
+
+// handWrittenInterfaces are the interfaces which are decoded by their own code rather than an unmarshaler.
+var handWrittenInterfaces = map[string]bool{"ChatID": true, "ReplyMarkup": true}
+
+// decls are the declarations of a package which the API is compared by.
+type decls struct {
+	types      map[string]map[string]bool // struct names to their field names
+	interfaces map[string]bool
+	methods    map[string]bool // the methods of API
+	funcs      map[string]bool
+	fieldTypes map[string][]string // struct names to the types of their fields
+}
+
+func newDecls() *decls {
+	return &decls{
+		types:      make(map[string]map[string]bool),
+		interfaces: make(map[string]bool),
+		methods:    make(map[string]bool),
+		funcs:      make(map[string]bool),
+		fieldTypes: make(map[string][]string),
+	}
+}
+
+func (d *decls) add(file *ast.File) {
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv == nil {
+				d.funcs[decl.Name.Name] = true
+			} else if star, ok := decl.Recv.List[0].Type.(*ast.StarExpr); ok {
+				if ident, ok := star.X.(*ast.Ident); ok && ident.Name == "API" {
+					d.methods[decl.Name.Name] = true
+				}
+			}
+
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+
+				switch typ := typeSpec.Type.(type) {
+				case *ast.InterfaceType:
+					d.interfaces[typeSpec.Name.Name] = true
+				case *ast.StructType:
+					fields := make(map[string]bool)
+					for _, field := range typ.Fields.List {
+						for _, name := range field.Names {
+							fields[name.Name] = true
+						}
+						d.fieldTypes[typeSpec.Name.Name] = append(d.fieldTypes[typeSpec.Name.Name], typeName(field.Type))
+					}
+					d.types[typeSpec.Name.Name] = fields
+				}
+			}
+		}
+	}
+}
+
+// typeName returns the name of the type, without its pointers and slices.
+func typeName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return expr.Name
+	case *ast.StarExpr:
+		return typeName(expr.X)
+	case *ast.ArrayType:
+		return typeName(expr.Elt)
+	}
+	return ""
+}
+
+// isReceived returns true if the type is used by a field of a type other than the methods' payloads,
+// so it may be received from telegram and needs to be unmarshaled.
+func (d *decls) isReceived(name string) bool {
+	for structName, types := range d.fieldTypes {
+		if d.methods[structName] {
+			continue
+		}
+
+		for _, typ := range types {
+			if typ == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
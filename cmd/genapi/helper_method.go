@@ -10,6 +10,8 @@ type MethodTemplate struct {
 	MethodName            string
 	ReturnType            string
 	ReturnsInterface      bool
+	ReturnsInterfaces     bool   // the return type is an array of an interface
+	ReturnElementType     string // the element type of the returned array
 	Description           string
 	EmptyReturnValue      string
 	Fields                []MethodField
@@ -66,6 +68,8 @@ func getMethodTemplate(section Section, sections []Section) MethodTemplate {
 		MethodName:            section.Name,
 		ReturnType:            returnType,
 		ReturnsInterface:      isInterface(returnType, sections),
+		ReturnsInterfaces:     isInterface(strings.TrimPrefix(returnType, "[]"), sections) && strings.HasPrefix(returnType, "[]"),
+		ReturnElementType:     strings.TrimPrefix(returnType, "[]"),
 		Description:           "// " + strings.Join(section.Description, "\n// "),
 		EmptyReturnValue:      defaultValueOfType(returnType),
 		Fields:                fields,
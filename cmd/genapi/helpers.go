@@ -26,6 +26,15 @@ var returnTypePatterns = []*regexp.Regexp{
 	regexp.MustCompile(`(?P<type>[A-Za-z]+) on success`),
 }
 
+// uploadableInterfaces are the interfaces whose implementers may contain files to be uploaded.
+var uploadableInterfaces = map[string]bool{
+	"InputMedia":        true,
+	"InputPaidMedia":    true,
+	"InputStoryContent": true,
+}
+
+func isUploadableInterface(s string) bool { return uploadableInterfaces[s] }
+
 func isMethod(s string) bool {
 	return strings.ToLower(s)[0] == s[0]
 }
@@ -154,7 +163,7 @@ func getNestedMediaFields(all []Section, s Section) (fields []Field) {
 		ft = strings.TrimPrefix(ft, "[]")
 		ft = strings.TrimPrefix(ft, "*")
 
-		if ft == "InputMedia" {
+		if isUploadableInterface(ft) {
 			fields = append(fields, f)
 			continue
 		}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"text/template"
+	"time"
+)
+
+const TelegramDocURL = "https://core.telegram.org/bots/api"
+
+//go:embed template.gotmpl
+var templateFS embed.FS
+
+type TemplateData struct {
+	Sections     []Section
+	Implementers map[string]string
+}
+
+var Template = template.Must(template.New("tgo").
+	Funcs(template.FuncMap{
+		"getInterfaceTemplate":  getInterfaceTemplate,
+		"getTypeTemplate":       getTypeTemplate,
+		"getMethodTemplate":     getMethodTemplate,
+		"isMethod":              isMethod,
+		"isArray":               isArray,
+		"isUploadableInterface": isUploadableInterface,
+	}).
+	ParseFS(templateFS, "template.gotmpl"),
+)
+
+var (
+	docSource = flag.String("doc", TelegramDocURL, "url or local copy of the bot API documentation")
+	specFile  = flag.String("spec", "", "url or local copy of a JSON spec of the bot API to use instead of the documentation")
+	output    = flag.String("out", "api.gen.go", "file to write the generated API into")
+	check     = flag.Bool("check", false, "report the API surface missing from the package instead of writing it")
+)
+
+func main() {
+	flag.Parse()
+
+	var parsedDoc TemplateData
+	if *specFile != "" {
+		spec, err := FetchSpec(*specFile)
+		if err != nil {
+			log.Fatalln("Failed to fetch the spec >", err)
+			return
+		}
+		parsedDoc = spec.TemplateData()
+	} else {
+		doc, err := Fetch(*docSource)
+		if err != nil {
+			log.Fatalln("Failed to fetch the documentation >", err)
+			return
+		}
+		parsedDoc = Parse(doc)
+	}
+
+	startTime := time.Now()
+
+	buf := bytes.NewBuffer(nil)
+
+	err := Template.ExecuteTemplate(buf, "template.gotmpl", parsedDoc)
+	if err != nil {
+		log.Fatalln("Failed to generate >", err)
+		return
+	}
+
+	generated := buf.Bytes()
+	if formatted, err := format.Source(generated); err == nil {
+		generated = formatted
+	}
+
+	if *check {
+		missing, err := Check(generated, filepath.Dir(*output))
+		if err != nil {
+			log.Fatalln("Failed to check >", err)
+			return
+		}
+
+		for _, line := range missing {
+			fmt.Println(line)
+		}
+		if len(missing) != 0 {
+			os.Exit(1)
+		}
+		return
+	}
+
+	os.WriteFile(*output, generated, os.ModePerm)
+
+	log.Println("generated in", time.Since(startTime))
+}
@@ -2,7 +2,9 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"net/http"
+	"os"
 	"strings"
 
 	"github.com/PuerkitoBio/goquery"
@@ -23,14 +25,26 @@ type Field struct {
 	IsOptional  bool
 }
 
-func Fetch() (*goquery.Document, error) {
-	resp, err := http.Get(TelegramDocURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch telegram doc > %s", err.Error())
+// Fetch fetches and parses the documentation from the url, or reads it from a local file
+// if the source is not a http(s) url, such as a saved copy of the page.
+func Fetch(source string) (*goquery.Document, error) {
+	var body io.ReadCloser
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		resp, err := http.Get(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch telegram doc > %s", err.Error())
+		}
+		body = resp.Body
+	} else {
+		file, err := os.Open(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read telegram doc > %s", err.Error())
+		}
+		body = file
 	}
-	defer resp.Body.Close()
+	defer body.Close()
 
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	doc, err := goquery.NewDocumentFromReader(body)
 	if err != nil {
 		return nil, err
 	}
@@ -94,13 +108,27 @@ func Parse(doc *goquery.Document) (data TemplateData) {
 			}
 		})
 
-		// Normalize the description
-		section.Description[0] = strings.Replace(section.Description[0], "This object", section.Name, 1)
-		section.Description[0] = strings.Replace(section.Description[0], "Use this method to", section.Name+" is used to", 1)
+		normalizeDescription(&section)
 
 		data.Sections = append(data.Sections, section)
 	})
 
+	extend(&data)
+	return data
+}
+
+// normalizeDescription names the section in the first line of its description.
+func normalizeDescription(section *Section) {
+	if len(section.Description) == 0 {
+		return
+	}
+
+	section.Description[0] = strings.Replace(section.Description[0], "This object", section.Name, 1)
+	section.Description[0] = strings.Replace(section.Description[0], "Use this method to", section.Name+" is used to", 1)
+}
+
+// extend adds the sections and implementers which are not in the documentation, but are used by tgo.
+func extend(data *TemplateData) {
 	// extended sections
 	data.Sections = append(data.Sections, Section{
 		Name:        "ReplyMarkup",
@@ -119,6 +147,4 @@ func Parse(doc *goquery.Document) (data TemplateData) {
 	data.Implementers["ReplyKeyboardMarkup"] = "ReplyMarkup"
 	data.Implementers["ReplyKeyboardRemove"] = "ReplyMarkup"
 	data.Implementers["ForceReply"] = "ReplyMarkup"
-
-	return data
 }
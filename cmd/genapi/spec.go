@@ -0,0 +1,207 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Spec is a machine-readable spec of the bot API, in the format of telegram-bot-api-spec's api.json.
+type Spec struct {
+	Version string
+	Types   []SpecSection
+	Methods []SpecSection
+}
+
+// SpecSection is a type or method of the Spec.
+type SpecSection struct {
+	Name        string      `json:"name"`
+	Description []string    `json:"description"`
+	Fields      []SpecField `json:"fields"`
+	Subtypes    []string    `json:"subtypes"`
+	SubtypeOf   []string    `json:"subtype_of"`
+}
+
+// SpecField is a field of a type, or a parameter of a method.
+type SpecField struct {
+	Name        string   `json:"name"`
+	Types       []string `json:"types"`
+	Required    bool     `json:"required"`
+	Description string   `json:"description"`
+}
+
+// FetchSpec fetches and decodes the spec from the url, or reads it from a local file if the
+// source is not a http(s) url. The types and methods are kept in the spec's order.
+func FetchSpec(source string) (*Spec, error) {
+	var body io.ReadCloser
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		resp, err := http.Get(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch the spec > %s", err.Error())
+		}
+		body = resp.Body
+	} else {
+		file, err := os.Open(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the spec > %s", err.Error())
+		}
+		body = file
+	}
+	defer body.Close()
+
+	var raw struct {
+		Version string          `json:"version"`
+		Types   json.RawMessage `json:"types"`
+		Methods json.RawMessage `json:"methods"`
+	}
+	if err := json.NewDecoder(body).Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	spec := &Spec{Version: raw.Version}
+
+	var err error
+	if spec.Types, err = decodeOrdered(raw.Types); err != nil {
+		return nil, fmt.Errorf("invalid types > %s", err.Error())
+	} else if spec.Methods, err = decodeOrdered(raw.Methods); err != nil {
+		return nil, fmt.Errorf("invalid methods > %s", err.Error())
+	}
+
+	return spec, nil
+}
+
+// decodeOrdered decodes the values of the JSON object in the order of its keys, which a map would lose.
+func decodeOrdered(data json.RawMessage) (sections []SpecSection, err error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if _, err = decoder.Token(); err != nil { // the opening brace
+		return nil, err
+	}
+
+	for decoder.More() {
+		if _, err = decoder.Token(); err != nil { // the key, which is the section's name as well
+			return nil, err
+		}
+
+		var section SpecSection
+		if err = decoder.Decode(&section); err != nil {
+			return nil, err
+		}
+		sections = append(sections, section)
+	}
+
+	return sections, nil
+}
+
+// TemplateData converts the spec to the same data which Parse extracts from the documentation.
+func (s *Spec) TemplateData() (data TemplateData) {
+	data.Implementers = make(map[string]string)
+
+	parents := make(map[string][]string)
+	for _, typ := range s.Types {
+		parents[typ.Name] = typ.SubtypeOf
+	}
+
+	for _, typ := range s.Types {
+		// We implement this ourselves.
+		if typ.Name == "InputFile" {
+			continue
+		}
+
+		section := Section{Name: typ.Name, Description: typ.Description}
+		if len(typ.Subtypes) != 0 {
+			section.InterfaceOf = typ.Subtypes
+			section.IsInterface = true
+			section.Description = append(section.Description, strings.Join(typ.Subtypes, ", "))
+
+			for _, subtype := range typ.Subtypes {
+				data.Implementers[subtype] = typ.Name
+			}
+		}
+
+		for _, f := range typ.Fields {
+			field := Field{Name: f.Name, Type: specType(f.Types, parents), Description: f.Description, IsOptional: !f.Required}
+
+			// the types' optional fields are described as such in the documentation.
+			if field.IsOptional && !strings.HasPrefix(field.Description, "Optional.") {
+				field.Description = "Optional. " + field.Description
+			}
+			section.Fields = append(section.Fields, field)
+		}
+
+		normalizeDescription(&section)
+		data.Sections = append(data.Sections, section)
+	}
+
+	for _, method := range s.Methods {
+		section := Section{Name: method.Name, Description: method.Description}
+		for _, f := range method.Fields {
+			section.Fields = append(section.Fields, Field{Name: f.Name, Type: specType(f.Types, parents), Description: f.Description, IsOptional: !f.Required})
+		}
+
+		normalizeDescription(&section)
+		data.Sections = append(data.Sections, section)
+	}
+
+	extend(&data)
+	return data
+}
+
+// specType returns the type of the field the way the documentation writes it, which getType understands.
+// The alternative types which implement the same interface are reduced to the interface itself.
+func specType(types []string, parents map[string][]string) string {
+	if len(types) == 1 {
+		return types[0]
+	}
+
+	var prefix string
+	elems := make([]string, len(types))
+	for i, typ := range types {
+		elem := strings.TrimPrefix(typ, "Array of ")
+		if i == 0 && elem != typ {
+			prefix = "Array of "
+		}
+		elems[i] = elem
+	}
+
+	if parent := commonParent(elems, parents); parent != "" {
+		return prefix + parent
+	}
+
+	return strings.Join(types, " or ")
+}
+
+// commonParent returns the interface which all the types implement, or an empty string if there's none.
+func commonParent(types []string, parents map[string][]string) string {
+	for _, candidate := range parents[types[0]] {
+		common := true
+		for _, typ := range types[1:] {
+			if !contains(parents[typ], candidate) {
+				common = false
+				break
+			}
+		}
+
+		if common {
+			return candidate
+		}
+	}
+
+	return ""
+}
+
+func contains(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
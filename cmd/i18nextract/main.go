@@ -0,0 +1,89 @@
+// Command i18nextract finds the translation keys used in a bot's source code and adds
+// the missing ones to its translation bundle files, to be translated.
+//
+//	go run github.com/haashemi/tgo/cmd/i18nextract -dir ./locales -langs en,fa ./
+//
+// With -check, it doesn't write anything and exits with status 1 if any key is missing.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/haashemi/tgo/i18n"
+)
+
+func main() {
+	dir := flag.String("dir", "locales", "directory of the bundle files")
+	langs := flag.String("langs", "", "comma-separated languages to update; defaults to the existing bundle files")
+	check := flag.Bool("check", false, "only report the missing keys, and exit with status 1 if there's any")
+	flag.Parse()
+
+	root := flag.Arg(0)
+	if root == "" {
+		root = "."
+	}
+
+	keys, err := i18n.ExtractKeys(root)
+	if err != nil {
+		log.Fatalln("Failed to extract the keys >", err)
+	}
+
+	languages := languagesOf(*dir, *langs)
+	if len(languages) == 0 {
+		log.Fatalln("No languages to update; pass them with -langs")
+	}
+
+	var missing map[string][]string
+	if *check {
+		missing = findMissing(*dir, languages, keys)
+	} else if err = os.MkdirAll(*dir, 0o755); err != nil {
+		log.Fatalln("Failed to create the bundle directory >", err)
+	} else if missing, err = i18n.UpdateFiles(*dir, languages, keys); err != nil {
+		log.Fatalln("Failed to update the bundle files >", err)
+	}
+
+	for _, language := range languages {
+		if len(missing[language]) != 0 {
+			fmt.Printf("%s: %d missing keys: %s\n", language, len(missing[language]), strings.Join(missing[language], ", "))
+		}
+	}
+
+	if *check && len(missing) != 0 {
+		os.Exit(1)
+	}
+}
+
+// languagesOf returns the passed languages, or the languages of the existing bundle files.
+func languagesOf(dir, langs string) (languages []string) {
+	if langs != "" {
+		return strings.Split(langs, ",")
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	for _, file := range files {
+		languages = append(languages, strings.TrimSuffix(filepath.Base(file), ".json"))
+	}
+
+	return languages
+}
+
+// findMissing returns the keys which are not in the bundle files of each language.
+func findMissing(dir string, languages, keys []string) map[string][]string {
+	missing := make(map[string][]string)
+
+	for _, language := range languages {
+		messages, _ := i18n.ReadFile(filepath.Join(dir, language+".json"))
+		for _, key := range keys {
+			if _, ok := messages[key]; !ok {
+				missing[language] = append(missing[language], key)
+			}
+		}
+	}
+
+	return missing
+}
@@ -0,0 +1,292 @@
+package tgo
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+)
+
+// MaxCommands is the maximum number of the commands of a scope and language.
+const MaxCommands = 100
+
+var commandNameRegex = regexp.MustCompile(`^[a-z0-9_]{1,32}$`)
+
+// ScopeDefault returns the default scope of the commands, used where no narrower scope has any.
+func ScopeDefault() BotCommandScope { return &BotCommandScopeDefault{Type: "default"} }
+
+// ScopeAllPrivateChats returns the scope of the commands covering all the private chats.
+func ScopeAllPrivateChats() BotCommandScope {
+	return &BotCommandScopeAllPrivateChats{Type: "all_private_chats"}
+}
+
+// ScopeAllGroupChats returns the scope of the commands covering all the group and supergroup chats.
+func ScopeAllGroupChats() BotCommandScope {
+	return &BotCommandScopeAllGroupChats{Type: "all_group_chats"}
+}
+
+// ScopeAllChatAdministrators returns the scope of the commands covering the administrators of all the
+// group and supergroup chats.
+func ScopeAllChatAdministrators() BotCommandScope {
+	return &BotCommandScopeAllChatAdministrators{Type: "all_chat_administrators"}
+}
+
+// ScopeChat returns the scope of the commands covering the chat.
+func ScopeChat(chatID ChatID) BotCommandScope {
+	return &BotCommandScopeChat{Type: "chat", ChatId: chatID}
+}
+
+// ScopeChatAdministrators returns the scope of the commands covering the administrators of the chat.
+func ScopeChatAdministrators(chatID ChatID) BotCommandScope {
+	return &BotCommandScopeChatAdministrators{Type: "chat_administrators", ChatId: chatID}
+}
+
+// ScopeChatMember returns the scope of the commands covering the member of the chat.
+func ScopeChatMember(chatID ChatID, userID int64) BotCommandScope {
+	return &BotCommandScopeChatMember{Type: "chat_member", ChatId: chatID, UserId: userID}
+}
+
+// Command is a command declared in a Commands registry.
+type Command struct {
+	Name        string            // the command without the slash, such as "help"
+	Description string            // the description shown in the menu
+	Scopes      []BotCommandScope // the scopes the command is shown in; ScopeDefault if it's empty
+	Category    string            // the category which the command is listed under by Commands.Help; optional
+
+	// Descriptions are the translated descriptions, by their two-letter language codes.
+	Descriptions map[string]string
+}
+
+// Translate sets the description of the command for the language, and returns the command.
+func (cmd *Command) Translate(languageCode, description string) *Command {
+	if cmd.Descriptions == nil {
+		cmd.Descriptions = make(map[string]string)
+	}
+	cmd.Descriptions[languageCode] = description
+	return cmd
+}
+
+// InCategory sets the category of the command, and returns the command.
+func (cmd *Command) InCategory(category string) *Command {
+	cmd.Category = category
+	return cmd
+}
+
+// description returns the command's description in the language, or its default one.
+func (cmd *Command) description(languageCode string) string {
+	if description, ok := cmd.Descriptions[languageCode]; ok {
+		return description
+	}
+	return cmd.Description
+}
+
+// Commands is a registry of the bot's commands, declared in the code and synced to the command menus
+// of their scopes and languages by Sync:
+//
+//	commands := tgo.NewCommands()
+//	commands.Add("start", "Start the bot")
+//	commands.Add("help", "Show the help").Translate("fa", "نمایش راهنما")
+//	commands.Add("ban", "Ban the replied member", tgo.ScopeAllChatAdministrators())
+//
+//	if err := commands.Sync(bot.API); err != nil {
+//		log.Fatal(err)
+//	}
+type Commands struct {
+	mut      sync.Mutex
+	commands []*Command
+}
+
+// NewCommands returns an empty Commands registry.
+func NewCommands() *Commands { return &Commands{} }
+
+// Add declares the command in the scopes, or in ScopeDefault if there's none, and returns it.
+// Adding a command of the same name again replaces it.
+func (c *Commands) Add(name, description string, scopes ...BotCommandScope) *Command {
+	cmd := &Command{Name: name, Description: description, Scopes: scopes}
+	c.AddCommand(cmd)
+	return cmd
+}
+
+// AddCommand declares the command, replacing the one of the same name.
+func (c *Commands) AddCommand(cmd *Command) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	for i, existing := range c.commands {
+		if existing.Name == cmd.Name {
+			c.commands[i] = cmd
+			return
+		}
+	}
+	c.commands = append(c.commands, cmd)
+}
+
+// List returns the declared commands, in the order they're added.
+func (c *Commands) List() []*Command {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	return append([]*Command(nil), c.commands...)
+}
+
+// commandMenu is the menu of the commands of a scope and language.
+type commandMenu struct {
+	scope        BotCommandScope
+	languageCode string
+	commands     []*BotCommand
+}
+
+// menus returns the command menus of the declared commands, keyed by the JSON-encoded scope
+// and the language code. The menu of each declared language includes the commands which aren't
+// translated to it, by their default descriptions.
+func (c *Commands) menus() (map[string]*commandMenu, error) {
+	commands := c.List()
+
+	languages := map[string]bool{"": true}
+	for _, cmd := range commands {
+		if !commandNameRegex.MatchString(cmd.Name) {
+			return nil, fmt.Errorf("tgo: invalid command name %q; it must be 1-32 lowercase letters, digits, and underscores", cmd.Name)
+		}
+		for languageCode, description := range cmd.Descriptions {
+			languages[languageCode] = true
+			if description == "" || len([]rune(description)) > 256 {
+				return nil, fmt.Errorf("tgo: the %s description of /%s must be 1-256 characters", languageCode, cmd.Name)
+			}
+		}
+		if cmd.Description == "" || len([]rune(cmd.Description)) > 256 {
+			return nil, fmt.Errorf("tgo: the description of /%s must be 1-256 characters", cmd.Name)
+		}
+	}
+
+	// the global scopes are always synced, so the removed commands are removed from them too.
+	menus := map[string]*commandMenu{}
+	for _, scope := range []BotCommandScope{ScopeDefault(), ScopeAllPrivateChats(), ScopeAllGroupChats(), ScopeAllChatAdministrators()} {
+		for languageCode := range languages {
+			if _, err := menuFor(menus, scope, languageCode); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, cmd := range commands {
+		scopes := cmd.Scopes
+		if len(scopes) == 0 {
+			scopes = []BotCommandScope{ScopeDefault()}
+		}
+
+		for _, scope := range scopes {
+			for languageCode := range languages {
+				menu, err := menuFor(menus, scope, languageCode)
+				if err != nil {
+					return nil, err
+				}
+				menu.commands = append(menu.commands, &BotCommand{Command: cmd.Name, Description: cmd.description(languageCode)})
+			}
+		}
+	}
+
+	for _, menu := range menus {
+		if len(menu.commands) > MaxCommands {
+			return nil, fmt.Errorf("tgo: %d commands are declared in a scope, more than %d", len(menu.commands), MaxCommands)
+		}
+	}
+	return menus, nil
+}
+
+// menuFor returns the menu of the scope and language, adding it if it's not in the menus.
+func menuFor(menus map[string]*commandMenu, scope BotCommandScope, languageCode string) (*commandMenu, error) {
+	encoded, err := json.Marshal(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	key := string(encoded) + "/" + languageCode
+	if menu, ok := menus[key]; ok {
+		return menu, nil
+	}
+
+	menu := &commandMenu{scope: scope, languageCode: languageCode}
+	menus[key] = menu
+	return menu, nil
+}
+
+// Sync makes the command menus match the declared commands. The menus of each of their scopes and
+// languages, and of the global scopes, are compared to the ones telegram has by getMyCommands; then
+// the changed ones are set by setMyCommands, and the emptied ones are deleted by deleteMyCommands.
+// The menus which are already up to date aren't touched.
+//
+// It returns the number of the changed menus. The chat scopes which no command is declared in
+// anymore aren't known to the registry, so they're left as is; delete them by deleteMyCommands.
+func (c *Commands) Sync(api *API) (changed int, err error) {
+	menus, err := c.menus()
+	if err != nil {
+		return 0, err
+	}
+
+	// the menus are synced in a stable order, so the failures are reproducible.
+	keys := make([]string, 0, len(menus))
+	for key := range menus {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		menu := menus[key]
+
+		current, err := api.GetMyCommands(&GetMyCommands{Scope: menu.scope, LanguageCode: menu.languageCode})
+		if err != nil {
+			return changed, err
+		} else if sameCommands(current, menu.commands) {
+			continue
+		}
+
+		if len(menu.commands) == 0 {
+			_, err = api.DeleteMyCommands(&DeleteMyCommands{Scope: menu.scope, LanguageCode: menu.languageCode})
+		} else {
+			_, err = api.SetMyCommands(&SetMyCommands{Commands: menu.commands, Scope: menu.scope, LanguageCode: menu.languageCode})
+		}
+		if err != nil {
+			return changed, err
+		}
+
+		changed++
+		api.log(LevelInfo, "command menu synced", "scope", key, "commands", len(menu.commands))
+	}
+
+	return changed, nil
+}
+
+func sameCommands(a, b []*BotCommand) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if *a[i] != *b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Commands returns the bot's Commands registry, set by Options.Commands; it's created on the first call
+// if the options have none.
+func (bot *Bot) Commands() *Commands {
+	bot.commandsOnce.Do(func() {
+		if bot.commands == nil {
+			bot.commands = NewCommands()
+		}
+	})
+	return bot.commands
+}
+
+// SyncCommands syncs the command menus to the commands of the bot's registry; see Commands.Sync.
+func (bot *Bot) SyncCommands() (changed int, err error) { return bot.Commands().Sync(bot.API) }
+
+// PublishCommands publishes the command menus of the commands of the bot's registry, including the
+// ones declared by the routers' command routes, such as message.Router.Command; see Commands.Sync.
+// Call it after the routers are added to the bot.
+func (bot *Bot) PublishCommands() error {
+	_, err := bot.SyncCommands()
+	return err
+}
@@ -0,0 +1,61 @@
+package tgo_test
+
+import (
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestSyncCommands(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	server.Handle("getMyCommands", func(call tgotest.Call) (any, *tgo.Error) {
+		scope, _ := call.Params["scope"].(map[string]any)
+		if scope["type"] == "default" && call.Params["language_code"] == nil {
+			return []*tgo.BotCommand{{Command: "start", Description: "Start the bot"}, {Command: "help", Description: "Show the help"}}, nil
+		}
+		return []any{}, nil
+	})
+
+	commands := tgo.NewCommands()
+	commands.Add("start", "Start the bot")
+	commands.Add("help", "Show the help").Translate("fa", "راهنما")
+	commands.Add("ban", "Ban the replied member", tgo.ScopeAllChatAdministrators())
+
+	bot := server.Bot(tgo.Options{Commands: commands})
+
+	changed, err := bot.SyncCommands()
+	if err != nil {
+		t.Fatal(err)
+	} else if changed != 3 {
+		t.Fatalf("changed %d menus, want 3", changed)
+	}
+
+	var sets []tgotest.Call
+	for _, call := range server.Calls() {
+		if call.Method == "setMyCommands" {
+			sets = append(sets, call)
+		} else if call.Method != "getMyCommands" {
+			t.Fatalf("unexpected %s call", call.Method)
+		}
+	}
+
+	for _, call := range sets {
+		scope := call.Params["scope"].(map[string]any)["type"]
+		list := call.Params["commands"].([]any)
+		if scope == "default" {
+			if call.Params["language_code"] != "fa" || len(list) != 2 || list[1].(map[string]any)["description"] != "راهنما" {
+				t.Errorf("unexpected default menu %v", call.Params)
+			}
+		} else if scope != "all_chat_administrators" || len(list) != 1 {
+			t.Errorf("unexpected %v menu %v", scope, call.Params)
+		}
+	}
+
+	commands.Add("Bad Name", "Invalid")
+	if _, err = bot.SyncCommands(); err == nil {
+		t.Fatal("the invalid command name is synced")
+	}
+}
@@ -0,0 +1,46 @@
+package redisstore
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// CacheBackend is a tgo.CacheBackend keeping each value in its own expiring key, so a tgo.ChatCache
+// is shared by the bot's instances, and its invalidations are seen by all of them.
+type CacheBackend struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewCacheBackend returns a CacheBackend keeping the values under the keys starting with prefix, such as "mybot:".
+func NewCacheBackend(client redis.UniversalClient, prefix string) *CacheBackend {
+	return &CacheBackend{client: client, prefix: prefix}
+}
+
+// Get implements the tgo.CacheBackend interface.
+func (b *CacheBackend) Get(key string) ([]byte, bool, error) {
+	value, err := b.client.Get(context.Background(), b.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// Set implements the tgo.CacheBackend interface.
+func (b *CacheBackend) Set(key string, value []byte, ttl time.Duration) error {
+	return b.client.Set(context.Background(), b.prefix+key, value, ttl).Err()
+}
+
+// Delete implements the tgo.CacheBackend interface.
+func (b *CacheBackend) Delete(keys ...string) error {
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = b.prefix + key
+	}
+	return b.client.Del(context.Background(), prefixed...).Err()
+}
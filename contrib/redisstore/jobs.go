@@ -0,0 +1,92 @@
+// Package redisstore contains the Redis-backed stores of tgo.
+package redisstore
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/haashemi/tgo"
+	"github.com/redis/go-redis/v9"
+)
+
+// JobStore is a tgo.JobStore keeping the jobs in Redis: their data in a hash, and their
+// ids in a sorted set by their time, so the due ones are found without scanning all.
+type JobStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewJobStore returns a JobStore keeping the jobs under the keys starting with prefix,
+// such as "mybot:"; use different prefixes for the bots sharing a Redis.
+func NewJobStore(client redis.UniversalClient, prefix string) *JobStore {
+	return &JobStore{client: client, prefix: prefix}
+}
+
+func (s *JobStore) dataKey() string  { return s.prefix + "jobs:data" }
+func (s *JobStore) queueKey() string { return s.prefix + "jobs:queue" }
+
+// Save implements the tgo.JobStore interface.
+func (s *JobStore) Save(job *tgo.Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, s.dataKey(), job.ID, data)
+		pipe.ZAdd(ctx, s.queueKey(), redis.Z{Score: float64(job.At.UnixMilli()), Member: job.ID})
+		return nil
+	})
+	return err
+}
+
+// Due implements the tgo.JobStore interface.
+func (s *JobStore) Due(now time.Time) ([]*tgo.Job, error) {
+	ctx := context.Background()
+
+	ids, err := s.client.ZRangeByScore(ctx, s.queueKey(), &redis.ZRangeBy{Min: "-inf", Max: strconv.FormatInt(now.UnixMilli(), 10)}).Result()
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	values, err := s.client.HMGet(ctx, s.dataKey(), ids...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]*tgo.Job, 0, len(values))
+	for _, value := range values {
+		// the job is deleted between the two calls.
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		job := &tgo.Job{}
+		if err = json.Unmarshal([]byte(data), job); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+// Delete implements the tgo.JobStore interface.
+func (s *JobStore) Delete(id string) error {
+	ctx := context.Background()
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HDel(ctx, s.dataKey(), id)
+		pipe.ZRem(ctx, s.queueKey(), id)
+		return nil
+	})
+	return err
+}
+
+// Ping implements the tgo.Pinger interface, so bot.Preflight checks the connectivity to Redis.
+func (s *JobStore) Ping(ctx context.Context) error {
+	return s.client.Ping(ctx).Err()
+}
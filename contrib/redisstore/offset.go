@@ -0,0 +1,38 @@
+package redisstore
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// OffsetStore is a tgo.OffsetStore keeping the offset of the polled updates in Redis.
+type OffsetStore struct {
+	client redis.UniversalClient
+	key    string
+}
+
+// NewOffsetStore returns an OffsetStore keeping the offset under the key starting with prefix,
+// such as "mybot:"; use different prefixes for the bots sharing a Redis.
+func NewOffsetStore(client redis.UniversalClient, prefix string) *OffsetStore {
+	return &OffsetStore{client: client, key: prefix + "offset"}
+}
+
+// LoadOffset implements the tgo.OffsetStore interface.
+func (s *OffsetStore) LoadOffset() (int64, error) {
+	offset, err := s.client.Get(context.Background(), s.key).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	return offset, err
+}
+
+// SaveOffset implements the tgo.OffsetStore interface.
+func (s *OffsetStore) SaveOffset(offset int64) error {
+	return s.client.Set(context.Background(), s.key, offset, 0).Err()
+}
+
+// Ping implements the tgo.Pinger interface, so bot.Preflight checks the connectivity to Redis.
+func (s *OffsetStore) Ping(ctx context.Context) error {
+	return s.client.Ping(ctx).Err()
+}
@@ -0,0 +1,113 @@
+package redisstore
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/haashemi/tgo"
+	"github.com/redis/go-redis/v9"
+)
+
+// OutboxStore is a tgo.OutboxStore keeping the messages in Redis like the JobStore, and each
+// deduplication key in its own expiring key.
+type OutboxStore struct {
+	client redis.UniversalClient
+	prefix string
+	window time.Duration
+}
+
+// NewOutboxStore returns an OutboxStore keeping the messages under the keys starting with prefix,
+// such as "mybot:", and remembering their deduplication keys for the window; it defaults to 24 hours.
+func NewOutboxStore(client redis.UniversalClient, prefix string, window time.Duration) *OutboxStore {
+	if window <= 0 {
+		window = 24 * time.Hour
+	}
+	return &OutboxStore{client: client, prefix: prefix, window: window}
+}
+
+func (s *OutboxStore) dataKey() string             { return s.prefix + "outbox:data" }
+func (s *OutboxStore) queueKey() string            { return s.prefix + "outbox:queue" }
+func (s *OutboxStore) dedupeKey(key string) string { return s.prefix + "outbox:key:" + key }
+
+// Add implements the tgo.OutboxStore interface.
+func (s *OutboxStore) Add(msg *tgo.OutboxMessage) (bool, error) {
+	ctx := context.Background()
+
+	added, err := s.client.SetNX(ctx, s.dedupeKey(msg.Key), 1, s.window).Result()
+	if err != nil || !added {
+		return false, err
+	}
+
+	if err = s.Save(msg); err != nil {
+		// it's not added, so it may be added again.
+		s.client.Del(ctx, s.dedupeKey(msg.Key))
+		return false, err
+	}
+	return true, nil
+}
+
+// Due implements the tgo.OutboxStore interface.
+func (s *OutboxStore) Due(now time.Time) ([]*tgo.OutboxMessage, error) {
+	ctx := context.Background()
+
+	keys, err := s.client.ZRangeByScore(ctx, s.queueKey(), &redis.ZRangeBy{Min: "-inf", Max: strconv.FormatInt(now.UnixMilli(), 10)}).Result()
+	if err != nil || len(keys) == 0 {
+		return nil, err
+	}
+
+	values, err := s.client.HMGet(ctx, s.dataKey(), keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]*tgo.OutboxMessage, 0, len(values))
+	for _, value := range values {
+		// the message is deleted between the two calls.
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		msg := &tgo.OutboxMessage{}
+		if err = json.Unmarshal([]byte(data), msg); err != nil {
+			return nil, err
+		}
+		messages = append(messages, msg)
+	}
+
+	return messages, nil
+}
+
+// Save implements the tgo.OutboxStore interface.
+func (s *OutboxStore) Save(msg *tgo.OutboxMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, s.dataKey(), msg.Key, data)
+		pipe.ZAdd(ctx, s.queueKey(), redis.Z{Score: float64(msg.At.UnixMilli()), Member: msg.Key})
+		return nil
+	})
+	return err
+}
+
+// Delete implements the tgo.OutboxStore interface.
+func (s *OutboxStore) Delete(key string) error {
+	ctx := context.Background()
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HDel(ctx, s.dataKey(), key)
+		pipe.ZRem(ctx, s.queueKey(), key)
+		return nil
+	})
+	return err
+}
+
+// Ping implements the tgo.Pinger interface, so bot.Preflight checks the connectivity to Redis.
+func (s *OutboxStore) Ping(ctx context.Context) error {
+	return s.client.Ping(ctx).Err()
+}
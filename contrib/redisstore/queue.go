@@ -0,0 +1,184 @@
+package redisstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/haashemi/tgo"
+	"github.com/redis/go-redis/v9"
+)
+
+// QueueOptions configures a Queue. The zero value is valid and uses the defaults.
+type QueueOptions struct {
+	// Group is the consumer group of the workers; it defaults to "tgo".
+	Group string
+
+	// Consumer is the name of this worker in the group; it defaults to "<hostname>-<pid>".
+	// It must be unique among the workers.
+	Consumer string
+
+	// MaxLen is the approximate number of the updates the stream keeps; it defaults to 100000.
+	MaxLen int64
+
+	// ClaimIdle is how long an update may stay unacknowledged, such as by a crashed worker,
+	// before it's delivered to another worker; it defaults to a minute.
+	ClaimIdle time.Duration
+
+	// Block is how long each read waits for the updates before checking the context; it defaults to two seconds.
+	Block time.Duration
+}
+
+// Queue is a tgo.Queue on a Redis Stream, consumed by a consumer group so each update is handled
+// by one of the workers, and the ones of the crashed workers are claimed by the others.
+type Queue struct {
+	client redis.UniversalClient
+	stream string
+	opts   QueueOptions
+
+	mut       sync.Mutex
+	hasGroup  bool
+	lastClaim time.Time
+}
+
+// NewQueue returns a Queue on the stream under the key starting with prefix, such as "mybot:";
+// use different prefixes for the bots sharing a Redis.
+func NewQueue(client redis.UniversalClient, prefix string, opts QueueOptions) *Queue {
+	if opts.Group == "" {
+		opts.Group = "tgo"
+	}
+	if opts.Consumer == "" {
+		hostname, _ := os.Hostname()
+		opts.Consumer = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	}
+	if opts.MaxLen <= 0 {
+		opts.MaxLen = 100000
+	}
+	if opts.ClaimIdle <= 0 {
+		opts.ClaimIdle = time.Minute
+	}
+	if opts.Block <= 0 {
+		opts.Block = 2 * time.Second
+	}
+
+	return &Queue{client: client, stream: prefix + "updates", opts: opts}
+}
+
+// Push implements the tgo.Queue interface.
+func (q *Queue) Push(ctx context.Context, update []byte) error {
+	return q.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: q.stream,
+		MaxLen: q.opts.MaxLen,
+		Approx: true,
+		Values: map[string]any{"update": update},
+	}).Err()
+}
+
+// Pop implements the tgo.Queue interface. It prefers the updates which are left unacknowledged
+// by the other workers for ClaimIdle over the new ones.
+func (q *Queue) Pop(ctx context.Context) (tgo.QueuedUpdate, error) {
+	if err := q.createGroup(ctx); err != nil {
+		return tgo.QueuedUpdate{}, err
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return tgo.QueuedUpdate{}, err
+		}
+
+		if message, ok, err := q.claim(ctx); err != nil {
+			return tgo.QueuedUpdate{}, err
+		} else if ok {
+			return q.queued(message), nil
+		}
+
+		streams, err := q.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    q.opts.Group,
+			Consumer: q.opts.Consumer,
+			Streams:  []string{q.stream, ">"},
+			Count:    1,
+			Block:    q.opts.Block,
+		}).Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		} else if err != nil {
+			return tgo.QueuedUpdate{}, err
+		}
+
+		for _, stream := range streams {
+			for _, message := range stream.Messages {
+				return q.queued(message), nil
+			}
+		}
+	}
+}
+
+// Ping implements the tgo.Pinger interface, so bot.Preflight checks the connectivity to Redis.
+func (q *Queue) Ping(ctx context.Context) error {
+	return q.client.Ping(ctx).Err()
+}
+
+// createGroup creates the consumer group and the stream once, if they don't exist.
+func (q *Queue) createGroup(ctx context.Context) error {
+	q.mut.Lock()
+	defer q.mut.Unlock()
+
+	if q.hasGroup {
+		return nil
+	}
+
+	// it starts from the beginning of the stream, so the updates pushed before any worker is up are handled too.
+	err := q.client.XGroupCreateMkStream(ctx, q.stream, q.opts.Group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return err
+	}
+
+	q.hasGroup = true
+	return nil
+}
+
+// claim takes over an update which is left unacknowledged for ClaimIdle. Once there's none,
+// it doesn't look again for half of ClaimIdle.
+func (q *Queue) claim(ctx context.Context) (redis.XMessage, bool, error) {
+	q.mut.Lock()
+	due := time.Since(q.lastClaim) >= q.opts.ClaimIdle/2
+	q.mut.Unlock()
+
+	if !due {
+		return redis.XMessage{}, false, nil
+	}
+
+	messages, _, err := q.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:   q.stream,
+		Group:    q.opts.Group,
+		Consumer: q.opts.Consumer,
+		MinIdle:  q.opts.ClaimIdle,
+		Start:    "0-0",
+		Count:    1,
+	}).Result()
+	if err != nil {
+		return redis.XMessage{}, false, err
+	} else if len(messages) == 0 {
+		q.mut.Lock()
+		q.lastClaim = time.Now()
+		q.mut.Unlock()
+		return redis.XMessage{}, false, nil
+	}
+
+	return messages[0], true, nil
+}
+
+func (q *Queue) queued(message redis.XMessage) tgo.QueuedUpdate {
+	data, _ := message.Values["update"].(string)
+
+	return tgo.QueuedUpdate{
+		Data: []byte(data),
+		Ack: func() error {
+			return q.client.XAck(context.Background(), q.stream, q.opts.Group, message.ID).Err()
+		},
+	}
+}
@@ -0,0 +1,118 @@
+// Package tgonats contains a tgo.Queue on NATS JetStream.
+package tgonats
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/haashemi/tgo"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// Options configures a Queue. The zero value is valid and uses the defaults.
+type Options struct {
+	// Stream is the name of the JetStream stream; it defaults to "TGO_UPDATES".
+	Stream string
+
+	// Subject is the subject the updates are published on; it defaults to "tgo.updates".
+	// Use different subjects and streams for the bots sharing a NATS.
+	Subject string
+
+	// Consumer is the name of the durable consumer shared by the workers; it defaults to "tgo".
+	Consumer string
+
+	// AckWait is how long an update may stay unacknowledged, such as by a crashed worker,
+	// before it's delivered again; it defaults to a minute.
+	AckWait time.Duration
+
+	// FetchWait is how long each fetch waits for the updates before checking the context;
+	// it defaults to two seconds.
+	FetchWait time.Duration
+}
+
+// Queue is a tgo.Queue on a JetStream work-queue stream, consumed by a durable pull consumer
+// so each update is handled by one of the workers.
+type Queue struct {
+	js       jetstream.JetStream
+	consumer jetstream.Consumer
+	opts     Options
+}
+
+// New creates or updates the stream and its consumer, and returns a Queue on them.
+func New(ctx context.Context, js jetstream.JetStream, opts Options) (*Queue, error) {
+	if opts.Stream == "" {
+		opts.Stream = "TGO_UPDATES"
+	}
+	if opts.Subject == "" {
+		opts.Subject = "tgo.updates"
+	}
+	if opts.Consumer == "" {
+		opts.Consumer = "tgo"
+	}
+	if opts.AckWait <= 0 {
+		opts.AckWait = time.Minute
+	}
+	if opts.FetchWait <= 0 {
+		opts.FetchWait = 2 * time.Second
+	}
+
+	_, err := js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:      opts.Stream,
+		Subjects:  []string{opts.Subject},
+		Retention: jetstream.WorkQueuePolicy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	consumer, err := js.CreateOrUpdateConsumer(ctx, opts.Stream, jetstream.ConsumerConfig{
+		Durable:       opts.Consumer,
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		AckWait:       opts.AckWait,
+		FilterSubject: opts.Subject,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Queue{js: js, consumer: consumer, opts: opts}, nil
+}
+
+// Push implements the tgo.Queue interface.
+func (q *Queue) Push(ctx context.Context, update []byte) error {
+	_, err := q.js.Publish(ctx, q.opts.Subject, update)
+	return err
+}
+
+// Pop implements the tgo.Queue interface.
+func (q *Queue) Pop(ctx context.Context) (tgo.QueuedUpdate, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			return tgo.QueuedUpdate{}, err
+		}
+
+		batch, err := q.consumer.Fetch(1, jetstream.FetchMaxWait(q.opts.FetchWait))
+		if err != nil {
+			if errors.Is(err, nats.ErrTimeout) {
+				continue
+			}
+			return tgo.QueuedUpdate{}, err
+		}
+
+		for message := range batch.Messages() {
+			return tgo.QueuedUpdate{Data: message.Data(), Ack: message.Ack}, nil
+		}
+
+		if err = batch.Error(); err != nil && !errors.Is(err, nats.ErrTimeout) {
+			return tgo.QueuedUpdate{}, err
+		}
+	}
+}
+
+// Ping implements the tgo.Pinger interface, so bot.Preflight checks the connectivity to JetStream.
+func (q *Queue) Ping(ctx context.Context) error {
+	_, err := q.js.AccountInfo(ctx)
+	return err
+}
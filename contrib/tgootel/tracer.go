@@ -0,0 +1,67 @@
+// Package tgootel traces the tgo bots with OpenTelemetry.
+package tgootel
+
+import (
+	"context"
+
+	"github.com/haashemi/tgo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope of the spans.
+const ScopeName = "github.com/haashemi/tgo/contrib/tgootel"
+
+// Tracer is a tgo.Tracer which starts a span for each update, and a child span for each API
+// call made while handling it; the handlers may get the update's context by ctx.Bot.Context().
+type Tracer struct{ tracer trace.Tracer }
+
+// New returns a Tracer using the provider, or the global one if it's nil.
+// Pass it as the tgo.Options' Tracer.
+func New(provider trace.TracerProvider) *Tracer {
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+	return &Tracer{tracer: provider.Tracer(ScopeName)}
+}
+
+// StartUpdate implements the tgo.Tracer interface.
+func (t *Tracer) StartUpdate(ctx context.Context, update *tgo.Update) (context.Context, func()) {
+	attrs := []attribute.KeyValue{
+		attribute.Int64("telegram.update_id", update.UpdateId),
+		attribute.String("telegram.update_type", update.Type()),
+	}
+	if msg := update.EffectiveMessage(); msg != nil {
+		attrs = append(attrs, attribute.Int64("telegram.chat_id", msg.Chat.Id))
+	}
+
+	ctx, span := t.tracer.Start(ctx, "tgo.update "+update.Type(), trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attrs...))
+	return ctx, func() { span.End() }
+}
+
+// StartCall implements the tgo.Tracer interface.
+func (t *Tracer) StartCall(ctx context.Context, method, chatID string) func(err error) {
+	attrs := []attribute.KeyValue{attribute.String("telegram.method", method)}
+	if chatID != "" {
+		attrs = append(attrs, attribute.String("telegram.chat_id", chatID))
+	}
+
+	_, span := t.tracer.Start(ctx, "tgo.call "+method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+
+	return func(err error) {
+		defer span.End()
+
+		if err == nil {
+			span.SetStatus(codes.Ok, "")
+			return
+		}
+
+		if tgErr, ok := err.(*tgo.Error); ok {
+			span.SetAttributes(attribute.Int("telegram.error_code", tgErr.ErrorCode))
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
@@ -0,0 +1,133 @@
+// Package tgoprom exports the metrics of tgo bots to prometheus.
+package tgoprom
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/haashemi/tgo"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Options configures a Collector. The zero value is valid and uses the defaults.
+type Options struct {
+	// Namespace is the prefix of the metrics' names; it defaults to "tgo".
+	Namespace string
+
+	// ConstLabels are added to all of the metrics, such as the bot's name when there are several.
+	ConstLabels prometheus.Labels
+
+	// Buckets are the buckets of the latency histograms; they default to prometheus.DefBuckets.
+	Buckets []float64
+
+	// QueueLen, if not nil, is reported as the queue depth gauge, such as the bot's DispatchQueueLen.
+	QueueLen func() int
+}
+
+// Collector is a tgo.Metrics which exposes the measurements as a prometheus.Collector:
+//
+//   - <namespace>_updates_received_total{type}
+//   - <namespace>_update_handling_seconds{type}
+//   - <namespace>_api_call_seconds{method}
+//   - <namespace>_api_errors_total{method,code}, where code is telegram's error code or "network"
+//   - <namespace>_retries_total{method}
+//   - <namespace>_queue_depth, if there's a QueueLen
+//
+// Pass it as the tgo.Options' Metrics, and register it to a prometheus.Registerer.
+type Collector struct {
+	updates   *prometheus.CounterVec
+	handling  *prometheus.HistogramVec
+	calls     *prometheus.HistogramVec
+	errors    *prometheus.CounterVec
+	retries   *prometheus.CounterVec
+	queueLen  prometheus.GaugeFunc
+	collected []prometheus.Collector
+}
+
+// New returns a new Collector.
+func New(opts Options) *Collector {
+	if opts.Namespace == "" {
+		opts.Namespace = "tgo"
+	}
+	if opts.Buckets == nil {
+		opts.Buckets = prometheus.DefBuckets
+	}
+
+	c := &Collector{
+		updates: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: opts.Namespace, ConstLabels: opts.ConstLabels,
+			Name: "updates_received_total", Help: "The number of the received updates, by their type.",
+		}, []string{"type"}),
+		handling: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: opts.Namespace, ConstLabels: opts.ConstLabels, Buckets: opts.Buckets,
+			Name: "update_handling_seconds", Help: "The time taken to handle the updates, by their type.",
+		}, []string{"type"}),
+		calls: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: opts.Namespace, ConstLabels: opts.ConstLabels, Buckets: opts.Buckets,
+			Name: "api_call_seconds", Help: "The latency of the API calls, by their method.",
+		}, []string{"method"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: opts.Namespace, ConstLabels: opts.ConstLabels,
+			Name: "api_errors_total", Help: "The number of the failed API calls, by their method and error code.",
+		}, []string{"method", "code"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: opts.Namespace, ConstLabels: opts.ConstLabels,
+			Name: "retries_total", Help: "The number of the retried and hedged API calls, by their method.",
+		}, []string{"method"}),
+	}
+	c.collected = []prometheus.Collector{c.updates, c.handling, c.calls, c.errors, c.retries}
+
+	if opts.QueueLen != nil {
+		c.queueLen = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: opts.Namespace, ConstLabels: opts.ConstLabels,
+			Name: "queue_depth", Help: "The number of the updates waiting to be handled.",
+		}, func() float64 { return float64(opts.QueueLen()) })
+		c.collected = append(c.collected, c.queueLen)
+	}
+
+	return c
+}
+
+// UpdateReceived implements the tgo.Metrics interface.
+func (c *Collector) UpdateReceived(updateType string) {
+	c.updates.WithLabelValues(updateType).Inc()
+}
+
+// UpdateHandled implements the tgo.Metrics interface.
+func (c *Collector) UpdateHandled(updateType string, took time.Duration) {
+	c.handling.WithLabelValues(updateType).Observe(took.Seconds())
+}
+
+// APICall implements the tgo.Metrics interface.
+func (c *Collector) APICall(method string, took time.Duration, err error) {
+	c.calls.WithLabelValues(method).Observe(took.Seconds())
+
+	if err == nil {
+		return
+	}
+
+	code := "network"
+	if tgErr, ok := err.(*tgo.Error); ok {
+		code = strconv.Itoa(tgErr.ErrorCode)
+	}
+	c.errors.WithLabelValues(method, code).Inc()
+}
+
+// Retried implements the tgo.Metrics interface.
+func (c *Collector) Retried(method string) {
+	c.retries.WithLabelValues(method).Inc()
+}
+
+// Describe implements the prometheus.Collector interface.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, collector := range c.collected {
+		collector.Describe(ch)
+	}
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	for _, collector := range c.collected {
+		collector.Collect(ch)
+	}
+}
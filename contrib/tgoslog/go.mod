@@ -0,0 +1,7 @@
+module github.com/haashemi/tgo/contrib/tgoslog
+
+go 1.21
+
+require github.com/haashemi/tgo v0.0.0
+
+replace github.com/haashemi/tgo => ../..
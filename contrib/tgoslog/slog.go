@@ -0,0 +1,26 @@
+// Package tgoslog passes the structured events of tgo to log/slog.
+package tgoslog
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/haashemi/tgo"
+)
+
+// Logger is a tgo.Logger writing the events to a *slog.Logger.
+type Logger struct{ logger *slog.Logger }
+
+// New returns a Logger writing to the logger, or to slog.Default() if it's nil.
+// Pass it as the tgo.Options' Logger.
+func New(logger *slog.Logger) *Logger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Logger{logger: logger}
+}
+
+// Log implements the tgo.Logger interface.
+func (l *Logger) Log(level tgo.LogLevel, msg string, args ...any) {
+	l.logger.Log(context.Background(), slog.Level(level), msg, args...)
+}
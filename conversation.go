@@ -0,0 +1,94 @@
+package tgo
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultConversationTimeout is how long a Conversation waits for each answer if the context has no deadline.
+const DefaultConversationTimeout = 5 * time.Minute
+
+// Conversation asks a user the questions in a chat and waits for their answers, so the wizard-like
+// flows are written as a plain sequence of calls instead of a state machine:
+//
+//	conv := bot.Conversation(chatID, userID)
+//	name, err := conv.Ask(ctx, "What's your name?")
+//	if err != nil {
+//		return // such as context.DeadlineExceeded if they didn't answer in time.
+//	}
+//	age, err := conv.Ask(ctx, "How old are you, "+name.Text+"?")
+//
+// The answers are the next messages of the user in the chat, and they're not passed to the routers.
+// The handlers are blocked while they wait, so don't use it in a dispatcher's handlers, which
+// would block the answers queued behind them.
+type Conversation struct {
+	bot *Bot
+
+	ChatID int64
+	UserID int64
+
+	// ThreadID, if not zero, is the forum topic which the questions are sent to.
+	ThreadID int64
+
+	// Timeout is how long each answer is waited for if the context has no deadline;
+	// it defaults to DefaultConversationTimeout.
+	Timeout time.Duration
+}
+
+// Conversation returns a Conversation with the user in the chat. The userID is the sender's
+// chat id for the messages sent on behalf of a chat, as returned by GetChatAndSenderID.
+func (bot *Bot) Conversation(chatID, userID int64) *Conversation {
+	return &Conversation{bot: bot, ChatID: chatID, UserID: userID, Timeout: DefaultConversationTimeout}
+}
+
+// Ask sends the text to the chat and waits for the user's answer until the context is done.
+func (c *Conversation) Ask(ctx context.Context, text string) (*Message, error) {
+	return c.AskMessage(ctx, &SendMessage{Text: text})
+}
+
+// AskMessage sends the message to the chat, unless its chat id is set, and waits for the user's
+// answer until the context is done.
+func (c *Conversation) AskMessage(ctx context.Context, msg Sendable) (*Message, error) {
+	if msg.GetChatID() == nil {
+		msg.SetChatID(c.ChatID)
+
+		if c.ThreadID != 0 {
+			SetMessageThreadID(msg, c.ThreadID)
+		}
+	}
+
+	ctx, cancel := c.context(ctx)
+	defer cancel()
+
+	uid := GetAskUID(c.ChatID, c.UserID)
+	waiter := c.bot.registerAsk(uid)
+
+	if _, err := c.bot.Send(msg); err != nil {
+		c.bot.unregisterAsk(uid, waiter)
+		return nil, err
+	}
+
+	return c.bot.awaitAnswer(ctx, uid, waiter)
+}
+
+// Await waits for the user's next message in the chat until the context is done, without asking anything.
+func (c *Conversation) Await(ctx context.Context) (*Message, error) {
+	ctx, cancel := c.context(ctx)
+	defer cancel()
+
+	uid := GetAskUID(c.ChatID, c.UserID)
+	return c.bot.awaitAnswer(ctx, uid, c.bot.registerAsk(uid))
+}
+
+// context applies the conversation's timeout to the context, if it has no deadline.
+func (c *Conversation) context(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return context.WithCancel(ctx)
+	}
+
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = DefaultConversationTimeout
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,58 @@
+package tgo_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func textUpdate(id, chatID, userID int64, text string) *tgo.Update {
+	return &tgo.Update{UpdateId: id, Message: &tgo.Message{
+		MessageId: id,
+		Chat:      tgo.Chat{Id: chatID, Type: "private"},
+		From:      &tgo.User{Id: userID},
+		Text:      text,
+	}}
+}
+
+func TestConversation(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	bot := server.Bot(tgo.Options{})
+	router := recordRouter{chats: make(chan int64, 1)}
+	bot.AddRouter(router)
+
+	// the user answers before the question's call is returned.
+	server.Handle("sendMessage", func(call tgotest.Call) (any, *tgo.Error) {
+		bot.HandleUpdate(textUpdate(1, 7, 70, "Gopher"))
+		return map[string]any{"message_id": 1, "date": 1, "chat": map[string]any{"id": 7, "type": "private"}}, nil
+	})
+
+	conv := bot.Conversation(7, 70)
+	answer, err := conv.Ask(context.Background(), "What's your name?")
+	if err != nil {
+		t.Fatal(err)
+	} else if answer.Text != "Gopher" {
+		t.Errorf("expected the answer Gopher, got %q", answer.Text)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err = conv.Await(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the deadline to exceed, got %v", err)
+	}
+
+	// the messages after the conversation are passed to the routers.
+	bot.HandleUpdate(textUpdate(2, 7, 70, "hello"))
+	select {
+	case <-router.chats:
+	default:
+		t.Error("the message is not passed to the routers")
+	}
+}
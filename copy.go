@@ -0,0 +1,71 @@
+package tgo
+
+import "sort"
+
+// MaxCopyMessagesCount is the maximum number of messages which can be copied or forwarded by a single
+// copyMessages or forwardMessages call.
+const MaxCopyMessagesCount = 100
+
+// CopyAll copies the messages of params.MessageIds, of any number, in chunks of MaxCopyMessagesCount
+// messages; and returns the ids of the copies, in order. The ids are sorted and deduplicated, as telegram
+// requires; the messages which can't be found or copied are skipped by telegram. An album crossing
+// two chunks is split in two.
+//
+// If copying a chunk fails, the ids of the copies made before it are returned with the error.
+// The passed params are not modified.
+func (api *API) CopyAll(params *CopyMessages) ([]*MessageId, error) {
+	var copies []*MessageId
+	for _, chunk := range messageIDChunks(params.MessageIds) {
+		chunkParams := *params
+		chunkParams.MessageIds = chunk
+
+		ids, err := api.CopyMessages(&chunkParams)
+		if err != nil {
+			return copies, err
+		}
+		copies = append(copies, ids...)
+	}
+
+	return copies, nil
+}
+
+// ForwardAll forwards the messages of params.MessageIds, of any number, like CopyAll; and returns
+// the ids of the forwarded messages, in order.
+func (api *API) ForwardAll(params *ForwardMessages) ([]*MessageId, error) {
+	var forwarded []*MessageId
+	for _, chunk := range messageIDChunks(params.MessageIds) {
+		chunkParams := *params
+		chunkParams.MessageIds = chunk
+
+		ids, err := api.ForwardMessages(&chunkParams)
+		if err != nil {
+			return forwarded, err
+		}
+		forwarded = append(forwarded, ids...)
+	}
+
+	return forwarded, nil
+}
+
+// messageIDChunks returns the sorted and deduplicated ids in chunks of MaxCopyMessagesCount.
+func messageIDChunks(ids []int64) [][]int64 {
+	sorted := append([]int64(nil), ids...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	unique := sorted[:0]
+	for i, id := range sorted {
+		if i == 0 || id != sorted[i-1] {
+			unique = append(unique, id)
+		}
+	}
+
+	var chunks [][]int64
+	for start := 0; start < len(unique); start += MaxCopyMessagesCount {
+		end := start + MaxCopyMessagesCount
+		if end > len(unique) {
+			end = len(unique)
+		}
+		chunks = append(chunks, unique[start:end])
+	}
+	return chunks
+}
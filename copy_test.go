@@ -0,0 +1,42 @@
+package tgo_test
+
+import (
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestCopyAll(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	bot := server.Bot(tgo.Options{})
+
+	// 150 unique ids, in the reverse order and with duplicates.
+	var ids []int64
+	for id := int64(150); id > 0; id-- {
+		ids = append(ids, id, id)
+	}
+
+	copies, err := bot.CopyAll(&tgo.CopyMessages{ChatId: tgo.ID(1), FromChatId: tgo.ID(2), MessageIds: ids})
+	if err != nil {
+		t.Fatal(err)
+	} else if len(copies) != 150 {
+		t.Fatalf("got %d copies, want 150", len(copies))
+	}
+
+	calls := server.Calls()
+	if len(calls) != 2 {
+		t.Fatalf("made %d calls, want 2", len(calls))
+	}
+
+	first, second := calls[0].Params["message_ids"].([]any), calls[1].Params["message_ids"].([]any)
+	if len(first) != 100 || len(second) != 50 || first[0] != float64(1) || second[49] != float64(150) {
+		t.Fatalf("unexpected chunks of %d and %d ids", len(first), len(second))
+	}
+
+	if len(ids) != 300 || ids[0] != 150 {
+		t.Fatal("the passed ids are modified")
+	}
+}
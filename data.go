@@ -0,0 +1,98 @@
+package tgo
+
+import "sync"
+
+// the keys of the data bags in the sessions; they're different, so the data of a user
+// doesn't mix with the data of their private chat, which has the same id.
+const (
+	userDataKey = "tgo.user_data"
+	chatDataKey = "tgo.chat_data"
+)
+
+// Data is a bag of values which the handlers keep for a user or a chat, such as the counters,
+// preferences, and flags. It's stored in the bot's sessions, and it's safe for concurrent use.
+// Use GetData, GetDataOr, and UpdateData to get the values by their types.
+type Data struct {
+	mut    sync.Mutex
+	values map[string]any
+}
+
+// UserData returns the data bag of the user.
+func (bot *Bot) UserData(userID int64) *Data { return sessionData(bot.GetSession(userID), userDataKey) }
+
+// ChatData returns the data bag of the chat.
+func (bot *Bot) ChatData(chatID int64) *Data { return sessionData(bot.GetSession(chatID), chatDataKey) }
+
+func sessionData(session *sync.Map, key string) *Data {
+	data, _ := session.LoadOrStore(key, &Data{values: make(map[string]any)})
+	return data.(*Data)
+}
+
+// Get returns the value of the key.
+func (d *Data) Get(key string) (value any, ok bool) {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+
+	value, ok = d.values[key]
+	return value, ok
+}
+
+// Set sets the value of the key.
+func (d *Data) Set(key string, value any) {
+	d.mut.Lock()
+	d.values[key] = value
+	d.mut.Unlock()
+}
+
+// Delete deletes the key.
+func (d *Data) Delete(key string) {
+	d.mut.Lock()
+	delete(d.values, key)
+	d.mut.Unlock()
+}
+
+// Keys returns the keys of the bag, in no particular order.
+func (d *Data) Keys() []string {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+
+	keys := make([]string, 0, len(d.values))
+	for key := range d.values {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// GetData returns the value of the key, and false if it's not set or it's not a T.
+func GetData[T any](d *Data, key string) (value T, ok bool) {
+	raw, ok := d.Get(key)
+	if !ok {
+		return value, false
+	}
+
+	value, ok = raw.(T)
+	return value, ok
+}
+
+// GetDataOr returns the value of the key, or the fallback if it's not set or it's not a T.
+func GetDataOr[T any](d *Data, key string, fallback T) T {
+	if value, ok := GetData[T](d, key); ok {
+		return value
+	}
+	return fallback
+}
+
+// UpdateData replaces the value of the key with the result of update, and returns it. The update gets
+// the zero T if it's not set or it's not a T. The bag is locked meanwhile, so the concurrent updates,
+// such as incrementing a counter, don't overwrite each other:
+//
+//	count := tgo.UpdateData(bot.UserData(userID), "messages", func(n int) int { return n + 1 })
+func UpdateData[T any](d *Data, key string, update func(value T) T) T {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+
+	value, _ := d.values[key].(T)
+	value = update(value)
+	d.values[key] = value
+	return value
+}
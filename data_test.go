@@ -0,0 +1,39 @@
+package tgo_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/haashemi/tgo"
+)
+
+func TestData(t *testing.T) {
+	bot := tgo.NewBot(":", tgo.Options{})
+
+	user := bot.UserData(42)
+	user.Set("lang", "en")
+
+	if lang, ok := tgo.GetData[string](bot.UserData(42), "lang"); !ok || lang != "en" {
+		t.Errorf("expected lang en, got %q", lang)
+	}
+	if _, ok := tgo.GetData[int](user, "lang"); ok {
+		t.Error("expected a string not to be got as an int")
+	}
+	if got := tgo.GetDataOr(bot.ChatData(42), "lang", "fa"); got != "fa" {
+		t.Errorf("expected the private chat's data to be separate from the user's, got %q", got)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tgo.UpdateData(bot.ChatData(-1), "count", func(n int) int { return n + 1 })
+		}()
+	}
+	wg.Wait()
+
+	if count := tgo.GetDataOr(bot.ChatData(-1), "count", 0); count != 100 {
+		t.Errorf("expected count 100, got %d", count)
+	}
+}
@@ -0,0 +1,64 @@
+package tgo
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultDedupeTTL is how long a MemoryDedupeStore remembers the updates by default.
+const DefaultDedupeTTL = time.Hour
+
+// DedupeStore remembers the ids of the received updates, so the ones delivered again, such as by the
+// webhook retries or the racing pollers, are dropped before they're handled. Implement it to share
+// the seen updates between the bot's instances, such as by the SET NX command of redis.
+type DedupeStore interface {
+	// Seen records the update id, and reports whether it was already recorded.
+	Seen(updateID int64) (bool, error)
+}
+
+// MemoryDedupeStore is an in-memory DedupeStore, which remembers the updates for its ttl, and forgets
+// the oldest ones beyond its maximum number of entries.
+type MemoryDedupeStore struct {
+	ttl     time.Duration
+	mut     sync.Mutex
+	backend *MemoryCacheBackend
+}
+
+// NewMemoryDedupeStore returns a MemoryDedupeStore of at most maxEntries updates, remembered for the ttl;
+// they default to 10000 and DefaultDedupeTTL.
+func NewMemoryDedupeStore(maxEntries int, ttl time.Duration) *MemoryDedupeStore {
+	if ttl <= 0 {
+		ttl = DefaultDedupeTTL
+	}
+	return &MemoryDedupeStore{ttl: ttl, backend: NewMemoryCacheBackend(maxEntries)}
+}
+
+// Seen implements the DedupeStore interface.
+func (s *MemoryDedupeStore) Seen(updateID int64) (bool, error) {
+	key := strconv.FormatInt(updateID, 10)
+
+	// the check and the record are done at once, so the concurrent deliveries pass only once.
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if _, ok, _ := s.backend.Get(key); ok {
+		return true, nil
+	}
+	return false, s.backend.Set(key, nil, s.ttl)
+}
+
+// isDuplicate reports whether the update is already received, by the bot's DedupeStore if it has one.
+// The updates without an id, such as the synthesized ones, and the store's failures let the update in.
+func (bot *Bot) isDuplicate(update *Update) bool {
+	if bot.dedupe == nil || update.UpdateId == 0 {
+		return false
+	}
+
+	seen, err := bot.dedupe.Seen(update.UpdateId)
+	if err != nil {
+		bot.log(LevelWarn, "failed to check the duplicate update", "update_id", update.UpdateId, "error", err)
+		return false
+	}
+	return seen
+}
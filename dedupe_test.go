@@ -0,0 +1,36 @@
+package tgo_test
+
+import (
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+type countingRouter struct{ handled int }
+
+func (r *countingRouter) Setup(bot *tgo.Bot) error { return nil }
+
+func (r *countingRouter) HandleUpdate(bot *tgo.Bot, upd *tgo.Update) bool {
+	r.handled++
+	return true
+}
+
+func TestDedupe(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	bot := server.Bot(tgo.Options{Dedupe: tgo.NewMemoryDedupeStore(2, 0)})
+
+	router := &countingRouter{}
+	bot.AddRouter(router)
+
+	for _, id := range []int64{1, 2, 1, 2, 3, 1, 0, 0} {
+		bot.HandleUpdate(&tgo.Update{UpdateId: id, Message: &tgo.Message{Chat: tgo.Chat{Id: 1}}})
+	}
+
+	// the 1st update is forgotten once the 3rd comes in, as the store keeps two of them.
+	if router.handled != 6 {
+		t.Errorf("expected 6 updates to be handled, got %d", router.handled)
+	}
+}
@@ -0,0 +1,74 @@
+package tgo
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/url"
+)
+
+// MaxStartPayloadLength is the maximum length of the deep links' start parameter.
+const MaxStartPayloadLength = 64
+
+var ErrInvalidStartPayload = errors.New("start payload must be up to 64 characters of A-Z, a-z, 0-9, _ and -")
+
+// EncodeStartPayload encodes the arbitrary data as unpadded base64url so it can be used as a start parameter.
+// Keep in mind that the result must still fit in MaxStartPayloadLength, which leaves room for 48 bytes.
+func EncodeStartPayload(data []byte) string { return base64.RawURLEncoding.EncodeToString(data) }
+
+// DecodeStartPayload decodes the start parameter encoded by EncodeStartPayload.
+func DecodeStartPayload(payload string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(payload)
+}
+
+// IsValidStartPayload returns true if the payload can be used as a deep link's start parameter as is.
+func IsValidStartPayload(payload string) bool {
+	if len(payload) > MaxStartPayloadLength {
+		return false
+	}
+
+	for _, c := range payload {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Me returns the bot's own user. It's fetched by getMe on the first call, and then cached.
+func (bot *Bot) Me() (*User, error) {
+	bot.meMut.Lock()
+	defer bot.meMut.Unlock()
+
+	if bot.me != nil {
+		return bot.me, nil
+	}
+
+	me, err := bot.GetMe()
+	if err != nil {
+		return nil, err
+	}
+
+	bot.me = me
+	return me, nil
+}
+
+// DeepLink returns a t.me link which starts the bot with the passed payload. The payload must be
+// valid as is; use EncodeStartPayload to pass arbitrary data.
+func (bot *Bot) DeepLink(payload string) (string, error) {
+	if !IsValidStartPayload(payload) {
+		return "", ErrInvalidStartPayload
+	}
+
+	me, err := bot.Me()
+	if err != nil {
+		return "", err
+	}
+
+	link := "https://t.me/" + me.Username
+	if payload != "" {
+		link += "?start=" + url.QueryEscape(payload)
+	}
+
+	return link, nil
+}
@@ -0,0 +1,139 @@
+package tgo
+
+import (
+	"sync"
+)
+
+// Dispatcher handles the updates independently of the bot's routers and of the other dispatchers,
+// such as an analytics sink or a moderation engine. *Bot is a Dispatcher itself.
+type Dispatcher interface {
+	HandleUpdate(update *Update)
+}
+
+// DispatcherFunc is a function which implements the Dispatcher interface.
+type DispatcherFunc func(update *Update)
+
+// HandleUpdate implements the Dispatcher interface.
+func (f DispatcherFunc) HandleUpdate(update *Update) { f(update) }
+
+// DispatcherOptions configures the worker pool of a dispatcher.
+type DispatcherOptions struct {
+	// Workers is the number of the goroutines handling the updates; it defaults to 1.
+	Workers int
+
+	// QueueSize is the number of the updates which can wait for the workers; it defaults to 100.
+	// The updates are dropped when the queue is full, so a slow dispatcher won't slow the others down.
+	QueueSize int
+
+	// OnDrop, if not nil, is called with the updates dropped because the queue was full.
+	OnDrop func(update *Update)
+
+	// OnPanic, if not nil, is called when the dispatcher panics while handling the update;
+	// the panic is logged by default. Either way, the worker recovers and continues.
+	OnPanic func(recovered any, update *Update)
+}
+
+type dispatcher struct {
+	Dispatcher
+	api   *API
+	opts  DispatcherOptions
+	queue chan *Update
+
+	mut     sync.RWMutex
+	stopped bool
+	workers sync.WaitGroup
+}
+
+// AddDispatcher attaches the dispatcher to the bot, which gets a copy of every update passed to bot.HandleUpdate,
+// before the asks and the routers, in its own worker pool. Add the dispatchers before receiving the updates.
+func (bot *Bot) AddDispatcher(d Dispatcher, opts DispatcherOptions) {
+	if opts.Workers <= 0 {
+		opts.Workers = 1
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = 100
+	}
+
+	ds := &dispatcher{Dispatcher: d, api: bot.API, opts: opts, queue: make(chan *Update, opts.QueueSize)}
+	ds.workers.Add(opts.Workers)
+	for i := 0; i < opts.Workers; i++ {
+		go ds.work()
+	}
+
+	bot.dispatchers = append(bot.dispatchers, ds)
+}
+
+// StopDispatchers stops accepting new updates in the dispatchers, and waits for
+// their workers to handle the already queued updates and exit.
+func (bot *Bot) StopDispatchers() {
+	for _, ds := range bot.dispatchers {
+		ds.stop()
+	}
+}
+
+// DispatchQueueLen returns the number of the updates waiting in the dispatchers' queues.
+func (bot *Bot) DispatchQueueLen() (n int) {
+	for _, ds := range bot.dispatchers {
+		n += len(ds.queue)
+	}
+	return n
+}
+
+// dispatch queues the update, or drops it if the queue is full or the dispatcher is stopped.
+func (ds *dispatcher) dispatch(update *Update) {
+	ds.mut.RLock()
+	defer ds.mut.RUnlock()
+
+	if ds.stopped {
+		if ds.opts.OnDrop != nil {
+			ds.opts.OnDrop(update)
+		}
+		return
+	}
+
+	// the queued update's values are kept until the dispatcher has handled it too.
+	holdUpdate(update)
+
+	select {
+	case ds.queue <- update:
+	default:
+		releaseUpdate(update)
+		if ds.opts.OnDrop != nil {
+			ds.opts.OnDrop(update)
+		}
+	}
+}
+
+func (ds *dispatcher) stop() {
+	ds.mut.Lock()
+	if !ds.stopped {
+		ds.stopped = true
+		close(ds.queue)
+	}
+	ds.mut.Unlock()
+
+	ds.workers.Wait()
+}
+
+func (ds *dispatcher) work() {
+	defer ds.workers.Done()
+
+	for update := range ds.queue {
+		ds.handle(update)
+		releaseUpdate(update)
+	}
+}
+
+func (ds *dispatcher) handle(update *Update) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			if ds.opts.OnPanic != nil {
+				ds.opts.OnPanic(recovered, update)
+			} else {
+				ds.api.log(LevelError, "dispatcher panicked", "update_id", update.UpdateId, "panic", recovered)
+			}
+		}
+	}()
+
+	ds.HandleUpdate(update)
+}
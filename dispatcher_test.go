@@ -0,0 +1,46 @@
+package tgo
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestDispatchers(t *testing.T) {
+	bot := NewBot("", Options{})
+
+	// the panics of a dispatcher must not stop its worker.
+	var panicked sync.WaitGroup
+	panicked.Add(3)
+	bot.AddDispatcher(DispatcherFunc(func(update *Update) {
+		panic("analytics is broken")
+	}), DispatcherOptions{OnPanic: func(recovered any, update *Update) { panicked.Done() }})
+
+	var handled, dropped []int64
+	started, block := make(chan struct{}, 1), make(chan struct{})
+	bot.AddDispatcher(DispatcherFunc(func(update *Update) {
+		started <- struct{}{}
+		<-block
+		handled = append(handled, update.UpdateId)
+	}), DispatcherOptions{QueueSize: 1, OnDrop: func(update *Update) { dropped = append(dropped, update.UpdateId) }})
+
+	// the first update is taken by the slow dispatcher's worker, the second one
+	// waits in its queue, and the third one is dropped.
+	bot.HandleUpdate(&Update{UpdateId: 1})
+	<-started
+	bot.HandleUpdate(&Update{UpdateId: 2})
+	bot.HandleUpdate(&Update{UpdateId: 3})
+
+	close(block)
+	panicked.Wait()
+	bot.StopDispatchers()
+
+	if len(handled) != 2 || len(dropped) != 1 || dropped[0] != 3 {
+		t.Errorf("handled %v and dropped %v, want [1 2] and [3]", handled, dropped)
+	}
+
+	// the stopped dispatchers drop the updates instead of panicking on the closed queue.
+	bot.HandleUpdate(&Update{UpdateId: 4})
+	if len(dropped) != 2 {
+		t.Errorf("dropped %v, want [3 4]", dropped)
+	}
+}
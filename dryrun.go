@@ -0,0 +1,118 @@
+package tgo
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// dryRunState is shared by the dry-running api and its copies.
+type dryRunState struct {
+	lastMessageID atomic.Int64
+}
+
+// DryRun reports whether the api only logs its changing calls; see Options.DryRun.
+func (api *API) DryRun() bool { return api.dryRun != nil }
+
+// dryRuns reports whether the method is a changing one, which the dry-running api doesn't send; the
+// getting ones, such as getUpdates and getChat, are sent anyway.
+func (api *API) dryRuns(method string) bool {
+	return api.dryRun != nil && !strings.HasPrefix(method, "get")
+}
+
+// dryRunCall logs the call of the method instead of sending it, and returns its synthesized result: the
+// sent and edited messages have the chat, text, caption, and reply markup of the params, and the new
+// ones have increasing ids; the other methods return true, or the zero value of their result.
+func dryRunCall[T any](a *API, method string, params any) (result T, err error) {
+	a.log(LevelInfo, "dry run", "method", method, "params", params)
+
+	fields := dryRunFields(params)
+
+	msg := map[string]any{"date": time.Now().Unix(), "chat": map[string]any{"id": fields["chat_id"], "type": "private"}}
+	if _, isNumber := fields["chat_id"].(json.Number); !isNumber {
+		msg["chat"] = map[string]any{"id": 0, "type": "private"}
+	}
+	if id, ok := fields["message_id"]; ok && strings.HasPrefix(method, "edit") {
+		msg["message_id"] = id
+	} else {
+		msg["message_id"] = a.dryRun.lastMessageID.Add(1)
+	}
+	for _, key := range []string{"text", "caption", "reply_markup"} {
+		if value, ok := fields[key]; ok {
+			msg[key] = value
+		}
+	}
+
+	var candidates []any
+	switch method {
+	case "sendMediaGroup":
+		media, _ := fields["media"].([]any)
+		messages := make([]any, len(media))
+		for i := range media {
+			messages[i] = map[string]any{"message_id": a.dryRun.lastMessageID.Add(1), "date": msg["date"], "chat": msg["chat"]}
+		}
+		candidates = append(candidates, messages)
+
+	case "copyMessages", "forwardMessages":
+		ids, _ := fields["message_ids"].([]any)
+		copies := make([]any, len(ids))
+		for i := range ids {
+			copies[i] = map[string]any{"message_id": a.dryRun.lastMessageID.Add(1)}
+		}
+		candidates = append(candidates, copies)
+	}
+	candidates = append(candidates, msg, true, map[string]any{}, []any{})
+
+	// the first of the candidates fitting the method's result type is returned.
+	for _, candidate := range candidates {
+		raw, _ := json.Marshal(candidate)
+		var decoded T
+		if json.Unmarshal(raw, &decoded) == nil {
+			return decoded, nil
+		}
+	}
+	return result, nil
+}
+
+// dryRunFields returns the params of a JSON or a multipart call as their decoded JSON fields,
+// keeping the numbers as json.Number.
+func dryRunFields(params any) map[string]any {
+	fields := make(map[string]any)
+
+	if form, ok := params.(map[string]string); ok {
+		// the multipart fields are the plain strings and numbers, or the JSON-encoded objects and arrays.
+		for key, value := range form {
+			if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+				fields[key] = json.Number(value)
+			} else if strings.HasPrefix(value, "{") || strings.HasPrefix(value, "[") {
+				fields[key] = decodeJSONNumbers([]byte(value))
+			} else {
+				fields[key] = value
+			}
+		}
+		return fields
+	}
+
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return fields
+	}
+	if decoded, ok := decodeJSONNumbers(raw).(map[string]any); ok {
+		fields = decoded
+	}
+	return fields
+}
+
+// decodeJSONNumbers decodes the JSON value, keeping its numbers as json.Number; it's nil if it's invalid.
+func decodeJSONNumbers(raw []byte) any {
+	var decoded any
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if dec.Decode(&decoded) != nil {
+		return nil
+	}
+	return decoded
+}
@@ -0,0 +1,58 @@
+package tgo_test
+
+import (
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestDryRun(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	var intercepted []string
+	bot := server.Bot(tgo.Options{DryRun: true, Interceptors: []tgo.Interceptor{{
+		AfterResponse: func(method string, result any, err error) error {
+			intercepted = append(intercepted, method)
+			return err
+		},
+	}}})
+
+	msg, err := bot.SendMessage(&tgo.SendMessage{ChatId: tgo.ID(42), Text: "hello"})
+	if err != nil {
+		t.Fatal(err)
+	} else if msg.Chat.Id != 42 || msg.Text != "hello" || msg.MessageId == 0 {
+		t.Fatalf("unexpected synthesized message %+v", msg)
+	}
+
+	edited, err := bot.EditMessageText(&tgo.EditMessageText{ChatId: tgo.ID(42), MessageId: msg.MessageId, Text: "edited"})
+	if err != nil {
+		t.Fatal(err)
+	} else if edited.MessageId != msg.MessageId {
+		t.Errorf("the edited message has the id %d, not %d", edited.MessageId, msg.MessageId)
+	}
+
+	if ok, err := bot.DeleteMessage(&tgo.DeleteMessage{ChatId: tgo.ID(42), MessageId: msg.MessageId}); err != nil || !ok {
+		t.Fatalf("unexpected deletion result %v, %v", ok, err)
+	}
+
+	ids, err := bot.CopyMessages(&tgo.CopyMessages{ChatId: tgo.ID(1), FromChatId: tgo.ID(42), MessageIds: []int64{1, 2, 3}})
+	if err != nil {
+		t.Fatal(err)
+	} else if len(ids) != 3 {
+		t.Errorf("expected 3 copied ids, got %d", len(ids))
+	}
+
+	if _, err = bot.GetMe(); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := server.Calls()
+	if len(calls) != 1 || calls[0].Method != "getMe" {
+		t.Errorf("expected only getMe to be sent, got %v", calls)
+	}
+	if len(intercepted) != 5 {
+		t.Errorf("expected 5 intercepted calls, got %v", intercepted)
+	}
+}
@@ -0,0 +1,75 @@
+package tgo
+
+import (
+	"errors"
+	"strings"
+)
+
+// uneditableDescriptions are the parts of the errors telling that a message can't be edited.
+var uneditableDescriptions = []string{
+	"message to edit not found",               // it's deleted
+	"message can't be edited",                 // it's too old, or it's not sent by the bot
+	"message_id_invalid",                      // it never existed
+	"there is no text in the message to edit", // it's a media message
+}
+
+// IsMessageNotModifiedErr returns true if the error is telegram telling that the edited message
+// already has the same content and reply markup.
+func IsMessageNotModifiedErr(err error) bool {
+	return errors.Is(err, ErrMessageNotModified)
+}
+
+// isUneditableErr returns true if the error is telegram telling that the message can't be edited.
+func isUneditableErr(err error) bool {
+	var tgErr *Error
+	if !errors.As(err, &tgErr) || tgErr.ErrorCode != 400 {
+		return false
+	}
+
+	description := strings.ToLower(tgErr.Description)
+	for _, part := range uneditableDescriptions {
+		if strings.Contains(description, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// EditOrSend edits the text and the inline keyboard of the message with the bot's DefaultParseMode,
+// or sends them as a new message to the chat if it can't be edited, such as when it's deleted,
+// too old, or a media message; a zero messageID always sends a new one. It returns the edited
+// or the sent message.
+//
+// Editing the message to the same text and keyboard, such as when a menu button is tapped twice,
+// isn't an error; nothing is sent then, and the returned message is nil.
+func (bot *Bot) EditOrSend(chatID ChatID, messageID int64, text string, markup *InlineKeyboardMarkup) (*Message, error) {
+	if messageID != 0 {
+		if err := bot.ShrinkCallbackData(markup); err != nil {
+			return nil, err
+		}
+
+		edit := &EditMessageText{
+			ChatId:      chatID,
+			MessageId:   messageID,
+			Text:        text,
+			ParseMode:   bot.DefaultParseMode,
+			ReplyMarkup: markup,
+		}
+		bot.applyLinkPreview(edit)
+
+		edited, err := bot.EditMessageText(edit)
+		if err == nil {
+			return edited, nil
+		} else if IsMessageNotModifiedErr(err) {
+			return nil, nil
+		} else if !isUneditableErr(err) {
+			return nil, err
+		}
+	}
+
+	msg := &SendMessage{ChatId: chatID, Text: text}
+	if markup != nil {
+		msg.ReplyMarkup = markup
+	}
+	return bot.Send(msg)
+}
@@ -0,0 +1,51 @@
+package tgo_test
+
+import (
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestEditOrSend(t *testing.T) {
+	tests := []struct {
+		name     string
+		editErr  *tgo.Error
+		wantSend bool
+		wantErr  bool
+	}{
+		{name: "edited"},
+		{name: "deleted", editErr: &tgo.Error{ErrorCode: 400, Description: "Bad Request: message to edit not found"}, wantSend: true},
+		{name: "too old", editErr: &tgo.Error{ErrorCode: 400, Description: "Bad Request: message can't be edited"}, wantSend: true},
+		{name: "not modified", editErr: tgo.ErrMessageNotModified},
+		{name: "other errors", editErr: tgo.ErrChatNotFound, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := tgotest.NewServer()
+			defer server.Close()
+
+			server.Handle("editMessageText", func(call tgotest.Call) (any, *tgo.Error) {
+				if tt.editErr != nil {
+					return nil, tt.editErr
+				}
+				return map[string]any{"message_id": 5, "date": 1, "chat": map[string]any{"id": 1, "type": "private"}}, nil
+			})
+
+			bot := server.Bot(tgo.Options{})
+			_, err := bot.EditOrSend(tgo.ID(1), 5, "menu", nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var sent bool
+			for _, call := range server.Calls() {
+				sent = sent || call.Method == "sendMessage"
+			}
+			if sent != tt.wantSend {
+				t.Errorf("expected sent to be %v", tt.wantSend)
+			}
+		})
+	}
+}
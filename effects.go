@@ -0,0 +1,30 @@
+package tgo
+
+import "reflect"
+
+// The standard message effects, which every user can see; they're only shown in the private chats.
+const (
+	EffectFire       = "5104841245755180586" // 🔥
+	EffectThumbsUp   = "5107584321108051014" // 👍
+	EffectThumbsDown = "5104858069142078462" // 👎
+	EffectHeart      = "5159385139981059251" // ❤️
+	EffectParty      = "5046509860389126442" // 🎉
+	EffectPoop       = "5046589136895476101" // 💩
+)
+
+// SetMessageEffect sets the message effect of the sendable, such as EffectParty, if it has one;
+// it returns false if the sendable has no MessageEffectId, such as the paid media.
+func SetMessageEffect(msg Sendable, effectID string) bool {
+	v := reflect.ValueOf(msg)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return false
+	}
+
+	field := v.Elem().FieldByName("MessageEffectId")
+	if !field.IsValid() || field.Kind() != reflect.String {
+		return false
+	}
+
+	field.SetString(effectID)
+	return true
+}
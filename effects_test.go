@@ -0,0 +1,36 @@
+package tgo_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestSetMessageEffect(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	bot := server.Bot(tgo.Options{})
+
+	text := &tgo.SendMessage{ChatId: tgo.ID(1), Text: "congrats"}
+	photo := &tgo.SendPhoto{ChatId: tgo.ID(1), Photo: tgo.FileFromReader("cake.jpg", strings.NewReader("cake"))}
+	for _, msg := range []tgo.Sendable{text, photo} {
+		if !tgo.SetMessageEffect(msg, tgo.EffectParty) {
+			t.Fatalf("the effect isn't set to %T", msg)
+		} else if _, err := bot.Send(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, call := range server.Calls() {
+		if call.Params["message_effect_id"] != tgo.EffectParty {
+			t.Errorf("%s is sent with the effect %v", call.Method, call.Params["message_effect_id"])
+		}
+	}
+
+	if tgo.SetMessageEffect(&tgo.SendPaidMedia{}, tgo.EffectParty) {
+		t.Error("the effect is set to a paid media")
+	}
+}
@@ -1,7 +1,10 @@
 package tgo
 
 import (
+	"errors"
+	"fmt"
 	"regexp"
+	"strings"
 	"time"
 )
 
@@ -15,6 +18,55 @@ type Error struct {
 
 func (e Error) Error() string { return e.Description }
 
+// Is reports whether the error is the target's kind of failure, so the well-known errors below can be
+// checked by errors.Is, even if they're wrapped. The target matches if it has the same error code and
+// its description starts the error's one; the descriptions are compared case-insensitively, as
+// telegram doesn't always capitalize them the same, and the details after them are ignored:
+//
+//	if errors.Is(err, tgo.ErrBlockedByUser) {
+//		bot.SetBlockedBy(userID, true)
+//	}
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok || t == nil || e.ErrorCode != t.ErrorCode {
+		return false
+	}
+	return strings.HasPrefix(normalizeDescription(e.Description), normalizeDescription(t.Description))
+}
+
+// As sets the *FloodError target if the error is telegram asking to retry the request later.
+func (e *Error) As(target any) bool {
+	flood, ok := target.(**FloodError)
+	if !ok || e.ErrorCode != 429 || e.Parameters == nil {
+		return false
+	}
+
+	*flood = &FloodError{RetryAfter: time.Duration(e.Parameters.RetryAfter) * time.Second, Err: e}
+	return true
+}
+
+func normalizeDescription(description string) string {
+	return strings.ToLower(strings.TrimPrefix(description, "[Error]: "))
+}
+
+// FloodError is the error of the rate-limited requests, which telegram asks to retry after RetryAfter.
+// The API errors can be checked for it by errors.As:
+//
+//	var flood *tgo.FloodError
+//	if errors.As(err, &flood) {
+//		time.Sleep(flood.RetryAfter)
+//	}
+type FloodError struct {
+	RetryAfter time.Duration
+	Err        *Error // the API error of the request
+}
+
+func (e *FloodError) Error() string {
+	return fmt.Sprintf("%s (retry after %s)", e.Err.Description, e.RetryAfter)
+}
+
+func (e *FloodError) Unwrap() error { return e.Err }
+
 // Most well-known telegram bot-api errors.
 //
 // Thanks to github.com/TelegramBotAPI/errors
@@ -28,16 +80,24 @@ var (
 	ErrBotCantSendMessageToBots      = &Error{ErrorCode: 403, Description: "Forbidden: bot can't send messages to bots"}                                                                   // You tried to send a message to another bot. This is not possible.
 	ErrInvalidFileID                 = &Error{ErrorCode: 400, Description: "Bad Request: invalid file id"}                                                                                 // The file id you are trying to retrieve doesn't exist
 	ErrMessageNotModified            = &Error{ErrorCode: 400, Description: "Bad Request: message is not modified"}                                                                         // The current and new message text and reply markups are the same
+	ErrMessageToDeleteNotFound       = &Error{ErrorCode: 400, Description: "Bad Request: message to delete not found"}                                                                     // The message is already deleted, or it never existed
 	ErrTerminatedByOtherLongPoll     = &Error{ErrorCode: 409, Description: "Conflict: terminated by other long poll or webhook"}                                                           // You have already set up a webhook and are trying to get the updates via getUpdates
 	ErrWrongParameterActionInRequest = &Error{ErrorCode: 400, Description: "Bad Request: wrong parameter action in request"}                                                               // Occurs when the action property value is invalid
 	ErrMessageTextIsEmpty            = &Error{ErrorCode: 400, Description: "Bad Request: message text is empty"}                                                                           // The message text is empty or not provided
 	ErrCantUseGetUpdatesWithWebhook  = &Error{ErrorCode: 409, Description: "Conflict: can't use getUpdates method while webhook is active; use deleteWebhook to delete the webhook first"} // You are trying to use getUpdates while a webhook is active
 )
 
+// The kinds of failures, matched by errors.Is.
+var (
+	ErrFlood         = &Error{ErrorCode: 429, Description: "Too Many Requests"}         // The requests are rate-limited; errors.As gives the *FloodError of it.
+	ErrBotKicked     = &Error{ErrorCode: 403, Description: "Forbidden: bot was kicked"} // Bot was kicked from a group, supergroup, or channel.
+	ErrBlockedByUser = ErrBotBlockedByUser                                              // The user have blocked the bot.
+)
+
 // IsRateLimitErr returns rate-limited duration and yes as true if the error is about when you are hitting the API limit.
 func IsRateLimitErr(err error) (retryAfter time.Duration, yes bool) {
-	tgErr, isTgError := err.(*Error)
-	if !isTgError {
+	var tgErr *Error
+	if !errors.As(err, &tgErr) {
 		return 0, false
 	}
 
@@ -53,8 +113,8 @@ func IsRateLimitErr(err error) (retryAfter time.Duration, yes bool) {
 
 // IsGroupMigratedToSupergroupErr returns new ChatID and yes as true if the error is about when a group chat has been converted/migrated to a supergroup.
 func IsGroupMigratedToSupergroupErr(err error) (newChatID int64, yes bool) {
-	tgErr, isTgError := err.(*Error)
-	if !isTgError {
+	var tgErr *Error
+	if !errors.As(err, &tgErr) {
 		return 0, false
 	}
 
@@ -0,0 +1,68 @@
+package tgo
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestErrorIs(t *testing.T) {
+	tests := []struct {
+		err    *Error
+		target *Error
+		want   bool
+	}{
+		{&Error{ErrorCode: 400, Description: "Bad Request: chat not found"}, ErrChatNotFound, true},
+		{&Error{ErrorCode: 400, Description: "Bad Request: user not found"}, ErrUserNotFound, true},
+		{&Error{ErrorCode: 400, Description: "Bad Request: message is not modified: specified new message content and reply markup are exactly the same"}, ErrMessageNotModified, true},
+		{&Error{ErrorCode: 403, Description: "Forbidden: bot was kicked from the supergroup chat"}, ErrBotKicked, true},
+		{&Error{ErrorCode: 403, Description: "Forbidden: bot was kicked from the supergroup chat"}, ErrBotWasKicked, false},
+		{&Error{ErrorCode: 403, Description: "Forbidden: bot was blocked by the user"}, ErrBlockedByUser, true},
+		{&Error{ErrorCode: 429, Description: "Too Many Requests: retry after 5"}, ErrFlood, true},
+		{&Error{ErrorCode: 400, Description: "Bad Request: chat not found"}, ErrUserNotFound, false},
+		{&Error{ErrorCode: 403, Description: "Bad Request: chat not found"}, ErrChatNotFound, false},
+	}
+
+	for _, test := range tests {
+		if got := errors.Is(fmt.Errorf("wrapped: %w", test.err), test.target); got != test.want {
+			t.Errorf("errors.Is(%q, %q) = %v, want %v", test.err.Description, test.target.Description, got, test.want)
+		}
+	}
+}
+
+func TestFloodError(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", &Error{
+		ErrorCode:   429,
+		Description: "Too Many Requests: retry after 5",
+		Parameters:  &ResponseParameters{RetryAfter: 5},
+	})
+
+	var flood *FloodError
+	if !errors.As(err, &flood) {
+		t.Fatal("the rate limit error isn't a FloodError")
+	} else if flood.RetryAfter != 5*time.Second {
+		t.Errorf("RetryAfter = %s, want 5s", flood.RetryAfter)
+	} else if !errors.Is(flood, ErrFlood) {
+		t.Error("the FloodError doesn't unwrap to ErrFlood")
+	}
+
+	if retryAfter, ok := IsRateLimitErr(err); !ok || retryAfter != 5*time.Second {
+		t.Errorf("IsRateLimitErr = %s, %v, want 5s, true", retryAfter, ok)
+	}
+
+	if errors.As(ErrChatNotFound, &flood) {
+		t.Error("ErrChatNotFound is a FloodError")
+	}
+}
+
+func TestRetryDelayOfWrappedFlood(t *testing.T) {
+	flood := &Error{ErrorCode: 429, Description: "Too Many Requests", Parameters: &ResponseParameters{RetryAfter: 7}}
+
+	if delay, ok := retryDelay(fmt.Errorf("intercepted: %w", flood), 0, 3, time.Second); !ok || delay != 7*time.Second {
+		t.Errorf("got a delay of %v, %v; want 7s", delay, ok)
+	}
+	if _, ok := retryDelay(fmt.Errorf("intercepted: %w", &Error{ErrorCode: 400}), 0, 3, time.Second); ok {
+		t.Error("the wrapped bad request is retried")
+	}
+}
@@ -0,0 +1,104 @@
+package filters
+
+import "github.com/haashemi/tgo"
+
+// Private passes the updates from the private chats.
+func Private() tgo.Filter { return chatType("private") }
+
+// Group passes the updates from the basic groups; use Supergroup for the supergroups.
+func Group() tgo.Filter { return chatType("group") }
+
+// Supergroup passes the updates from the supergroups, including the forums.
+func Supergroup() tgo.Filter { return chatType("supergroup") }
+
+// Channel passes the updates from the channels.
+func Channel() tgo.Filter { return chatType("channel") }
+
+// Forum passes the updates from the supergroups with topics enabled.
+func Forum() tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool {
+		chat := extractChat(update)
+		return chat != nil && chat.IsForum
+	})
+}
+
+// ChatIDs passes the updates from the chats with the passed IDs.
+func ChatIDs(IDs ...int64) tgo.Filter {
+	return ChatIDsFunc(func() []int64 { return IDs })
+}
+
+// TopicID passes the messages (and the callback queries of the messages) sent in the forum topic with the passed thread ID.
+func TopicID(threadID int64) tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool {
+		var msg *tgo.Message
+
+		switch data := ExtractUpdate(update).(type) {
+		case *tgo.Message:
+			msg = data
+		case *tgo.CallbackQuery:
+			msg = data.Message
+		}
+
+		return msg != nil && msg.IsTopicMessage && msg.MessageThreadId == threadID
+	})
+}
+
+func chatType(chatType string) tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool {
+		chat := extractChat(update)
+		return chat != nil && chat.Type == chatType
+	})
+}
+
+// extractChat returns the chat which the update happened in, or nil if it's not bound to a chat.
+func extractChat(update *tgo.Update) *tgo.Chat {
+	switch data := ExtractUpdate(update).(type) {
+	case *tgo.Message:
+		return &data.Chat
+	case *tgo.CallbackQuery:
+		if data.Message != nil {
+			return &data.Message.Chat
+		}
+	case *tgo.ChatMemberUpdated:
+		return &data.Chat
+	case *tgo.ChatJoinRequest:
+		return &data.Chat
+	case *tgo.BusinessMessagesDeleted:
+		return &data.Chat
+	case *tgo.MessageReactionUpdated:
+		return &data.Chat
+	case *tgo.MessageReactionCountUpdated:
+		return &data.Chat
+	}
+
+	return nil
+}
+
+// BotBlockedBy passes the my_chat_member updates of the users blocking the bot, with one of the passed ids if there's any.
+func BotBlockedBy(userIDs ...int64) tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool {
+		return update.MyChatMember != nil && update.MyChatMember.BlockedBot() && hasID(update.MyChatMember.Chat.Id, userIDs)
+	})
+}
+
+// BotUnblockedBy passes the my_chat_member updates of the users unblocking the bot, with one of the passed ids if there's any.
+func BotUnblockedBy(userIDs ...int64) tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool {
+		return update.MyChatMember != nil && update.MyChatMember.UnblockedBot() && hasID(update.MyChatMember.Chat.Id, userIDs)
+	})
+}
+
+// hasID returns true if the ids are empty or contain the id.
+func hasID(id int64, ids []int64) bool {
+	if len(ids) == 0 {
+		return true
+	}
+
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+
+	return false
+}
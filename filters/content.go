@@ -0,0 +1,101 @@
+package filters
+
+import "github.com/haashemi/tgo"
+
+// WebAppData passes the messages containing the data sent by a Web App,
+// opened from one of the keyboard buttons with the passed texts if there's any.
+func WebAppData(buttonTexts ...string) tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool {
+		msg, ok := ExtractUpdate(update).(*tgo.Message)
+		if !ok || msg.WebAppData == nil {
+			return false
+		}
+
+		return len(buttonTexts) == 0 || contains(buttonTexts, msg.WebAppData.ButtonText)
+	})
+}
+
+// Photo passes the messages containing a photo.
+func Photo() tgo.Filter {
+	return messageHas(func(msg *tgo.Message) bool { return len(msg.Photo) != 0 })
+}
+
+// Video passes the messages containing a video.
+func Video() tgo.Filter {
+	return messageHas(func(msg *tgo.Message) bool { return msg.Video != nil })
+}
+
+// Document passes the messages containing a general file.
+func Document() tgo.Filter {
+	return messageHas(func(msg *tgo.Message) bool { return msg.Document != nil })
+}
+
+// Audio passes the messages containing an audio file.
+func Audio() tgo.Filter {
+	return messageHas(func(msg *tgo.Message) bool { return msg.Audio != nil })
+}
+
+// Voice passes the messages containing a voice message.
+func Voice() tgo.Filter {
+	return messageHas(func(msg *tgo.Message) bool { return msg.Voice != nil })
+}
+
+// VideoNote passes the messages containing a video note.
+func VideoNote() tgo.Filter {
+	return messageHas(func(msg *tgo.Message) bool { return msg.VideoNote != nil })
+}
+
+// Sticker passes the messages containing a sticker.
+func Sticker() tgo.Filter {
+	return messageHas(func(msg *tgo.Message) bool { return msg.Sticker != nil })
+}
+
+// Animation passes the messages containing an animation (GIF or H.264/MPEG-4 AVC video without sound).
+func Animation() tgo.Filter {
+	return messageHas(func(msg *tgo.Message) bool { return msg.Animation != nil })
+}
+
+// Contact passes the messages containing a shared contact.
+func Contact() tgo.Filter {
+	return messageHas(func(msg *tgo.Message) bool { return msg.Contact != nil })
+}
+
+// Location passes the messages containing a shared location, including the venues.
+func Location() tgo.Filter {
+	return messageHas(func(msg *tgo.Message) bool { return msg.Location != nil })
+}
+
+// Poll passes the messages containing a native poll.
+func Poll() tgo.Filter {
+	return messageHas(func(msg *tgo.Message) bool { return msg.Poll != nil })
+}
+
+// Dice passes the messages containing a dice, with one of the passed emojis if there's any.
+func Dice(emojis ...string) tgo.Filter {
+	return messageHas(func(msg *tgo.Message) bool {
+		return msg.Dice != nil && (len(emojis) == 0 || contains(emojis, msg.Dice.Emoji))
+	})
+}
+
+// NewChatMembers passes the service messages about the new members added to the group.
+func NewChatMembers() tgo.Filter {
+	return messageHas(func(msg *tgo.Message) bool { return len(msg.NewChatMembers) != 0 })
+}
+
+// AutoDeleteTimerChanged passes the service messages about a change in the chat's auto-delete timer.
+func AutoDeleteTimerChanged() tgo.Filter {
+	return messageHas(func(msg *tgo.Message) bool { return msg.MessageAutoDeleteTimerChanged != nil })
+}
+
+// MessagePinned passes the service messages about a message pinned in the chat.
+func MessagePinned() tgo.Filter {
+	return messageHas(func(msg *tgo.Message) bool { return msg.PinnedMessage != nil })
+}
+
+// messageHas passes the updates with a message which the check passes for.
+func messageHas(check func(msg *tgo.Message) bool) tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool {
+		msg, ok := ExtractUpdate(update).(*tgo.Message)
+		return ok && check(msg)
+	})
+}
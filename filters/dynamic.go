@@ -0,0 +1,163 @@
+package filters
+
+import (
+	"sync"
+
+	"github.com/haashemi/tgo"
+)
+
+// IDStore is a set of ids which the filters look up when they're checked, such as the administrators
+// of the bot loaded from a database, so its changes are respected without adding the routes again.
+type IDStore interface {
+	Contains(id int64) (bool, error)
+}
+
+// IDSet is an in-memory IDStore, safe for concurrent use; its ids may be replaced at any time, such as
+// by the ones reloaded from a database periodically.
+type IDSet struct {
+	mut sync.RWMutex
+	ids map[int64]struct{}
+}
+
+// NewIDSet returns an IDSet of the ids.
+func NewIDSet(ids ...int64) *IDSet {
+	s := &IDSet{}
+	s.Set(ids...)
+	return s
+}
+
+// Set replaces the ids of the set.
+func (s *IDSet) Set(ids ...int64) {
+	set := make(map[int64]struct{}, len(ids))
+	for _, id := range ids {
+		set[id] = struct{}{}
+	}
+
+	s.mut.Lock()
+	s.ids = set
+	s.mut.Unlock()
+}
+
+// Add adds the ids to the set.
+func (s *IDSet) Add(ids ...int64) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if s.ids == nil {
+		s.ids = make(map[int64]struct{}, len(ids))
+	}
+	for _, id := range ids {
+		s.ids[id] = struct{}{}
+	}
+}
+
+// Remove removes the ids from the set.
+func (s *IDSet) Remove(ids ...int64) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	for _, id := range ids {
+		delete(s.ids, id)
+	}
+}
+
+// Contains implements the IDStore interface.
+func (s *IDSet) Contains(id int64) (bool, error) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	_, ok := s.ids[id]
+	return ok, nil
+}
+
+// Dynamic passes the updates which the filter returned by resolve passes; it's called whenever the
+// filter is checked, so the filter may depend on the runtime values, such as the bot's settings:
+//
+//	router.Handle(filters.Dynamic(func() tgo.Filter {
+//		if settings.Maintenance() {
+//			return filters.Whitelist(settings.Admins()...)
+//		}
+//		return filters.True()
+//	}), handler)
+func Dynamic(resolve func() tgo.Filter) tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool { return resolve().Check(update) })
+}
+
+// WhitelistFunc is the Whitelist of the ids returned by the function whenever it's checked.
+func WhitelistFunc(ids func() []int64) tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool {
+		senderID, ok := whitelistSenderID(update)
+		return ok && containsID(ids(), senderID)
+	})
+}
+
+// BlacklistFunc is the Blacklist of the ids returned by the function whenever it's checked.
+func BlacklistFunc(ids func() []int64) tgo.Filter { return Not(WhitelistFunc(ids)) }
+
+// WhitelistFrom is the Whitelist of the ids of the store, looked up whenever it's checked:
+//
+//	admins := filters.NewIDSet(loadAdmins()...)
+//	router.Handle(filters.And(filters.Command("stats", botUsername), filters.WhitelistFrom(admins)), handleStats)
+//	// later, once the administrators are changed:
+//	admins.Set(loadAdmins()...)
+//
+// The store's failures don't pass the filter.
+func WhitelistFrom(store IDStore) tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool {
+		senderID, ok := whitelistSenderID(update)
+		if !ok {
+			return false
+		}
+
+		contains, err := store.Contains(senderID)
+		return err == nil && contains
+	})
+}
+
+// ChatIDsFunc is the ChatIDs of the ids returned by the function whenever it's checked.
+func ChatIDsFunc(ids func() []int64) tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool {
+		chat := extractChat(update)
+		return chat != nil && containsID(ids(), chat.Id)
+	})
+}
+
+// ChatIDsFrom is the ChatIDs of the ids of the store, looked up whenever it's checked; the store's
+// failures don't pass the filter.
+func ChatIDsFrom(store IDStore) tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool {
+		chat := extractChat(update)
+		if chat == nil {
+			return false
+		}
+
+		contains, err := store.Contains(chat.Id)
+		return err == nil && contains
+	})
+}
+
+// whitelistSenderID returns the sender id of the message or callback query, and false for the other updates.
+func whitelistSenderID(update *tgo.Update) (int64, bool) {
+	switch data := ExtractUpdate(update).(type) {
+	case *tgo.Message:
+		if data.From != nil {
+			return data.From.Id, true
+		}
+		return 0, true
+	case *tgo.CallbackQuery:
+		return data.From.Id, true
+	}
+
+	// avoid unnecessary id comparisons.
+	return 0, false
+}
+
+// containsID returns true if the ids contain the id.
+func containsID(ids []int64, id int64) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package filters_test
+
+import (
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/filters"
+)
+
+func TestDynamicFilters(t *testing.T) {
+	update := &tgo.Update{Message: &tgo.Message{From: &tgo.User{Id: 7}, Chat: tgo.Chat{Id: -100}}}
+
+	admins := []int64{1}
+	whitelist := filters.WhitelistFunc(func() []int64 { return admins })
+	if whitelist.Check(update) {
+		t.Fatal("the sender passed before being whitelisted")
+	}
+	admins = append(admins, 7)
+	if !whitelist.Check(update) {
+		t.Fatal("the whitelisted sender didn't pass")
+	}
+
+	chats := filters.NewIDSet()
+	chatFilter := filters.ChatIDsFrom(chats)
+	if chatFilter.Check(update) {
+		t.Fatal("the chat passed before being added")
+	}
+	chats.Add(-100)
+	if !chatFilter.Check(update) {
+		t.Fatal("the added chat didn't pass")
+	}
+	chats.Set(1, 2)
+	if chatFilter.Check(update) {
+		t.Fatal("the replaced chat passed")
+	}
+
+	maintenance := true
+	dynamic := filters.Dynamic(func() tgo.Filter {
+		if maintenance {
+			return filters.False()
+		}
+		return filters.True()
+	})
+	if dynamic.Check(update) {
+		t.Fatal("the update passed in the maintenance")
+	}
+	maintenance = false
+	if !dynamic.Check(update) {
+		t.Fatal("the update didn't pass after the maintenance")
+	}
+}
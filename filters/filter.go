@@ -102,29 +102,7 @@ func Regex(reg *regexp.Regexp) tgo.Filter {
 
 // Whitelist compares IDs with the sender-id of the message or callback query. returns true if sender-id is in the blacklist.
 func Whitelist(IDs ...int64) tgo.Filter {
-	return NewFilter(func(update *tgo.Update) bool {
-		var senderID int64
-
-		switch data := ExtractUpdate(update).(type) {
-		case *tgo.Message:
-			if data.From != nil {
-				senderID = data.From.Id
-			}
-		case *tgo.CallbackQuery:
-			senderID = data.From.Id
-		default:
-			// avoid unnecessary id comparisons.
-			return false
-		}
-
-		for _, id := range IDs {
-			if id == senderID {
-				return true
-			}
-		}
-
-		return false
-	})
+	return WhitelistFunc(func() []int64 { return IDs })
 }
 
 // Blacklist compares IDs with the sender-id of the message or callback query. returns false if sender-id is in the blacklist.
@@ -163,3 +141,117 @@ func Commands(prefix, botUsername string, cmds ...string) tgo.Filter {
 		return false
 	})
 }
+
+// regexMatchesKey is the update value key of the RegexCapture's capture groups.
+const regexMatchesKey = "filters.regex_matches"
+
+type regexMatches struct {
+	indexed []string
+	named   map[string]string
+}
+
+// RegexCapture works like Regex, and also stores the capture groups of the match for the update,
+// to be retrieved by Matches and NamedMatches, or ctx.Matches() in the handlers.
+func RegexCapture(reg *regexp.Regexp) tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool {
+		indexed := reg.FindStringSubmatch(ExtractUpdateText(update))
+		if indexed == nil {
+			return false
+		}
+
+		named := make(map[string]string)
+		for i, name := range reg.SubexpNames() {
+			if name != "" {
+				named[name] = indexed[i]
+			}
+		}
+
+		tgo.SetUpdateValue(update, regexMatchesKey, &regexMatches{indexed: indexed, named: named})
+		return true
+	})
+}
+
+// Matches returns the capture groups of the last RegexCapture which the update passed, or nil.
+// The first one is the whole match.
+func Matches(update *tgo.Update) []string {
+	if matches, ok := tgo.GetUpdateValue(update, regexMatchesKey); ok {
+		return matches.(*regexMatches).indexed
+	}
+	return nil
+}
+
+// NamedMatches returns the named capture groups of the last RegexCapture which the update passed, or nil.
+func NamedMatches(update *tgo.Update) map[string]string {
+	if matches, ok := tgo.GetUpdateValue(update, regexMatchesKey); ok {
+		return matches.(*regexMatches).named
+	}
+	return nil
+}
+
+// startParamKey is the update value key of the StartPayload's parameter.
+const startParamKey = "filters.start_param"
+
+// StartPayload passes the /start commands whose deep-link parameter begins with prefix, and stores
+// the rest of the parameter for the update, to be retrieved by StartParam or ctx.StartParam() in the handlers.
+func StartPayload(prefix string) tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool {
+		msg, ok := ExtractUpdate(update).(*tgo.Message)
+		if !ok || !strings.HasPrefix(msg.Text, "/start") {
+			return false
+		}
+
+		command, param, _ := strings.Cut(msg.Text, " ")
+		if command != "/start" && !strings.HasPrefix(command, "/start@") {
+			return false
+		}
+
+		param = strings.TrimSpace(param)
+		if param == "" || !strings.HasPrefix(param, prefix) {
+			return false
+		}
+
+		tgo.SetUpdateValue(update, startParamKey, strings.TrimPrefix(param, prefix))
+		return true
+	})
+}
+
+// startIDKey is the update value key of the StartID's decoded id.
+const startIDKey = "filters.start_id"
+
+// StartID passes the /start commands whose deep-link parameter is the prefix followed by an id encoded
+// by the codec, such as by bot.DeepLinkID, and stores the decoded id for the update, to be retrieved
+// by StartIDValue in the handlers. The parameters which fail the codec's verification don't pass.
+func StartID(prefix string, codec tgo.IDCodec) tgo.Filter {
+	payload := StartPayload(prefix)
+
+	return NewFilter(func(update *tgo.Update) bool {
+		if !payload.Check(update) {
+			return false
+		}
+
+		id, err := codec.DecodeID(StartParam(update))
+		if err != nil {
+			return false
+		}
+
+		tgo.SetUpdateValue(update, startIDKey, id)
+		return true
+	})
+}
+
+// StartIDValue returns the id decoded by the last StartID which the update passed.
+func StartIDValue(update *tgo.Update) (id int64, ok bool) {
+	if id, ok := tgo.GetUpdateValue(update, startIDKey); ok {
+		return id.(int64), true
+	}
+	return 0, false
+}
+
+// StartParam returns the deep-link parameter, without the prefix, of the last StartPayload which the update passed.
+// Use tgo.DecodeStartPayload to decode it if it's made by tgo.EncodeStartPayload.
+func StartParam(update *tgo.Update) string {
+	if param, ok := tgo.GetUpdateValue(update, startParamKey); ok {
+		return param.(string)
+	}
+	return ""
+}
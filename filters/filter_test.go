@@ -0,0 +1,91 @@
+package filters_test
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/filters"
+)
+
+func TestRegexCapture(t *testing.T) {
+	filter := filters.RegexCapture(regexp.MustCompile(`^/ban (?P<user>\d+) (\w+)$`))
+
+	update := &tgo.Update{Message: &tgo.Message{Text: "/ban 42 spam"}}
+	defer tgo.ForgetUpdate(update)
+
+	if !filter.Check(update) {
+		t.Fatal("filter didn't pass")
+	}
+	if got, want := filters.Matches(update), []string{"/ban 42 spam", "42", "spam"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got matches %q, want %q", got, want)
+	}
+	if got := filters.NamedMatches(update)["user"]; got != "42" {
+		t.Errorf("got user %q, want 42", got)
+	}
+
+	other := &tgo.Update{Message: &tgo.Message{Text: "/ban everyone"}}
+	if filter.Check(other) || filters.Matches(other) != nil {
+		t.Error("stored matches of the failed filter")
+	}
+}
+
+func TestFuzzy(t *testing.T) {
+	filter := filters.Fuzzy("Hello", 1)
+
+	for text, want := range map[string]bool{
+		"hello":   true,
+		" HELO ":  true,
+		"hallo":   true,
+		"hellooo": false,
+		"world":   false,
+	} {
+		update := &tgo.Update{Message: &tgo.Message{Text: text}}
+		if got := filter.Check(update); got != want {
+			t.Errorf("%q: got %v, want %v", text, got, want)
+		}
+	}
+}
+
+func TestStartPayload(t *testing.T) {
+	filter := filters.StartPayload("ref_")
+
+	for text, want := range map[string]string{
+		"/start ref_42":        "42",
+		"/start@my_bot ref_ab": "ab",
+		"/start other":         "",
+		"/start":               "",
+		"/starter ref_42":      "",
+	} {
+		update := &tgo.Update{Message: &tgo.Message{Text: text}}
+		if passed := filter.Check(update); passed != (want != "") {
+			t.Errorf("%q: passed is %v", text, passed)
+		}
+		if got := filters.StartParam(update); got != want {
+			t.Errorf("%q: got param %q, want %q", text, got, want)
+		}
+		tgo.ForgetUpdate(update)
+	}
+}
+
+func TestStartID(t *testing.T) {
+	codec := tgo.NewHashIDs([]byte("secret"))
+	filter := filters.StartID("item_", codec)
+
+	update := &tgo.Update{Message: &tgo.Message{Text: "/start item_" + codec.EncodeID(42)}}
+	defer tgo.ForgetUpdate(update)
+
+	if !filter.Check(update) {
+		t.Fatal("encoded id didn't pass")
+	} else if id, ok := filters.StartIDValue(update); !ok || id != 42 {
+		t.Errorf("got id %d, %v", id, ok)
+	}
+
+	madeUp := &tgo.Update{Message: &tgo.Message{Text: "/start item_42"}}
+	defer tgo.ForgetUpdate(madeUp)
+
+	if filter.Check(madeUp) {
+		t.Error("made-up id passed")
+	}
+}
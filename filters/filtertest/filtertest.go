@@ -0,0 +1,245 @@
+// Package filtertest builds the synthetic updates of each kind, and runs the table tests of the filters
+// against them, so the filters are tested without writing the updates by hand:
+//
+//	filtertest.Suite{
+//		{Name: "admin", Update: filtertest.Message("/ban").From(1).Supergroup(-100).Build(), Want: true},
+//		{Name: "member", Update: filtertest.Message("/ban").From(2).Supergroup(-100).Build(), Want: false},
+//		{Name: "callback", Update: filtertest.Callback("ban").From(1).Build(), Want: true},
+//	}.Run(t, filters.Whitelist(1))
+package filtertest
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/haashemi/tgo"
+)
+
+// The defaults of the built updates.
+const (
+	UserID    int64 = 1 // the id of the sender, and of its private chat
+	MessageID int64 = 1 // the id of the messages
+)
+
+// Builder builds a synthetic update; its methods change the update's message, or the message of its
+// callback query, and return the builder, so the calls can be chained.
+type Builder struct {
+	update *tgo.Update
+
+	// msg is the update's message, or the message of its callback query; inline is its inline query.
+	msg    *tgo.Message
+	inline *tgo.InlineQuery
+}
+
+// newMessage returns a message of the text from the default user, in their private chat. The texts
+// starting with a slash get their bot_command entity.
+func newMessage(text string) *tgo.Message {
+	msg := &tgo.Message{
+		MessageId: MessageID,
+		Date:      time.Now().Unix(),
+		From:      &tgo.User{Id: UserID, FirstName: "Test"},
+		Chat:      tgo.Chat{Id: UserID, Type: "private", FirstName: "Test"},
+		Text:      text,
+	}
+
+	if strings.HasPrefix(text, "/") {
+		command := strings.Fields(text)[0]
+		msg.Entities = []*tgo.MessageEntity{{Type: "bot_command", Length: int64(len(utf16.Encode([]rune(command))))}}
+	}
+	return msg
+}
+
+// Message returns a builder of the update of a new message of the text.
+func Message(text string) *Builder {
+	msg := newMessage(text)
+	return &Builder{update: &tgo.Update{UpdateId: 1, Message: msg}, msg: msg}
+}
+
+// EditedMessage returns a builder of the update of an edited message of the text.
+func EditedMessage(text string) *Builder {
+	msg := newMessage(text)
+	msg.EditDate = msg.Date
+	return &Builder{update: &tgo.Update{UpdateId: 1, EditedMessage: msg}, msg: msg}
+}
+
+// ChannelPost returns a builder of the update of a new post of the text in a channel.
+func ChannelPost(text string) *Builder {
+	msg := newMessage(text)
+	msg.From, msg.Chat = nil, tgo.Chat{Id: -1001, Type: "channel", Title: "Test"}
+	msg.SenderChat = &msg.Chat
+	return &Builder{update: &tgo.Update{UpdateId: 1, ChannelPost: msg}, msg: msg}
+}
+
+// EditedChannelPost returns a builder of the update of an edited post of the text in a channel.
+func EditedChannelPost(text string) *Builder {
+	b := ChannelPost(text)
+	b.msg.EditDate = b.msg.Date
+	b.update.ChannelPost, b.update.EditedChannelPost = nil, b.msg
+	return b
+}
+
+// Callback returns a builder of the update of a callback query of the data, pressed on a message of
+// the bot in the private chat of its sender.
+func Callback(data string) *Builder {
+	msg := newMessage("")
+	msg.From = &tgo.User{Id: 123456, IsBot: true, FirstName: "Bot"}
+
+	query := &tgo.CallbackQuery{Id: "1", From: tgo.User{Id: UserID, FirstName: "Test"}, Message: msg, ChatInstance: "1", Data: data}
+	return &Builder{update: &tgo.Update{UpdateId: 1, CallbackQuery: query}, msg: msg}
+}
+
+// Inline returns a builder of the update of an inline query of the text.
+func Inline(query string) *Builder {
+	inline := &tgo.InlineQuery{Id: "1", From: tgo.User{Id: UserID, FirstName: "Test"}, Query: query, ChatType: "sender"}
+	return &Builder{update: &tgo.Update{UpdateId: 1, InlineQuery: inline}, inline: inline}
+}
+
+// ID sets the update's id.
+func (b *Builder) ID(updateID int64) *Builder {
+	b.update.UpdateId = updateID
+	return b
+}
+
+// From sets the sender's id; the private chats are of the sender.
+func (b *Builder) From(userID int64) *Builder {
+	return b.FromUser(&tgo.User{Id: userID, FirstName: "Test"})
+}
+
+// FromUser sets the sender; the private chats are of the sender.
+func (b *Builder) FromUser(user *tgo.User) *Builder {
+	switch {
+	case b.update.CallbackQuery != nil:
+		b.update.CallbackQuery.From = *user
+		if b.msg.Chat.Type == "private" {
+			b.msg.Chat.Id = user.Id
+		}
+	case b.inline != nil:
+		b.inline.From = *user
+	default:
+		b.msg.From = user
+		if b.msg.Chat.Type == "private" {
+			b.msg.Chat.Id = user.Id
+		}
+	}
+	return b
+}
+
+// SenderChat sets the chat on behalf of which the message is sent, such as the anonymous administrators'
+// group or a linked channel; the message's sender is the one telegram sets for them.
+func (b *Builder) SenderChat(chat *tgo.Chat) *Builder {
+	if b.msg != nil {
+		b.msg.SenderChat = chat
+		b.msg.From = &tgo.User{Id: 1087968824, IsBot: true, FirstName: "Group", Username: "GroupAnonymousBot"}
+		if chat.Type == "channel" && chat.Id != b.msg.Chat.Id {
+			b.msg.From = &tgo.User{Id: 136817688, IsBot: true, FirstName: "Channel", Username: "Channel_Bot"}
+		}
+	}
+	return b
+}
+
+// Chat sets the chat of the message, or of the message of the callback query; the inline queries get
+// its type.
+func (b *Builder) Chat(chat tgo.Chat) *Builder {
+	if b.msg != nil {
+		b.msg.Chat = chat
+	}
+	if b.inline != nil {
+		b.inline.ChatType = chat.Type
+	}
+	return b
+}
+
+// Group sets the chat to be the basic group of the id.
+func (b *Builder) Group(chatID int64) *Builder {
+	return b.Chat(tgo.Chat{Id: chatID, Type: "group", Title: "Test"})
+}
+
+// Supergroup sets the chat to be the supergroup of the id.
+func (b *Builder) Supergroup(chatID int64) *Builder {
+	return b.Chat(tgo.Chat{Id: chatID, Type: "supergroup", Title: "Test"})
+}
+
+// Topic sets the chat to be a forum, and the message to be sent in its topic of the thread id.
+func (b *Builder) Topic(threadID int64) *Builder {
+	if b.msg != nil {
+		b.msg.Chat.IsForum = true
+		b.msg.MessageThreadId = threadID
+		b.msg.IsTopicMessage = true
+	}
+	return b
+}
+
+// Caption makes the message a photo of the caption, instead of a text one.
+func (b *Builder) Caption(caption string) *Builder {
+	if b.msg != nil {
+		b.msg.Photo = []*tgo.PhotoSize{{FileId: "photo", FileUniqueId: "photo", Width: 90, Height: 90}}
+		b.msg.Caption, b.msg.CaptionEntities = caption, b.msg.Entities
+		b.msg.Text, b.msg.Entities = "", nil
+	}
+	return b
+}
+
+// Entities sets the entities of the message's text, or of its caption.
+func (b *Builder) Entities(entities ...*tgo.MessageEntity) *Builder {
+	switch {
+	case b.msg == nil:
+	case b.msg.Caption != "":
+		b.msg.CaptionEntities = entities
+	default:
+		b.msg.Entities = entities
+	}
+	return b
+}
+
+// ReplyTo makes the message a reply to the message.
+func (b *Builder) ReplyTo(msg *tgo.Message) *Builder {
+	if b.msg != nil {
+		b.msg.ReplyToMessage = msg
+	}
+	return b
+}
+
+// Edit changes the update with the function, for the fields which the builder doesn't set.
+func (b *Builder) Edit(edit func(update *tgo.Update)) *Builder {
+	edit(b.update)
+	return b
+}
+
+// Build returns the built update. Each call of the builder's methods changes the same update.
+func (b *Builder) Build() *tgo.Update { return b.update }
+
+// Case is a case of the table tests of a filter.
+type Case struct {
+	Name   string      // the name of the subtest
+	Update *tgo.Update // the checked update
+	Want   bool        // whether the filter should pass the update
+}
+
+// Suite is a table of the cases of a filter; the suites may be combined by append, such as the shared
+// cases of the filters of the same kind.
+type Suite []Case
+
+// Run checks the filter against the update of each case in its own subtest. The data which the filters
+// store for the updates, such as their regex matches, are forgotten afterwards.
+func (s Suite) Run(t *testing.T, filter tgo.Filter) {
+	t.Helper()
+
+	for _, c := range s {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			defer tgo.ForgetUpdate(c.Update)
+
+			if got := filter.Check(c.Update); got != c.Want {
+				t.Errorf("the filter returned %v, want %v", got, c.Want)
+			}
+		})
+	}
+}
+
+// Run checks the filter against the cases; see Suite.Run.
+func Run(t *testing.T, filter tgo.Filter, cases ...Case) {
+	t.Helper()
+	Suite(cases).Run(t, filter)
+}
@@ -0,0 +1,49 @@
+package filtertest_test
+
+import (
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/filters"
+	"github.com/haashemi/tgo/filters/filtertest"
+)
+
+func TestBuilders(t *testing.T) {
+	t.Run("text", func(t *testing.T) {
+		filtertest.Suite{
+			{Name: "message", Update: filtertest.Message("hi").Build(), Want: true},
+			{Name: "edited", Update: filtertest.EditedMessage("hi").Build(), Want: true},
+			{Name: "post", Update: filtertest.ChannelPost("hi").Build(), Want: true},
+			{Name: "edited post", Update: filtertest.EditedChannelPost("hi").Build(), Want: true},
+			{Name: "callback", Update: filtertest.Callback("hi").Build(), Want: true},
+			{Name: "inline", Update: filtertest.Inline("hi").Build(), Want: true},
+			{Name: "caption", Update: filtertest.Message("").Caption("hi").Build(), Want: true},
+			{Name: "other", Update: filtertest.Message("bye").Build(), Want: false},
+		}.Run(t, filters.Text("hi"))
+	})
+
+	t.Run("kinds", func(t *testing.T) {
+		filtertest.Run(t, filters.IsEditedChannelPost(),
+			filtertest.Case{Name: "edited post", Update: filtertest.EditedChannelPost("hi").Build(), Want: true},
+			filtertest.Case{Name: "post", Update: filtertest.ChannelPost("hi").Build(), Want: false},
+		)
+	})
+
+	t.Run("sender and chat", func(t *testing.T) {
+		filtertest.Run(t, filters.And(filters.Whitelist(7), filters.Supergroup(), filters.TopicID(3)),
+			filtertest.Case{Name: "topic", Update: filtertest.Message("hi").From(7).Supergroup(-100).Topic(3).Build(), Want: true},
+			filtertest.Case{Name: "callback", Update: filtertest.Callback("hi").From(7).Supergroup(-100).Topic(3).Build(), Want: true},
+			filtertest.Case{Name: "other topic", Update: filtertest.Message("hi").From(7).Supergroup(-100).Topic(4).Build(), Want: false},
+			filtertest.Case{Name: "other sender", Update: filtertest.Message("hi").From(8).Supergroup(-100).Topic(3).Build(), Want: false},
+			filtertest.Case{Name: "private", Update: filtertest.Message("hi").From(7).Build(), Want: false},
+		)
+	})
+
+	update := filtertest.Message("/start@test_bot now").SenderChat(&tgo.Chat{Id: -100, Type: "supergroup"}).Build()
+	if entity := update.Message.Entities[0]; entity.Type != "bot_command" || entity.Length != 15 {
+		t.Errorf("unexpected command entity %+v", entity)
+	}
+	if !filters.SenderChat().Check(update) || !filters.Command("start", "test_bot").Check(update) {
+		t.Error("the anonymous command doesn't pass")
+	}
+}
@@ -0,0 +1,64 @@
+package filters
+
+import "github.com/haashemi/tgo"
+
+// SuccessfulPayment passes the service messages about successful payments,
+// with one of the passed invoice payloads if there's any.
+func SuccessfulPayment(payloads ...string) tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool {
+		msg, ok := ExtractUpdate(update).(*tgo.Message)
+		if !ok || msg.SuccessfulPayment == nil {
+			return false
+		}
+
+		return len(payloads) == 0 || contains(payloads, msg.SuccessfulPayment.InvoicePayload)
+	})
+}
+
+// StarPayment passes the successful payments and pre-checkout queries which are paid in telegram stars.
+func StarPayment() tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool {
+		switch data := ExtractUpdate(update).(type) {
+		case *tgo.Message:
+			return data.SuccessfulPayment != nil && data.SuccessfulPayment.IsStars()
+		case *tgo.PreCheckoutQuery:
+			return data.IsStars()
+		}
+
+		return false
+	})
+}
+
+// InvoicePayload passes the pre-checkout queries, shipping queries, and successful payments
+// with one of the passed invoice payloads.
+func InvoicePayload(payloads ...string) tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool {
+		switch data := ExtractUpdate(update).(type) {
+		case *tgo.Message:
+			return data.SuccessfulPayment != nil && contains(payloads, data.SuccessfulPayment.InvoicePayload)
+		case *tgo.PreCheckoutQuery:
+			return contains(payloads, data.InvoicePayload)
+		case *tgo.ShippingQuery:
+			return contains(payloads, data.InvoicePayload)
+		}
+
+		return false
+	})
+}
+
+func contains(items []string, item string) bool {
+	for _, x := range items {
+		if x == item {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PaidMediaPayload passes the paid media purchases with one of the passed payloads, or any purchase if there's none.
+func PaidMediaPayload(payloads ...string) tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool {
+		return update.PurchasedPaidMedia != nil && (len(payloads) == 0 || contains(payloads, update.PurchasedPaidMedia.PaidMediaPayload))
+	})
+}
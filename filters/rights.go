@@ -0,0 +1,162 @@
+package filters
+
+import "github.com/haashemi/tgo"
+
+// Right is an administrator right, named by its field in the chat members' JSON.
+type Right string
+
+const (
+	CanManageChat       Right = "can_manage_chat"
+	CanDeleteMessages   Right = "can_delete_messages"
+	CanManageVideoChats Right = "can_manage_video_chats"
+	CanRestrictMembers  Right = "can_restrict_members"
+	CanPromoteMembers   Right = "can_promote_members"
+	CanChangeInfo       Right = "can_change_info"
+	CanInviteUsers      Right = "can_invite_users"
+	CanPostMessages     Right = "can_post_messages"
+	CanEditMessages     Right = "can_edit_messages"
+	CanPinMessages      Right = "can_pin_messages"
+	CanPostStories      Right = "can_post_stories"
+	CanEditStories      Right = "can_edit_stories"
+	CanDeleteStories    Right = "can_delete_stories"
+	CanManageTopics     Right = "can_manage_topics"
+)
+
+// grantedTo reports whether the administrator has the right.
+func (r Right) grantedTo(admin *tgo.ChatMemberAdministrator) bool {
+	switch r {
+	case CanManageChat:
+		return admin.CanManageChat
+	case CanDeleteMessages:
+		return admin.CanDeleteMessages
+	case CanManageVideoChats:
+		return admin.CanManageVideoChats
+	case CanRestrictMembers:
+		return admin.CanRestrictMembers
+	case CanPromoteMembers:
+		return admin.CanPromoteMembers
+	case CanChangeInfo:
+		return admin.CanChangeInfo
+	case CanInviteUsers:
+		return admin.CanInviteUsers
+	case CanPostMessages:
+		return admin.CanPostMessages
+	case CanEditMessages:
+		return admin.CanEditMessages
+	case CanPinMessages:
+		return admin.CanPinMessages
+	case CanPostStories:
+		return admin.CanPostStories
+	case CanEditStories:
+		return admin.CanEditStories
+	case CanDeleteStories:
+		return admin.CanDeleteStories
+	case CanManageTopics:
+		return admin.CanManageTopics
+	}
+	return false
+}
+
+// RightsOptions configures RequireRights. The zero value is valid and uses the defaults.
+type RightsOptions struct {
+	// Denied, if not empty, is replied to the messages of the senders lacking the rights, and shown
+	// as the answer of their callback queries.
+	Denied string
+
+	// AllowAnonymous lets the messages of the anonymous administrators pass. Telegram doesn't tell
+	// which administrator sent them, so their rights can't be checked, and they don't pass by default.
+	AllowAnonymous bool
+
+	// OnError, if not nil, is called when fetching the sender's membership of a chat fails.
+	// The sender doesn't pass the filter in this case.
+	OnError func(chatID int64, err error)
+}
+
+// RequireRights passes the messages and callback queries of the chat's administrators who have all
+// the rights, and the chat's owner. Their memberships are read by bot.CachedChatMember, so pass an
+// Options.ChatCache to cache them until they change:
+//
+//	router.Handle(filters.And(filters.Command("ban", botUsername), filters.RequireRights(bot, filters.RightsOptions{
+//		Denied: "You can't ban the members here.",
+//	}, filters.CanRestrictMembers)), handleBan)
+//
+// As the denial is sent whenever the filter doesn't pass, it should be checked after the route's other
+// filters, and not be combined by Or or Not.
+func RequireRights(bot *tgo.Bot, opts RightsOptions, rights ...Right) tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool {
+		chat := extractChat(update)
+		if chat == nil || chat.Type == "private" {
+			return false
+		}
+
+		if msg, ok := ExtractUpdate(update).(*tgo.Message); ok && msg.SenderChat != nil {
+			if msg.SenderChat.Id == chat.Id && opts.AllowAnonymous {
+				return true
+			}
+			deny(bot, update, opts.Denied)
+			return false
+		}
+
+		sender := extractSender(update)
+		if sender == nil {
+			return false
+		}
+
+		member, err := bot.CachedChatMember(chat.Id, sender.Id)
+		if err != nil {
+			if opts.OnError != nil {
+				opts.OnError(chat.Id, err)
+			}
+			return false
+		}
+
+		if hasRights(member, rights) {
+			return true
+		}
+
+		deny(bot, update, opts.Denied)
+		return false
+	})
+}
+
+// hasRights reports whether the member is the owner, or an administrator having all the rights.
+func hasRights(member tgo.ChatMember, rights []Right) bool {
+	switch member := member.(type) {
+	case *tgo.ChatMemberOwner:
+		return true
+
+	case *tgo.ChatMemberAdministrator:
+		for _, right := range rights {
+			if !right.grantedTo(member) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return false
+}
+
+// deny replies the denial to the update, if there's one.
+func deny(bot *tgo.Bot, update *tgo.Update, denied string) {
+	if denied == "" {
+		return
+	}
+
+	var err error
+	switch data := ExtractUpdate(update).(type) {
+	case *tgo.Message:
+		_, err = bot.Send(&tgo.SendMessage{
+			ChatId:           tgo.ID(data.Chat.Id),
+			MessageThreadId:  data.MessageThreadId,
+			Text:             denied,
+			ReplyToMessageId: data.MessageId,
+		})
+	case *tgo.CallbackQuery:
+		_, err = bot.AnswerCallbackQuery(&tgo.AnswerCallbackQuery{CallbackQueryId: data.Id, Text: denied, ShowAlert: true})
+	}
+
+	if err != nil {
+		bot.Logger().Log(tgo.LevelError, "failed to send the rights denial", "error", err)
+	}
+}
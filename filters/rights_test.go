@@ -0,0 +1,73 @@
+package filters_test
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/filters"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestRequireRights(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	var lookups atomic.Int64
+	var promoted atomic.Bool
+	server.Handle("getChatMember", func(call tgotest.Call) (any, *tgo.Error) {
+		lookups.Add(1)
+		switch userID := call.Params["user_id"]; userID {
+		case float64(1):
+			return map[string]any{"status": "creator", "user": map[string]any{"id": 1}}, nil
+		case float64(2):
+			return map[string]any{"status": "administrator", "user": map[string]any{"id": 2}, "can_delete_messages": true, "can_restrict_members": true}, nil
+		case float64(3):
+			return map[string]any{"status": "administrator", "user": map[string]any{"id": 3}, "can_delete_messages": true, "can_restrict_members": promoted.Load()}, nil
+		default:
+			return map[string]any{"status": "member", "user": map[string]any{"id": userID}}, nil
+		}
+	})
+
+	bot := server.Bot(tgo.Options{ChatCache: tgo.NewChatCache(tgo.ChatCacheOptions{})})
+	filter := filters.RequireRights(bot, filters.RightsOptions{Denied: "denied"}, filters.CanDeleteMessages, filters.CanRestrictMembers)
+	message := func(senderID int64) *tgo.Update {
+		return &tgo.Update{Message: &tgo.Message{MessageId: 9, From: &tgo.User{Id: senderID}, Chat: tgo.Chat{Id: -100, Type: "supergroup"}}}
+	}
+
+	for senderID, want := range map[int64]bool{1: true, 2: true, 3: false, 4: false} {
+		if got := filter.Check(message(senderID)); got != want {
+			t.Errorf("sender %d: got %v, want %v", senderID, got, want)
+		}
+	}
+
+	// the memberships are cached until a chat_member update changes them.
+	filter.Check(message(3))
+	if n := lookups.Load(); n != 4 {
+		t.Errorf("expected the memberships to be cached, got %d lookups", n)
+	}
+	promoted.Store(true)
+	bot.HandleUpdate(&tgo.Update{ChatMember: &tgo.ChatMemberUpdated{
+		Chat:          tgo.Chat{Id: -100, Type: "supergroup"},
+		NewChatMember: &tgo.ChatMemberAdministrator{Status: "administrator", User: tgo.User{Id: 3}, CanDeleteMessages: true, CanRestrictMembers: true},
+	}})
+	if !filter.Check(message(3)) {
+		t.Error("the promoted administrator didn't pass")
+	}
+
+	anonymous := message(1087968824)
+	anonymous.Message.SenderChat = &tgo.Chat{Id: -100, Type: "supergroup"}
+	if filter.Check(anonymous) {
+		t.Error("the anonymous administrator passed without AllowAnonymous")
+	}
+
+	var denials int
+	for _, call := range server.Calls() {
+		if call.Method == "sendMessage" && call.Params["text"] == "denied" && call.Params["reply_to_message_id"] == float64(9) {
+			denials++
+		}
+	}
+	if denials != 4 {
+		t.Errorf("expected 4 denials, got %d", denials)
+	}
+}
@@ -0,0 +1,224 @@
+package filters
+
+import (
+	"sync"
+	"time"
+
+	"github.com/haashemi/tgo"
+)
+
+// DefaultAdminsCacheTTL is how long the administrators of a chat are cached by FromAdmin by default.
+const DefaultAdminsCacheTTL = 5 * time.Minute
+
+// FromAdminOptions configures the administrators cache of FromAdmin.
+type FromAdminOptions struct {
+	// TTL is how long the administrators of a chat are cached; it defaults to DefaultAdminsCacheTTL.
+	TTL time.Duration
+
+	// OnError, if not nil, is called when fetching the administrators of a chat fails.
+	// The sender doesn't pass the filter in this case.
+	OnError func(chatID int64, err error)
+}
+
+// adminsEntry is the cached administrators of a chat, which are being fetched until ready is closed.
+type adminsEntry struct {
+	ready  chan struct{}
+	admins map[int64]tgo.ChatMember
+	err    error
+	expiry time.Time
+}
+
+type adminsCache struct {
+	ttl       time.Duration
+	mut       sync.Mutex
+	entries   map[int64]*adminsEntry
+	nextSweep time.Time
+}
+
+// get returns the chat's administrators by their user ids, from the cache if it's not expired.
+// Concurrent lookups of the same chat share a single getChatAdministrators call.
+func (c *adminsCache) get(api *tgo.API, chatID int64) (map[int64]tgo.ChatMember, error) {
+	now := time.Now()
+
+	c.mut.Lock()
+	c.sweep(now)
+
+	entry, ok := c.entries[chatID]
+	if !ok || (isReady(entry) && now.After(entry.expiry)) {
+		entry = &adminsEntry{ready: make(chan struct{})}
+		c.entries[chatID] = entry
+		c.mut.Unlock()
+
+		c.fetch(api, chatID, entry)
+		return entry.admins, entry.err
+	}
+	c.mut.Unlock()
+
+	<-entry.ready
+	return entry.admins, entry.err
+}
+
+func (c *adminsCache) fetch(api *tgo.API, chatID int64, entry *adminsEntry) {
+	defer close(entry.ready)
+
+	members, err := api.GetChatAdministrators(&tgo.GetChatAdministrators{ChatId: tgo.ID(chatID)})
+	if err != nil {
+		entry.err = err
+
+		// don't cache the failures, so the next lookup tries again.
+		c.mut.Lock()
+		if c.entries[chatID] == entry {
+			delete(c.entries, chatID)
+		}
+		c.mut.Unlock()
+		return
+	}
+
+	entry.admins = make(map[int64]tgo.ChatMember, len(members))
+	for _, member := range members {
+		if user := tgo.ChatMemberUser(member); user != nil {
+			entry.admins[user.Id] = member
+		}
+	}
+	entry.expiry = time.Now().Add(c.ttl)
+}
+
+// sweep evicts the expired entries, at most once per ttl. It must be called with the lock held.
+func (c *adminsCache) sweep(now time.Time) {
+	if now.Before(c.nextSweep) {
+		return
+	}
+	c.nextSweep = now.Add(c.ttl)
+
+	for chatID, entry := range c.entries {
+		if isReady(entry) && now.After(entry.expiry) {
+			delete(c.entries, chatID)
+		}
+	}
+}
+
+func isReady(entry *adminsEntry) bool {
+	select {
+	case <-entry.ready:
+		return true
+	default:
+		return false
+	}
+}
+
+// FromAdmin passes the messages and callback queries sent by the chat's administrators, including
+// the anonymous ones. The administrators are cached per chat; private chats never pass.
+func FromAdmin(api *tgo.API, opts FromAdminOptions) tgo.Filter {
+	if opts.TTL <= 0 {
+		opts.TTL = DefaultAdminsCacheTTL
+	}
+
+	cache := &adminsCache{ttl: opts.TTL, entries: make(map[int64]*adminsEntry)}
+
+	return NewFilter(func(update *tgo.Update) bool {
+		chat := extractChat(update)
+		if chat == nil || chat.Type == "private" {
+			return false
+		}
+
+		// the anonymous administrators send the messages on behalf of the group itself.
+		if msg, ok := ExtractUpdate(update).(*tgo.Message); ok && msg.SenderChat != nil {
+			return msg.SenderChat.Id == chat.Id
+		}
+
+		sender := extractSender(update)
+		if sender == nil {
+			return false
+		}
+
+		admins, err := cache.get(api, chat.Id)
+		if err != nil {
+			if opts.OnError != nil {
+				opts.OnError(chat.Id, err)
+			}
+			return false
+		}
+
+		_, isAdmin := admins[sender.Id]
+		return isAdmin
+	})
+}
+
+// IsBot passes the updates sent by the bots.
+func IsBot() tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool {
+		sender := extractSender(update)
+		return sender != nil && sender.IsBot
+	})
+}
+
+// IsPremium passes the updates sent by the telegram premium users.
+func IsPremium() tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool {
+		sender := extractSender(update)
+		return sender != nil && sender.IsPremium
+	})
+}
+
+// ViaBot passes the messages sent via an inline bot, with one of the passed ids if there's any.
+func ViaBot(botIDs ...int64) tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool {
+		msg, ok := ExtractUpdate(update).(*tgo.Message)
+		if !ok || msg.ViaBot == nil {
+			return false
+		}
+
+		if len(botIDs) == 0 {
+			return true
+		}
+
+		for _, id := range botIDs {
+			if id == msg.ViaBot.Id {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// SenderChat passes the messages sent on behalf of a chat, such as the channels
+// and the group itself for the anonymous administrators.
+func SenderChat() tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool {
+		msg, ok := ExtractUpdate(update).(*tgo.Message)
+		return ok && msg.SenderChat != nil
+	})
+}
+
+// extractSender returns the user who sent the update, or nil if it's not sent by a user.
+func extractSender(update *tgo.Update) *tgo.User {
+	switch data := ExtractUpdate(update).(type) {
+	case *tgo.Message:
+		return data.From
+	case *tgo.CallbackQuery:
+		return &data.From
+	case *tgo.InlineQuery:
+		return &data.From
+	case *tgo.ChosenInlineResult:
+		return &data.From
+	case *tgo.ShippingQuery:
+		return &data.From
+	case *tgo.PreCheckoutQuery:
+		return &data.From
+	case *tgo.PaidMediaPurchased:
+		return &data.From
+	case *tgo.PollAnswer:
+		return data.User
+	case *tgo.ChatMemberUpdated:
+		return &data.From
+	case *tgo.ChatJoinRequest:
+		return &data.From
+	case *tgo.BusinessConnection:
+		return &data.User
+	case *tgo.MessageReactionUpdated:
+		return data.User
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package filters_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/filters"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestFromAdmin(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	server.Handle("getChatAdministrators", func(call tgotest.Call) (any, *tgo.Error) {
+		if call.Params["chat_id"] == float64(-200) {
+			return nil, &tgo.Error{ErrorCode: 400, Description: "Bad Request: chat not found"}
+		}
+
+		// give the concurrent lookups a chance to pile up.
+		time.Sleep(10 * time.Millisecond)
+		return []any{
+			map[string]any{"status": "creator", "user": map[string]any{"id": 1}, "is_anonymous": false},
+			map[string]any{"status": "administrator", "user": map[string]any{"id": 2}},
+		}, nil
+	})
+
+	var failedChats []int64
+	filter := filters.FromAdmin(server.Bot(tgo.Options{}).API, filters.FromAdminOptions{
+		OnError: func(chatID int64, err error) { failedChats = append(failedChats, chatID) },
+	})
+	message := func(senderID int64) *tgo.Update {
+		return &tgo.Update{Message: &tgo.Message{From: &tgo.User{Id: senderID}, Chat: tgo.Chat{Id: -100, Type: "supergroup"}}}
+	}
+
+	var wg sync.WaitGroup
+	for senderID, want := range map[int64]bool{1: true, 2: true, 3: false} {
+		wg.Add(1)
+		go func(senderID int64, want bool) {
+			defer wg.Done()
+			if got := filter.Check(message(senderID)); got != want {
+				t.Errorf("sender %d: got %v, want %v", senderID, got, want)
+			}
+		}(senderID, want)
+	}
+	wg.Wait()
+
+	if calls := server.Calls(); len(calls) != 1 {
+		t.Errorf("administrators are fetched %d times, want once", len(calls))
+	}
+
+	anonymous := message(1087968824)
+	anonymous.Message.SenderChat = &tgo.Chat{Id: -100, Type: "supergroup"}
+	if !filter.Check(anonymous) {
+		t.Error("anonymous administrator didn't pass")
+	}
+
+	unknown := message(1)
+	unknown.Message.Chat.Id = -200
+	if filter.Check(unknown) || len(failedChats) != 1 || failedChats[0] != -200 {
+		t.Errorf("unexpected result for the failed lookup, failed chats: %v", failedChats)
+	}
+}
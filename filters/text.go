@@ -0,0 +1,98 @@
+package filters
+
+import (
+	"strings"
+
+	"github.com/haashemi/tgo"
+)
+
+// TextInsensitive compares the update (message's text or caption, callback query, inline query) with the passed texts,
+// ignoring the case and the surrounding whitespaces.
+func TextInsensitive(texts ...string) tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool {
+		raw := strings.TrimSpace(ExtractUpdateText(update))
+
+		for _, text := range texts {
+			if strings.EqualFold(raw, strings.TrimSpace(text)) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// Contains tests whether the update (message's text or caption, callback query, inline query) contains the substr, case-insensitively.
+func Contains(substr string) tgo.Filter {
+	return ContainsAny(substr)
+}
+
+// ContainsAny tests whether the update (message's text or caption, callback query, inline query) contains at least
+// one of the passed substrings, case-insensitively.
+func ContainsAny(substrs ...string) tgo.Filter {
+	lowered := make([]string, len(substrs))
+	for index, substr := range substrs {
+		lowered[index] = strings.ToLower(substr)
+	}
+
+	return NewFilter(func(update *tgo.Update) bool {
+		raw := strings.ToLower(ExtractUpdateText(update))
+
+		for _, substr := range lowered {
+			if strings.Contains(raw, substr) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// Fuzzy passes the updates (message's text or caption, callback query, inline query) whose text is at most maxDistance
+// edits (insertions, deletions, or substitutions) away from the passed text, ignoring the case and the surrounding whitespaces.
+func Fuzzy(text string, maxDistance int) tgo.Filter {
+	want := []rune(strings.ToLower(strings.TrimSpace(text)))
+
+	return NewFilter(func(update *tgo.Update) bool {
+		got := []rune(strings.ToLower(strings.TrimSpace(ExtractUpdateText(update))))
+
+		// the distance is at least the difference of the lengths.
+		if diff := len(got) - len(want); diff > maxDistance || -diff > maxDistance {
+			return false
+		}
+
+		return levenshtein(got, want) <= maxDistance
+	})
+}
+
+// levenshtein returns the edit distance of a and b.
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = prev[j-1] + cost
+			if prev[j]+1 < curr[j] {
+				curr[j] = prev[j] + 1
+			}
+			if curr[j-1]+1 < curr[j] {
+				curr[j] = curr[j-1] + 1
+			}
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
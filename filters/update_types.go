@@ -27,6 +27,30 @@ func IsEditedChannelPost() tgo.Filter {
 	return NewFilter(func(update *tgo.Update) bool { return update.EditedChannelPost != nil })
 }
 
+func IsBusinessConnection() tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool { return update.BusinessConnection != nil })
+}
+
+func IsBusinessMessage() tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool { return update.BusinessMessage != nil })
+}
+
+func IsEditedBusinessMessage() tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool { return update.EditedBusinessMessage != nil })
+}
+
+func IsDeletedBusinessMessages() tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool { return update.DeletedBusinessMessages != nil })
+}
+
+func IsMessageReaction() tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool { return update.MessageReaction != nil })
+}
+
+func IsMessageReactionCount() tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool { return update.MessageReactionCount != nil })
+}
+
 func IsInlineQuery() tgo.Filter {
 	return NewFilter(func(update *tgo.Update) bool { return update.InlineQuery != nil })
 }
@@ -47,6 +71,10 @@ func IsPreCheckoutQuery() tgo.Filter {
 	return NewFilter(func(update *tgo.Update) bool { return update.PreCheckoutQuery != nil })
 }
 
+func IsPurchasedPaidMedia() tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool { return update.PurchasedPaidMedia != nil })
+}
+
 func IsPoll() tgo.Filter {
 	return NewFilter(func(update *tgo.Update) bool { return update.Poll != nil })
 }
@@ -66,3 +94,11 @@ func IsChatMember() tgo.Filter {
 func IsChatJoinRequest() tgo.Filter {
 	return NewFilter(func(update *tgo.Update) bool { return update.ChatJoinRequest != nil })
 }
+
+// GameCallback passes the callback queries of the games' buttons, with one of the passed short names if there's any.
+func GameCallback(shortNames ...string) tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool {
+		return update.CallbackQuery != nil && update.CallbackQuery.GameShortName != "" &&
+			(len(shortNames) == 0 || contains(shortNames, update.CallbackQuery.GameShortName))
+	})
+}
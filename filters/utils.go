@@ -12,6 +12,18 @@ func ExtractUpdate(update *tgo.Update) any {
 		return update.ChannelPost
 	case update.EditedChannelPost != nil:
 		return update.EditedChannelPost
+	case update.BusinessConnection != nil:
+		return update.BusinessConnection
+	case update.BusinessMessage != nil:
+		return update.BusinessMessage
+	case update.EditedBusinessMessage != nil:
+		return update.EditedBusinessMessage
+	case update.DeletedBusinessMessages != nil:
+		return update.DeletedBusinessMessages
+	case update.MessageReaction != nil:
+		return update.MessageReaction
+	case update.MessageReactionCount != nil:
+		return update.MessageReactionCount
 	case update.InlineQuery != nil:
 		return update.InlineQuery
 	case update.ChosenInlineResult != nil:
@@ -22,6 +34,8 @@ func ExtractUpdate(update *tgo.Update) any {
 		return update.ShippingQuery
 	case update.PreCheckoutQuery != nil:
 		return update.PreCheckoutQuery
+	case update.PurchasedPaidMedia != nil:
+		return update.PurchasedPaidMedia
 	case update.Poll != nil:
 		return update.Poll
 	case update.PollAnswer != nil:
@@ -37,18 +51,89 @@ func ExtractUpdate(update *tgo.Update) any {
 	return nil
 }
 
+// TextSource is the field of the update which ExtractUpdateText returns the text of.
+type TextSource int
+
+const (
+	TextNone               TextSource = iota // the update has no text
+	TextMessage                              // the text of the message, including the edited ones, the channel posts, and the business messages
+	TextCaption                              // the caption of the message's media
+	TextCallbackData                         // the data of the callback query
+	TextInlineQuery                          // the text of the inline query
+	TextChosenInlineResult                   // the query of the chosen inline result
+	TextPollQuestion                         // the question of the poll, or of the message's poll
+	TextShippingPayload                      // the invoice payload of the shipping query
+	TextPreCheckoutPayload                   // the invoice payload of the pre-checkout query
+	TextPaidMediaPayload                     // the payload of the purchased paid media
+)
+
+var textSourceNames = [...]string{"none", "message_text", "message_caption", "callback_data", "inline_query", "chosen_inline_result_query", "poll_question", "shipping_payload", "pre_checkout_payload", "paid_media_payload"}
+
+func (s TextSource) String() string {
+	if s < 0 || int(s) >= len(textSourceNames) {
+		return "unknown"
+	}
+	return textSourceNames[s]
+}
+
+// ExtractUpdateText returns the text of the update; see ExtractUpdateTextSource for which ones have it.
 func ExtractUpdateText(update *tgo.Update) string {
+	text, _ := ExtractUpdateTextSource(update)
+	return text
+}
+
+// ExtractUpdateTextSource returns the text of the update, and the field it's taken from: the message's
+// caption, or its text, or its poll's question, the callback query's data, the inline query's text, the
+// chosen inline result's query, the poll's question, and the payloads of the shipping and pre-checkout
+// queries and the purchased paid media. It's TextNone for the other updates, and the empty texts.
+func ExtractUpdateTextSource(update *tgo.Update) (string, TextSource) {
+	var text string
+	var source TextSource
+
 	switch data := ExtractUpdate(update).(type) {
 	case *tgo.Message:
-		if data.Caption != "" {
-			return data.Caption
+		switch {
+		case data.Caption != "":
+			text, source = data.Caption, TextCaption
+		case data.Poll != nil && data.Text == "":
+			text, source = data.Poll.Question, TextPollQuestion
+		default:
+			text, source = data.Text, TextMessage
 		}
-		return data.Text
 	case *tgo.CallbackQuery:
-		return data.Data
+		text, source = data.Data, TextCallbackData
 	case *tgo.InlineQuery:
-		return data.Query
+		text, source = data.Query, TextInlineQuery
+	case *tgo.ChosenInlineResult:
+		text, source = data.Query, TextChosenInlineResult
+	case *tgo.Poll:
+		text, source = data.Question, TextPollQuestion
+	case *tgo.ShippingQuery:
+		text, source = data.InvoicePayload, TextShippingPayload
+	case *tgo.PreCheckoutQuery:
+		text, source = data.InvoicePayload, TextPreCheckoutPayload
+	case *tgo.PaidMediaPurchased:
+		text, source = data.PaidMediaPayload, TextPaidMediaPayload
+	}
+
+	if text == "" {
+		return "", TextNone
 	}
+	return text, source
+}
 
-	return ""
+// TextFrom passes the updates whose text, by ExtractUpdateTextSource, is taken from one of the sources,
+// such as for the text filters which shouldn't match the callback data:
+//
+//	filters.And(filters.TextFrom(filters.TextMessage, filters.TextCaption), filters.Text("hi"))
+func TextFrom(sources ...TextSource) tgo.Filter {
+	return NewFilter(func(update *tgo.Update) bool {
+		_, source := ExtractUpdateTextSource(update)
+		for _, s := range sources {
+			if s == source {
+				return true
+			}
+		}
+		return false
+	})
 }
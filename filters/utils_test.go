@@ -0,0 +1,41 @@
+package filters_test
+
+import (
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/filters"
+	"github.com/haashemi/tgo/filters/filtertest"
+)
+
+func TestExtractUpdateTextSource(t *testing.T) {
+	for _, c := range []struct {
+		update *tgo.Update
+		text   string
+		source filters.TextSource
+	}{
+		{filtertest.Message("hi").Build(), "hi", filters.TextMessage},
+		{filtertest.EditedChannelPost("hi").Build(), "hi", filters.TextMessage},
+		{filtertest.Message("").Caption("hi").Build(), "hi", filters.TextCaption},
+		{filtertest.Callback("data").Build(), "data", filters.TextCallbackData},
+		{filtertest.Inline("query").Build(), "query", filters.TextInlineQuery},
+		{&tgo.Update{ChosenInlineResult: &tgo.ChosenInlineResult{Query: "query"}}, "query", filters.TextChosenInlineResult},
+		{&tgo.Update{Poll: &tgo.Poll{Question: "why?"}}, "why?", filters.TextPollQuestion},
+		{&tgo.Update{Message: &tgo.Message{Poll: &tgo.Poll{Question: "why?"}}}, "why?", filters.TextPollQuestion},
+		{&tgo.Update{ShippingQuery: &tgo.ShippingQuery{InvoicePayload: "order"}}, "order", filters.TextShippingPayload},
+		{&tgo.Update{PreCheckoutQuery: &tgo.PreCheckoutQuery{InvoicePayload: "order"}}, "order", filters.TextPreCheckoutPayload},
+		{&tgo.Update{PurchasedPaidMedia: &tgo.PaidMediaPurchased{PaidMediaPayload: "album"}}, "album", filters.TextPaidMediaPayload},
+		{filtertest.Message("").Build(), "", filters.TextNone},
+		{&tgo.Update{PollAnswer: &tgo.PollAnswer{}}, "", filters.TextNone},
+	} {
+		text, source := filters.ExtractUpdateTextSource(c.update)
+		if text != c.text || source != c.source {
+			t.Errorf("got %q from %v, want %q from %v", text, source, c.text, c.source)
+		}
+	}
+
+	filtertest.Run(t, filters.And(filters.TextFrom(filters.TextMessage), filters.Text("hi")),
+		filtertest.Case{Name: "message", Update: filtertest.Message("hi").Build(), Want: true},
+		filtertest.Case{Name: "callback", Update: filtertest.Callback("hi").Build(), Want: false},
+	)
+}
@@ -0,0 +1,81 @@
+package tgo
+
+import "reflect"
+
+// ForumTopicRef refers to a topic of a forum supergroup, to manage it without repeating its chat and thread ids.
+type ForumTopicRef struct {
+	api      *API
+	ChatID   int64
+	ThreadID int64
+}
+
+// Topic returns a reference to the forum topic of the chat with the thread id.
+func (api *API) Topic(chatID, threadID int64) *ForumTopicRef {
+	return &ForumTopicRef{api: api, ChatID: chatID, ThreadID: threadID}
+}
+
+// NewTopic creates a forum topic in the chat, and returns a reference to it. The icon color
+// and custom emoji id are optional.
+func (api *API) NewTopic(chatID int64, name string, iconColor int64, iconCustomEmojiID string) (*ForumTopicRef, error) {
+	topic, err := api.CreateForumTopic(&CreateForumTopic{ChatId: ID(chatID), Name: name, IconColor: iconColor, IconCustomEmojiId: iconCustomEmojiID})
+	if err != nil {
+		return nil, err
+	}
+
+	return api.Topic(chatID, topic.MessageThreadId), nil
+}
+
+// Edit changes the topic's name and icon; the empty ones are kept as they are.
+func (t *ForumTopicRef) Edit(name, iconCustomEmojiID string) error {
+	_, err := t.api.EditForumTopic(&EditForumTopic{ChatId: ID(t.ChatID), MessageThreadId: t.ThreadID, Name: name, IconCustomEmojiId: iconCustomEmojiID})
+	return err
+}
+
+// Close closes the topic.
+func (t *ForumTopicRef) Close() error {
+	_, err := t.api.CloseForumTopic(&CloseForumTopic{ChatId: ID(t.ChatID), MessageThreadId: t.ThreadID})
+	return err
+}
+
+// Reopen reopens the closed topic.
+func (t *ForumTopicRef) Reopen() error {
+	_, err := t.api.ReopenForumTopic(&ReopenForumTopic{ChatId: ID(t.ChatID), MessageThreadId: t.ThreadID})
+	return err
+}
+
+// Delete deletes the topic along with all of its messages.
+func (t *ForumTopicRef) Delete() error {
+	_, err := t.api.DeleteForumTopic(&DeleteForumTopic{ChatId: ID(t.ChatID), MessageThreadId: t.ThreadID})
+	return err
+}
+
+// UnpinAll unpins all of the topic's pinned messages.
+func (t *ForumTopicRef) UnpinAll() error {
+	_, err := t.api.UnpinAllForumTopicMessages(&UnpinAllForumTopicMessages{ChatId: ID(t.ChatID), MessageThreadId: t.ThreadID})
+	return err
+}
+
+// Send sends the message into the topic.
+func (t *ForumTopicRef) Send(msg Sendable) (*Message, error) {
+	msg.SetChatID(t.ChatID)
+	SetMessageThreadID(msg, t.ThreadID)
+
+	return msg.Send(t.api)
+}
+
+// SetMessageThreadID sets the sendable's message thread id, to send it into a forum topic.
+// It returns false if the sendable can't be sent into the topics.
+func SetMessageThreadID(msg Sendable, threadID int64) bool {
+	v := reflect.ValueOf(msg)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return false
+	}
+
+	field := v.Elem().FieldByName("MessageThreadId")
+	if !field.IsValid() || field.Kind() != reflect.Int64 {
+		return false
+	}
+
+	field.SetInt(threadID)
+	return true
+}
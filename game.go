@@ -0,0 +1,60 @@
+package tgo
+
+// The emojis of the dices which telegram can throw.
+const (
+	DiceEmojiDice        = "🎲"
+	DiceEmojiDarts       = "🎯"
+	DiceEmojiBasketball  = "🏀"
+	DiceEmojiFootball    = "⚽"
+	DiceEmojiBowling     = "🎳"
+	DiceEmojiSlotMachine = "🎰"
+)
+
+// DiceMaxValue returns the maximum value of the dice with the emoji, or zero if it's unknown.
+func DiceMaxValue(emoji string) int64 {
+	switch emoji {
+	case DiceEmojiDice, DiceEmojiDarts, DiceEmojiBowling, "":
+		return 6
+	case DiceEmojiBasketball, DiceEmojiFootball:
+		return 5
+	case DiceEmojiSlotMachine:
+		return 64
+	}
+
+	return 0
+}
+
+// IsMax returns true if the dice has landed on its maximum value, such as a bullseye or a jackpot.
+func (d *Dice) IsMax() bool { return d.Value == DiceMaxValue(d.Emoji) }
+
+// SetCallbackGameScore sets the score of the user who pressed the game's button, in the game's message
+// which the query is from, whether it's a chat message or an inline one. The score can only decrease if force is true.
+func (api *API) SetCallbackGameScore(query *CallbackQuery, score int64, force bool) error {
+	params := &SetGameScore{UserId: query.From.Id, Score: score, Force: force, InlineMessageId: query.InlineMessageId}
+
+	// the inline messages are not returned, but just true.
+	if query.InlineMessageId != "" {
+		_, err := callJson[bool](api, "setGameScore", params)
+		return err
+	}
+
+	if query.Message != nil {
+		params.ChatId = query.Message.Chat.Id
+		params.MessageId = query.Message.MessageId
+	}
+
+	_, err := api.SetGameScore(params)
+	return err
+}
+
+// GetCallbackGameHighScores returns the high scores of the game's message which the query is from,
+// around the user who pressed the game's button.
+func (api *API) GetCallbackGameHighScores(query *CallbackQuery) ([]*GameHighScore, error) {
+	params := &GetGameHighScores{UserId: query.From.Id, InlineMessageId: query.InlineMessageId}
+	if query.Message != nil && query.InlineMessageId == "" {
+		params.ChatId = query.Message.Chat.Id
+		params.MessageId = query.Message.MessageId
+	}
+
+	return api.GetGameHighScores(params)
+}
@@ -0,0 +1,121 @@
+package tgo
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// HedgeOptions configures a Hedger. The zero value is valid and uses the defaults.
+type HedgeOptions struct {
+	// Delay is how long the first attempt may take before the second one is sent. Defaults to 300 milliseconds.
+	Delay time.Duration
+
+	// Methods is the list of the methods which get hedged. They must be safe to be received twice by
+	// telegram, as the first attempt isn't known to be lost. Defaults to answerCallbackQuery and answerInlineQuery.
+	Methods []string
+}
+
+// HedgeStats contains the total counters of a Hedger.
+type HedgeStats struct {
+	Calls  uint64 // calls of the hedged methods
+	Hedged uint64 // calls which took longer than the delay, so a second attempt was sent
+	Wins   uint64 // calls which were answered by the second attempt first
+}
+
+// Hedger sends a second attempt of the latency-sensitive calls if the first one is taking too long,
+// and takes the first successful response of them; the other attempt gets canceled.
+//
+// The calls are hedged only once, and only the ones sent as json. A call fails only if both of its
+// attempts fail, with the error of the first attempt, as the second one may fail only because
+// telegram has already received the first one, such as a callback query which is already answered.
+type Hedger struct {
+	opts    HedgeOptions
+	methods map[string]bool
+
+	calls, hedged, wins atomic.Uint64
+}
+
+// NewHedger returns a new Hedger; pass it to the Options to use it.
+func NewHedger(opts HedgeOptions) *Hedger {
+	if opts.Delay <= 0 {
+		opts.Delay = 300 * time.Millisecond
+	}
+	if opts.Methods == nil {
+		opts.Methods = []string{"answerCallbackQuery", "answerInlineQuery"}
+	}
+
+	methods := make(map[string]bool, len(opts.Methods))
+	for _, method := range opts.Methods {
+		methods[method] = true
+	}
+
+	return &Hedger{opts: opts, methods: methods}
+}
+
+// Stats returns the total counters of the hedger.
+func (h *Hedger) Stats() HedgeStats {
+	return HedgeStats{Calls: h.calls.Load(), Hedged: h.hedged.Load(), Wins: h.wins.Load()}
+}
+
+func (h *Hedger) covers(method string) bool { return h.methods[method] }
+
+// hedge calls the attempt, and once more if the first one is not done after the delay.
+// The attempts are passed a context which is canceled as soon as the call is done.
+func hedge[T any](a *API, method string, attempt func(ctx context.Context) (T, error)) (result T, err error) {
+	h := a.hedger
+	h.calls.Add(1)
+
+	ctx, cancel := context.WithCancel(a.Context())
+	defer cancel()
+
+	type outcome struct {
+		result T
+		err    error
+		second bool
+	}
+
+	// it's buffered for both attempts, so the loser doesn't block after the call is done.
+	outcomes := make(chan outcome, 2)
+	run := func(second bool) {
+		result, err := attempt(ctx)
+		outcomes <- outcome{result: result, err: err, second: second}
+	}
+
+	go run(false)
+
+	timer := time.NewTimer(h.opts.Delay)
+	defer timer.Stop()
+
+	delay, pending := timer.C, 1
+	for {
+		select {
+		case <-delay:
+			h.hedged.Add(1)
+			a.log(LevelInfo, "call hedged", "method", method, "delay", h.opts.Delay)
+			if a.metrics != nil {
+				a.metrics.Retried(method)
+			}
+			delay, pending = nil, pending+1
+			go run(true)
+
+		case o := <-outcomes:
+			pending--
+			if o.err == nil {
+				if o.second {
+					h.wins.Add(1)
+				}
+				return o.result, nil
+			}
+
+			if !o.second || err == nil {
+				err = o.err
+			}
+
+			// a failed first attempt is not hedged, as it's about the latency and not retrying.
+			if pending == 0 {
+				return result, err
+			}
+		}
+	}
+}
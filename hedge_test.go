@@ -0,0 +1,44 @@
+package tgo_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestHedger(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	var attempts atomic.Int64
+	server.Handle("answerCallbackQuery", func(call tgotest.Call) (any, *tgo.Error) {
+		if attempts.Add(1) == 1 {
+			time.Sleep(300 * time.Millisecond)
+			return nil, &tgo.Error{ErrorCode: 400, Description: "Bad Request: query is too old"}
+		}
+		return true, nil
+	})
+
+	hedger := tgo.NewHedger(tgo.HedgeOptions{Delay: 20 * time.Millisecond})
+	bot := server.Bot(tgo.Options{Hedger: hedger})
+
+	start := time.Now()
+	if _, err := bot.AnswerCallbackQuery(&tgo.AnswerCallbackQuery{CallbackQueryId: "1"}); err != nil {
+		t.Fatal(err)
+	} else if took := time.Since(start); took > 200*time.Millisecond {
+		t.Fatalf("the hedged call took %s", took)
+	}
+
+	if stats := hedger.Stats(); stats != (tgo.HedgeStats{Calls: 1, Hedged: 1, Wins: 1}) {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+
+	if _, err := bot.SendMessage(&tgo.SendMessage{ChatId: tgo.ID(1), Text: "hi"}); err != nil {
+		t.Fatal(err)
+	} else if stats := hedger.Stats(); stats.Calls != 1 {
+		t.Fatalf("sendMessage is hedged: %+v", stats)
+	}
+}
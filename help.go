@@ -0,0 +1,84 @@
+package tgo
+
+import (
+	"html"
+	"strings"
+)
+
+// HelpOptions configures Commands.Help. The zero value is valid, and lists all the commands.
+type HelpOptions struct {
+	// Header, if not empty, is the text above the commands, such as "Here's what I can do:".
+	Header string
+
+	// LanguageCode is the language of the commands' descriptions, which are translated by Command.Translate.
+	LanguageCode string
+
+	// Translate, if not nil, translates the header and the categories into the language, such as by bot.Translate.
+	Translate func(languageCode, key string) string
+
+	// Filter, if not nil, returns whether the command is listed, such as for hiding the administrators'
+	// commands from the members.
+	Filter func(cmd *Command) bool
+}
+
+// Help returns the help of the declared commands, formatted by ParseModeHTML: each command is listed by
+// its description in the language, and they're grouped by their categories, in the order the first
+// command of each of them is added, after the commands without a category:
+//
+//	<b>Admin</b>
+//	/ban — Ban the replied member
+func (c *Commands) Help(opts HelpOptions) string {
+	translate := opts.Translate
+	if translate == nil {
+		translate = func(languageCode, key string) string { return key }
+	}
+
+	var categories []string
+	grouped := map[string][]*Command{}
+	for _, cmd := range c.List() {
+		if opts.Filter != nil && !opts.Filter(cmd) {
+			continue
+		}
+		if _, ok := grouped[cmd.Category]; !ok && cmd.Category != "" {
+			categories = append(categories, cmd.Category)
+		}
+		grouped[cmd.Category] = append(grouped[cmd.Category], cmd)
+	}
+
+	var sections []string
+	if opts.Header != "" {
+		sections = append(sections, html.EscapeString(translate(opts.LanguageCode, opts.Header)))
+	}
+
+	for _, category := range append([]string{""}, categories...) {
+		commands := grouped[category]
+		if len(commands) == 0 {
+			continue
+		}
+
+		var section strings.Builder
+		if category != "" {
+			section.WriteString("<b>" + html.EscapeString(translate(opts.LanguageCode, category)) + "</b>\n")
+		}
+		for i, cmd := range commands {
+			if i != 0 {
+				section.WriteByte('\n')
+			}
+			section.WriteString("/" + cmd.Name)
+			if description := cmd.description(opts.LanguageCode); description != "" {
+				section.WriteString(" — " + html.EscapeString(description))
+			}
+		}
+		sections = append(sections, section.String())
+	}
+
+	return strings.Join(sections, "\n\n")
+}
+
+// Help returns the help of the bot's commands in the language, as Commands.Help, translating the
+// header and the categories by the bot's translator.
+func (bot *Bot) Help(languageCode, header string) string {
+	return bot.Commands().Help(HelpOptions{Header: header, LanguageCode: languageCode, Translate: func(languageCode, key string) string {
+		return bot.Translate(languageCode, key)
+	}})
+}
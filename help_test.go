@@ -0,0 +1,32 @@
+package tgo_test
+
+import (
+	"testing"
+
+	"github.com/haashemi/tgo"
+)
+
+func TestHelp(t *testing.T) {
+	commands := tgo.NewCommands()
+	commands.Add("ban", "Ban <the> member").InCategory("Admin")
+	commands.Add("start", "Start the bot")
+	commands.Add("help", "Show the help").Translate("fa", "راهنما")
+	commands.Add("secret", "Hidden")
+
+	help := commands.Help(tgo.HelpOptions{
+		Header:       "Commands:",
+		LanguageCode: "fa",
+		Translate: func(languageCode, key string) string {
+			if key == "Admin" {
+				return "مدیران"
+			}
+			return key
+		},
+		Filter: func(cmd *tgo.Command) bool { return cmd.Name != "secret" },
+	})
+
+	want := "Commands:\n\n/start — Start the bot\n/help — راهنما\n\n<b>مدیران</b>\n/ban — Ban &lt;the&gt; member"
+	if help != want {
+		t.Errorf("got help\n%s\nwant\n%s", help, want)
+	}
+}
@@ -0,0 +1,127 @@
+// Package i18n provides translation bundles for the bots.
+//
+// A bundle is loaded from a directory of JSON files, one per language and named by the
+// language code (such as "en.json"), each containing a flat object of keys to translations.
+// The translations are formatted with fmt.Sprintf if any argument is passed.
+//
+//	{
+//		"greeting": "Hi %s!",
+//		"help": "Send me a photo."
+//	}
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Bundle contains the translations of all languages.
+//
+// It implements the tgo.Translator interface.
+type Bundle struct {
+	defaultLanguage string
+
+	mut      sync.RWMutex
+	messages map[string]map[string]string
+}
+
+// NewBundle returns an empty bundle, which falls back to the default language for the missing translations.
+func NewBundle(defaultLanguage string) *Bundle {
+	return &Bundle{defaultLanguage: defaultLanguage, messages: make(map[string]map[string]string)}
+}
+
+// LoadDir loads all of the "<language>.json" files of the directory into the bundle.
+func (b *Bundle) LoadDir(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		messages, err := ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		b.Add(strings.TrimSuffix(filepath.Base(file), ".json"), messages)
+	}
+
+	return nil
+}
+
+// Add adds the translations of the language to the bundle, replacing the existing ones with the same key.
+func (b *Bundle) Add(language string, messages map[string]string) {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	if b.messages[language] == nil {
+		b.messages[language] = make(map[string]string, len(messages))
+	}
+	for key, message := range messages {
+		b.messages[language][key] = message
+	}
+}
+
+// Languages returns the languages of the bundle, sorted.
+func (b *Bundle) Languages() []string {
+	b.mut.RLock()
+	defer b.mut.RUnlock()
+
+	languages := make([]string, 0, len(b.messages))
+	for language := range b.messages {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+
+	return languages
+}
+
+// Translate returns the translation of the key in the language, or in the default language if it's missing
+// or empty. It returns the key itself if it's not translated at all.
+func (b *Bundle) Translate(language, key string, args ...any) string {
+	b.mut.RLock()
+	message := b.messages[language][key]
+	if message == "" {
+		message = b.messages[b.defaultLanguage][key]
+	}
+	b.mut.RUnlock()
+
+	if message == "" {
+		message = key
+	}
+
+	if len(args) != 0 {
+		return fmt.Sprintf(message, args...)
+	}
+	return message
+}
+
+// ReadFile reads the translations of a bundle file.
+func ReadFile(filename string) (map[string]string, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make(map[string]string)
+	if err = json.Unmarshal(data, &messages); err != nil {
+		return nil, fmt.Errorf("i18n: %s: %w", filename, err)
+	}
+
+	return messages, nil
+}
+
+// WriteFile writes the translations into a bundle file, with sorted keys.
+func WriteFile(filename string, messages map[string]string) error {
+	data, err := json.MarshalIndent(messages, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filename, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,138 @@
+package i18n
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// translationFuncs are the names of the methods which take a translation key, such as
+// ctx.T("greeting"), ctx.ReplyTemplate("greeting"), and bot.Translate(lang, "greeting").
+var translationFuncs = []string{"T", "ReplyTemplate", "Translate"}
+
+// ExtractKeys scans the Go files under the root directory, except for the vendor and testdata
+// directories, and returns the sorted translation keys passed as string literals to the
+// T, ReplyTemplate, and Translate methods.
+func ExtractKeys(root string) ([]string, error) {
+	found := make(map[string]bool)
+	fileSet := token.NewFileSet()
+
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if name := entry.Name(); path != root && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		} else if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fileSet, path, nil, 0)
+		if err != nil {
+			return err
+		}
+
+		ast.Inspect(file, func(node ast.Node) bool {
+			if key, ok := translationKey(node); ok {
+				found[key] = true
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(found))
+	for key := range found {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+// translationKey returns the key of a call to one of the translationFuncs, if it's a string literal.
+func translationKey(node ast.Node) (string, bool) {
+	call, ok := node.(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 {
+		return "", false
+	}
+
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || !isTranslationFunc(selector.Sel.Name) {
+		return "", false
+	}
+
+	// Translate takes the language first.
+	arg := call.Args[0]
+	if selector.Sel.Name == "Translate" {
+		if len(call.Args) < 2 {
+			return "", false
+		}
+		arg = call.Args[1]
+	}
+
+	lit, ok := arg.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+
+	key, err := strconv.Unquote(lit.Value)
+	return key, err == nil && key != ""
+}
+
+func isTranslationFunc(name string) bool {
+	for _, fn := range translationFuncs {
+		if fn == name {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateFiles adds the missing keys with empty translations to the bundle files of the languages
+// in the directory, creating the missing files, and returns the added keys of each language.
+// The existing translations are kept, even if they're not used anymore.
+func UpdateFiles(dir string, languages []string, keys []string) (missing map[string][]string, err error) {
+	missing = make(map[string][]string)
+
+	for _, language := range languages {
+		filename := filepath.Join(dir, language+".json")
+
+		messages, err := ReadFile(filename)
+		if os.IsNotExist(err) {
+			messages = make(map[string]string)
+		} else if err != nil {
+			return nil, err
+		}
+
+		for _, key := range keys {
+			if _, ok := messages[key]; !ok {
+				messages[key] = ""
+				missing[language] = append(missing[language], key)
+			}
+		}
+
+		if len(missing[language]) == 0 {
+			continue
+		}
+
+		if err = WriteFile(filename, messages); err != nil {
+			return nil, err
+		}
+	}
+
+	return missing, nil
+}
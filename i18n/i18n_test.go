@@ -0,0 +1,64 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestExtractAndUpdate(t *testing.T) {
+	keys, err := ExtractKeys("testdata/bot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"goodbye", "greeting", "help"}; !reflect.DeepEqual(keys, want) {
+		t.Fatalf("got keys %v, want %v", keys, want)
+	}
+
+	dir := t.TempDir()
+	if err = WriteFile(filepath.Join(dir, "en.json"), map[string]string{"greeting": "Hi %s!", "unused": "kept"}); err != nil {
+		t.Fatal(err)
+	}
+
+	missing, err := UpdateFiles(dir, []string{"en", "fa"}, keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string][]string{"en": {"goodbye", "help"}, "fa": {"goodbye", "greeting", "help"}}; !reflect.DeepEqual(missing, want) {
+		t.Errorf("got missing keys %v, want %v", missing, want)
+	}
+
+	en, _ := ReadFile(filepath.Join(dir, "en.json"))
+	if want := map[string]string{"greeting": "Hi %s!", "unused": "kept", "goodbye": "", "help": ""}; !reflect.DeepEqual(en, want) {
+		t.Errorf("got en bundle %v, want %v", en, want)
+	}
+
+	if _, err = os.Stat(filepath.Join(dir, "fa.json")); err != nil {
+		t.Error("fa bundle is not created:", err)
+	}
+}
+
+func TestBundle(t *testing.T) {
+	bundle := NewBundle("en")
+	bundle.Add("en", map[string]string{"greeting": "Hi %s!", "help": "Send me a photo."})
+	bundle.Add("fa", map[string]string{"greeting": "سلام %s!", "help": ""})
+
+	tests := []struct{ language, key, want string }{
+		{"fa", "greeting", "سلام Ali!"},
+		{"fa", "help", "Send me a photo."},
+		{"de", "greeting", "Hi Ali!"},
+		{"fa", "unknown", "unknown"},
+	}
+
+	for _, test := range tests {
+		var args []any
+		if test.key == "greeting" {
+			args = append(args, "Ali")
+		}
+
+		if got := bundle.Translate(test.language, test.key, args...); got != test.want {
+			t.Errorf("%s/%s: got %q, want %q", test.language, test.key, got, test.want)
+		}
+	}
+}
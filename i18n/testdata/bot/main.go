@@ -0,0 +1,10 @@
+package main
+
+func handler(ctx *message.Context) {
+	ctx.ReplyTemplate("greeting", ctx.From.FirstName)
+	ctx.Send(&tgo.SendMessage{Text: ctx.T("help")})
+	ctx.Bot.Translate("en", "goodbye")
+
+	key := "dynamic"
+	ctx.T(key) // not a literal, can't be extracted.
+}
@@ -0,0 +1,145 @@
+package tgo
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/big"
+)
+
+var ErrInvalidID = errors.New("invalid or tampered encoded id")
+
+// IDCodec obfuscates the internal numeric ids, such as the database keys, so they aren't exposed
+// as is in the deep links and buttons. The encoded ids must only contain A-Z, a-z, 0-9, _ and -,
+// so they're valid in the deep links' start parameter and the callback data.
+type IDCodec interface {
+	// EncodeID returns the public reference of the id.
+	EncodeID(id int64) string
+
+	// DecodeID returns the id of the reference made by EncodeID, or ErrInvalidID if it's not made by the codec.
+	DecodeID(encoded string) (int64, error)
+}
+
+const (
+	hashIDsAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	hashIDsRounds   = 4
+	hashIDsTagSize  = 2
+	hashIDsLength   = 14 // the digits needed for 80 bits in base 62
+)
+
+// HashIDs is a hashids-style IDCodec which shuffles the ids by a secret key. It permutes the id
+// with a keyed Feistel network, so the consecutive ids look unrelated, and appends a 16 bits tag
+// to detect the made-up references. The encoded ids are always 14 characters long.
+//
+// It's an obfuscation rather than an encryption: it hides the ids and how many of them there are,
+// but the data which must not be tampered with should still be signed, such as by a callbackdata key.
+type HashIDs struct {
+	key      []byte
+	alphabet []byte
+	digits   [256]int
+}
+
+// NewHashIDs returns a HashIDs codec with the secret key, whose encoded ids are only decodable by the same key.
+func NewHashIDs(key []byte) *HashIDs {
+	h := &HashIDs{key: key, alphabet: []byte(hashIDsAlphabet)}
+
+	// shuffle the alphabet by the key, so the encoded ids don't even share the digits between the keys.
+	seed := h.mac("alphabet")
+	for i := len(h.alphabet) - 1; i > 0; i-- {
+		j := int(seed[i%len(seed)]) % (i + 1)
+		h.alphabet[i], h.alphabet[j] = h.alphabet[j], h.alphabet[i]
+	}
+
+	for i := range h.digits {
+		h.digits[i] = -1
+	}
+	for i, c := range h.alphabet {
+		h.digits[c] = i
+	}
+
+	return h
+}
+
+// EncodeID implements the IDCodec interface.
+func (h *HashIDs) EncodeID(id int64) string {
+	var raw [8 + hashIDsTagSize]byte
+	binary.BigEndian.PutUint64(raw[:8], h.permute(uint64(id)))
+	copy(raw[8:], h.mac("tag", raw[:8]...))
+
+	n := new(big.Int).SetBytes(raw[:])
+	base, digit := big.NewInt(int64(len(h.alphabet))), new(big.Int)
+
+	encoded := make([]byte, hashIDsLength)
+	for i := len(encoded) - 1; i >= 0; i-- {
+		n.DivMod(n, base, digit)
+		encoded[i] = h.alphabet[digit.Int64()]
+	}
+
+	return string(encoded)
+}
+
+// DecodeID implements the IDCodec interface.
+func (h *HashIDs) DecodeID(encoded string) (int64, error) {
+	if len(encoded) != hashIDsLength {
+		return 0, ErrInvalidID
+	}
+
+	n, base := new(big.Int), big.NewInt(int64(len(h.alphabet)))
+	for i := 0; i < len(encoded); i++ {
+		digit := h.digits[encoded[i]]
+		if digit < 0 {
+			return 0, ErrInvalidID
+		}
+		n.Mul(n, base).Add(n, big.NewInt(int64(digit)))
+	}
+
+	var raw [8 + hashIDsTagSize]byte
+	if n.BitLen() > len(raw)*8 {
+		return 0, ErrInvalidID
+	}
+	n.FillBytes(raw[:])
+
+	if !hmac.Equal(raw[8:], h.mac("tag", raw[:8]...)[:hashIDsTagSize]) {
+		return 0, ErrInvalidID
+	}
+
+	return int64(h.unpermute(binary.BigEndian.Uint64(raw[:8]))), nil
+}
+
+// permute shuffles the value by the rounds of a balanced Feistel network, which is reversible by unpermute.
+func (h *HashIDs) permute(v uint64) uint64 {
+	left, right := uint32(v>>32), uint32(v)
+	for round := byte(0); round < hashIDsRounds; round++ {
+		left, right = right, left^h.round(round, right)
+	}
+	return uint64(left)<<32 | uint64(right)
+}
+
+func (h *HashIDs) unpermute(v uint64) uint64 {
+	left, right := uint32(v>>32), uint32(v)
+	for round := byte(hashIDsRounds); round > 0; round-- {
+		left, right = right^h.round(round-1, left), left
+	}
+	return uint64(left)<<32 | uint64(right)
+}
+
+func (h *HashIDs) round(round byte, half uint32) uint32 {
+	var data [5]byte
+	data[0] = round
+	binary.BigEndian.PutUint32(data[1:], half)
+	return binary.BigEndian.Uint32(h.mac("round", data[:]...))
+}
+
+func (h *HashIDs) mac(label string, data ...byte) []byte {
+	mac := hmac.New(sha256.New, h.key)
+	mac.Write([]byte(label))
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// DeepLinkID returns a t.me link which starts the bot with the prefix followed by the id encoded by the codec.
+// Use filters.StartID with the same prefix and codec to decode it in the handlers.
+func (bot *Bot) DeepLinkID(prefix string, codec IDCodec, id int64) (string, error) {
+	return bot.DeepLink(prefix + codec.EncodeID(id))
+}
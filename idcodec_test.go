@@ -0,0 +1,36 @@
+package tgo
+
+import "testing"
+
+func TestHashIDs(t *testing.T) {
+	codec := NewHashIDs([]byte("secret"))
+
+	seen := make(map[string]bool)
+	for _, id := range []int64{0, 1, 2, 42, -1001234567890, 1<<63 - 1, -1 << 63} {
+		encoded := codec.EncodeID(id)
+		if !IsValidStartPayload(encoded) || len(encoded) != hashIDsLength {
+			t.Errorf("%d: invalid encoded id %q", id, encoded)
+		} else if seen[encoded] {
+			t.Errorf("%d: duplicate encoded id %q", id, encoded)
+		}
+		seen[encoded] = true
+
+		if decoded, err := codec.DecodeID(encoded); err != nil || decoded != id {
+			t.Errorf("%d: decoded %q to %d, %v", id, encoded, decoded, err)
+		}
+	}
+
+	encoded := codec.EncodeID(42)
+	tampered := []byte(encoded)
+	tampered[3] = codec.alphabet[(codec.digits[tampered[3]]+1)%len(codec.alphabet)]
+
+	for _, invalid := range []string{"", "short", string(tampered), encoded[:13] + "!"} {
+		if _, err := codec.DecodeID(invalid); err != ErrInvalidID {
+			t.Errorf("%q: got %v, want ErrInvalidID", invalid, err)
+		}
+	}
+
+	if _, err := NewHashIDs([]byte("other")).DecodeID(encoded); err != ErrInvalidID {
+		t.Errorf("decoded by another key: %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package tgo
+
+import "fmt"
+
+// Interceptor hooks into all the API calls of a client, such as for custom logging, metrics, caching,
+// or changing the requests; both of its hooks are optional:
+//
+//	bot := tgo.NewBot(token, tgo.Options{Interceptors: []tgo.Interceptor{{
+//		BeforeRequest: func(method string, params any) (any, error) {
+//			if msg, ok := params.(*tgo.SendMessage); ok {
+//				msg.DisableNotification = true
+//			}
+//			return nil, nil
+//		},
+//	}}})
+type Interceptor struct {
+	// BeforeRequest is called before the method is called. The params are the method's options, such
+	// as a *SendMessage, or the map of the text fields of the multipart calls uploading files; they
+	// may be changed in place. A non-nil result is returned instead of calling the method, such as
+	// a cached one, and must be of the method's result type; an error fails the call without sending it.
+	BeforeRequest func(method string, params any) (result any, err error)
+
+	// AfterResponse is called with the result of the method, or its error, and returns the call's error;
+	// it's called even if the call is skipped or failed by a BeforeRequest hook.
+	AfterResponse func(method string, result any, err error) error
+}
+
+// WithInterceptors returns a copy of the api which passes its calls through the interceptors, after
+// the ones which the api already has. The BeforeRequest hooks are called in order, and the
+// AfterResponse ones in reverse.
+func (api *API) WithInterceptors(interceptors ...Interceptor) *API {
+	clone := *api
+	clone.interceptors = append(append([]Interceptor(nil), api.interceptors...), interceptors...)
+	return &clone
+}
+
+// WithInterceptors returns a copy of the bot whose API passes its calls through the interceptors,
+// such as for a single call; see Options.Interceptors for all of them.
+func (bot *Bot) WithInterceptors(interceptors ...Interceptor) *Bot {
+	clone := *bot
+	clone.API = bot.API.WithInterceptors(interceptors...)
+	return &clone
+}
+
+// intercept makes the call of the method through the api's interceptors, or only logs it if the api
+// dry runs it. The messages to the chats in which the bot is muted are refused after the BeforeRequest
+// hooks, which may change their chat.
+func intercept[T any](a *API, method string, params any, do func() (T, error)) (result T, err error) {
+	if a.dryRuns(method) {
+		do = func() (T, error) { return dryRunCall[T](a, method, params) }
+	}
+	if a.muteCheck != nil && sendsMessage(method) {
+		send := do
+		do = func() (result T, err error) {
+			if err = a.muteCheck(paramsChatID(params)); err != nil {
+				return result, err
+			}
+			return send()
+		}
+	}
+
+	if len(a.interceptors) == 0 {
+		return do()
+	}
+
+	skipped := false
+	for _, interceptor := range a.interceptors {
+		if interceptor.BeforeRequest == nil {
+			continue
+		}
+
+		var replaced any
+		if replaced, err = interceptor.BeforeRequest(method, params); err != nil {
+			skipped = true
+			break
+		} else if replaced != nil {
+			typed, ok := replaced.(T)
+			if !ok {
+				err = fmt.Errorf("tgo: the interceptor's result of %s is a %T, not a %T", method, replaced, result)
+			}
+			result, skipped = typed, true
+			break
+		}
+	}
+
+	if !skipped {
+		result, err = do()
+	}
+
+	for i := len(a.interceptors) - 1; i >= 0; i-- {
+		if hook := a.interceptors[i].AfterResponse; hook != nil {
+			err = hook(method, result, err)
+		}
+	}
+	return result, err
+}
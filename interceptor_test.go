@@ -0,0 +1,69 @@
+package tgo_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestInterceptors(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	var order []string
+	cached := &tgo.User{Id: 1, Username: "cached_bot"}
+	errSilenced := errors.New("silenced")
+
+	bot := server.Bot(tgo.Options{Interceptors: []tgo.Interceptor{
+		{
+			BeforeRequest: func(method string, params any) (any, error) {
+				order = append(order, "before:"+method)
+				if msg, ok := params.(*tgo.SendMessage); ok {
+					msg.Text = strings.ToUpper(msg.Text)
+				}
+				return nil, nil
+			},
+			AfterResponse: func(method string, result any, err error) error {
+				order = append(order, "after:"+method)
+				return err
+			},
+		},
+		{
+			BeforeRequest: func(method string, params any) (any, error) {
+				switch method {
+				case "getMe":
+					return cached, nil
+				case "sendDice":
+					return nil, errSilenced
+				}
+				return nil, nil
+			},
+		},
+	}})
+
+	if _, err := bot.SendMessage(&tgo.SendMessage{ChatId: tgo.ID(42), Text: "hello"}); err != nil {
+		t.Fatal(err)
+	} else if calls := server.Calls(); len(calls) != 1 || calls[0].Params["text"] != "HELLO" {
+		t.Fatalf("the request isn't changed by the interceptor: %v", calls)
+	}
+
+	if me, err := bot.GetMe(); err != nil || me != cached {
+		t.Fatalf("GetMe = %v, %v, want the cached user", me, err)
+	}
+
+	if _, err := bot.SendDice(&tgo.SendDice{ChatId: tgo.ID(42)}); !errors.Is(err, errSilenced) {
+		t.Fatalf("SendDice failed by %v, want the interceptor's error", err)
+	}
+
+	if len(server.Calls()) != 1 {
+		t.Fatalf("the intercepted calls are sent: %v", server.Calls())
+	}
+
+	want := "before:sendMessage after:sendMessage before:getMe after:getMe before:sendDice after:sendDice"
+	if got := strings.Join(order, " "); got != want {
+		t.Fatalf("the hooks are called as %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,108 @@
+// Package keyboard contains the helpers to lay out the keyboards' buttons.
+package keyboard
+
+import (
+	"unicode/utf8"
+
+	"github.com/haashemi/tgo"
+)
+
+// MaxButtonsPerRow is the maximum number of the buttons which telegram allows in a row.
+const MaxButtonsPerRow = 8
+
+// buttonPadding is the width added to the labels' length, as the buttons are wider than their labels.
+const buttonPadding = 4
+
+// BalanceOptions configures Balance. The zero value is valid and uses the defaults.
+type BalanceOptions struct {
+	// MaxPerRow is the maximum number of the buttons in a row; it defaults to, and can't exceed, MaxButtonsPerRow.
+	MaxPerRow int
+
+	// MaxRowWidth is the maximum width of a row, roughly in characters, where
+	// each button is as wide as its label plus 4; it defaults to 40.
+	MaxRowWidth int
+}
+
+// Balance arranges the buttons, in order, into the fewest rows which fit in the options' limits,
+// keeping the rows' widths as close to each other as possible. A button wider than MaxRowWidth
+// gets a row of its own.
+func Balance(buttons []*tgo.InlineKeyboardButton, opts BalanceOptions) [][]*tgo.InlineKeyboardButton {
+	return BalanceFunc(buttons, func(b *tgo.InlineKeyboardButton) string { return b.Text }, opts)
+}
+
+// BalanceReply is like Balance, but for the reply keyboards' buttons.
+func BalanceReply(buttons []*tgo.KeyboardButton, opts BalanceOptions) [][]*tgo.KeyboardButton {
+	return BalanceFunc(buttons, func(b *tgo.KeyboardButton) string { return b.Text }, opts)
+}
+
+// BalanceFunc is like Balance, but for any kind of item, whose labels are returned by the label function.
+func BalanceFunc[T any](items []T, label func(T) string, opts BalanceOptions) [][]T {
+	if opts.MaxPerRow <= 0 || opts.MaxPerRow > MaxButtonsPerRow {
+		opts.MaxPerRow = MaxButtonsPerRow
+	}
+	if opts.MaxRowWidth <= 0 {
+		opts.MaxRowWidth = 40
+	}
+
+	widths := make([]int, len(items))
+	for i, item := range items {
+		widths[i] = utf8.RuneCountInString(label(item)) + buttonPadding
+	}
+
+	var rows [][]T
+	start := 0
+	for _, size := range balance(widths, opts) {
+		rows = append(rows, items[start:start+size:start+size])
+		start += size
+	}
+
+	return rows
+}
+
+// balance returns the sizes of the rows which the items of the widths should be arranged in.
+func balance(widths []int, opts BalanceOptions) []int {
+	n := len(widths)
+	if n == 0 {
+		return nil
+	}
+
+	// prefix[i] is the total width of the first i items.
+	prefix := make([]int, n+1)
+	for i, width := range widths {
+		prefix[i+1] = prefix[i] + width
+	}
+
+	// fits returns true if the items i to j (exclusive) can be put in a single row.
+	fits := func(i, j int) bool {
+		return j-i <= opts.MaxPerRow && (j-i == 1 || prefix[j]-prefix[i] <= opts.MaxRowWidth)
+	}
+
+	// the rows are kept as few as possible; among the arrangements with the same number of rows,
+	// the one with the least sum of the squared row widths is the most balanced.
+	const infinity = int(^uint(0) >> 1)
+	type cell struct{ rows, cost, prev int }
+
+	best := make([]cell, n+1)
+	for j := 1; j <= n; j++ {
+		best[j] = cell{rows: infinity}
+
+		for i := j - 1; i >= 0 && fits(i, j); i-- {
+			if best[i].rows == infinity {
+				continue
+			}
+
+			width := prefix[j] - prefix[i]
+			candidate := cell{rows: best[i].rows + 1, cost: best[i].cost + width*width, prev: i}
+			if candidate.rows < best[j].rows || candidate.rows == best[j].rows && candidate.cost < best[j].cost {
+				best[j] = candidate
+			}
+		}
+	}
+
+	var sizes []int
+	for j := n; j > 0; j = best[j].prev {
+		sizes = append([]int{j - best[j].prev}, sizes...)
+	}
+
+	return sizes
+}
@@ -0,0 +1,33 @@
+package keyboard
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBalance(t *testing.T) {
+	tests := []struct {
+		labels []string
+		opts   BalanceOptions
+		sizes  []int
+	}{
+		{labels: nil, sizes: nil},
+		{labels: []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10"}, sizes: []int{5, 5}},
+		{labels: []string{"Yes", "No"}, sizes: []int{2}},
+		{labels: []string{"A very long label for a button", "OK", "Cancel"}, opts: BalanceOptions{MaxRowWidth: 30}, sizes: []int{1, 2}},
+		{labels: []string{"a", "b", "c", "d", "e"}, opts: BalanceOptions{MaxPerRow: 2}, sizes: []int{2, 2, 1}},
+	}
+
+	for _, test := range tests {
+		rows := BalanceFunc(test.labels, func(s string) string { return s }, test.opts)
+
+		var sizes []int
+		for _, row := range rows {
+			sizes = append(sizes, len(row))
+		}
+
+		if !reflect.DeepEqual(sizes, test.sizes) {
+			t.Errorf("%q: got rows of %v, want %v", test.labels, sizes, test.sizes)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package tgo
+
+// LinkPreviewDisabled returns the link preview options which disable the preview.
+func LinkPreviewDisabled() *LinkPreviewOptions { return &LinkPreviewOptions{IsDisabled: true} }
+
+// LinkPreviewSmall returns the link preview options which show the url's preview with a shrunk media,
+// or the first url of the text's if it's empty.
+func LinkPreviewSmall(url string) *LinkPreviewOptions {
+	return &LinkPreviewOptions{Url: url, PreferSmallMedia: true}
+}
+
+// LinkPreviewLarge returns the link preview options which show the url's preview with an enlarged media,
+// or the first url of the text's if it's empty.
+func LinkPreviewLarge(url string) *LinkPreviewOptions {
+	return &LinkPreviewOptions{Url: url, PreferLargeMedia: true}
+}
+
+// AboveText makes the preview shown above the message's text, and returns the options:
+//
+//	bot.Send(&tgo.SendMessage{Text: text, LinkPreviewOptions: tgo.LinkPreviewLarge(url).AboveText()})
+func (o *LinkPreviewOptions) AboveText() *LinkPreviewOptions {
+	o.ShowAboveText = true
+	return o
+}
+
+// LinkPreviewSettable is an interface that represents any object that can have its LinkPreviewOptions set,
+// or in other words, the text messages.
+type LinkPreviewSettable interface {
+	GetLinkPreview() *LinkPreviewOptions
+	SetLinkPreview(options *LinkPreviewOptions)
+}
+
+func (x *SendMessage) GetLinkPreview() *LinkPreviewOptions             { return x.LinkPreviewOptions }
+func (x *EditMessageText) GetLinkPreview() *LinkPreviewOptions         { return x.LinkPreviewOptions }
+func (x *InputTextMessageContent) GetLinkPreview() *LinkPreviewOptions { return x.LinkPreviewOptions }
+
+func (x *SendMessage) SetLinkPreview(options *LinkPreviewOptions)     { x.LinkPreviewOptions = options }
+func (x *EditMessageText) SetLinkPreview(options *LinkPreviewOptions) { x.LinkPreviewOptions = options }
+func (x *InputTextMessageContent) SetLinkPreview(options *LinkPreviewOptions) {
+	x.LinkPreviewOptions = options
+}
+
+// applyLinkPreview sets the bot's DefaultLinkPreview to the message, if it has none of its own.
+func (b *Bot) applyLinkPreview(msg any) {
+	if b.DefaultLinkPreview == nil {
+		return
+	}
+
+	if x, ok := msg.(LinkPreviewSettable); ok && x.GetLinkPreview() == nil {
+		// each message gets its own copy, so changing one doesn't change the default.
+		options := *b.DefaultLinkPreview
+		x.SetLinkPreview(&options)
+	}
+}
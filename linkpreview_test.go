@@ -0,0 +1,35 @@
+package tgo_test
+
+import (
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestDefaultLinkPreview(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	bot := server.Bot(tgo.Options{DefaultLinkPreview: tgo.LinkPreviewDisabled()})
+
+	bot.Send(&tgo.SendMessage{ChatId: tgo.ID(1), Text: "https://example.com"})
+	bot.Send(&tgo.SendMessage{ChatId: tgo.ID(1), Text: "https://example.com", LinkPreviewOptions: tgo.LinkPreviewLarge("").AboveText()})
+	bot.EditOrSend(tgo.ID(1), 5, "https://example.com", nil)
+
+	calls := server.Calls()
+	if len(calls) != 3 {
+		t.Fatalf("made %d calls, want 3", len(calls))
+	}
+
+	defaulted, own, edited := calls[0].Params["link_preview_options"], calls[1].Params["link_preview_options"], calls[2].Params["link_preview_options"]
+	if options, _ := defaulted.(map[string]any); options["is_disabled"] != true {
+		t.Errorf("the sent message got %v, want the default", defaulted)
+	}
+	if options, _ := own.(map[string]any); options["prefer_large_media"] != true || options["show_above_text"] != true || options["is_disabled"] != nil {
+		t.Errorf("the message's own options are replaced by %v", own)
+	}
+	if options, _ := edited.(map[string]any); options["is_disabled"] != true {
+		t.Errorf("the edited message got %v, want the default", edited)
+	}
+}
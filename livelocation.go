@@ -0,0 +1,153 @@
+package tgo
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrNoLivePeriod = errors.New("tgo: live location must have a live period")
+
+// Position is a point of a live location.
+type Position struct {
+	Latitude           float64
+	Longitude          float64
+	HorizontalAccuracy float64 // optional; the radius of uncertainty in meters
+	Heading            int64   // optional; the direction of the movement in degrees, 1-360
+}
+
+// LiveLocationOptions configures a live location's updater. The zero value is valid and uses the defaults.
+type LiveLocationOptions struct {
+	// Interval is the minimum duration between two edits of the location; it defaults to 10 seconds.
+	// Only the latest position received in the meantime is sent.
+	Interval time.Duration
+
+	// OnError, if not nil, is called when editing the location fails.
+	OnError func(err error)
+}
+
+// LiveLocation is a live location message which is kept updated from a position source.
+type LiveLocation struct {
+	// Message is the sent live location message.
+	Message *Message
+
+	api       *API
+	opts      LiveLocationOptions
+	positions <-chan Position
+	expiry    time.Time
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+	stopErr  error
+}
+
+// SendLiveLocation sends the live location, and then keeps it updated with the positions received from
+// the channel, until the channel is closed, its live period expires, or the Stop method is called.
+func (bot *Bot) SendLiveLocation(params *SendLocation, positions <-chan Position, opts LiveLocationOptions) (*LiveLocation, error) {
+	if params.LivePeriod <= 0 {
+		return nil, ErrNoLivePeriod
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = 10 * time.Second
+	}
+
+	msg, err := bot.Send(params)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &LiveLocation{
+		Message:   msg,
+		api:       bot.API,
+		opts:      opts,
+		positions: positions,
+		expiry:    time.Now().Add(time.Duration(params.LivePeriod) * time.Second),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	go l.run()
+
+	return l, nil
+}
+
+// Done is closed when the location is no longer updated.
+func (l *LiveLocation) Done() <-chan struct{} { return l.done }
+
+// Stop stops updating the location, and stops its live sharing by stopMessageLiveLocation
+// unless its live period is already expired. It's safe to call it more than once.
+func (l *LiveLocation) Stop() error {
+	l.stopOnce.Do(func() { close(l.stop) })
+	<-l.done
+
+	return l.stopErr
+}
+
+func (l *LiveLocation) run() {
+	defer close(l.done)
+
+	ticker := time.NewTicker(l.opts.Interval)
+	defer ticker.Stop()
+
+	expired := time.NewTimer(time.Until(l.expiry))
+	defer expired.Stop()
+
+	var latest *Position
+	positions := l.positions
+
+	for {
+		select {
+		case <-expired.C:
+			return
+
+		case <-l.stop:
+			l.stopLive()
+			return
+
+		case position, ok := <-positions:
+			if !ok {
+				// send the last position before stopping.
+				if latest != nil {
+					l.edit(*latest)
+				}
+				l.stopLive()
+				return
+			}
+			latest = &position
+
+		case <-ticker.C:
+			if latest != nil {
+				l.edit(*latest)
+				latest = nil
+			}
+		}
+	}
+}
+
+func (l *LiveLocation) edit(position Position) {
+	_, err := l.api.EditMessageLiveLocation(&EditMessageLiveLocation{
+		ChatId:             ID(l.Message.Chat.Id),
+		MessageId:          l.Message.MessageId,
+		Latitude:           position.Latitude,
+		Longitude:          position.Longitude,
+		HorizontalAccuracy: position.HorizontalAccuracy,
+		Heading:            position.Heading,
+	})
+
+	if tgErr, ok := err.(*Error); ok && tgErr.Description == ErrMessageNotModified.Description {
+		return
+	} else if err != nil && l.opts.OnError != nil {
+		l.opts.OnError(err)
+	}
+}
+
+func (l *LiveLocation) stopLive() {
+	if time.Now().After(l.expiry) {
+		return
+	}
+
+	_, l.stopErr = l.api.StopMessageLiveLocation(&StopMessageLiveLocation{
+		ChatId:    ID(l.Message.Chat.Id),
+		MessageId: l.Message.MessageId,
+	})
+}
@@ -0,0 +1,41 @@
+package tgo_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestLiveLocation(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	bot := server.Bot(tgo.Options{})
+
+	positions := make(chan tgo.Position)
+	live, err := bot.SendLiveLocation(&tgo.SendLocation{ChatId: tgo.ID(1), LivePeriod: 60}, positions, tgo.LiveLocationOptions{Interval: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	positions <- tgo.Position{Latitude: 1, Longitude: 1}
+	positions <- tgo.Position{Latitude: 2, Longitude: 2}
+	close(positions)
+
+	select {
+	case <-live.Done():
+	case <-time.After(time.Second):
+		t.Fatal("live location is not stopped")
+	}
+
+	// only the latest position is sent before stopping.
+	var methods []string
+	for _, call := range server.Calls() {
+		methods = append(methods, call.Method)
+	}
+	if len(methods) != 3 || methods[1] != "editMessageLiveLocation" || methods[2] != "stopMessageLiveLocation" {
+		t.Errorf("unexpected calls: %q", methods)
+	}
+}
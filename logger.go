@@ -0,0 +1,87 @@
+package tgo
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// LogLevel is the severity of a logged event. Its values match the ones of log/slog.
+type LogLevel int
+
+const (
+	LevelDebug LogLevel = -4 // the updates, the handlers which used them, and the API calls
+	LevelInfo  LogLevel = 0  // the failed API calls and the hedged ones
+	LevelWarn  LogLevel = 4  // the flood waits and the exceeded call budgets
+	LevelError LogLevel = 8  // the failures which are otherwise lost, such as the stores' errors
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	}
+	return fmt.Sprintf("LEVEL(%d)", int(l))
+}
+
+// Logger receives the structured events of the bot, such as the received updates and the API calls.
+// The args are the event's attributes as alternating keys and values, just like the ones of slog.
+//
+// Use the contrib/tgoslog module to pass them to a *slog.Logger.
+type Logger interface {
+	Log(level LogLevel, msg string, args ...any)
+}
+
+// LoggerFunc is an adapter to use an ordinary function as a Logger.
+type LoggerFunc func(level LogLevel, msg string, args ...any)
+
+// Log implements the Logger interface.
+func (f LoggerFunc) Log(level LogLevel, msg string, args ...any) { f(level, msg, args...) }
+
+// stdLogger is the logger of the bots without Options.Logger. It prints the warnings
+// and the errors using the standard log package, and drops everything else.
+type stdLogger struct{}
+
+// Log implements the Logger interface.
+func (stdLogger) Log(level LogLevel, msg string, args ...any) {
+	if level < LevelWarn {
+		return
+	}
+
+	var line strings.Builder
+	line.WriteString("tgo: " + msg)
+	for i := 0; i+1 < len(args); i += 2 {
+		fmt.Fprintf(&line, " %v=%v", args[i], args[i+1])
+	}
+
+	log.Print(line.String())
+}
+
+func (api *API) log(level LogLevel, msg string, args ...any) { api.logger.Log(level, msg, args...) }
+
+// Logger returns the api's logger, for the packages built on tgo to log through it.
+func (api *API) Logger() Logger { return api.logger }
+
+// logCall logs the result of an API call, and the flood waits on their own.
+func (api *API) logCall(method string, err error, args ...any) {
+	args = append([]any{"method", method}, args...)
+
+	if retryAfter, isFloodWait := IsRateLimitErr(err); isFloodWait {
+		api.log(LevelWarn, "flood wait", append(args, "retry_after", retryAfter)...)
+	} else if err != nil {
+		api.log(LevelInfo, "api call failed", append(args, "error", err)...)
+	} else {
+		api.log(LevelDebug, "api call", args...)
+	}
+}
+
+// updateSummary lazily describes the update in the logs.
+type updateSummary struct{ update *Update }
+
+func (s updateSummary) String() string { return describeUpdate(s.update) }
@@ -0,0 +1,39 @@
+package tgo_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestLogger(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	server.Handle("sendMessage", func(call tgotest.Call) (any, *tgo.Error) {
+		return nil, &tgo.Error{ErrorCode: 429, Description: "Too Many Requests: retry after 3", Parameters: &tgo.ResponseParameters{RetryAfter: 3}}
+	})
+
+	var mut sync.Mutex
+	var events []string
+	bot := server.Bot(tgo.Options{Logger: tgo.LoggerFunc(func(level tgo.LogLevel, msg string, args ...any) {
+		mut.Lock()
+		events = append(events, level.String()+" "+msg)
+		mut.Unlock()
+	})})
+
+	bot.AddRouter(&echoRouter{})
+	bot.HandleUpdate(&tgo.Update{Message: &tgo.Message{Chat: tgo.Chat{Id: 1}, Text: "hi"}})
+
+	expected := []string{"DEBUG update received", "WARN flood wait", "DEBUG update handled"}
+	if len(events) != len(expected) {
+		t.Fatalf("expected the events %q, got %q", expected, events)
+	}
+	for i := range expected {
+		if events[i] != expected[i] {
+			t.Fatalf("expected the events %q, got %q", expected, events)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package tgo
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxMessageLength is the maximum length of a message's text, in UTF-16 code units after its entities parsing.
+const MaxMessageLength = 4096
+
+// ErrMarkdownTooLong is returned by SendLongMessage for the Markdown texts longer than MaxMessageLength,
+// which it can't split without breaking their formatting.
+var ErrMarkdownTooLong = errors.New("tgo: the Markdown texts longer than a message can't be split; use the entities or HTML")
+
+// SendLongMessage sends the text of the message in as many messages as it takes, split by SplitWithEntities
+// at MaxMessageLength, in order; and returns the sent messages. Only the first one replies to the
+// ReplyToMessageId, and only the last one has the ReplyMarkup.
+//
+// The texts with entities and the plain ones are split as is. The HTML ones are split outside of their tags,
+// which are closed at the end of each chunk and opened again at the start of the next one. The Markdown
+// ones are sent as is, or fail with ErrMarkdownTooLong if they don't fit in a message.
+//
+// If a chunk fails to be sent, the messages sent before it are returned with the error.
+func (b *Bot) SendLongMessage(msg *SendMessage) ([]*Message, error) {
+	parseMode := msg.ParseMode
+	if parseMode == ParseModeNone && len(msg.Entities) == 0 {
+		parseMode = b.DefaultParseMode
+	}
+
+	var chunks []TextChunk
+	switch {
+	case UTF16Len(msg.Text) <= MaxMessageLength:
+		chunks = []TextChunk{{Text: msg.Text, Entities: msg.Entities}}
+	case len(msg.Entities) != 0 || parseMode == ParseModeNone:
+		chunks = SplitWithEntities(msg.Text, msg.Entities, MaxMessageLength)
+	case parseMode == ParseModeHTML:
+		for _, text := range splitHTML(msg.Text, MaxMessageLength) {
+			chunks = append(chunks, TextChunk{Text: text})
+		}
+	default:
+		return nil, ErrMarkdownTooLong
+	}
+
+	sent := make([]*Message, 0, len(chunks))
+	for i, chunk := range chunks {
+		part := *msg
+		part.Text, part.Entities = chunk.Text, chunk.Entities
+		if len(part.Entities) == 0 {
+			part.ParseMode = parseMode
+		}
+		if i != 0 {
+			part.ReplyToMessageId = 0
+		}
+		if i != len(chunks)-1 {
+			part.ReplyMarkup = nil
+		}
+
+		message, err := b.Send(&part)
+		if err != nil {
+			return sent, err
+		}
+		sent = append(sent, message)
+	}
+
+	return sent, nil
+}
+
+// htmlTag is an open tag of an HTML text.
+type htmlTag struct{ name, opening string }
+
+// htmlCut is where an HTML text is split.
+type htmlCut struct {
+	end, next int // the byte offsets of the chunk's end and the next chunk's start
+	length    int // the chunk's length, in UTF-16 code units
+	open      []htmlTag
+}
+
+// splitHTML splits the HTML text like SplitWithEntities, but only outside of its tags and character
+// references. The tags open at each split are closed at the end of its chunk, and opened again in the
+// next one. The chunks are measured with their tags, so they fit after the parsing too.
+func splitHTML(text string, max int) []string {
+	var chunks []string
+	var open []htmlTag
+
+	for text != "" {
+		var prefix strings.Builder
+		for _, tag := range open {
+			prefix.WriteString(tag.opening)
+		}
+
+		if UTF16Len(prefix.String())+UTF16Len(text) <= max {
+			chunks = append(chunks, prefix.String()+text)
+			break
+		}
+
+		cut := htmlSplitPoint(text, open, max-UTF16Len(prefix.String()))
+		chunks = append(chunks, prefix.String()+text[:cut.end]+closingTags(cut.open))
+		text, open = text[cut.next:], cut.open
+	}
+
+	return chunks
+}
+
+// htmlSplitPoint returns where the HTML text, after the open tags, is split to fit in the budget.
+func htmlSplitPoint(text string, open []htmlTag, budget int) htmlCut {
+	stack := append([]htmlTag(nil), open...)
+	last := htmlCut{open: stack}
+
+	// the last split at each of the splitSeparators.
+	var found [3]*htmlCut
+
+	for i, length := 0, 0; i < len(text); {
+		token := htmlToken(text[i:])
+
+		if sep, ok := htmlSeparator(text[i:]); ok {
+			found[sep] = &htmlCut{end: i, next: i + len(splitSeparators[sep]), length: length, open: stack}
+		}
+
+		next := pushTag(stack, token)
+		size := UTF16Len(token)
+		if length+size+UTF16Len(closingTags(next)) > budget {
+			break
+		}
+
+		i, length, stack = i+len(token), length+size, next
+		last = htmlCut{end: i, next: i, length: length, open: stack}
+	}
+
+	for _, cut := range found {
+		if cut != nil && cut.end > 0 && cut.length >= budget/2 {
+			return *cut
+		}
+	}
+
+	// a single token is taken if nothing fits, so the splitting goes on.
+	if last.end == 0 {
+		token := htmlToken(text)
+		return htmlCut{end: len(token), next: len(token), open: pushTag(open, token)}
+	}
+	return last
+}
+
+// htmlSeparator returns the index of the splitSeparators which the text starts with, if any.
+func htmlSeparator(text string) (int, bool) {
+	switch {
+	case strings.HasPrefix(text, "\n\n"):
+		return 0, true
+	case strings.HasPrefix(text, "\n"):
+		return 1, true
+	case strings.HasPrefix(text, " "):
+		return 2, true
+	}
+	return 0, false
+}
+
+// htmlToken returns the tag, the character reference, or the character which the text starts with.
+func htmlToken(text string) string {
+	switch text[0] {
+	case '<':
+		if end := strings.IndexByte(text, '>'); end != -1 {
+			return text[:end+1]
+		}
+	case '&':
+		if end := strings.IndexByte(text, ';'); end != -1 && end < 12 {
+			return text[:end+1]
+		}
+	}
+
+	_, size := utf8.DecodeRuneInString(text)
+	return text[:size]
+}
+
+// pushTag returns the stack of the open tags after the token; the passed stack is not modified.
+func pushTag(stack []htmlTag, token string) []htmlTag {
+	if len(token) < 3 || token[0] != '<' {
+		return stack
+	}
+
+	if token[1] == '/' {
+		name := strings.ToLower(strings.TrimSpace(token[2 : len(token)-1]))
+		for i := len(stack) - 1; i >= 0; i-- {
+			if stack[i].name == name {
+				return stack[:i:i]
+			}
+		}
+		return stack
+	}
+
+	fields := strings.Fields(token[1 : len(token)-1])
+	if len(fields) == 0 {
+		return stack
+	}
+	return append(stack[:len(stack):len(stack)], htmlTag{name: strings.ToLower(fields[0]), opening: token})
+}
+
+// closingTags returns the tags closing the open ones, the innermost first.
+func closingTags(open []htmlTag) string {
+	var closing strings.Builder
+	for i := len(open) - 1; i >= 0; i-- {
+		closing.WriteString("</" + open[i].name + ">")
+	}
+	return closing.String()
+}
@@ -0,0 +1,60 @@
+package tgo_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestSendLongMessage(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	bot := server.Bot(tgo.Options{DefaultParseMode: tgo.ParseModeHTML})
+
+	paragraph := strings.Repeat("word ", 500)
+	text := `<b>` + paragraph + `<a href="https://example.com">` + paragraph + `</a></b>` + "\n\n" + paragraph
+
+	sent, err := bot.SendLongMessage(&tgo.SendMessage{
+		ChatId:           tgo.ID(42),
+		Text:             text,
+		ReplyToMessageId: 7,
+		ReplyMarkup:      &tgo.InlineKeyboardMarkup{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	} else if len(sent) != 2 {
+		t.Fatalf("sent %d messages, want 2", len(sent))
+	}
+
+	calls := server.Calls()
+	first, second := calls[0].Params, calls[1].Params
+	if first["reply_to_message_id"] != float64(7) || second["reply_to_message_id"] != nil {
+		t.Fatal("only the first chunk should reply to the message")
+	} else if first["reply_markup"] != nil || second["reply_markup"] == nil {
+		t.Fatal("only the last chunk should have the reply markup")
+	}
+
+	for _, params := range []map[string]any{first, second} {
+		chunk := params["text"].(string)
+		if tgo.UTF16Len(chunk) > tgo.MaxMessageLength {
+			t.Fatalf("a chunk of %d units is sent", tgo.UTF16Len(chunk))
+		} else if strings.Count(chunk, "<b>") != strings.Count(chunk, "</b>") || strings.Count(chunk, "<a ") != strings.Count(chunk, "</a>") {
+			t.Fatalf("the tags of a chunk aren't balanced: %q", chunk)
+		} else if params["parse_mode"] != "HTML" {
+			t.Fatalf("a chunk is sent in %v", params["parse_mode"])
+		}
+	}
+
+	if !strings.HasPrefix(second["text"].(string), `<b><a href="https://example.com">`) {
+		t.Fatalf("the open tags aren't opened again in the next chunk: %.40q", second["text"])
+	}
+
+	_, err = bot.SendLongMessage(&tgo.SendMessage{ChatId: tgo.ID(42), Text: text, ParseMode: tgo.ParseModeMarkdownV2})
+	if !errors.Is(err, tgo.ErrMarkdownTooLong) {
+		t.Fatalf("the long Markdown text failed with %v", err)
+	}
+}
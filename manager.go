@@ -0,0 +1,199 @@
+package tgo
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrBotNotManaged is returned by Manager.Remove for the bots which are not created by the manager.
+var ErrBotNotManaged = errors.New("tgo: bot is not managed by the manager")
+
+// ErrBotAlreadyManaged is returned by Manager.NewBot for the tokens of the bots which the manager
+// already has; remove them first to replace them.
+var ErrBotAlreadyManaged = errors.New("tgo: bot is already managed by the manager")
+
+// ManagerOptions configures a Manager. The zero value is valid and uses the defaults.
+type ManagerOptions struct {
+	// Workers is the number of the goroutines handling the updates of all the bots; it defaults to 64.
+	Workers int
+
+	// QueueSize is the number of the updates which can wait for the workers; it defaults to 1024.
+	QueueSize int
+
+	// RateLimit, if not zero, is the number of the API calls per second which all the bots may make
+	// together, with the bursts of up to Burst calls.
+	RateLimit float64
+	Burst     int
+
+	// WebhookPrefix is the path prefix which the bots' webhooks are served under; it defaults to DefaultWebhookMuxPrefix.
+	WebhookPrefix string
+
+	// OnPollingError, if not nil, is called when the polling of a bot fails; it's logged by default.
+	OnPollingError func(bot *Bot, err error)
+}
+
+// Manager runs many bots of different tokens in one process, such as a white-label bot platform.
+// The bots share a worker pool, an optional rate limit, and a WebhookMux to route the updates to
+// each of them, and they're shut down all together.
+type Manager struct {
+	opts    ManagerOptions
+	pool    *WorkerPool
+	limiter *RateLimiter
+	mux     *WebhookMux
+
+	mut  sync.Mutex
+	bots map[string]*Bot // by their token hash
+	wg   sync.WaitGroup  // the pollers
+}
+
+// NewManager returns a new Manager. Shut it down when you're done, to stop its workers.
+func NewManager(opts ManagerOptions) *Manager {
+	if opts.Workers <= 0 {
+		opts.Workers = 64
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = 1024
+	}
+
+	m := &Manager{
+		opts: opts,
+		pool: NewWorkerPool(opts.Workers, opts.QueueSize),
+		mux:  NewWebhookMux(opts.WebhookPrefix),
+		bots: make(map[string]*Bot),
+	}
+	if opts.RateLimit > 0 {
+		m.limiter = NewRateLimiter(opts.RateLimit, opts.Burst)
+	}
+
+	return m
+}
+
+// NewBot creates a bot which uses the manager's worker pool and rate limit, replacing the ones
+// of the options, and adds it to the manager. It fails with ErrBotAlreadyManaged if the manager
+// already has a bot of the token, which is shut down by Remove before it's added again.
+func (m *Manager) NewBot(token string, opts Options) (*Bot, error) {
+	opts.WorkerPool = m.pool
+	opts.RateLimiter = m.limiter
+
+	hash := TokenHash(token)
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	if _, ok := m.bots[hash]; ok {
+		return nil, ErrBotAlreadyManaged
+	}
+
+	bot := NewBot(token, opts)
+	m.bots[hash] = bot
+	return bot, nil
+}
+
+// Bot returns the managed bot of the token hash, such as the last part of its webhook path.
+func (m *Manager) Bot(tokenHash string) (*Bot, bool) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	bot, ok := m.bots[tokenHash]
+	return bot, ok
+}
+
+// Bots returns all of the managed bots, in no particular order.
+func (m *Manager) Bots() []*Bot {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	bots := make([]*Bot, 0, len(m.bots))
+	for _, bot := range m.bots {
+		bots = append(bots, bot)
+	}
+	return bots
+}
+
+// StartPolling starts polling the managed bot in a new goroutine, which runs until the bot
+// is shut down or the polling fails.
+func (m *Manager) StartPolling(bot *Bot, opts PollingOptions) {
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+
+		if err := bot.StartPollingWithOptions(opts); err != nil {
+			if m.opts.OnPollingError != nil {
+				m.opts.OnPollingError(bot, err)
+			} else {
+				bot.log(LevelError, "polling failed", "error", err)
+			}
+		}
+	}()
+}
+
+// ServeWebhook starts routing the managed bot's webhook updates by the manager's Handler, and returns
+// the path it's served on, to be appended to the public URL passed to SetWebhook.
+func (m *Manager) ServeWebhook(bot *Bot, opts WebhookOptions) (path string) {
+	return m.mux.Add(bot, opts)
+}
+
+// Handler returns the http.Handler serving the webhooks of all the bots passed to ServeWebhook.
+func (m *Manager) Handler() *WebhookMux { return m.mux }
+
+// QueueLen returns the number of the updates waiting for the manager's workers.
+func (m *Manager) QueueLen() int { return m.pool.Len() }
+
+// Remove shuts the bot down, and removes it from the manager.
+func (m *Manager) Remove(ctx context.Context, bot *Bot) error {
+	hash := TokenHash(bot.token)
+
+	m.mut.Lock()
+	managed, ok := m.bots[hash]
+	if ok && managed == bot {
+		delete(m.bots, hash)
+	}
+	m.mut.Unlock()
+
+	if !ok || managed != bot {
+		return ErrBotNotManaged
+	}
+
+	m.mux.Remove(bot)
+	return bot.Shutdown(ctx)
+}
+
+// Shutdown shuts all of the bots down at once, and then stops the workers once they're done.
+// It returns the first error of the bots' shutdowns, or the context's error if the pollers don't return
+// before it's done, leaving the workers running in these cases.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	bots := m.Bots()
+
+	errs := make(chan error, len(bots))
+	for _, bot := range bots {
+		go func(bot *Bot) { errs <- bot.Shutdown(ctx) }(bot)
+	}
+
+	var firstErr error
+	for range bots {
+		if err := <-errs; err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	// the pollers return once their bots are shut down, unless they're stuck in a call.
+	polled := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(polled)
+	}()
+
+	select {
+	case <-polled:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	m.pool.Stop()
+	return nil
+}
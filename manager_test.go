@@ -0,0 +1,76 @@
+package tgo_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+type recordRouter struct{ chats chan int64 }
+
+func (r recordRouter) Setup(bot *tgo.Bot) error { return nil }
+
+func (r recordRouter) HandleUpdate(bot *tgo.Bot, update *tgo.Update) bool {
+	r.chats <- update.Message.Chat.Id
+	return true
+}
+
+func TestManager(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	manager := tgo.NewManager(tgo.ManagerOptions{Workers: 2, RateLimit: 100, Burst: 10})
+
+	var paths []string
+	var routers []recordRouter
+	for _, token := range []string{"1:first", "2:second"} {
+		bot, err := manager.NewBot(token, tgo.Options{Host: server.URL, Client: server.Client()})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router := recordRouter{chats: make(chan int64, 1)}
+		bot.AddRouter(router)
+
+		routers = append(routers, router)
+		paths = append(paths, manager.ServeWebhook(bot, tgo.WebhookOptions{}))
+	}
+
+	for i, path := range paths {
+		body := `{"update_id": 1, "message": {"message_id": 1, "date": 1, "chat": {"id": ` + string(rune('1'+i)) + `, "type": "private"}}}`
+		recorder := httptest.NewRecorder()
+		manager.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("the webhook of bot %d answered %d", i, recorder.Code)
+		}
+	}
+
+	for i, router := range routers {
+		select {
+		case chat := <-router.chats:
+			if chat != int64(i+1) {
+				t.Fatalf("bot %d got the update of chat %d", i, chat)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("bot %d didn't get its update", i)
+		}
+	}
+
+	if _, err := manager.NewBot("1:first", tgo.Options{}); !errors.Is(err, tgo.ErrBotAlreadyManaged) {
+		t.Fatalf("adding the token again returned %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := manager.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,53 @@
+package tgo
+
+import "io"
+
+// MediaInfo describes an uploaded file passed to the media transformers.
+type MediaInfo struct {
+	Method string // the API method uploading the file, such as "sendPhoto"
+	Field  string // the multipart field of the file, such as "photo", or its attachment name in the media groups
+	Name   string // the name of the file, as passed to FileFromReader
+}
+
+// MediaTransformer transforms the content of the uploaded files, such as watermarking or resizing
+// the images, before they're sent to telegram. The transformers should stream the content where
+// possible, and return the reader as is for the files they don't care about.
+type MediaTransformer interface {
+	Transform(info MediaInfo, r io.Reader) (io.Reader, error)
+}
+
+// MediaTransformerFunc is a function which implements the MediaTransformer interface.
+type MediaTransformerFunc func(info MediaInfo, r io.Reader) (io.Reader, error)
+
+// Transform implements the MediaTransformer interface.
+func (f MediaTransformerFunc) Transform(info MediaInfo, r io.Reader) (io.Reader, error) {
+	return f(info, r)
+}
+
+// WithMediaPipeline returns a copy of the api which passes the uploaded files through the
+// transformers, in order, after the ones which the api already has.
+func (api *API) WithMediaPipeline(transformers ...MediaTransformer) *API {
+	clone := *api
+	clone.mediaPipeline = append(append([]MediaTransformer(nil), api.mediaPipeline...), transformers...)
+	return &clone
+}
+
+// WithMediaPipeline returns a copy of the bot whose API passes the uploaded files through the
+// transformers, such as for a single send call; see Options.MediaPipeline for all of them.
+func (bot *Bot) WithMediaPipeline(transformers ...MediaTransformer) *Bot {
+	clone := *bot
+	clone.API = bot.API.WithMediaPipeline(transformers...)
+	return &clone
+}
+
+// transformMedia passes the file's content through the api's media pipeline.
+func (api *API) transformMedia(info MediaInfo, r io.Reader) (io.Reader, error) {
+	var err error
+	for _, transformer := range api.mediaPipeline {
+		if r, err = transformer.Transform(info, r); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
@@ -0,0 +1,375 @@
+// Package media contains the built-in transformers of the outgoing media, to be used in the
+// bots' media pipelines; see tgo.Options.MediaPipeline and bot.WithMediaPipeline.
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	"github.com/haashemi/tgo"
+)
+
+const (
+	// MaxPhotoSize is the maximum size of the photos which telegram accepts.
+	MaxPhotoSize = 10 << 20
+
+	// MaxPhotoDimensionSum is the maximum sum of the photos' width and height which telegram accepts.
+	MaxPhotoDimensionSum = 10000
+)
+
+// ImageOptions configures NormalizeImages. The zero value is valid and uses the defaults.
+type ImageOptions struct {
+	// MaxBytes is the maximum size of the images; it defaults to MaxPhotoSize.
+	MaxBytes int
+
+	// MaxDimensionSum is the maximum sum of the images' width and height; it defaults to MaxPhotoDimensionSum.
+	MaxDimensionSum int
+
+	// MaxSide, if not zero, is the maximum width and height of the images.
+	MaxSide int
+
+	// JPEGQuality is the quality of the re-encoded JPEG images; it defaults to 90.
+	JPEGQuality int
+
+	// Methods are the API methods whose uploads are normalized; it defaults to sendPhoto and sendMediaGroup,
+	// to keep the documents as they are.
+	Methods []string
+}
+
+// NormalizeImages returns a transformer which strips the metadata, such as the EXIF and its GPS location,
+// of the uploaded JPEG and PNG images, and downscales the ones which exceed the options' limits.
+//
+// The images are only re-encoded when they need to be downscaled or rotated by their EXIF orientation;
+// otherwise, only their metadata segments are removed. The other files are passed as they are.
+func NormalizeImages(opts ImageOptions) tgo.MediaTransformer {
+	if opts.MaxBytes <= 0 {
+		opts.MaxBytes = MaxPhotoSize
+	}
+	if opts.MaxDimensionSum <= 0 {
+		opts.MaxDimensionSum = MaxPhotoDimensionSum
+	}
+	if opts.JPEGQuality <= 0 {
+		opts.JPEGQuality = 90
+	}
+	if len(opts.Methods) == 0 {
+		opts.Methods = []string{"sendPhoto", "sendMediaGroup"}
+	}
+
+	return tgo.MediaTransformerFunc(func(info tgo.MediaInfo, r io.Reader) (io.Reader, error) {
+		if !contains(opts.Methods, info.Method) {
+			return r, nil
+		}
+
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case bytes.HasPrefix(data, []byte("\xff\xd8")):
+			data, err = normalizeJPEG(data, opts)
+		case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+			data, err = normalizePNG(data, opts)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+		return bytes.NewReader(data), nil
+	})
+}
+
+func normalizeJPEG(data []byte, opts ImageOptions) ([]byte, error) {
+	config, err := jpeg.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	orientation := jpegOrientation(data)
+	if orientation <= 1 && len(data) <= opts.MaxBytes && fitScale(config.Width, config.Height, opts) == 1 {
+		return stripJPEG(data), nil
+	}
+
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeFitting(orient(toRGBA(img), orientation), opts, func(w io.Writer, img image.Image) error {
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: opts.JPEGQuality})
+	})
+}
+
+func normalizePNG(data []byte, opts ImageOptions) ([]byte, error) {
+	config, err := png.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) <= opts.MaxBytes && fitScale(config.Width, config.Height, opts) == 1 {
+		return stripPNG(data), nil
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeFitting(toRGBA(img), opts, png.Encode)
+}
+
+// encodeFitting downscales the image to fit in the options' dimensions, and then encodes it,
+// downscaling it further while it's larger than MaxBytes.
+func encodeFitting(img *image.RGBA, opts ImageOptions, encode func(w io.Writer, img image.Image) error) ([]byte, error) {
+	bounds := img.Bounds()
+	if scale := fitScale(bounds.Dx(), bounds.Dy(), opts); scale < 1 {
+		img = resize(img, scale)
+	}
+
+	for attempt := 0; ; attempt++ {
+		var buf bytes.Buffer
+		if err := encode(&buf, img); err != nil {
+			return nil, err
+		}
+
+		if buf.Len() <= opts.MaxBytes || attempt == 4 {
+			return buf.Bytes(), nil
+		}
+		img = resize(img, 0.75)
+	}
+}
+
+// fitScale returns the scale to downscale the image of the dimensions with, to fit in the options' limits.
+func fitScale(width, height int, opts ImageOptions) float64 {
+	scale := 1.0
+	if sum := width + height; sum > opts.MaxDimensionSum {
+		scale = float64(opts.MaxDimensionSum) / float64(sum)
+	}
+
+	side := width
+	if height > side {
+		side = height
+	}
+	if opts.MaxSide > 0 && side > opts.MaxSide {
+		if s := float64(opts.MaxSide) / float64(side); s < scale {
+			scale = s
+		}
+	}
+
+	return scale
+}
+
+func toRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok && rgba.Rect.Min == (image.Point{}) {
+		return rgba
+	}
+
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, bounds.Min, draw.Src)
+	return rgba
+}
+
+// resize downscales the image by the scale, averaging the source pixels covered by each of the new ones.
+func resize(src *image.RGBA, scale float64) *image.RGBA {
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+
+	nw, nh := int(float64(w)*scale), int(float64(h)*scale)
+	if nw < 1 {
+		nw = 1
+	}
+	if nh < 1 {
+		nh = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, nw, nh))
+	for y := 0; y < nh; y++ {
+		y0, y1 := y*h/nh, (y+1)*h/nh
+		if y1 == y0 {
+			y1++
+		}
+
+		for x := 0; x < nw; x++ {
+			x0, x1 := x*w/nw, (x+1)*w/nw
+			if x1 == x0 {
+				x1++
+			}
+
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					for c := 0; c < 4; c++ {
+						sum[c] += int(row[sx*4+c])
+					}
+				}
+			}
+
+			n := (y1 - y0) * (x1 - x0)
+			offset := y*dst.Stride + x*4
+			for c := 0; c < 4; c++ {
+				dst.Pix[offset+c] = uint8(sum[c] / n)
+			}
+		}
+	}
+
+	return dst
+}
+
+// orient rotates and flips the image by its EXIF orientation, so it's displayed the same without it.
+func orient(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // flipped horizontally
+				sx, sy = w-1-x, y
+			case 3: // rotated by 180 degrees
+				sx, sy = w-1-x, h-1-y
+			case 4: // flipped vertically
+				sx, sy = x, h-1-y
+			case 5: // transposed
+				sx, sy = y, x
+			case 6: // needs to be rotated clockwise
+				sx, sy = y, h-1-x
+			case 7: // transversed
+				sx, sy = w-1-y, h-1-x
+			case 8: // needs to be rotated counter-clockwise
+				sx, sy = w-1-y, x
+			}
+
+			copy(dst.Pix[y*dst.Stride+x*4:][:4], src.Pix[sy*src.Stride+sx*4:][:4])
+		}
+	}
+
+	return dst
+}
+
+// jpegSegments calls f with the marker and the whole of each of the JPEG's segments before its image data,
+// and returns the offset of the image data.
+func jpegSegments(data []byte, f func(marker byte, segment []byte)) int {
+	i := 2
+	for i+4 <= len(data) && data[i] == 0xff {
+		marker := data[i+1]
+		if marker == 0xda { // start of scan; the image data follows.
+			break
+		} else if marker >= 0xd0 && marker <= 0xd7 || marker == 0x01 {
+			f(marker, data[i:i+2])
+			i += 2
+			continue
+		}
+
+		end := i + 2 + int(binary.BigEndian.Uint16(data[i+2:]))
+		if end > len(data) {
+			break
+		}
+
+		f(marker, data[i:end])
+		i = end
+	}
+
+	return i
+}
+
+// stripJPEG removes the EXIF, XMP, IPTC, and comment segments of the JPEG, keeping its color profile.
+func stripJPEG(data []byte) []byte {
+	stripped := append(make([]byte, 0, len(data)), data[:2]...)
+
+	rest := jpegSegments(data, func(marker byte, segment []byte) {
+		if marker != 0xe1 && marker != 0xed && marker != 0xfe {
+			stripped = append(stripped, segment...)
+		}
+	})
+
+	return append(stripped, data[rest:]...)
+}
+
+// jpegOrientation returns the EXIF orientation of the JPEG, or zero if it has none.
+func jpegOrientation(data []byte) (orientation int) {
+	jpegSegments(data, func(marker byte, segment []byte) {
+		if marker != 0xe1 || orientation != 0 || !bytes.HasPrefix(segment[4:], []byte("Exif\x00\x00")) {
+			return
+		}
+		tiff := segment[10:]
+		if len(tiff) < 8 {
+			return
+		}
+
+		var order binary.ByteOrder
+		switch string(tiff[:2]) {
+		case "II":
+			order = binary.LittleEndian
+		case "MM":
+			order = binary.BigEndian
+		default:
+			return
+		}
+
+		ifd := int(order.Uint32(tiff[4:]))
+		if ifd+2 > len(tiff) {
+			return
+		}
+
+		for i, n := 0, int(order.Uint16(tiff[ifd:])); i < n; i++ {
+			entry := ifd + 2 + i*12
+			if entry+12 > len(tiff) {
+				return
+			}
+
+			if order.Uint16(tiff[entry:]) == 0x0112 {
+				orientation = int(order.Uint16(tiff[entry+8:]))
+				return
+			}
+		}
+	})
+
+	return orientation
+}
+
+// stripPNG removes the textual, EXIF, and time chunks of the PNG.
+func stripPNG(data []byte) []byte {
+	stripped := append(make([]byte, 0, len(data)), data[:8]...)
+
+	for i := 8; i+12 <= len(data); {
+		end := i + 12 + int(binary.BigEndian.Uint32(data[i:]))
+		if end > len(data) {
+			return append(stripped, data[i:]...)
+		}
+
+		switch string(data[i+4 : i+8]) {
+		case "tEXt", "zTXt", "iTXt", "eXIf", "tIME":
+		default:
+			stripped = append(stripped, data[i:end]...)
+		}
+		i = end
+	}
+
+	return stripped
+}
+
+func contains(items []string, item string) bool {
+	for _, x := range items {
+		if x == item {
+			return true
+		}
+	}
+
+	return false
+}
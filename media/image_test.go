@@ -0,0 +1,61 @@
+package media
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+	"testing"
+
+	"github.com/haashemi/tgo"
+)
+
+// exifJPEG returns a JPEG of the dimensions with an EXIF segment of the orientation.
+func exifJPEG(t *testing.T, width, height int, orientation byte) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	img.Set(0, 0, color.White)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// a big-endian TIFF with a single IFD entry of the orientation.
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08\x00\x01\x01\x12\x00\x03\x00\x00\x00\x01\x00" + string(orientation) + "\x00\x00\x00\x00\x00\x00")
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := append([]byte{0xff, 0xe1, byte((len(payload) + 2) >> 8), byte(len(payload) + 2)}, payload...)
+
+	data := buf.Bytes()
+	return append(append(append([]byte{}, data[:2]...), segment...), data[2:]...)
+}
+
+func transform(t *testing.T, opts ImageOptions, data []byte) []byte {
+	r, err := NormalizeImages(opts).Transform(tgo.MediaInfo{Method: "sendPhoto", Field: "photo"}, bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, _ := io.ReadAll(r)
+	return result
+}
+
+func TestNormalizeImages(t *testing.T) {
+	// the upright images are only stripped.
+	data := exifJPEG(t, 40, 20, 1)
+	if got := transform(t, ImageOptions{}, data); jpegOrientation(got) != 0 || len(got) >= len(data) || bytes.Contains(got, []byte("Exif")) {
+		t.Error("EXIF is not stripped")
+	}
+
+	// the rotated images are rotated before their orientation is stripped.
+	got := transform(t, ImageOptions{}, exifJPEG(t, 40, 20, 6))
+	if config, err := jpeg.DecodeConfig(bytes.NewReader(got)); err != nil || config.Width != 20 || config.Height != 40 {
+		t.Errorf("rotated image is %dx%d (%v), want 20x40", config.Width, config.Height, err)
+	}
+
+	// the large images are downscaled.
+	got = transform(t, ImageOptions{MaxDimensionSum: 30}, exifJPEG(t, 40, 20, 1))
+	if config, err := jpeg.DecodeConfig(bytes.NewReader(got)); err != nil || config.Width+config.Height > 30 {
+		t.Errorf("downscaled image is %dx%d (%v)", config.Width, config.Height, err)
+	}
+}
@@ -0,0 +1,74 @@
+package tgo_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestMediaPipeline(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	var seen []tgo.MediaInfo
+	record := tgo.MediaTransformerFunc(func(info tgo.MediaInfo, r io.Reader) (io.Reader, error) {
+		seen = append(seen, info)
+		return r, nil
+	})
+
+	bot := server.Bot(tgo.Options{MediaPipeline: []tgo.MediaTransformer{record}})
+
+	photo := &tgo.SendPhoto{ChatId: tgo.ID(1), Photo: tgo.FileFromReader("cat.jpg", strings.NewReader("jpeg"))}
+	if _, err := bot.Send(photo); err != nil {
+		t.Fatal(err)
+	}
+	if len(seen) != 1 || seen[0] != (tgo.MediaInfo{Method: "sendPhoto", Field: "photo", Name: "cat.jpg"}) {
+		t.Errorf("unexpected transformed files: %+v", seen)
+	}
+
+	// the per-call transformers run after the global ones, and their errors abort the upload.
+	errRejected := errors.New("rejected")
+	reject := tgo.MediaTransformerFunc(func(info tgo.MediaInfo, r io.Reader) (io.Reader, error) { return nil, errRejected })
+
+	photo = &tgo.SendPhoto{ChatId: tgo.ID(1), Photo: tgo.FileFromReader("dog.jpg", strings.NewReader("jpeg"))}
+	if _, err := bot.WithMediaPipeline(reject).Send(photo); err == nil {
+		t.Error("the upload is not aborted")
+	}
+	if len(seen) != 2 {
+		t.Errorf("global transformer ran %d times, want 2", len(seen))
+	}
+}
+
+func TestSendPaidMedia(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	bot := server.Bot(tgo.Options{}).WithBusinessConnection("conn")
+
+	paid := &tgo.SendPaidMedia{StarCount: 10, Media: []tgo.InputPaidMedia{
+		tgo.PaidPhoto(tgo.FileFromReader("secret.jpg", strings.NewReader("jpeg"))),
+		tgo.PaidVideo(tgo.FileFromID("video-id")),
+	}}
+	paid.SetChatID(1)
+
+	if _, err := bot.Send(paid); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := server.Calls()
+	if len(calls) != 1 || calls[0].Method != "sendPaidMedia" {
+		t.Fatalf("unexpected calls: %+v", calls)
+	}
+
+	params := calls[0].Params
+	if params["secret.jpg"] != "attach://secret.jpg" || params["star_count"] != float64(10) || params["business_connection_id"] != "conn" {
+		t.Errorf("unexpected params: %+v", params)
+	}
+	if media, _ := params["media"].([]any); len(media) != 2 {
+		t.Errorf("unexpected media: %+v", params["media"])
+	}
+}
@@ -0,0 +1,203 @@
+// Package menus renders the nested menus declared as a tree of nodes in inline keyboards,
+// and navigates them in place as their buttons are tapped.
+package menus
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/filters"
+	"github.com/haashemi/tgo/keyboard"
+	"github.com/haashemi/tgo/routers/callback"
+)
+
+// DefaultBackText is the text of the buttons going back to the parent menus by default.
+const DefaultBackText = "« Back"
+
+// Node is a menu, or an item of its parent menu.
+type Node struct {
+	// ID identifies the node in the callback data; it's required, and it must be unique in the tree.
+	ID string
+
+	// Title is the text of the node's button in its parent menu.
+	Title string
+
+	// Text is the text of the message showing the node's menu; it defaults to the Title.
+	Text string
+
+	// Children are the items of the node's menu, in order.
+	Children []*Node
+
+	// Action, if not nil, is called when the node's button is tapped instead of opening its menu,
+	// such as for the items doing something. The callback query is answered after it returns,
+	// unless it's answered by the Action itself.
+	Action func(ctx *callback.Context)
+}
+
+// Options configures a Menu. The zero value is valid and uses the defaults.
+type Options struct {
+	// Prefix is the prefix of the menu's callback data; it defaults to "menu", and it must be unique
+	// among the callback data of the bot's keyboards.
+	Prefix string
+
+	// BackText is the text of the buttons going back to the parent menus; it defaults to DefaultBackText.
+	BackText string
+
+	// Layout arranges the children's buttons into the rows; see keyboard.Balance.
+	Layout keyboard.BalanceOptions
+}
+
+// Menu renders the menus of a tree of nodes, edits them in place as their buttons are tapped,
+// and remembers the menu which each user is at in their data bag:
+//
+//	settings, err := menus.New(&menus.Node{ID: "root", Title: "Settings", Children: []*menus.Node{
+//		{ID: "lang", Title: "Language", Children: []*menus.Node{
+//			{ID: "en", Title: "English", Action: setLanguage("en")},
+//		}},
+//	}}, menus.Options{})
+//	settings.Register(callbackRouter)
+//	settings.Send(bot, tgo.ID(chatID), userID)
+type Menu struct {
+	root    *Node
+	opts    Options
+	nodes   map[string]*Node
+	parents map[string]*Node
+}
+
+// New returns a Menu of the tree, or an error if any of its nodes has an empty or a repeated ID.
+func New(root *Node, opts Options) (*Menu, error) {
+	if opts.Prefix == "" {
+		opts.Prefix = "menu"
+	}
+	if opts.BackText == "" {
+		opts.BackText = DefaultBackText
+	}
+
+	m := &Menu{root: root, opts: opts, nodes: make(map[string]*Node), parents: make(map[string]*Node)}
+	if err := m.index(root, nil); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *Menu) index(node, parent *Node) error {
+	if node.ID == "" {
+		return errors.New("menus: the node " + node.Title + " has no id")
+	} else if _, ok := m.nodes[node.ID]; ok {
+		return errors.New("menus: the node id " + node.ID + " is repeated")
+	}
+
+	m.nodes[node.ID] = node
+	if parent != nil {
+		m.parents[node.ID] = parent
+	}
+
+	for _, child := range node.Children {
+		if err := m.index(child, node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Node returns the node of the id, or nil if there's none.
+func (m *Menu) Node(id string) *Node { return m.nodes[id] }
+
+// Register adds the route handling the menu's buttons to the router, with the middlewares.
+func (m *Menu) Register(router *callback.Router, middlewares ...callback.Middleware) {
+	router.Handle(filters.WithPrefix(m.opts.Prefix+":"), m.handle, middlewares...)
+}
+
+// Render returns the text and the keyboard of the node's menu, with a button going back to its parent.
+func (m *Menu) Render(node *Node) (text string, markup *tgo.InlineKeyboardMarkup) {
+	buttons := make([]*tgo.InlineKeyboardButton, len(node.Children))
+	for i, child := range node.Children {
+		buttons[i] = &tgo.InlineKeyboardButton{Text: child.Title, CallbackData: m.opts.Prefix + ":" + child.ID}
+	}
+
+	rows := keyboard.Balance(buttons, m.opts.Layout)
+	if parent := m.parents[node.ID]; parent != nil {
+		rows = append(rows, []*tgo.InlineKeyboardButton{{Text: m.opts.BackText, CallbackData: m.opts.Prefix + ":" + parent.ID}})
+	}
+
+	text = node.Text
+	if text == "" {
+		text = node.Title
+	}
+	return text, &tgo.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// Send sends the root menu to the chat for the user.
+func (m *Menu) Send(bot *tgo.Bot, chatID tgo.ChatID, userID int64) (*tgo.Message, error) {
+	return m.send(bot, chatID, userID, m.root)
+}
+
+// Resume sends the menu which the user was at last time to the chat, or the root menu if there's none.
+func (m *Menu) Resume(bot *tgo.Bot, chatID tgo.ChatID, userID int64) (*tgo.Message, error) {
+	node := m.Current(bot, userID)
+	if node == nil {
+		node = m.root
+	}
+	return m.send(bot, chatID, userID, node)
+}
+
+// Current returns the node of the menu which the user is at, or nil if they've not opened any.
+func (m *Menu) Current(bot *tgo.Bot, userID int64) *Node {
+	id, _ := tgo.GetData[string](bot.UserData(userID), m.stateKey())
+	return m.nodes[id]
+}
+
+// Open edits the callback query's message to the menu of the node by the id, such as from an Action.
+func (m *Menu) Open(ctx *callback.Context, id string) error {
+	node := m.nodes[id]
+	if node == nil {
+		return fmt.Errorf("menus: there's no node with the id %s", id)
+	}
+
+	text, markup := m.Render(node)
+	if _, err := ctx.EditOrSend(text, markup); err != nil {
+		return err
+	}
+
+	ctx.UserData().Set(m.stateKey(), node.ID)
+	return nil
+}
+
+func (m *Menu) send(bot *tgo.Bot, chatID tgo.ChatID, userID int64, node *Node) (*tgo.Message, error) {
+	text, markup := m.Render(node)
+	msg, err := bot.Send(&tgo.SendMessage{ChatId: chatID, Text: text, ReplyMarkup: markup})
+	if err == nil {
+		bot.UserData(userID).Set(m.stateKey(), node.ID)
+	}
+	return msg, err
+}
+
+func (m *Menu) stateKey() string { return "tgo.menus." + m.opts.Prefix }
+
+func (m *Menu) handle(ctx *callback.Context) {
+	node := m.nodes[strings.TrimPrefix(ctx.Data, m.opts.Prefix+":")]
+
+	switch {
+	case node == nil:
+		// the tree has changed since the keyboard is sent, so it's taken back to the root.
+		text, markup := m.Render(m.root)
+		ctx.RespondStale(callback.StaleOptions{
+			EditText: text,
+			Rerender: func(ctx *callback.Context) *tgo.InlineKeyboardMarkup { return markup },
+		})
+		ctx.UserData().Set(m.stateKey(), m.root.ID)
+		return
+
+	case node.Action != nil:
+		node.Action(ctx)
+
+	default:
+		if err := m.Open(ctx, node.ID); err != nil {
+			ctx.Bot.Logger().Log(tgo.LevelError, "failed to open the menu", "prefix", m.opts.Prefix, "node", node.ID, "error", err)
+		}
+	}
+
+	ctx.Answer(&tgo.AnswerCallbackQuery{})
+}
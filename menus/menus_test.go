@@ -0,0 +1,70 @@
+package menus
+
+import (
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/routers/callback"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestNew(t *testing.T) {
+	_, err := New(&Node{ID: "root", Children: []*Node{{ID: "a"}, {ID: "a"}}}, Options{})
+	if err == nil {
+		t.Error("expected the repeated id to fail")
+	}
+}
+
+func TestNavigation(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	server.Handle("editMessageText", func(call tgotest.Call) (any, *tgo.Error) {
+		return map[string]any{"message_id": 5, "date": 1, "chat": map[string]any{"id": 1, "type": "private"}}, nil
+	})
+
+	var chosen bool
+	menu, err := New(&Node{ID: "root", Title: "Settings", Children: []*Node{
+		{ID: "lang", Title: "Language", Text: "Choose a language", Children: []*Node{
+			{ID: "en", Title: "English", Action: func(ctx *callback.Context) { chosen = true }},
+		}},
+	}}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bot := server.Bot(tgo.Options{})
+	router := callback.NewRouter()
+	menu.Register(router)
+
+	tap := func(data string) {
+		router.HandleUpdate(bot, &tgo.Update{CallbackQuery: &tgo.CallbackQuery{
+			Id: "1", From: tgo.User{Id: 7}, Data: data,
+			Message: &tgo.Message{MessageId: 5, Chat: tgo.Chat{Id: 1}},
+		}})
+	}
+
+	tap("menu:lang")
+	if calls := server.Reset(); len(calls) == 0 || calls[0].Params["text"] != "Choose a language" {
+		t.Errorf("the menu is not opened: %+v", calls)
+	}
+	if node := menu.Current(bot, 7); node == nil || node.ID != "lang" {
+		t.Errorf("expected the user to be at lang, got %v", node)
+	}
+
+	_, markup := menu.Render(menu.Node("lang"))
+	back := markup.InlineKeyboard[len(markup.InlineKeyboard)-1][0]
+	if back.Text != DefaultBackText || back.CallbackData != "menu:root" {
+		t.Errorf("unexpected back button: %+v", back)
+	}
+
+	tap("menu:en")
+	if !chosen {
+		t.Error("the action is not called")
+	}
+
+	tap(back.CallbackData)
+	if node := menu.Current(bot, 7); node == nil || node.ID != "root" {
+		t.Errorf("expected the user to be back at root, got %v", node)
+	}
+}
@@ -0,0 +1,21 @@
+package tgo
+
+import "time"
+
+// Metrics receives the measurements of the bot, to be exported to a monitoring system.
+// Its methods are called concurrently, and must not block.
+//
+// Use the contrib/tgoprom module to export them to prometheus.
+type Metrics interface {
+	// UpdateReceived is called for every update passed to bot.HandleUpdate, with its Type.
+	UpdateReceived(updateType string)
+
+	// UpdateHandled is called after the update is handled by the dispatchers, asks, and routers.
+	UpdateHandled(updateType string, took time.Duration)
+
+	// APICall is called after every API call, with its error if it has failed.
+	APICall(method string, took time.Duration, err error)
+
+	// Retried is called when a call is attempted once more, either as a hedge or by the scheduler.
+	Retried(method string)
+}
@@ -0,0 +1,70 @@
+package tgo
+
+import "time"
+
+// AllPermissions returns the chat permissions which allow everything to a non-administrator user.
+func AllPermissions() ChatPermissions {
+	return ChatPermissions{
+		CanSendMessages:       true,
+		CanSendAudios:         true,
+		CanSendDocuments:      true,
+		CanSendPhotos:         true,
+		CanSendVideos:         true,
+		CanSendVideoNotes:     true,
+		CanSendVoiceNotes:     true,
+		CanSendPolls:          true,
+		CanSendOtherMessages:  true,
+		CanAddWebPagePreviews: true,
+		CanChangeInfo:         true,
+		CanInviteUsers:        true,
+		CanPinMessages:        true,
+		CanManageTopics:       true,
+	}
+}
+
+// BanUser bans the user from the chat for the passed duration, or forever if it's zero.
+//
+// Note: telegram considers durations shorter than 30 seconds or longer than 366 days as forever.
+func (api *API) BanUser(chatID ChatID, userID int64, duration time.Duration) error {
+	_, err := api.BanChatMember(&BanChatMember{ChatId: chatID, UserId: userID, UntilDate: untilDate(duration)})
+	return err
+}
+
+// UnbanUser unbans the user if it's banned, without kicking it if it's still in the chat.
+func (api *API) UnbanUser(chatID ChatID, userID int64) error {
+	_, err := api.UnbanChatMember(&UnbanChatMember{ChatId: chatID, UserId: userID, OnlyIfBanned: true})
+	return err
+}
+
+// MuteUser prevents the user from sending anything to the supergroup for the passed duration, or forever if it's zero.
+//
+// Note: telegram considers durations shorter than 30 seconds or longer than 366 days as forever.
+func (api *API) MuteUser(chatID ChatID, userID int64, duration time.Duration) error {
+	return api.RestrictUser(chatID, userID, ChatPermissions{}, duration)
+}
+
+// RestrictUser sets the user's permissions in the supergroup for the passed duration, or forever if it's zero.
+// Each permission is set independently.
+func (api *API) RestrictUser(chatID ChatID, userID int64, permissions ChatPermissions, duration time.Duration) error {
+	_, err := api.RestrictChatMember(&RestrictChatMember{
+		ChatId:                        chatID,
+		UserId:                        userID,
+		Permissions:                   permissions,
+		UseIndependentChatPermissions: true,
+		UntilDate:                     untilDate(duration),
+	})
+	return err
+}
+
+// UnrestrictUser lifts all of the user's restrictions in the supergroup.
+func (api *API) UnrestrictUser(chatID ChatID, userID int64) error {
+	return api.RestrictUser(chatID, userID, AllPermissions(), 0)
+}
+
+// untilDate returns the unix time after the duration, or zero for the non-positive durations.
+func untilDate(duration time.Duration) int64 {
+	if duration <= 0 {
+		return 0
+	}
+	return time.Now().Add(duration).Unix()
+}
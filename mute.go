@@ -0,0 +1,352 @@
+package tgo
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultMuteDuration is how long the /mutebot command mutes the bot for if no duration is passed.
+const DefaultMuteDuration = time.Hour
+
+// MuteStore persists the chats in which the bot is muted, and until when. Implement it to keep
+// the chat settings in your own database.
+type MuteStore interface {
+	// SetMuted mutes the bot in the chat until the time; a zero time unmutes it.
+	SetMuted(chatID int64, until time.Time) error
+
+	// MutedUntil returns the time until which the bot is muted in the chat, or the zero time if it's not.
+	MutedUntil(chatID int64) (time.Time, error)
+}
+
+// MemoryMuteStore is an in-memory MuteStore; it's used by the bots by default.
+type MemoryMuteStore struct {
+	mut   sync.Mutex
+	chats map[int64]time.Time
+}
+
+// SetMuted implements the MuteStore interface.
+func (s *MemoryMuteStore) SetMuted(chatID int64, until time.Time) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if until.IsZero() {
+		delete(s.chats, chatID)
+		return nil
+	}
+
+	if s.chats == nil {
+		s.chats = make(map[int64]time.Time)
+	}
+	s.chats[chatID] = until
+	return nil
+}
+
+// MutedUntil implements the MuteStore interface.
+func (s *MemoryMuteStore) MutedUntil(chatID int64) (time.Time, error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	until, ok := s.chats[chatID]
+	if ok && time.Now().After(until) {
+		// the mute is over, so there's no need to keep it anymore.
+		delete(s.chats, chatID)
+		return time.Time{}, nil
+	}
+
+	return until, nil
+}
+
+// ChatMutedError is returned by the send, copy, and forward methods for the messages which are suppressed,
+// as the bot is muted in the chat.
+type ChatMutedError struct {
+	ChatID int64     // the muted chat
+	Until  time.Time // the time until which the bot is muted
+}
+
+func (e *ChatMutedError) Error() string {
+	return fmt.Sprintf("tgo: bot is muted in chat %d until %s", e.ChatID, e.Until.Format(time.RFC3339))
+}
+
+// MuteChat mutes the bot in the chat for the duration. While it's muted, all the messages sent, copied,
+// or forwarded to the chat are suppressed, including the scheduled ones, unless they're sent while
+// handling an update from one of the chat's administrators or the bot's owners.
+func (bot *Bot) MuteChat(chatID int64, duration time.Duration) error {
+	return bot.muteStore.SetMuted(chatID, time.Now().Add(duration))
+}
+
+// UnmuteChat lets the bot send the messages to the chat again.
+func (bot *Bot) UnmuteChat(chatID int64) error {
+	return bot.muteStore.SetMuted(chatID, time.Time{})
+}
+
+// ChatMutedUntil returns the time until which the bot is muted in the chat, or the zero time if it's not.
+// The store's errors are treated as not being muted.
+func (bot *Bot) ChatMutedUntil(chatID int64) time.Time {
+	until, err := bot.muteStore.MutedUntil(chatID)
+	if err != nil {
+		bot.log(LevelError, "failed to load the chat's mute", "chat_id", chatID, "error", err)
+		return time.Time{}
+	}
+	return until
+}
+
+// Len returns the number of the muted chats, including the ones whose mute is over but not checked since.
+func (s *MemoryMuteStore) Len() int {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	return len(s.chats)
+}
+
+// IsChatMuted returns true if the bot is muted in the chat.
+func (bot *Bot) IsChatMuted(chatID int64) bool { return !bot.ChatMutedUntil(chatID).IsZero() }
+
+// withMuteCheck returns a copy of the api which checks the mutes of the chats by the function.
+func (api *API) withMuteCheck(check func(chatID ChatID) error) *API {
+	clone := *api
+	clone.muteCheck = check
+	return &clone
+}
+
+// sendsMessage reports whether the method sends a message to the chat of its chat_id, which is
+// suppressed in the muted chats.
+func sendsMessage(method string) bool {
+	return strings.HasPrefix(method, "send") || strings.HasPrefix(method, "copy") || strings.HasPrefix(method, "forward")
+}
+
+// paramsChatID returns the chat_id of the method's params, which are either a struct of the
+// method's options, the text fields of a multipart call, or the encoded options, or nil if they have none.
+func paramsChatID(params any) ChatID {
+	switch params := params.(type) {
+	case map[string]string:
+		if params["chat_id"] == "" {
+			return nil
+		}
+		chatID, _ := unmarshalChatID([]byte(params["chat_id"]))
+		return chatID
+
+	case json.RawMessage:
+		// the params of the outbox and the scheduled jobs are already encoded.
+		var fields struct {
+			ChatID json.RawMessage `json:"chat_id"`
+		}
+		if json.Unmarshal(params, &fields) != nil || len(fields.ChatID) == 0 {
+			return nil
+		}
+		chatID, _ := unmarshalChatID(fields.ChatID)
+		return chatID
+	}
+
+	value := reflect.ValueOf(params)
+	for value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	switch field := value.FieldByName("ChatId"); {
+	case !field.IsValid():
+		return nil
+	case field.Kind() == reflect.Int64:
+		return ID(field.Int())
+	default:
+		chatID, _ := field.Interface().(ChatID)
+		return chatID
+	}
+}
+
+// checkMute returns a *ChatMutedError if the bot is muted in the chat and the message isn't
+// triggered by one of its administrators. The usernames are resolved to their chats by getChat,
+// unless the store tells that no chat is muted.
+func (bot *Bot) checkMute(chatID ChatID) error {
+	if store, ok := bot.muteStore.(interface{ Len() int }); ok && store.Len() == 0 {
+		return nil
+	}
+
+	var id int64
+	switch chatID := chatID.(type) {
+	case ID:
+		id = int64(chatID)
+	case Username:
+		var err error
+		if id, err = bot.resolveUsername(chatID); err != nil {
+			bot.log(LevelError, "failed to resolve the chat's username", "username", string(chatID), "error", err)
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	until := bot.ChatMutedUntil(id)
+	if until.IsZero() || bot.triggeredByAdmin(id) {
+		return nil
+	}
+
+	return &ChatMutedError{ChatID: id, Until: until}
+}
+
+// resolveUsername returns the id of the chat of the username, from the bot's ChatCache if it has one.
+func (bot *Bot) resolveUsername(username Username) (int64, error) {
+	// the usernames are case-insensitive, so they're cached by their lower case.
+	raw, err := bot.usernames.lookup(usernameCacheKey(strings.ToLower(string(username))), func() (any, error) {
+		chat, err := bot.GetChat(&GetChat{ChatId: username})
+		if err != nil {
+			return nil, err
+		}
+		return chat.Id, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var id int64
+	err = json.Unmarshal(raw, &id)
+	return id, err
+}
+
+// triggeredByAdmin returns true if the bot is handling an update sent by one of the chat's administrators,
+// including the anonymous ones, or by one of the bot's owners. The result is kept for the rest of the update.
+func (bot *Bot) triggeredByAdmin(chatID int64) bool {
+	update := bot.trigger
+	if update == nil {
+		return false
+	}
+
+	key := fmt.Sprintf("tgo.mute_admin.%d", chatID)
+	if isAdmin, ok := GetUpdateValue(update, key); ok {
+		return isAdmin.(bool)
+	}
+
+	isAdmin := bot.isChatAdmin(update, chatID)
+	SetUpdateValue(update, key, isAdmin)
+	return isAdmin
+}
+
+func (bot *Bot) isChatAdmin(update *Update, chatID int64) bool {
+	var sender *User
+	if msg := update.EffectiveMessage(); msg != nil {
+		// the anonymous administrators send the messages on behalf of the group itself.
+		if msg.SenderChat != nil {
+			return msg.SenderChat.Id == chatID
+		}
+		sender = msg.From
+	} else if update.CallbackQuery != nil {
+		sender = &update.CallbackQuery.From
+	}
+
+	if sender == nil {
+		return false
+	}
+
+	for _, owner := range bot.owners {
+		if owner == sender.Id {
+			return true
+		}
+	}
+
+	member, err := bot.CachedChatMember(chatID, sender.Id)
+	return err == nil && IsChatMemberAdmin(member)
+}
+
+// MuteCommandsOptions configures the router of the /mutebot and /unmutebot commands.
+type MuteCommandsOptions struct {
+	// Muted and Unmuted are the replies to the commands; the Muted one is formatted with the duration.
+	// They default to English texts, and are translated by bot.Translate if there's a translator.
+	Muted, Unmuted string
+
+	// DefaultDuration is the duration which /mutebot mutes the bot for; it defaults to DefaultMuteDuration.
+	DefaultDuration time.Duration
+}
+
+type muteCommands struct {
+	opts     MuteCommandsOptions
+	username string
+}
+
+// NewMuteCommands returns a router which handles the /mutebot [duration] and /unmutebot commands of
+// the chats' administrators, such as "/mutebot 30m". The commands of the others are ignored.
+func NewMuteCommands(opts MuteCommandsOptions) Router {
+	if opts.Muted == "" {
+		opts.Muted = "I'll keep quiet for %s."
+	}
+	if opts.Unmuted == "" {
+		opts.Unmuted = "I'm back."
+	}
+	if opts.DefaultDuration <= 0 {
+		opts.DefaultDuration = DefaultMuteDuration
+	}
+
+	return &muteCommands{opts: opts}
+}
+
+// Setup implements the Router interface.
+func (r *muteCommands) Setup(bot *Bot) error {
+	me, err := bot.Me()
+	if err != nil {
+		return err
+	}
+
+	r.username = me.Username
+	return nil
+}
+
+// UpdateTypes implements the UpdateTypesRouter interface.
+func (r *muteCommands) UpdateTypes() []string { return []string{"message"} }
+
+// HandleUpdate implements the Router interface.
+func (r *muteCommands) HandleUpdate(bot *Bot, update *Update) bool {
+	msg := update.Message
+	if msg == nil || msg.Chat.Type == "private" {
+		return false
+	}
+
+	fields := strings.Fields(msg.Text)
+	if len(fields) == 0 {
+		return false
+	}
+
+	command := strings.TrimSuffix(strings.ToLower(fields[0]), "@"+strings.ToLower(r.username))
+	if command != "/mutebot" && command != "/unmutebot" {
+		return false
+	} else if !bot.triggeredByAdmin(msg.Chat.Id) {
+		return false
+	}
+
+	language := ""
+	if msg.From != nil {
+		language = msg.From.LanguageCode
+	}
+
+	var err error
+	var text string
+
+	if command == "/unmutebot" {
+		err = bot.UnmuteChat(msg.Chat.Id)
+		text = bot.Translate(language, r.opts.Unmuted)
+	} else {
+		duration := r.opts.DefaultDuration
+		if len(fields) > 1 {
+			if duration, err = time.ParseDuration(fields[1]); err != nil || duration <= 0 {
+				return false
+			}
+		}
+
+		err = bot.MuteChat(msg.Chat.Id, duration)
+		text = bot.Translate(language, r.opts.Muted, duration)
+	}
+
+	if err != nil {
+		bot.log(LevelError, "failed to store the chat's mute", "chat_id", msg.Chat.Id, "error", err)
+		return true
+	}
+
+	if _, err = bot.Send(&SendMessage{ChatId: ID(msg.Chat.Id), Text: text, ReplyToMessageId: msg.MessageId}); err != nil {
+		bot.log(LevelError, "failed to reply to the command", "command", command, "error", err)
+	}
+
+	return true
+}
@@ -0,0 +1,145 @@
+package tgo_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+type echoRouter struct{ errs []error }
+
+func (r *echoRouter) Setup(bot *tgo.Bot) error { return nil }
+
+func (r *echoRouter) HandleUpdate(bot *tgo.Bot, update *tgo.Update) bool {
+	_, err := bot.Send(&tgo.SendMessage{ChatId: tgo.ID(update.Message.Chat.Id), Text: "echo"})
+	r.errs = append(r.errs, err)
+	return true
+}
+
+func TestMuteChat(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	server.Handle("getChatMember", func(call tgotest.Call) (any, *tgo.Error) {
+		if call.Params["user_id"] == float64(1) {
+			return map[string]any{"status": "administrator", "user": map[string]any{"id": 1}}, nil
+		}
+		return map[string]any{"status": "member", "user": map[string]any{"id": call.Params["user_id"]}}, nil
+	})
+
+	bot := server.Bot(tgo.Options{})
+	echo := &echoRouter{}
+	if err := bot.AddRouter(tgo.NewMuteCommands(tgo.MuteCommandsOptions{})); err != nil {
+		t.Fatal(err)
+	}
+	bot.AddRouter(echo)
+
+	send := func(userID int64, text string) {
+		bot.HandleUpdate(&tgo.Update{Message: &tgo.Message{
+			MessageId: 1,
+			From:      &tgo.User{Id: userID},
+			Chat:      tgo.Chat{Id: -100, Type: "supergroup"},
+			Text:      text,
+		}})
+	}
+
+	send(2, "/mutebot")
+	if bot.IsChatMuted(-100) {
+		t.Fatal("a member has muted the bot")
+	}
+
+	send(1, "/mutebot@test_bot 30m")
+	if !bot.IsChatMuted(-100) {
+		t.Fatal("the admin couldn't mute the bot")
+	}
+
+	send(2, "hello")
+	var mutedErr *tgo.ChatMutedError
+	if err := echo.errs[len(echo.errs)-1]; !errors.As(err, &mutedErr) || mutedErr.ChatID != -100 {
+		t.Fatalf("expected a *ChatMutedError for the member's message, got %v", err)
+	}
+
+	send(1, "hello")
+	if err := echo.errs[len(echo.errs)-1]; err != nil {
+		t.Fatalf("the admin's message is suppressed: %v", err)
+	}
+
+	if _, err := bot.Send(&tgo.SendMessage{ChatId: tgo.ID(-100), Text: "scheduled"}); !errors.As(err, &mutedErr) {
+		t.Fatalf("expected a *ChatMutedError outside of the handlers, got %v", err)
+	}
+
+	send(1, "/unmutebot")
+	send(2, "hello")
+	if err := echo.errs[len(echo.errs)-1]; err != nil {
+		t.Fatalf("the message is suppressed after unmuting: %v", err)
+	}
+
+	var texts []any
+	for _, call := range server.Calls() {
+		if call.Method == "sendMessage" {
+			texts = append(texts, call.Params["text"])
+		}
+	}
+	if len(texts) != 5 {
+		t.Fatalf("expected member /mutebot echo, mute reply, admin echo, unmute reply, and member echo; got %v", texts)
+	}
+}
+
+func TestMuteAllSends(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	server.Handle("getChat", func(call tgotest.Call) (any, *tgo.Error) {
+		return map[string]any{"id": -100, "type": "channel"}, nil
+	})
+
+	bot := server.Bot(tgo.Options{})
+	if err := bot.MuteChat(-100, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	failed := make(chan error, 1)
+	outbox := bot.StartOutbox(tgo.OutboxOptions{OnError: func(msg *tgo.OutboxMessage, err error) { failed <- err }})
+	defer outbox.Stop()
+
+	_, copyErr := bot.CopyMessage(&tgo.CopyMessage{ChatId: tgo.ID(-100), FromChatId: tgo.ID(1), MessageId: 1})
+	_, forwardErr := bot.ForwardAll(&tgo.ForwardMessages{ChatId: tgo.Username("@Muted"), FromChatId: tgo.ID(1), MessageIds: []int64{1, 2}})
+	_, groupErr := bot.SendMediaGroup(&tgo.SendMediaGroup{ChatId: tgo.ID(-100), Media: []tgo.InputMedia{
+		&tgo.InputMediaPhoto{Media: tgo.FileFromReader("a.jpg", strings.NewReader("a"))},
+		&tgo.InputMediaPhoto{Media: tgo.FileFromReader("b.jpg", strings.NewReader("b"))},
+	}})
+
+	if _, err := bot.SendOutbox("", &tgo.SendMessage{ChatId: tgo.ID(-100), Text: "later"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var outboxErr error
+	select {
+	case outboxErr = <-failed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the outbox's message is neither sent nor given up")
+	}
+
+	for name, err := range map[string]error{"copy": copyErr, "forward": forwardErr, "media group": groupErr, "outbox": outboxErr} {
+		var mutedErr *tgo.ChatMutedError
+		if !errors.As(err, &mutedErr) || mutedErr.ChatID != -100 {
+			t.Errorf("expected a *ChatMutedError for the %s, got %v", name, err)
+		}
+	}
+
+	for _, call := range server.Calls() {
+		if call.Method != "getMe" && call.Method != "getChat" {
+			t.Errorf("unexpected call of %s to the muted chat", call.Method)
+		}
+	}
+
+	if err := bot.UnmuteChat(-100); err != nil {
+		t.Fatal(err)
+	} else if _, err = bot.CopyMessage(&tgo.CopyMessage{ChatId: tgo.ID(-100), FromChatId: tgo.ID(1), MessageId: 1}); err != nil {
+		t.Errorf("the copy is refused after unmuting: %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+package tgo
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// OffsetStore persists the offset of the polled updates, so the poller resumes from it after a restart.
+// The saved offset is the update_id after the last one whose handler is done, and all of the ones
+// before it, so the restarted poller neither replays the handled updates nor drops the unhandled ones.
+type OffsetStore interface {
+	// LoadOffset returns the saved offset, or zero if there's none.
+	LoadOffset() (int64, error)
+
+	// SaveOffset replaces the saved offset.
+	SaveOffset(offset int64) error
+}
+
+// FileOffsetStore is an OffsetStore keeping the offset in a file.
+type FileOffsetStore struct {
+	path string
+}
+
+// NewFileOffsetStore returns a FileOffsetStore keeping the offset in the file at the path.
+func NewFileOffsetStore(path string) *FileOffsetStore {
+	return &FileOffsetStore{path: path}
+}
+
+// LoadOffset implements the OffsetStore interface.
+func (s *FileOffsetStore) LoadOffset() (int64, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+}
+
+// SaveOffset implements the OffsetStore interface. The file is replaced atomically,
+// so it's never left half-written.
+func (s *FileOffsetStore) SaveOffset(offset int64) error {
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.WriteString(strconv.FormatInt(offset, 10)); err != nil {
+		tmp.Close()
+		return err
+	} else if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// offsetTracker tracks the polled updates which are being handled, and saves the offset after the
+// ones which are done to the store.
+type offsetTracker struct {
+	api   *API
+	store OffsetStore
+
+	mut     sync.Mutex
+	pending map[int64]bool
+	next    int64 // the offset after the last polled update
+	saved   int64
+}
+
+// polled marks the update as being handled.
+func (t *offsetTracker) polled(updateID int64) {
+	t.mut.Lock()
+	t.pending[updateID] = true
+	t.next = updateID + 1
+	t.mut.Unlock()
+}
+
+// handled marks the update as done, and saves the offset if all of the updates before it are done too.
+func (t *offsetTracker) handled(updateID int64) {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+
+	delete(t.pending, updateID)
+
+	offset := t.next
+	for id := range t.pending {
+		if id < offset {
+			offset = id
+		}
+	}
+
+	if offset <= t.saved {
+		return
+	}
+
+	// it's saved with the lock held, so the offsets are saved in order.
+	if err := t.store.SaveOffset(offset); err != nil {
+		t.api.log(LevelError, "failed to save the offset", "offset", offset, "error", err)
+		return
+	}
+	t.saved = offset
+}
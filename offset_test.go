@@ -0,0 +1,51 @@
+package tgo_test
+
+import (
+	"context"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestOffsetStore(t *testing.T) {
+	store := tgo.NewFileOffsetStore(filepath.Join(t.TempDir(), "offset"))
+	if offset, err := store.LoadOffset(); err != nil || offset != 0 {
+		t.Fatalf("expected no offset, got %d, %v", offset, err)
+	} else if err = store.SaveOffset(42); err != nil {
+		t.Fatal(err)
+	}
+
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	var firstOffset atomic.Value
+	server.Handle("getUpdates", func(call tgotest.Call) (any, *tgo.Error) {
+		if firstOffset.CompareAndSwap(nil, call.Params["offset"]) {
+			return []map[string]any{{"update_id": 42}, {"update_id": 43}}, nil
+		}
+		time.Sleep(10 * time.Millisecond)
+		return []any{}, nil
+	})
+
+	bot := server.Bot(tgo.Options{})
+	go bot.StartPollingWithOptions(tgo.PollingOptions{Timeout: 1, OffsetStore: store})
+	defer bot.Shutdown(context.Background())
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if offset, _ := store.LoadOffset(); offset == 44 {
+			break
+		} else if time.Now().After(deadline) {
+			t.Fatalf("the offset of the handled updates is not saved, got %d", offset)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if offset := firstOffset.Load(); offset != float64(42) {
+		t.Fatalf("the polling didn't resume from the saved offset, got %v", offset)
+	}
+}
@@ -0,0 +1,361 @@
+package tgo
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	ErrNoOutbox     = errors.New("tgo: outbox is not started; call bot.StartOutbox first")
+	ErrOutboxUpload = errors.New("tgo: messages with uploaded files can't be put in the outbox; use file ids or urls")
+)
+
+// OutboxMessage is an API call waiting in the outbox to be made.
+type OutboxMessage struct {
+	Key      string          `json:"key"`      // the deduplication key
+	At       time.Time       `json:"at"`       // when the call should be tried next
+	Method   string          `json:"method"`   // the API method, such as "sendMessage"
+	Params   json.RawMessage `json:"params"`   // the JSON-encoded parameters of the method
+	Attempts int             `json:"attempts"` // the number of the failed attempts so far
+}
+
+// OutboxStore persists the outbox's messages. Implement it to keep them across the crashes and
+// restarts; see contrib/redisstore for a Redis one.
+type OutboxStore interface {
+	// Add adds the message and returns true, unless a message with the same key is added
+	// within the store's deduplication window, even if it's sent already.
+	Add(msg *OutboxMessage) (bool, error)
+
+	// Due returns the messages which should be sent at now, the earliest first.
+	Due(now time.Time) ([]*OutboxMessage, error)
+
+	// Save replaces the message with the same key, such as to retry it later.
+	Save(msg *OutboxMessage) error
+
+	// Delete removes the message once it's sent or given up; its key is still remembered
+	// for the deduplication window. It's not an error if the message doesn't exist.
+	Delete(key string) error
+}
+
+// MemoryOutboxStore is an in-memory OutboxStore; it deduplicates the messages, but they're
+// lost when the program exits.
+type MemoryOutboxStore struct {
+	window time.Duration
+
+	mut       sync.Mutex
+	messages  map[string]*OutboxMessage
+	keys      map[string]time.Time // until when each key is remembered
+	lastSweep time.Time
+}
+
+// NewMemoryOutboxStore returns a MemoryOutboxStore remembering the keys for the window;
+// it defaults to 24 hours.
+func NewMemoryOutboxStore(window time.Duration) *MemoryOutboxStore {
+	if window <= 0 {
+		window = 24 * time.Hour
+	}
+
+	return &MemoryOutboxStore{
+		window:   window,
+		messages: make(map[string]*OutboxMessage),
+		keys:     make(map[string]time.Time),
+	}
+}
+
+// Add implements the OutboxStore interface.
+func (s *MemoryOutboxStore) Add(msg *OutboxMessage) (bool, error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.lastSweep) >= time.Minute {
+		for key, until := range s.keys {
+			if now.After(until) {
+				delete(s.keys, key)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	if until, ok := s.keys[msg.Key]; ok && !now.After(until) {
+		return false, nil
+	}
+
+	copied := *msg
+	s.messages[msg.Key] = &copied
+	s.keys[msg.Key] = now.Add(s.window)
+	return true, nil
+}
+
+// Due implements the OutboxStore interface.
+func (s *MemoryOutboxStore) Due(now time.Time) ([]*OutboxMessage, error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	var due []*OutboxMessage
+	for _, msg := range s.messages {
+		if !msg.At.After(now) {
+			copied := *msg
+			due = append(due, &copied)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool { return due[i].At.Before(due[j].At) })
+	return due, nil
+}
+
+// Save implements the OutboxStore interface.
+func (s *MemoryOutboxStore) Save(msg *OutboxMessage) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	copied := *msg
+	s.messages[msg.Key] = &copied
+	return nil
+}
+
+// Delete implements the OutboxStore interface.
+func (s *MemoryOutboxStore) Delete(key string) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	delete(s.messages, key)
+	return nil
+}
+
+// OutboxOptions configures the bot's outbox. The zero value is valid and uses the defaults.
+type OutboxOptions struct {
+	// Store persists the messages; it defaults to a MemoryOutboxStore.
+	Store OutboxStore
+
+	// Interval is how often the store is checked for the messages to retry; it defaults to 1 second.
+	// The new messages are sent right away.
+	Interval time.Duration
+
+	// MaxAttempts is the number of the times a message is tried before it's given up; it defaults to 5.
+	// Only the network errors, rate limits, and telegram's server errors are retried.
+	MaxAttempts int
+
+	// RetryDelay is the delay before the first retry, which is doubled for the next ones; it defaults to 2 seconds.
+	// The rate-limited messages are retried after the duration telegram asks for instead.
+	RetryDelay time.Duration
+
+	// OnError, if not nil, is called with the messages which are given up, and with the store's errors with
+	// a nil message. They're logged by default.
+	OnError func(msg *OutboxMessage, err error)
+}
+
+// Outbox sends the messages put in it by bot.SendOutbox from a background worker. The messages are
+// written to its store before they're sent and deleted once telegram has accepted them, so they're
+// delivered at least once even if the program crashes in between; a message may be sent twice if
+// it crashes right after telegram has accepted it.
+//
+// Run a single outbox on each store, as the outboxes don't coordinate the sends between themselves.
+type Outbox struct {
+	api  *API
+	opts OutboxOptions
+
+	wake chan struct{}
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+
+	sendMut sync.Mutex // held while sending the due messages
+}
+
+// StartOutbox starts the bot's outbox in a new goroutine, which runs until its Stop method or bot.Shutdown
+// is called; bot.Shutdown also sends the due messages before it returns. Start it once, before the handlers
+// put any messages in it.
+func (bot *Bot) StartOutbox(opts OutboxOptions) *Outbox {
+	if opts.Store == nil {
+		opts.Store = NewMemoryOutboxStore(0)
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = time.Second
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 5
+	}
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = 2 * time.Second
+	}
+
+	o := &Outbox{
+		api:  bot.API,
+		opts: opts,
+		wake: make(chan struct{}, 1),
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	go o.run()
+
+	bot.outboxMut.Lock()
+	bot.outbox = o
+	bot.outboxMut.Unlock()
+
+	bot.OnShutdown(o.Flush)
+
+	return o
+}
+
+// Outbox returns the bot's outbox, or nil if it's not started.
+func (bot *Bot) Outbox() *Outbox {
+	bot.outboxMut.RLock()
+	defer bot.outboxMut.RUnlock()
+
+	return bot.outbox
+}
+
+// SendOutbox puts the message in the outbox to be sent in the background, and returns false if a message
+// with the same deduplication key is already put in it, such as by a handler retrying the same update.
+// An empty key is replaced by a random one. The messages uploading files can't be put in the outbox,
+// as they can't be persisted.
+func (bot *Bot) SendOutbox(key string, msg Sendable) (bool, error) {
+	o := bot.Outbox()
+	if o == nil {
+		return false, ErrNoOutbox
+	}
+
+	if x, ok := msg.(interface{ getFiles() map[string]*InputFile }); ok && len(x.getFiles()) != 0 {
+		return false, ErrOutboxUpload
+	}
+
+	if x, ok := msg.(ParseModeSettable); ok && x.GetParseMode() == ParseModeNone {
+		x.SetParseMode(bot.DefaultParseMode)
+	}
+	bot.applyLinkPreview(msg)
+
+	if err := bot.shrinkSendable(msg); err != nil {
+		return false, err
+	}
+
+	return o.Add(key, sendableMethod(msg), msg)
+}
+
+// Add puts the API method to be called with the JSON-encoded params in the outbox; see bot.SendOutbox.
+func (o *Outbox) Add(key, method string, params any) (bool, error) {
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return false, err
+	}
+
+	if key == "" {
+		var id [16]byte
+		if _, err = rand.Read(id[:]); err != nil {
+			return false, err
+		}
+		key = hex.EncodeToString(id[:])
+	}
+
+	added, err := o.opts.Store.Add(&OutboxMessage{Key: key, At: time.Now(), Method: method, Params: encoded})
+	if added {
+		select {
+		case o.wake <- struct{}{}:
+		default:
+		}
+	}
+	return added, err
+}
+
+// Stop stops the outbox and waits for the message which is being sent. The remaining messages
+// are kept in the store, to be sent by the next outbox using it.
+func (o *Outbox) Stop() {
+	o.once.Do(func() { close(o.stop) })
+	<-o.done
+}
+
+// Flush stops the outbox, and sends the due messages until the context is done, which also cancels
+// the message which is being sent. The ones which are retried later are kept in the store.
+func (o *Outbox) Flush(ctx context.Context) error {
+	o.Stop()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		} else if o.sendDue(ctx, ctx.Done()) == 0 {
+			return ctx.Err()
+		}
+	}
+}
+
+func (o *Outbox) run() {
+	defer close(o.done)
+
+	ticker := time.NewTicker(o.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-o.stop:
+			return
+		case <-o.wake:
+		case <-ticker.C:
+		}
+
+		o.sendDue(o.api.Context(), o.stop)
+	}
+}
+
+// sendDue sends the due messages with the context until the cancel channel is closed, reschedules
+// the ones which should be retried, and returns the number of the messages it has sent.
+func (o *Outbox) sendDue(ctx context.Context, cancel <-chan struct{}) (sent int) {
+	o.sendMut.Lock()
+	defer o.sendMut.Unlock()
+
+	messages, err := o.opts.Store.Due(time.Now())
+	if err != nil {
+		o.fail(nil, err)
+		return 0
+	}
+
+	api := o.api.WithContext(ctx)
+	for _, msg := range messages {
+		select {
+		case <-cancel:
+			return sent
+		default:
+		}
+
+		_, err := callJson[json.RawMessage](api, msg.Method, msg.Params)
+		if err == nil {
+			sent++
+			err = o.opts.Store.Delete(msg.Key)
+		} else if ctx.Err() != nil {
+			// the message is cut off by the context rather than failed, so it's kept as it is.
+			return sent
+		} else if delay, retry := retryDelay(err, msg.Attempts, o.opts.MaxAttempts, o.opts.RetryDelay); retry {
+			msg.Attempts++
+			msg.At = time.Now().Add(delay)
+			o.api.log(LevelInfo, "outbox message retry", "key", msg.Key, "method", msg.Method, "attempt", msg.Attempts+1, "delay", delay)
+			if o.api.metrics != nil {
+				o.api.metrics.Retried(msg.Method)
+			}
+			err = o.opts.Store.Save(msg)
+		} else {
+			o.fail(msg, err)
+			err = o.opts.Store.Delete(msg.Key)
+		}
+
+		if err != nil {
+			o.fail(nil, err)
+		}
+	}
+
+	return sent
+}
+
+func (o *Outbox) fail(msg *OutboxMessage, err error) {
+	if o.opts.OnError != nil {
+		o.opts.OnError(msg, err)
+	} else if msg != nil {
+		o.api.log(LevelError, "outbox message failed", "key", msg.Key, "method", msg.Method, "attempts", msg.Attempts+1, "error", err)
+	} else {
+		o.api.log(LevelError, "outbox's store failed", "error", err)
+	}
+}
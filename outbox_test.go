@@ -0,0 +1,113 @@
+package tgo_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestOutbox(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	var calls atomic.Int64
+	sent := make(chan string, 4)
+	server.Handle("sendMessage", func(call tgotest.Call) (any, *tgo.Error) {
+		if calls.Add(1) == 1 {
+			return nil, &tgo.Error{ErrorCode: 500, Description: "Internal Server Error"}
+		}
+		sent <- call.Params["text"].(string)
+		return map[string]any{"message_id": 1, "date": 1, "chat": map[string]any{"id": 1, "type": "private"}}, nil
+	})
+
+	bot := server.Bot(tgo.Options{})
+	store := tgo.NewMemoryOutboxStore(time.Hour)
+	outbox := bot.StartOutbox(tgo.OutboxOptions{Store: store, Interval: 10 * time.Millisecond, RetryDelay: 10 * time.Millisecond})
+
+	for i, want := range []bool{true, false} {
+		added, err := bot.SendOutbox("order-1", &tgo.SendMessage{ChatId: tgo.ID(1), Text: "first"})
+		if err != nil {
+			t.Fatal(err)
+		} else if added != want {
+			t.Errorf("attempt %d: expected added to be %v", i+1, want)
+		}
+	}
+
+	select {
+	case text := <-sent:
+		if text != "first" {
+			t.Errorf("unexpected text %q", text)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the message is not retried")
+	}
+
+	// once it's stopped, the messages are only sent by the shutdown's flush.
+	outbox.Stop()
+	if _, err := bot.SendOutbox("", &tgo.SendMessage{ChatId: tgo.ID(1), Text: "second"}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := bot.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	} else if text := <-sent; text != "second" {
+		t.Errorf("unexpected text %q", text)
+	}
+
+	if due, _ := store.Due(time.Now()); len(due) != 0 {
+		t.Errorf("expected an empty outbox, got %d messages", len(due))
+	}
+}
+
+func TestOutboxSendPath(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	params := make(chan map[string]any, 1)
+	server.Handle("sendMessage", func(call tgotest.Call) (any, *tgo.Error) {
+		params <- call.Params
+		<-release
+		return nil, &tgo.Error{ErrorCode: 500, Description: "Internal Server Error"}
+	})
+
+	bot := server.Bot(tgo.Options{DefaultLinkPreview: tgo.LinkPreviewDisabled()})
+	store := tgo.NewMemoryOutboxStore(time.Hour)
+	outbox := bot.StartOutbox(tgo.OutboxOptions{Store: store})
+	outbox.Stop()
+
+	if _, err := bot.SendOutbox("", &tgo.SendMessage{ChatId: tgo.ID(1), Text: "hi"}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	flushed := make(chan error, 1)
+	go func() { flushed <- outbox.Flush(ctx) }()
+
+	select {
+	case err := <-flushed:
+		if err != context.DeadlineExceeded {
+			t.Errorf("expected the flush to end by its context, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the flush's context doesn't cancel the message which is being sent")
+	}
+
+	if p := <-params; p["link_preview_options"] == nil {
+		t.Errorf("expected the default link preview to be applied, got %v", p)
+	}
+	if due, _ := store.Due(time.Now()); len(due) != 1 || due[0].Attempts != 0 {
+		t.Errorf("expected the cut off message to be kept as it is, got %+v", due)
+	}
+}
@@ -0,0 +1,202 @@
+// Package paginator contains an inline keyboard widget which lists the items page by page,
+// with the buttons to select them and to move between the pages.
+package paginator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/filters"
+	"github.com/haashemi/tgo/keyboard"
+	"github.com/haashemi/tgo/routers/callback"
+)
+
+// DefaultPageSize is the number of the items on each page by default.
+const DefaultPageSize = 10
+
+// DefaultGoneText is the callback answer of selecting an item which is no longer in the list.
+const DefaultGoneText = "This item is no longer available."
+
+// FetchFunc returns up to limit items of the list starting from the offset, and the
+// number of all the items in the list, such as from a database query.
+type FetchFunc[T any] func(offset, limit int) (items []T, total int, err error)
+
+// Options configures a Paginator.
+type Options[T any] struct {
+	// Prefix is the prefix of the paginator's callback data, such as "users". It's required,
+	// and it must be unique among the callback data of the bot's keyboards.
+	Prefix string
+
+	// PageSize is the number of the items on each page; it defaults to DefaultPageSize.
+	PageSize int
+
+	// Label returns the text of an item's button, by the item's number in the list which starts
+	// from 1; it defaults to "<number>. <item>".
+	Label func(number int, item T) string
+
+	// Text returns the text of the message showing a page, whose number starts from 1;
+	// it defaults to "Page <page> of <pages>".
+	Text func(page, pages int) string
+
+	// OnSelect is called when an item's button is tapped, with its index in the list. The callback
+	// query is answered after it returns, unless it's answered by OnSelect itself.
+	OnSelect func(ctx *callback.Context, index int, item T)
+
+	// Prev and Next are the texts of the buttons moving between the pages; they default to "«" and "»".
+	Prev, Next string
+
+	// Layout arranges the items' buttons into the rows; see keyboard.Balance.
+	Layout keyboard.BalanceOptions
+}
+
+// Paginator lists the items of a slice, or of a FetchFunc, in an inline keyboard page by page.
+// Register it on a callback router, and send its first page by Send:
+//
+//	users := paginator.New(names, paginator.Options[string]{
+//		Prefix:   "users",
+//		OnSelect: func(ctx *callback.Context, index int, name string) { ... },
+//	})
+//	users.Register(callbackRouter)
+//	users.Send(bot, tgo.ID(chatID))
+//
+// The pages are edited in place when the buttons are tapped, and the paginator keeps no state,
+// so it works across the restarts and for any number of the users.
+type Paginator[T any] struct {
+	fetch FetchFunc[T]
+	opts  Options[T]
+}
+
+// New returns a Paginator listing the items of the slice.
+func New[T any](items []T, opts Options[T]) *Paginator[T] {
+	return NewWithFetch(func(offset, limit int) ([]T, int, error) {
+		if offset >= len(items) {
+			return nil, len(items), nil
+		}
+
+		end := offset + limit
+		if end > len(items) {
+			end = len(items)
+		}
+		return items[offset:end], len(items), nil
+	}, opts)
+}
+
+// NewWithFetch returns a Paginator listing the items returned by the fetch function.
+func NewWithFetch[T any](fetch FetchFunc[T], opts Options[T]) *Paginator[T] {
+	if opts.PageSize <= 0 {
+		opts.PageSize = DefaultPageSize
+	}
+	if opts.Label == nil {
+		opts.Label = func(number int, item T) string { return fmt.Sprintf("%d. %v", number, item) }
+	}
+	if opts.Text == nil {
+		opts.Text = func(page, pages int) string { return fmt.Sprintf("Page %d of %d", page, pages) }
+	}
+	if opts.Prev == "" {
+		opts.Prev = "«"
+	}
+	if opts.Next == "" {
+		opts.Next = "»"
+	}
+
+	return &Paginator[T]{fetch: fetch, opts: opts}
+}
+
+// Register adds the route handling the paginator's buttons to the router, with the middlewares.
+func (p *Paginator[T]) Register(router *callback.Router, middlewares ...callback.Middleware) {
+	router.Handle(filters.WithPrefix(p.opts.Prefix+":"), p.handle, middlewares...)
+}
+
+// Render returns the text and the keyboard of the page, whose index starts from 0. The pages after
+// the last one are rendered as the last one, such as when the list has shrunk.
+func (p *Paginator[T]) Render(page int) (text string, markup *tgo.InlineKeyboardMarkup, err error) {
+	if page < 0 {
+		page = 0
+	}
+
+	items, total, err := p.fetch(page*p.opts.PageSize, p.opts.PageSize)
+	if err != nil {
+		return "", nil, err
+	}
+
+	pages := (total + p.opts.PageSize - 1) / p.opts.PageSize
+	if pages == 0 {
+		pages = 1
+	}
+	if page >= pages {
+		page = pages - 1
+		if items, total, err = p.fetch(page*p.opts.PageSize, p.opts.PageSize); err != nil {
+			return "", nil, err
+		}
+	}
+
+	buttons := make([]*tgo.InlineKeyboardButton, len(items))
+	for i, item := range items {
+		index := page*p.opts.PageSize + i
+		buttons[i] = &tgo.InlineKeyboardButton{Text: p.opts.Label(index+1, item), CallbackData: p.data("s", index)}
+	}
+
+	rows := keyboard.Balance(buttons, p.opts.Layout)
+	if pages > 1 {
+		var nav []*tgo.InlineKeyboardButton
+		if page > 0 {
+			nav = append(nav, &tgo.InlineKeyboardButton{Text: p.opts.Prev, CallbackData: p.data("p", page-1)})
+		}
+		nav = append(nav, &tgo.InlineKeyboardButton{Text: fmt.Sprintf("%d/%d", page+1, pages), CallbackData: p.opts.Prefix + ":n"})
+		if page < pages-1 {
+			nav = append(nav, &tgo.InlineKeyboardButton{Text: p.opts.Next, CallbackData: p.data("p", page+1)})
+		}
+		rows = append(rows, nav)
+	}
+
+	return p.opts.Text(page+1, pages), &tgo.InlineKeyboardMarkup{InlineKeyboard: rows}, nil
+}
+
+// Send sends the first page to the chat.
+func (p *Paginator[T]) Send(bot *tgo.Bot, chatID tgo.ChatID) (*tgo.Message, error) {
+	text, markup, err := p.Render(0)
+	if err != nil {
+		return nil, err
+	}
+
+	return bot.Send(&tgo.SendMessage{ChatId: chatID, Text: text, ReplyMarkup: markup})
+}
+
+func (p *Paginator[T]) data(action string, n int) string {
+	return p.opts.Prefix + ":" + action + ":" + strconv.Itoa(n)
+}
+
+func (p *Paginator[T]) handle(ctx *callback.Context) {
+	action, arg, _ := strings.Cut(strings.TrimPrefix(ctx.Data, p.opts.Prefix+":"), ":")
+	n, _ := strconv.Atoi(arg)
+
+	switch action {
+	case "p":
+		text, markup, err := p.Render(n)
+		if err == nil {
+			_, err = ctx.EditOrSend(text, markup)
+		}
+		if err != nil {
+			ctx.Bot.Logger().Log(tgo.LevelError, "failed to render the page", "prefix", p.opts.Prefix, "page", n, "error", err)
+		}
+		ctx.Answer(&tgo.AnswerCallbackQuery{})
+
+	case "s":
+		items, _, err := p.fetch(n, 1)
+		if err != nil || len(items) == 0 {
+			ctx.Answer(&tgo.AnswerCallbackQuery{Text: DefaultGoneText})
+			return
+		}
+
+		if p.opts.OnSelect != nil {
+			p.opts.OnSelect(ctx, n, items[0])
+		}
+		ctx.Answer(&tgo.AnswerCallbackQuery{})
+
+	default:
+		// the page number's button does nothing.
+		ctx.Answer(&tgo.AnswerCallbackQuery{})
+	}
+}
@@ -0,0 +1,95 @@
+package paginator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/routers/callback"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestRender(t *testing.T) {
+	items := make([]int, 25)
+	for i := range items {
+		items[i] = i
+	}
+	p := New(items, Options[int]{Prefix: "nums"})
+
+	tests := []struct {
+		page     int
+		text     string
+		buttons  int
+		navTexts []string
+	}{
+		{page: 0, text: "Page 1 of 3", buttons: 10, navTexts: []string{"1/3", "»"}},
+		{page: 1, text: "Page 2 of 3", buttons: 10, navTexts: []string{"«", "2/3", "»"}},
+		{page: 9, text: "Page 3 of 3", buttons: 5, navTexts: []string{"«", "3/3"}},
+	}
+
+	for _, tt := range tests {
+		text, markup, err := p.Render(tt.page)
+		if err != nil {
+			t.Fatal(err)
+		} else if text != tt.text {
+			t.Errorf("page %d: expected text %q, got %q", tt.page, tt.text, text)
+		}
+
+		rows := markup.InlineKeyboard
+		nav := rows[len(rows)-1]
+
+		var buttons int
+		for _, row := range rows[:len(rows)-1] {
+			buttons += len(row)
+		}
+		if buttons != tt.buttons {
+			t.Errorf("page %d: expected %d buttons, got %d", tt.page, tt.buttons, buttons)
+		}
+
+		var navTexts []string
+		for _, button := range nav {
+			navTexts = append(navTexts, button.Text)
+		}
+		if fmt.Sprint(navTexts) != fmt.Sprint(tt.navTexts) {
+			t.Errorf("page %d: expected the navigation %v, got %v", tt.page, tt.navTexts, navTexts)
+		}
+	}
+}
+
+func TestHandle(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	server.Handle("editMessageText", func(call tgotest.Call) (any, *tgo.Error) {
+		return map[string]any{"message_id": 5, "date": 1, "chat": map[string]any{"id": 1, "type": "private"}}, nil
+	})
+
+	bot := server.Bot(tgo.Options{})
+
+	selected := -1
+	p := New([]string{"a", "b", "c"}, Options[string]{
+		Prefix:   "letters",
+		PageSize: 2,
+		OnSelect: func(ctx *callback.Context, index int, item string) { selected = index },
+	})
+
+	router := callback.NewRouter()
+	p.Register(router)
+
+	query := func(data string) *tgo.Update {
+		return &tgo.Update{CallbackQuery: &tgo.CallbackQuery{Id: "1", Data: data, Message: &tgo.Message{MessageId: 5, Chat: tgo.Chat{Id: 1}}}}
+	}
+
+	router.HandleUpdate(bot, query("letters:s:2"))
+	if selected != 2 {
+		t.Errorf("expected the item 2 to be selected, got %d", selected)
+	}
+
+	server.Reset()
+	router.HandleUpdate(bot, query("letters:p:1"))
+
+	calls := server.Calls()
+	if len(calls) != 2 || calls[0].Method != "editMessageText" || calls[0].Params["text"] != "Page 2 of 2" {
+		t.Errorf("unexpected calls: %+v", calls)
+	}
+}
@@ -0,0 +1,30 @@
+package tgo
+
+// Pin pins the message in the chat, notifying its members; see PinSilently for the quiet one.
+func (api *API) Pin(chatID ChatID, messageID int64) error {
+	_, err := api.PinChatMessage(&PinChatMessage{ChatId: chatID, MessageId: messageID})
+	return err
+}
+
+// PinSilently pins the message in the chat without notifying its members.
+func (api *API) PinSilently(chatID ChatID, messageID int64) error {
+	_, err := api.PinChatMessage(&PinChatMessage{ChatId: chatID, MessageId: messageID, DisableNotification: true})
+	return err
+}
+
+// Unpin unpins the message in the chat, or its most recent pinned one if messageID is zero.
+func (api *API) Unpin(chatID ChatID, messageID int64) error {
+	_, err := api.UnpinChatMessage(&UnpinChatMessage{ChatId: chatID, MessageId: messageID})
+	return err
+}
+
+// UnpinAll unpins all the pinned messages of the chat, or of its forum topic if threadID is not zero.
+func (api *API) UnpinAll(chatID ChatID, threadID int64) error {
+	var err error
+	if threadID != 0 {
+		_, err = api.UnpinAllForumTopicMessages(&UnpinAllForumTopicMessages{ChatId: chatID, MessageThreadId: threadID})
+	} else {
+		_, err = api.UnpinAllChatMessages(&UnpinAllChatMessages{ChatId: chatID})
+	}
+	return err
+}
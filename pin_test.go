@@ -0,0 +1,34 @@
+package tgo_test
+
+import (
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestPins(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	bot := server.Bot(tgo.Options{})
+	for _, method := range []string{"pinChatMessage", "unpinChatMessage", "unpinAllChatMessages", "unpinAllForumTopicMessages"} {
+		server.Handle(method, func(call tgotest.Call) (any, *tgo.Error) { return true, nil })
+	}
+
+	bot.PinSilently(tgo.ID(1), 10)
+	bot.Unpin(tgo.ID(1), 0)
+	bot.UnpinAll(tgo.ID(1), 0)
+	bot.UnpinAll(tgo.ID(1), 5)
+
+	calls := server.Calls()
+	if len(calls) != 4 {
+		t.Fatalf("made %d calls, want 4", len(calls))
+	} else if calls[0].Params["disable_notification"] != true || calls[0].Params["message_id"] != float64(10) {
+		t.Fatalf("unexpected pin params %v", calls[0].Params)
+	} else if _, ok := calls[1].Params["message_id"]; ok {
+		t.Fatal("the most recent message is unpinned by its id")
+	} else if calls[2].Method != "unpinAllChatMessages" || calls[3].Method != "unpinAllForumTopicMessages" || calls[3].Params["message_thread_id"] != float64(5) {
+		t.Fatalf("unexpected unpin all calls %v, %v", calls[2], calls[3])
+	}
+}
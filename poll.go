@@ -0,0 +1,110 @@
+package tgo
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var (
+	ErrPollQuestion      = errors.New("tgo: poll question must be 1-300 characters")
+	ErrPollOptions       = errors.New("tgo: poll must have 2-10 options of 1-100 characters")
+	ErrPollCorrectOption = errors.New("tgo: quiz's correct option is out of range")
+	ErrPollOpenPeriod    = errors.New("tgo: poll's open period must be 5-600 seconds")
+)
+
+// PollBuilder builds the parameters of a regular poll or a quiz, and validates them.
+type PollBuilder struct {
+	params SendPoll
+}
+
+// NewPoll starts building a regular, and by default anonymous, poll with the question and options.
+func NewPoll(question string, options ...string) *PollBuilder {
+	return &PollBuilder{params: SendPoll{Question: question, Options: options, IsAnonymous: true, Type: "regular"}}
+}
+
+// Option adds the options to the poll.
+func (b *PollBuilder) Option(options ...string) *PollBuilder {
+	b.params.Options = append(b.params.Options, options...)
+	return b
+}
+
+// Quiz makes the poll a quiz with the 0-based index of the correct option.
+func (b *PollBuilder) Quiz(correctOption int) *PollBuilder {
+	b.params.Type = "quiz"
+	b.params.CorrectOptionId = int64(correctOption)
+	return b
+}
+
+// Explanation sets the text shown after choosing an incorrect answer of the quiz, with its entities if any.
+func (b *PollBuilder) Explanation(text string, entities ...*MessageEntity) *PollBuilder {
+	b.params.Explanation = text
+	b.params.ExplanationEntities = entities
+	return b
+}
+
+// Public makes the poll non-anonymous, so the bot receives its answers.
+func (b *PollBuilder) Public() *PollBuilder {
+	b.params.IsAnonymous = false
+	return b
+}
+
+// MultipleAnswers allows choosing more than one option; it's ignored by the quizzes.
+func (b *PollBuilder) MultipleAnswers() *PollBuilder {
+	b.params.AllowsMultipleAnswers = true
+	return b
+}
+
+// OpenPeriod closes the poll automatically after the duration, which must be 5-600 seconds.
+func (b *PollBuilder) OpenPeriod(d time.Duration) *PollBuilder {
+	b.params.OpenPeriod = int64(d / time.Second)
+	return b
+}
+
+// Build validates and returns the poll's parameters, to be sent by bot.Send.
+func (b *PollBuilder) Build() (*SendPoll, error) {
+	params := b.params
+
+	if n := len([]rune(params.Question)); n < 1 || n > 300 {
+		return nil, ErrPollQuestion
+	}
+
+	if len(params.Options) < 2 || len(params.Options) > 10 {
+		return nil, ErrPollOptions
+	}
+	for _, option := range params.Options {
+		if n := len([]rune(option)); n < 1 || n > 100 {
+			return nil, ErrPollOptions
+		}
+	}
+
+	if params.Type == "quiz" {
+		if params.CorrectOptionId < 0 || params.CorrectOptionId >= int64(len(params.Options)) {
+			return nil, ErrPollCorrectOption
+		}
+		params.AllowsMultipleAnswers = false
+	}
+
+	if params.OpenPeriod != 0 && (params.OpenPeriod < 5 || params.OpenPeriod > 600) {
+		return nil, ErrPollOpenPeriod
+	}
+
+	params.Options = append([]string(nil), params.Options...)
+	return &params, nil
+}
+
+// MarshalJSON keeps the correct_option_id of the quizzes even if it's zero, as telegram requires it for them.
+func (x *SendPoll) MarshalJSON() ([]byte, error) {
+	type sendPoll SendPoll
+
+	data := struct {
+		*sendPoll
+		CorrectOptionId *int64 `json:"correct_option_id,omitempty"`
+	}{sendPoll: (*sendPoll)(x)}
+
+	if x.Type == "quiz" {
+		data.CorrectOptionId = &x.CorrectOptionId
+	}
+
+	return json.Marshal(data)
+}
@@ -0,0 +1,23 @@
+package tgo
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestPollBuilder(t *testing.T) {
+	if _, err := NewPoll("Pick one", "only").Build(); err != ErrPollOptions {
+		t.Errorf("got %v for a single option", err)
+	}
+
+	quiz, err := NewPoll("2 + 2?", "4", "5").Quiz(0).Explanation("basic math").Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ := json.Marshal(quiz)
+	if !strings.Contains(string(data), `"correct_option_id":0`) || !strings.Contains(string(data), `"type":"quiz"`) {
+		t.Errorf("unexpected quiz params: %s", data)
+	}
+}
@@ -1,26 +1,129 @@
 package tgo
 
 import (
+	"context"
 	"errors"
 	"syscall"
 	"time"
 )
 
+// PollingOptions configures bot.StartPollingWithOptions.
+type PollingOptions struct {
+	// Timeout is the seconds which each getUpdates call waits for the updates, if there's none.
+	// Zero does a short polling, which you usually don't want.
+	Timeout int64
+
+	// Limit is the maximum number of the updates fetched by each getUpdates call, from 1 to 100.
+	// It defaults to telegram's default, which is 100.
+	Limit int64
+
+	// AllowedUpdates are the types of the updates to be received, such as "message" or "callback_query".
+	// It defaults to telegram's default, which is all of them but chat_member, message_reaction
+	// and message_reaction_count.
+	AllowedUpdates []string
+
+	// AutoAllowedUpdates, if true and there's no AllowedUpdates, receives only the types of the updates
+	// which are handled by the bot's routers; see bot.AllowedUpdates.
+	AutoAllowedUpdates bool
+
+	// RequestTimeout is how long each getUpdates call may take before it's retried, to not get stuck
+	// on a stalled connection. It defaults to 10 seconds more than the Timeout.
+	RequestTimeout time.Duration
+
+	// OffsetStore, if not nil, persists the offset of the handled updates, and the polling resumes from it.
+	OffsetStore OffsetStore
+}
+
+// UpdateTypesRouter is implemented by the routers which know the types of the updates they handle,
+// so bot.AllowedUpdates can tell which ones the bot needs.
+type UpdateTypesRouter interface {
+	Router
+
+	// UpdateTypes returns the types of the updates it handles, as named in the allowed_updates.
+	UpdateTypes() []string
+}
+
+// AllowedUpdates returns the types of the updates which are handled by the bot's routers, and the
+// my_chat_member updates to track the blocks. It returns nil, which is telegram's default, if the
+// bot has dispatchers, or any of its routers doesn't implement the UpdateTypesRouter interface.
+func (bot *Bot) AllowedUpdates() []string {
+	if len(bot.dispatchers) != 0 {
+		return nil
+	}
+
+	types := []string{"my_chat_member"}
+	seen := map[string]bool{"my_chat_member": true}
+
+	for _, router := range bot.routers {
+		r, ok := router.(UpdateTypesRouter)
+		if !ok {
+			return nil
+		}
+
+		for _, updateType := range r.UpdateTypes() {
+			if !seen[updateType] {
+				seen[updateType] = true
+				types = append(types, updateType)
+			}
+		}
+	}
+
+	return types
+}
+
 // StartPolling does an infinite GetUpdates with the timeout of the passed timeoutSeconds.
 // allowedUpdates by default passes nothing and uses the telegram's default.
 //
 // see tgo.GetUpdate for more detailed information.
 func (bot *Bot) StartPolling(timeoutSeconds int64, allowedUpdates ...string) error {
+	return bot.StartPollingWithOptions(PollingOptions{Timeout: timeoutSeconds, AllowedUpdates: allowedUpdates})
+}
+
+// StartPollingWithOptions does an infinite GetUpdates with the options, and passes the updates to
+// bot.HandleUpdate in their own goroutines.
+//
+// It returns nil once bot.Shutdown is called, after passing the already fetched updates to the handlers.
+func (bot *Bot) StartPollingWithOptions(opts PollingOptions) error {
+	if opts.AllowedUpdates == nil && opts.AutoAllowedUpdates {
+		opts.AllowedUpdates = bot.AllowedUpdates()
+	}
+	if opts.RequestTimeout <= 0 {
+		opts.RequestTimeout = time.Duration(opts.Timeout)*time.Second + 10*time.Second
+	}
+
 	var offset int64
+	var tracker *offsetTracker
+
+	if opts.OffsetStore != nil {
+		var err error
+		if offset, err = opts.OffsetStore.LoadOffset(); err != nil {
+			return err
+		}
+		tracker = &offsetTracker{api: bot.API, store: opts.OffsetStore, pending: make(map[int64]bool), next: offset, saved: offset}
+	}
+
+	if !bot.inflight.enter() {
+		return nil
+	}
+	defer bot.inflight.done()
 
 	for {
-		data, err := bot.GetUpdates(&GetUpdates{
+		// the poller's calls get canceled by bot.Shutdown, so it doesn't wait for the long poll.
+		ctx, cancel := context.WithTimeout(bot.polling, opts.RequestTimeout)
+		data, err := bot.API.WithContext(ctx).GetUpdates(&GetUpdates{
 			Offset:         offset, // Is there any better way to do this? open an issue/pull-request if you know. thx.
-			Timeout:        timeoutSeconds,
-			AllowedUpdates: allowedUpdates,
+			Limit:          opts.Limit,
+			Timeout:        opts.Timeout,
+			AllowedUpdates: opts.AllowedUpdates,
 		})
+		cancel()
+
 		if err != nil {
-			if errors.Is(err, syscall.ECONNRESET) {
+			if bot.IsShuttingDown() {
+				return nil
+			} else if errors.Is(err, context.DeadlineExceeded) {
+				continue
+			} else if errors.Is(err, syscall.ECONNRESET) {
 				time.Sleep(time.Second / 2)
 				continue
 			}
@@ -29,18 +132,25 @@ func (bot *Bot) StartPolling(timeoutSeconds int64, allowedUpdates ...string) err
 
 		for _, update := range data {
 			offset = update.UpdateId + 1
+			bot.polledOffset.Store(offset)
+			if tracker != nil {
+				tracker.polled(update.UpdateId)
+			}
 
-			go func(update *Update) {
-				if update.Message != nil && bot.sendAnswerIfAsked(update.Message) {
-					return
-				}
+			update := update
+			bot.inflight.hold()
+			bot.spawn(func() {
+				defer bot.inflight.done()
+				bot.HandleUpdate(update)
 
-				for _, router := range bot.routers {
-					if used := router.HandleUpdate(bot, update); used {
-						return
-					}
+				if tracker != nil {
+					tracker.handled(update.UpdateId)
 				}
-			}(update)
+			})
+		}
+
+		if bot.IsShuttingDown() {
+			return nil
 		}
 	}
 }
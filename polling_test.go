@@ -0,0 +1,52 @@
+package tgo_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/routers/callback"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestPollingOptions(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	polled := make(chan tgotest.Call, 1)
+	server.Handle("getUpdates", func(call tgotest.Call) (any, *tgo.Error) {
+		select {
+		case polled <- call:
+		default:
+		}
+		time.Sleep(10 * time.Millisecond)
+		return []any{}, nil
+	})
+
+	bot := server.Bot(tgo.Options{})
+	bot.AddRouter(tgo.NewMuteCommands(tgo.MuteCommandsOptions{}))
+	bot.AddRouter(callback.NewRouter())
+
+	expected := []string{"my_chat_member", "message", "callback_query"}
+	if types := bot.AllowedUpdates(); !reflect.DeepEqual(types, expected) {
+		t.Fatalf("expected the allowed updates %q, got %q", expected, types)
+	}
+
+	go bot.StartPollingWithOptions(tgo.PollingOptions{Timeout: 1, Limit: 10, AutoAllowedUpdates: true})
+	defer bot.Shutdown(context.Background())
+
+	var call tgotest.Call
+	select {
+	case call = <-polled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the bot is not polling")
+	}
+
+	if call.Params["limit"] != float64(10) || call.Params["timeout"] != float64(1) {
+		t.Fatalf("unexpected getUpdates params %v", call.Params)
+	} else if allowed := call.Params["allowed_updates"]; !reflect.DeepEqual(allowed, []any{"my_chat_member", "message", "callback_query"}) {
+		t.Fatalf("unexpected allowed updates %v", allowed)
+	}
+}
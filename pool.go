@@ -0,0 +1,75 @@
+package tgo
+
+import "sync"
+
+// WorkerPool is a fixed number of goroutines handling the updates, which may be shared by many bots
+// to bound the concurrency of the whole process. Pass it to the Options to use it.
+type WorkerPool struct {
+	tasks chan func()
+
+	mut     sync.RWMutex
+	stopped bool
+	workers sync.WaitGroup
+}
+
+// NewWorkerPool starts a WorkerPool of the workers (at least one), whose queue may hold queueSize
+// tasks; submitting to it blocks while the queue is full, to slow the pollers down.
+func NewWorkerPool(workers, queueSize int) *WorkerPool {
+	if workers < 1 {
+		workers = 1
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+
+	p := &WorkerPool{tasks: make(chan func(), queueSize)}
+	p.workers.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.work()
+	}
+	return p
+}
+
+func (p *WorkerPool) work() {
+	defer p.workers.Done()
+
+	for task := range p.tasks {
+		task()
+	}
+}
+
+// Submit runs the task by one of the workers. If the pool is stopped, it's run in a new goroutine.
+func (p *WorkerPool) Submit(task func()) {
+	p.mut.RLock()
+	defer p.mut.RUnlock()
+
+	if p.stopped {
+		go task()
+		return
+	}
+	p.tasks <- task
+}
+
+// Len returns the number of the tasks waiting for the workers.
+func (p *WorkerPool) Len() int { return len(p.tasks) }
+
+// Stop waits for the workers to run the queued tasks and exit.
+func (p *WorkerPool) Stop() {
+	p.mut.Lock()
+	if !p.stopped {
+		p.stopped = true
+		close(p.tasks)
+	}
+	p.mut.Unlock()
+
+	p.workers.Wait()
+}
+
+// spawn runs the task by the bot's worker pool, or in a new goroutine if it has none.
+func (bot *Bot) spawn(task func()) {
+	if bot.pool != nil {
+		bot.pool.Submit(task)
+	} else {
+		go task()
+	}
+}
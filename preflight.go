@@ -0,0 +1,247 @@
+package tgo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Pinger is implemented by the stores which can check their connectivity, such as the ones
+// backed by a database; bot.Preflight pings the bot's stores which implement it.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// PreflightChat is a chat which the bot must be a member of, such as a log channel or an admin group.
+type PreflightChat struct {
+	ChatID ChatID
+
+	// Rights are the administrator rights which the bot must have in the chat; if none of them is
+	// set, the bot only has to be a member of it.
+	Rights ChatAdministratorRights
+}
+
+// PreflightOptions configures the checks of bot.Preflight, other than the ones which always run.
+type PreflightOptions struct {
+	// WebhookURL is the webhook which must be set, or empty if the bot is receiving the updates by polling.
+	WebhookURL string
+
+	// Chats are the chats which the bot must be a member of, with the rights it needs in them.
+	Chats []PreflightChat
+
+	// Commands, if not nil, are the commands of the default scope which must be set for the bot.
+	Commands []*BotCommand
+}
+
+// PreflightCheck is the result of a single check of bot.Preflight.
+type PreflightCheck struct {
+	Name string // what's checked, such as "token" or "chat @logs"
+	Err  error  // why the check failed, or nil if it's passed
+}
+
+// PreflightReport is the result of bot.Preflight.
+type PreflightReport struct {
+	Checks []PreflightCheck
+}
+
+// OK returns true if all of the checks are passed.
+func (r *PreflightReport) OK() bool { return r.Err() == nil }
+
+// Err returns an error describing all of the failed checks, or nil if there's none.
+func (r *PreflightReport) Err() error {
+	var failures []string
+	for _, check := range r.Checks {
+		if check.Err != nil {
+			failures = append(failures, check.Name+": "+check.Err.Error())
+		}
+	}
+
+	if len(failures) == 0 {
+		return nil
+	}
+	return errors.New("tgo: preflight failed:\n\t" + strings.Join(failures, "\n\t"))
+}
+
+// String returns a line for each check, with its state.
+func (r *PreflightReport) String() string {
+	var lines strings.Builder
+	for _, check := range r.Checks {
+		if check.Err != nil {
+			fmt.Fprintf(&lines, "FAIL %s: %v\n", check.Name, check.Err)
+		} else {
+			fmt.Fprintf(&lines, "OK   %s\n", check.Name)
+		}
+	}
+	return lines.String()
+}
+
+func (r *PreflightReport) add(name string, err error) {
+	r.Checks = append(r.Checks, PreflightCheck{name, err})
+}
+
+// Preflight verifies the bot's setup, so the deployments fail fast with a clear diagnostic:
+// the token, the webhook, the connectivity of the stores which implement Pinger, the owners,
+// and the chats and commands of the options. It runs all of the checks, unless the token is
+// invalid or the context is done; use the report's Err to fail on any of them.
+func (bot *Bot) Preflight(ctx context.Context, opts PreflightOptions) *PreflightReport {
+	report := &PreflightReport{}
+
+	me, err := bot.Me()
+	if report.add("token", err); err != nil {
+		return report
+	}
+
+	checks := []func(){
+		func() { report.add("webhook", bot.checkWebhook(opts.WebhookURL)) },
+		func() { bot.pingStores(ctx, report) },
+		func() {
+			for _, owner := range bot.owners {
+				_, err := bot.GetChat(&GetChat{ChatId: ID(owner)})
+				report.add(fmt.Sprintf("owner %d", owner), err)
+			}
+		},
+		func() {
+			for _, chat := range opts.Chats {
+				report.add("chat "+describeChatID(chat.ChatID), bot.checkChatRights(me.Id, chat))
+			}
+		},
+		func() {
+			if opts.Commands != nil {
+				report.add("commands", bot.checkCommands(opts.Commands))
+			}
+		},
+	}
+
+	for _, check := range checks {
+		if err := ctx.Err(); err != nil {
+			report.add("preflight", err)
+			return report
+		}
+		check()
+	}
+
+	return report
+}
+
+func (bot *Bot) checkWebhook(expected string) error {
+	info, err := bot.GetWebhookInfo()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case expected == "" && info.Url != "":
+		return fmt.Errorf("webhook is set to %q, but the bot is polling", info.Url)
+	case expected != "" && info.Url != expected:
+		if info.Url == "" {
+			return fmt.Errorf("webhook is not set, expected %q", expected)
+		}
+		return fmt.Errorf("webhook is set to %q, expected %q", info.Url, expected)
+	}
+
+	return nil
+}
+
+func (bot *Bot) pingStores(ctx context.Context, report *PreflightReport) {
+	stores := map[string]any{"block store": bot.blockStore, "mute store": bot.muteStore}
+	if bot.callbackStore != nil {
+		stores["callback store"] = bot.callbackStore
+	}
+	if s := bot.Scheduler(); s != nil {
+		stores["job store"] = s.opts.Store
+	}
+	if o := bot.Outbox(); o != nil {
+		stores["outbox store"] = o.opts.Store
+	}
+
+	for _, name := range []string{"block store", "mute store", "callback store", "job store", "outbox store"} {
+		if pinger, ok := stores[name].(Pinger); ok {
+			report.add(name, pinger.Ping(ctx))
+		}
+	}
+}
+
+// checkChatRights returns an error if the user isn't a member of the chat, or lacks any of the rights.
+func (bot *Bot) checkChatRights(userID int64, chat PreflightChat) error {
+	member, err := bot.GetChatMember(&GetChatMember{ChatId: chat.ChatID, UserId: userID})
+	if err != nil {
+		return err
+	} else if !IsChatMemberPresent(member) {
+		return fmt.Errorf("bot is not a member, but %s", ChatMemberStatus(member))
+	}
+
+	// the rights are compared by their json fields, which are the same in ChatMemberAdministrator.
+	var required, granted map[string]any
+	if err = remarshal(chat.Rights, &required); err != nil {
+		return err
+	} else if err = remarshal(member, &granted); err != nil {
+		return err
+	}
+
+	var missing []string
+	for right, value := range required {
+		if value == true && granted[right] != true {
+			missing = append(missing, right)
+		}
+	}
+
+	if len(missing) != 0 && ChatMemberStatus(member) != "creator" {
+		sort.Strings(missing)
+		return fmt.Errorf("bot lacks the rights %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// checkCommands returns an error if the bot's commands of the default scope differ from the expected ones.
+func (bot *Bot) checkCommands(expected []*BotCommand) error {
+	commands, err := bot.GetMyCommands(&GetMyCommands{})
+	if err != nil {
+		return err
+	}
+
+	set := make(map[string]string, len(commands))
+	for _, command := range commands {
+		set[command.Command] = command.Description
+	}
+
+	var diff []string
+	for _, command := range expected {
+		description, ok := set[command.Command]
+		if !ok {
+			diff = append(diff, "/"+command.Command+" is missing")
+		} else if description != command.Description {
+			diff = append(diff, "/"+command.Command+" has a different description")
+		}
+		delete(set, command.Command)
+	}
+	for command := range set {
+		diff = append(diff, "/"+command+" is not expected")
+	}
+
+	if len(diff) != 0 {
+		sort.Strings(diff)
+		return errors.New("commands are out of sync: " + strings.Join(diff, "; "))
+	}
+	return nil
+}
+
+func describeChatID(chatID ChatID) string {
+	switch id := chatID.(type) {
+	case ID:
+		return fmt.Sprint(int64(id))
+	case Username:
+		return string(id)
+	}
+	return fmt.Sprint(chatID)
+}
+
+func remarshal(from, to any) error {
+	data, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, to)
+}
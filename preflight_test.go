@@ -0,0 +1,53 @@
+package tgo_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestPreflight(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	server.Handle("getWebhookInfo", func(call tgotest.Call) (any, *tgo.Error) {
+		return map[string]any{"url": "https://example.com/old", "has_custom_certificate": false, "pending_update_count": 0}, nil
+	})
+	server.Handle("getChatMember", func(call tgotest.Call) (any, *tgo.Error) {
+		return map[string]any{"status": "administrator", "user": map[string]any{"id": 123456}, "can_delete_messages": true}, nil
+	})
+	server.Handle("getMyCommands", func(call tgotest.Call) (any, *tgo.Error) {
+		return []map[string]any{{"command": "start", "description": "Start"}, {"command": "old", "description": "Old"}}, nil
+	})
+	server.Handle("getChat", func(call tgotest.Call) (any, *tgo.Error) {
+		return map[string]any{"id": call.Params["chat_id"], "type": "private"}, nil
+	})
+
+	bot := server.Bot(tgo.Options{Owners: []int64{1}})
+	report := bot.Preflight(context.Background(), tgo.PreflightOptions{
+		WebhookURL: "https://example.com/new",
+		Chats: []tgo.PreflightChat{
+			{ChatID: tgo.Username("@logs")},
+			{ChatID: tgo.ID(-100), Rights: tgo.ChatAdministratorRights{CanDeleteMessages: true, CanPinMessages: true}},
+		},
+		Commands: []*tgo.BotCommand{{Command: "start", Description: "Start"}, {Command: "help", Description: "Help"}},
+	})
+
+	expected := strings.Join([]string{
+		"OK   token",
+		`FAIL webhook: webhook is set to "https://example.com/old", expected "https://example.com/new"`,
+		"OK   owner 1",
+		"OK   chat @logs",
+		"FAIL chat -100: bot lacks the rights can_pin_messages",
+		"FAIL commands: commands are out of sync: /help is missing; /old is not expected",
+	}, "\n") + "\n"
+
+	if report.OK() {
+		t.Fatal("the report is OK")
+	} else if report.String() != expected {
+		t.Fatalf("unexpected report:\n%s", report)
+	}
+}
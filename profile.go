@@ -0,0 +1,148 @@
+package tgo
+
+import (
+	"fmt"
+	"sort"
+	"unicode/utf8"
+)
+
+// The length limits of the bot's profile texts, in characters.
+const (
+	MaxBotNameLength             = 64
+	MaxBotDescriptionLength      = 512
+	MaxBotShortDescriptionLength = 120
+)
+
+// Profile is the bot's name and descriptions in a language.
+type Profile struct {
+	Name             string // the name shown in the chats
+	Description      string // shown in the empty chat with the bot
+	ShortDescription string // shown on the bot's profile page, and sent with the links to the bot
+}
+
+// validate returns an error if any of the profile's texts is too long.
+func (p *Profile) validate(languageCode string) error {
+	for _, field := range []struct {
+		name, value string
+		max         int
+	}{
+		{"name", p.Name, MaxBotNameLength},
+		{"description", p.Description, MaxBotDescriptionLength},
+		{"short description", p.ShortDescription, MaxBotShortDescriptionLength},
+	} {
+		if utf8.RuneCountInString(field.value) > field.max {
+			return fmt.Errorf("tgo: the %s of the %q profile is longer than %d characters", field.name, languageCode, field.max)
+		}
+	}
+	return nil
+}
+
+// Profile returns the bot's profile in the language, or its default one if languageCode is empty.
+func (api *API) Profile(languageCode string) (*Profile, error) {
+	name, err := api.GetMyName(&GetMyName{LanguageCode: languageCode})
+	if err != nil {
+		return nil, err
+	}
+
+	description, err := api.GetMyDescription(&GetMyDescription{LanguageCode: languageCode})
+	if err != nil {
+		return nil, err
+	}
+
+	shortDescription, err := api.GetMyShortDescription(&GetMyShortDescription{LanguageCode: languageCode})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Profile{Name: name.Name, Description: description.Description, ShortDescription: shortDescription.ShortDescription}, nil
+}
+
+// SetProfile sets the bot's profile in the language, or its default one if languageCode is empty. The
+// empty texts remove the language's dedicated ones, which falls back to the default profile. Only the
+// texts differing from the current ones are set, as telegram rate limits the changes strictly; it
+// returns the number of the set texts.
+func (api *API) SetProfile(languageCode string, profile *Profile) (changed int, err error) {
+	if err = profile.validate(languageCode); err != nil {
+		return 0, err
+	}
+
+	current, err := api.Profile(languageCode)
+	if err != nil {
+		return 0, err
+	}
+
+	if profile.Name != current.Name {
+		if _, err = api.SetMyName(&SetMyName{Name: profile.Name, LanguageCode: languageCode}); err != nil {
+			return changed, err
+		}
+		changed++
+	}
+
+	if profile.Description != current.Description {
+		if _, err = api.SetMyDescription(&SetMyDescription{Description: profile.Description, LanguageCode: languageCode}); err != nil {
+			return changed, err
+		}
+		changed++
+	}
+
+	if profile.ShortDescription != current.ShortDescription {
+		if _, err = api.SetMyShortDescription(&SetMyShortDescription{ShortDescription: profile.ShortDescription, LanguageCode: languageCode}); err != nil {
+			return changed, err
+		}
+		changed++
+	}
+
+	return changed, nil
+}
+
+// SetProfiles sets the bot's profiles by their two-letter language codes, and the default one by the
+// empty code, as SetProfile, such as for provisioning the bot at its startup:
+//
+//	changed, err := bot.SetProfiles(map[string]*tgo.Profile{
+//		"":   {Name: "Weather", Description: "Tells the weather of your city.", ShortDescription: "Weather forecasts"},
+//		"fa": {Name: "هواشناسی", Description: "هوای شهرتان را می‌گوید."},
+//	})
+//
+// The profiles are validated before any of them is set, and the languages are set in order.
+func (api *API) SetProfiles(profiles map[string]*Profile) (changed int, err error) {
+	languages := make([]string, 0, len(profiles))
+	for languageCode, profile := range profiles {
+		if err = profile.validate(languageCode); err != nil {
+			return 0, err
+		}
+		languages = append(languages, languageCode)
+	}
+	sort.Strings(languages)
+
+	for _, languageCode := range languages {
+		n, err := api.SetProfile(languageCode, profiles[languageCode])
+		if changed += n; err != nil {
+			return changed, err
+		}
+	}
+	return changed, nil
+}
+
+// MenuCommands returns the menu button opening the bot's list of commands.
+func MenuCommands() MenuButton { return &MenuButtonCommands{Type: "commands"} }
+
+// MenuWebApp returns the menu button of the text, launching the web app of the url.
+func MenuWebApp(text, url string) MenuButton {
+	return &MenuButtonWebApp{Type: "web_app", Text: text, WebApp: WebAppInfo{Url: url}}
+}
+
+// MenuDefault returns the default menu button, which is the bot's default one in the private chats.
+func MenuDefault() MenuButton { return &MenuButtonDefault{Type: "default"} }
+
+// SetMenuButton sets the bot's menu button in the private chat, or its default one if chatID is zero:
+//
+//	err := bot.SetMenuButton(0, tgo.MenuWebApp("Open the shop", "https://example.com/shop"))
+func (api *API) SetMenuButton(chatID int64, button MenuButton) error {
+	_, err := api.SetChatMenuButton(&SetChatMenuButton{ChatId: chatID, MenuButton: button})
+	return err
+}
+
+// MenuButton returns the bot's menu button in the private chat, or its default one if chatID is zero.
+func (api *API) MenuButton(chatID int64) (MenuButton, error) {
+	return api.GetChatMenuButton(&GetChatMenuButton{ChatId: chatID})
+}
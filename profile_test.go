@@ -0,0 +1,53 @@
+package tgo_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestSetProfiles(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	server.Handle("getMyName", func(call tgotest.Call) (any, *tgo.Error) {
+		if call.Params["language_code"] == nil {
+			return map[string]any{"name": "Weather"}, nil
+		}
+		return map[string]any{"name": ""}, nil
+	})
+	server.Handle("getMyDescription", func(call tgotest.Call) (any, *tgo.Error) {
+		return map[string]any{"description": ""}, nil
+	})
+	server.Handle("getMyShortDescription", func(call tgotest.Call) (any, *tgo.Error) {
+		return map[string]any{"short_description": ""}, nil
+	})
+
+	bot := server.Bot(tgo.Options{})
+
+	changed, err := bot.SetProfiles(map[string]*tgo.Profile{
+		"":   {Name: "Weather", Description: "Tells the weather of your city."},
+		"fa": {Name: "هواشناسی"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	} else if changed != 2 {
+		t.Fatalf("changed %d texts, want 2", changed)
+	}
+
+	var sets []string
+	for _, call := range server.Calls() {
+		if strings.HasPrefix(call.Method, "set") {
+			sets = append(sets, call.Method)
+		}
+	}
+	if strings.Join(sets, ",") != "setMyDescription,setMyName" {
+		t.Errorf("unexpected calls %v", sets)
+	}
+
+	if _, err = bot.SetProfile("en", &tgo.Profile{ShortDescription: strings.Repeat("a", 121)}); err == nil {
+		t.Error("the too long short description is set")
+	}
+}
@@ -0,0 +1,117 @@
+package tgo
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// MaxDeleteMessagesCount is the maximum number of messages which can be deleted by a single deleteMessages call.
+	MaxDeleteMessagesCount = 100
+
+	// MaxDeletableMessageAge is the age after which the messages can't be deleted by the bots anymore.
+	MaxDeletableMessageAge = 48 * time.Hour
+)
+
+// PurgeReport is the result of a purge, which may be partially done.
+type PurgeReport struct {
+	// Deleted contains the messages which are deleted, or were already gone.
+	Deleted []int64
+
+	// Skipped contains the messages which are not even tried, as they're older than MaxDeletableMessageAge.
+	Skipped []int64
+
+	// Failed contains the messages which couldn't be deleted, and the reason.
+	Failed map[int64]error
+}
+
+// Err returns the failure of the message with the lowest id, or nil if there's none.
+func (r *PurgeReport) Err() error {
+	var lowest int64
+	var err error
+
+	for id, failure := range r.Failed {
+		if err == nil || id < lowest {
+			lowest, err = id, failure
+		}
+	}
+
+	return err
+}
+
+// PurgeMessages deletes the messages of the chat in chunks of MaxDeleteMessagesCount messages.
+// If deleting a chunk fails, its messages are deleted one by one to delete as many as possible,
+// unless the bot lacks the rights to delete them; then it stops and marks the rest as failed.
+func (api *API) PurgeMessages(chatID ChatID, messageIDs []int64) *PurgeReport {
+	report := &PurgeReport{Failed: make(map[int64]error)}
+
+	// failRest marks the messages from the index onwards as failed.
+	failRest := func(index int, err error) *PurgeReport {
+		for _, id := range messageIDs[index:] {
+			report.Failed[id] = err
+		}
+		return report
+	}
+
+	for start := 0; start < len(messageIDs); start += MaxDeleteMessagesCount {
+		end := start + MaxDeleteMessagesCount
+		if end > len(messageIDs) {
+			end = len(messageIDs)
+		}
+		chunk := messageIDs[start:end]
+
+		_, err := api.DeleteMessages(&DeleteMessages{ChatId: chatID, MessageIds: chunk})
+		if err == nil {
+			report.Deleted = append(report.Deleted, chunk...)
+			continue
+		} else if isPermissionErr(err) {
+			return failRest(start, err)
+		}
+
+		for i, id := range chunk {
+			if _, err = api.DeleteMessage(&DeleteMessage{ChatId: chatID, MessageId: id}); err == nil {
+				report.Deleted = append(report.Deleted, id)
+			} else if isPermissionErr(err) {
+				return failRest(start+i, err)
+			} else {
+				report.Failed[id] = err
+			}
+		}
+	}
+
+	return report
+}
+
+// isPermissionErr returns true if the error is about the bot not being allowed to do the action
+// at all, which won't change by retrying it for the other messages.
+func isPermissionErr(err error) bool {
+	tgErr, isTgError := err.(*Error)
+	if !isTgError {
+		return false
+	}
+
+	description := strings.ToLower(tgErr.Description)
+	return tgErr.ErrorCode == 403 ||
+		strings.Contains(description, "not enough rights") ||
+		strings.Contains(description, "chat_admin_required") ||
+		strings.Contains(description, "have no rights")
+}
+
+// PurgeChatMessages deletes the messages of the same chat, skipping the ones which are too old to be deleted.
+func (api *API) PurgeChatMessages(chatID int64, messages []*Message) *PurgeReport {
+	var ids, skipped []int64
+
+	deadline := time.Now().Add(-MaxDeletableMessageAge).Unix()
+	for _, msg := range messages {
+		if msg.Date != 0 && msg.Date < deadline {
+			skipped = append(skipped, msg.MessageId)
+		} else {
+			ids = append(ids, msg.MessageId)
+		}
+	}
+
+	report := api.PurgeMessages(ID(chatID), ids)
+	report.Skipped = skipped
+
+	return report
+}
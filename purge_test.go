@@ -0,0 +1,67 @@
+package tgo_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestPurgeMessages(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	// the second chunk fails in bulk, and its message 150 can't be deleted at all.
+	server.Handle("deleteMessages", func(call tgotest.Call) (any, *tgo.Error) {
+		if len(call.Params["message_ids"].([]any)) != tgo.MaxDeleteMessagesCount {
+			return nil, &tgo.Error{ErrorCode: 400, Description: "Bad Request: message can't be deleted"}
+		}
+		return true, nil
+	})
+	server.Handle("deleteMessage", func(call tgotest.Call) (any, *tgo.Error) {
+		if call.Params["message_id"] == float64(150) {
+			return nil, &tgo.Error{ErrorCode: 400, Description: "Bad Request: message can't be deleted"}
+		}
+		return true, nil
+	})
+
+	api := server.Bot(tgo.Options{}).API
+
+	messages := []*tgo.Message{{MessageId: 1000, Date: time.Now().Add(-72 * time.Hour).Unix()}}
+	for id := int64(1); id <= 150; id++ {
+		messages = append(messages, &tgo.Message{MessageId: id, Date: time.Now().Unix()})
+	}
+
+	report := api.PurgeChatMessages(1, messages)
+	if len(report.Deleted) != 149 || len(report.Skipped) != 1 || len(report.Failed) != 1 || report.Failed[150] == nil {
+		t.Errorf("unexpected report: deleted %d, skipped %v, failed %v", len(report.Deleted), report.Skipped, report.Failed)
+	}
+
+	if calls := server.Calls(); len(calls) != 2+50 {
+		t.Errorf("got %d calls, want %d", len(calls), 2+50)
+	}
+}
+
+func TestPurgeMessagesWithoutRights(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	server.Handle("deleteMessages", func(call tgotest.Call) (any, *tgo.Error) {
+		return nil, &tgo.Error{ErrorCode: 400, Description: "Bad Request: not enough rights to delete a message"}
+	})
+
+	ids := make([]int64, 250)
+	for i := range ids {
+		ids[i] = int64(i + 1)
+	}
+
+	report := server.Bot(tgo.Options{}).PurgeMessages(tgo.ID(1), ids)
+	if len(report.Deleted) != 0 || len(report.Failed) != len(ids) {
+		t.Errorf("unexpected report: deleted %d, failed %d", len(report.Deleted), len(report.Failed))
+	}
+
+	if calls := server.Calls(); len(calls) != 1 {
+		t.Errorf("got %d calls, want only the first bulk delete", len(calls))
+	}
+}
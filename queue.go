@@ -0,0 +1,105 @@
+package tgo
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Queue decouples receiving the updates from handling them: the webhook receivers push the raw
+// updates into it, and the worker processes consume them by bot.ConsumeQueue, so the handlers
+// can be scaled horizontally. See contrib/redisstore for a Queue on Redis Streams, and
+// contrib/tgonats for one on NATS JetStream.
+type Queue interface {
+	// Push adds the raw update to the queue.
+	Push(ctx context.Context, update []byte) error
+
+	// Pop blocks until there's an update in the queue or the context is done, and returns it.
+	Pop(ctx context.Context) (QueuedUpdate, error)
+}
+
+// QueuedUpdate is a raw update popped from a Queue.
+type QueuedUpdate struct {
+	Data []byte
+
+	// Ack, if not nil, is called once the update is handled. The queues which support
+	// it deliver the unacknowledged updates again, such as the ones of a crashed worker.
+	Ack func() error
+}
+
+// MemoryQueue is a Queue keeping the updates in memory, for the receivers and workers of the same process.
+type MemoryQueue struct {
+	updates chan []byte
+}
+
+// NewMemoryQueue returns a MemoryQueue holding up to size updates; Push blocks while it's full.
+func NewMemoryQueue(size int) *MemoryQueue {
+	return &MemoryQueue{updates: make(chan []byte, size)}
+}
+
+// Push implements the Queue interface.
+func (q *MemoryQueue) Push(ctx context.Context, update []byte) error {
+	select {
+	case q.updates <- update:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Pop implements the Queue interface.
+func (q *MemoryQueue) Pop(ctx context.Context) (QueuedUpdate, error) {
+	select {
+	case update := <-q.updates:
+		return QueuedUpdate{Data: update}, nil
+	case <-ctx.Done():
+		return QueuedUpdate{}, ctx.Err()
+	}
+}
+
+// Len returns the number of the updates in the queue.
+func (q *MemoryQueue) Len() int { return len(q.updates) }
+
+// ConsumeQueue pops the updates from the queue, and passes them to bot.HandleUpdate in their own
+// goroutines. Each update is acknowledged after its handlers are done, and the invalid ones are
+// acknowledged and dropped.
+//
+// It returns nil once bot.Shutdown is called, and the error of the queue if popping fails.
+func (bot *Bot) ConsumeQueue(queue Queue) error {
+	if !bot.inflight.enter() {
+		return nil
+	}
+	defer bot.inflight.done()
+
+	for {
+		queued, err := queue.Pop(bot.polling)
+		if err != nil {
+			if bot.IsShuttingDown() {
+				return nil
+			}
+			return err
+		}
+
+		update := &Update{}
+		if err = json.Unmarshal(queued.Data, update); err != nil {
+			bot.log(LevelError, "dropped an invalid queued update", "error", err)
+			bot.ackQueued(queued)
+			continue
+		}
+
+		bot.inflight.hold()
+		bot.spawn(func() {
+			defer bot.inflight.done()
+			bot.HandleUpdate(update)
+			bot.ackQueued(queued)
+		})
+	}
+}
+
+func (bot *Bot) ackQueued(queued QueuedUpdate) {
+	if queued.Ack == nil {
+		return
+	}
+	if err := queued.Ack(); err != nil {
+		bot.log(LevelError, "failed to acknowledge the queued update", "error", err)
+	}
+}
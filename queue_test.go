@@ -0,0 +1,76 @@
+package tgo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+type ackedQueue struct {
+	*tgo.MemoryQueue
+	acked atomic.Int64
+}
+
+func (q *ackedQueue) Pop(ctx context.Context) (tgo.QueuedUpdate, error) {
+	queued, err := q.MemoryQueue.Pop(ctx)
+	queued.Ack = func() error { q.acked.Add(1); return nil }
+	return queued, err
+}
+
+func TestQueue(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	queue := &ackedQueue{MemoryQueue: tgo.NewMemoryQueue(4)}
+
+	receiver := server.Bot(tgo.Options{})
+	receiver.AddRouter(recordRouter{chats: make(chan int64)}) // it would block if the receiver handled the update.
+	handler := receiver.WebhookHandler(tgo.WebhookOptions{Queue: queue})
+
+	for _, body := range []string{`{"update_id": 1, "message": {"message_id": 1, "date": 1, "chat": {"id": 7, "type": "private"}}}`, `[]`} {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+		if body != `[]` && recorder.Code != http.StatusOK {
+			t.Fatalf("the webhook answered %d", recorder.Code)
+		}
+	}
+	if queue.Len() != 1 {
+		t.Fatalf("expected 1 queued update, got %d", queue.Len())
+	}
+
+	worker := server.Bot(tgo.Options{})
+	router := recordRouter{chats: make(chan int64, 1)}
+	worker.AddRouter(router)
+
+	consuming := make(chan error, 1)
+	go func() { consuming <- worker.ConsumeQueue(queue) }()
+
+	select {
+	case chat := <-router.chats:
+		if chat != 7 {
+			t.Errorf("expected chat 7, got %d", chat)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the queued update is not handled")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := worker.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	} else if err = <-consuming; err != nil {
+		t.Fatal(err)
+	}
+
+	if acked := queue.acked.Load(); acked != 1 {
+		t.Errorf("expected 1 acknowledged update, got %d", acked)
+	}
+}
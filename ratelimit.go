@@ -0,0 +1,59 @@
+package tgo
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket limiting the rate of the API calls, which may be shared by many bots
+// to keep them in a common budget; getUpdates calls are not limited.
+type RateLimiter struct {
+	mut    sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a RateLimiter allowing perSecond calls on average, and up to burst calls at once.
+// Pass it to the Options to use it.
+func NewRateLimiter(perSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{rate: perSecond, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// Wait blocks until a call is allowed, or the context is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mut.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	// the token is reserved right away, so the waiting calls are let in the order they came.
+	l.tokens--
+	wait := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mut.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mut.Lock()
+		l.tokens++
+		l.mut.Unlock()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,101 @@
+package tgo
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// Raw calls the method with the params and returns its raw result, for the methods which aren't
+// covered by tgo yet. The params may be anything which encodes to a JSON object, such as a struct
+// or a map[string]any, or nil for no params.
+//
+// The uploadable *InputFile values found anywhere in the params are sent as multipart files;
+// the ones which are a direct field of the params are sent under the field's name, and the
+// nested ones are referenced by their "attach://<name>", just like the typed methods.
+//
+// The call goes through the same machinery as the typed ones, such as the breaker and the call budget.
+func (api *API) Raw(method string, params any) (json.RawMessage, error) {
+	if params == nil {
+		params = struct{}{}
+	}
+
+	files := map[string]*InputFile{}
+	collectUploads(reflect.ValueOf(params), files)
+	if len(files) == 0 {
+		return callJson[json.RawMessage](api, method, params)
+	}
+
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(encoded, &fields); err != nil {
+		return nil, err
+	}
+
+	uploads := make(map[string]*InputFile, len(files))
+	payload := make(map[string]string, len(fields))
+
+	for key, value := range fields {
+		var text string
+		if json.Unmarshal(value, &text) != nil {
+			payload[key] = string(value)
+			continue
+		}
+
+		if name := strings.TrimPrefix(text, "attach://"); name != text && files[name] != nil {
+			// it's a direct field, so the file is sent under the field's name.
+			uploads[key] = files[name]
+			delete(files, name)
+			continue
+		}
+
+		payload[key] = text
+	}
+
+	for name, file := range files {
+		uploads[name] = file
+	}
+
+	return callMultipart[json.RawMessage](api, method, payload, uploads)
+}
+
+// collectUploads adds the uploadable input files found in the value to the files, by their names.
+func collectUploads(value reflect.Value, files map[string]*InputFile) {
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if value.IsNil() {
+			return
+		}
+
+		if file, ok := value.Interface().(*InputFile); ok {
+			if file.IsUploadable() {
+				files[file.Value] = file
+			}
+			return
+		}
+
+		collectUploads(value.Elem(), files)
+
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			if value.Type().Field(i).IsExported() {
+				collectUploads(value.Field(i), files)
+			}
+		}
+
+	case reflect.Map:
+		iter := value.MapRange()
+		for iter.Next() {
+			collectUploads(iter.Value(), files)
+		}
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			collectUploads(value.Index(i), files)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package tgo_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestRaw(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	server.Handle("getShinyThing", func(call tgotest.Call) (any, *tgo.Error) {
+		return map[string]any{"shine": call.Params["level"]}, nil
+	})
+
+	bot := server.Bot(tgo.Options{})
+
+	result, err := bot.Raw("getShinyThing", map[string]any{"level": 3})
+	if err != nil {
+		t.Fatal(err)
+	} else if string(result) != `{"shine":3}` {
+		t.Fatalf("unexpected raw result %s", result)
+	}
+
+	type media struct {
+		Type  string         `json:"type"`
+		Media *tgo.InputFile `json:"media"`
+	}
+
+	_, err = bot.Raw("sendShinyAlbum", map[string]any{
+		"chat_id": 42,
+		"cover":   tgo.FileFromReader("cover.jpg", strings.NewReader("cover")),
+		"media":   []media{{Type: "photo", Media: tgo.FileFromReader("first.jpg", strings.NewReader("first"))}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calls := server.Calls()
+	params := calls[len(calls)-1].Params
+	if params["chat_id"] != float64(42) || params["cover"] != "attach://cover.jpg" || params["first.jpg"] != "attach://first.jpg" {
+		t.Fatalf("unexpected multipart params %v", params)
+	}
+
+	encoded, _ := json.Marshal(params["media"])
+	if string(encoded) != `[{"media":"attach://first.jpg","type":"photo"}]` {
+		t.Fatalf("unexpected nested media %s", encoded)
+	}
+}
@@ -0,0 +1,77 @@
+package tgo
+
+// EmojiReaction returns the reaction of the emoji, such as "👍".
+func EmojiReaction(emoji string) *ReactionTypeEmoji {
+	return &ReactionTypeEmoji{Type: "emoji", Emoji: emoji}
+}
+
+// CustomEmojiReaction returns the reaction of the custom emoji.
+func CustomEmojiReaction(customEmojiID string) *ReactionTypeCustomEmoji {
+	return &ReactionTypeCustomEmoji{Type: "custom_emoji", CustomEmojiId: customEmojiID}
+}
+
+// ReactionKey returns a string which identifies the reaction, to compare the reactions or use them
+// as map keys: the emoji itself, the custom emoji id prefixed by "custom:", or "paid".
+func ReactionKey(reaction ReactionType) string {
+	switch r := reaction.(type) {
+	case *ReactionTypeEmoji:
+		return r.Emoji
+	case ReactionTypeEmoji:
+		return r.Emoji
+	case *ReactionTypeCustomEmoji:
+		return "custom:" + r.CustomEmojiId
+	case ReactionTypeCustomEmoji:
+		return "custom:" + r.CustomEmojiId
+	case *ReactionTypePaid, ReactionTypePaid:
+		return "paid"
+	}
+
+	return ""
+}
+
+// React sets the emojis as the bot's reactions on the message, replacing the previous ones;
+// no emojis removes them. Keep in mind that the bots can set up to one reaction per message.
+func (api *API) React(chatID ChatID, messageID int64, emojis ...string) error {
+	reactions := make([]ReactionType, len(emojis))
+	for i, emoji := range emojis {
+		reactions[i] = EmojiReaction(emoji)
+	}
+
+	_, err := api.SetMessageReaction(&SetMessageReaction{ChatId: chatID, MessageId: messageID, Reaction: reactions})
+	return err
+}
+
+// Added returns the reactions which the user has added by the change.
+func (x *MessageReactionUpdated) Added() []ReactionType {
+	return diffReactions(x.NewReaction, x.OldReaction)
+}
+
+// Removed returns the reactions which the user has removed by the change.
+func (x *MessageReactionUpdated) Removed() []ReactionType {
+	return diffReactions(x.OldReaction, x.NewReaction)
+}
+
+// diffReactions returns the reactions of a which are not in b.
+func diffReactions(a, b []ReactionType) (diff []ReactionType) {
+	inB := make(map[string]bool, len(b))
+	for _, reaction := range b {
+		inB[ReactionKey(reaction)] = true
+	}
+
+	for _, reaction := range a {
+		if !inB[ReactionKey(reaction)] {
+			diff = append(diff, reaction)
+		}
+	}
+
+	return diff
+}
+
+// Counts returns the total count of each reaction on the message, keyed by ReactionKey.
+func (x *MessageReactionCountUpdated) Counts() map[string]int64 {
+	counts := make(map[string]int64, len(x.Reactions))
+	for _, reaction := range x.Reactions {
+		counts[ReactionKey(reaction.Type)] += reaction.TotalCount
+	}
+	return counts
+}
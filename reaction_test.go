@@ -0,0 +1,26 @@
+package tgo
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestReactionDiff(t *testing.T) {
+	var update Update
+	err := json.Unmarshal([]byte(`{"update_id": 1, "message_reaction": {
+		"chat": {"id": 1}, "message_id": 2, "date": 3,
+		"old_reaction": [{"type": "emoji", "emoji": "👍"}, {"type": "custom_emoji", "custom_emoji_id": "42"}],
+		"new_reaction": [{"type": "custom_emoji", "custom_emoji_id": "42"}, {"type": "emoji", "emoji": "🔥"}]
+	}}`), &update)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	added, removed := update.MessageReaction.Added(), update.MessageReaction.Removed()
+	if len(added) != 1 || ReactionKey(added[0]) != "🔥" {
+		t.Errorf("unexpected added reactions: %v", added)
+	}
+	if len(removed) != 1 || ReactionKey(removed[0]) != "👍" {
+		t.Errorf("unexpected removed reactions: %v", removed)
+	}
+}
@@ -0,0 +1,110 @@
+package tgo
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Recording is a line of the recorded updates.
+type Recording struct {
+	Time   time.Time `json:"time"`   // when the update was received
+	Update *Update   `json:"update"` // the received update
+}
+
+// Recorder writes the bot's received updates to its writer as JSON lines of Recording, to be replayed
+// by Replay, such as for reproducing the bugs of the handlers offline:
+//
+//	file, _ := os.Create("updates.jsonl")
+//	bot := tgo.NewBot(token, tgo.Options{Recorder: tgo.NewRecorder(file)})
+//
+// The recordings contain the users' messages, so they should be kept as privately as the bot's database.
+type Recorder struct {
+	mut sync.Mutex
+	enc *json.Encoder
+}
+
+// NewRecorder returns a Recorder writing to the writer; the writes are serialized.
+func NewRecorder(w io.Writer) *Recorder { return &Recorder{enc: json.NewEncoder(w)} }
+
+// Record writes the update, received now.
+func (r *Recorder) Record(update *Update) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	return r.enc.Encode(&Recording{Time: time.Now(), Update: update})
+}
+
+// record records the update by the bot's Recorder, if it has one.
+func (bot *Bot) record(update *Update) {
+	if bot.recorder == nil {
+		return
+	}
+	if err := bot.recorder.Record(update); err != nil {
+		bot.log(LevelWarn, "failed to record the update", "update_id", update.UpdateId, "error", err)
+	}
+}
+
+// ReplayOptions configures Replay. The zero value is valid and replays the updates at once.
+type ReplayOptions struct {
+	// Speed is the speed of the replay relative to the recorded one, such as 1 for the real time and 2
+	// for twice as fast; the updates are replayed at once, in order, if it's zero.
+	Speed float64
+
+	// Context, if not nil, stops the replay when it's done.
+	Context context.Context
+}
+
+// Replay feeds the updates recorded by a Recorder to the bot's HandleUpdate, in order, and returns the
+// number of the replayed ones. Each update is handled before the next one is replayed, so the bugs of
+// the handlers are reproduced deterministically:
+//
+//	file, _ := os.Open("updates.jsonl")
+//	n, err := tgo.Replay(bot, file, tgo.ReplayOptions{})
+//
+// The bot calls telegram while handling the updates, so it's usually a test one, such as of tgotest.
+func Replay(bot *Bot, r io.Reader, opts ReplayOptions) (replayed int, err error) {
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 16<<20) // the updates may be larger than the default 64 kB lines.
+
+	var last time.Time
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var recording Recording
+		if err = json.Unmarshal(scanner.Bytes(), &recording); err != nil {
+			return replayed, fmt.Errorf("tgo: invalid recording at line %d: %w", line, err)
+		} else if recording.Update == nil {
+			return replayed, fmt.Errorf("tgo: no update at line %d", line)
+		}
+
+		if opts.Speed > 0 && !last.IsZero() && recording.Time.After(last) {
+			timer := time.NewTimer(time.Duration(float64(recording.Time.Sub(last)) / opts.Speed))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return replayed, ctx.Err()
+			case <-timer.C:
+			}
+		} else if err = ctx.Err(); err != nil {
+			return replayed, err
+		}
+		last = recording.Time
+
+		bot.HandleUpdate(recording.Update)
+		replayed++
+	}
+
+	return replayed, scanner.Err()
+}
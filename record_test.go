@@ -0,0 +1,48 @@
+package tgo_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	var recorded bytes.Buffer
+	bot := server.Bot(tgo.Options{Recorder: tgo.NewRecorder(&recorded)})
+
+	bot.HandleUpdate(&tgo.Update{UpdateId: 1, Message: &tgo.Message{Chat: tgo.Chat{Id: 1}, Text: "hello"}})
+	bot.HandleUpdate(&tgo.Update{UpdateId: 2, MyChatMember: &tgo.ChatMemberUpdated{
+		Chat:          tgo.Chat{Id: 1, Type: "private"},
+		OldChatMember: &tgo.ChatMemberMember{Status: "member"},
+		NewChatMember: &tgo.ChatMemberBanned{Status: "kicked"},
+	}})
+
+	if lines := strings.Count(recorded.String(), "\n"); lines != 2 {
+		t.Fatalf("expected 2 recorded lines, got %d", lines)
+	}
+
+	replayer := server.Bot(tgo.Options{})
+	router := &countingRouter{}
+	replayer.AddRouter(router)
+
+	n, err := tgo.Replay(replayer, &recorded, tgo.ReplayOptions{Speed: 100})
+	if err != nil {
+		t.Fatal(err)
+	} else if n != 2 || router.handled != 2 {
+		t.Fatalf("expected 2 replayed updates, got %d replayed and %d handled", n, router.handled)
+	}
+
+	if !replayer.IsBlockedBy(1) {
+		t.Error("the replayed my_chat_member update isn't tracked")
+	}
+
+	if _, err = tgo.Replay(replayer, strings.NewReader("{bad\n"), tgo.ReplayOptions{}); err == nil {
+		t.Error("the invalid recording is replayed")
+	}
+}
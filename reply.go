@@ -0,0 +1,83 @@
+package tgo
+
+// RepliedContent is what a message is replying to, regardless of whether the replied
+// message is in the same chat, in another chat or forum topic, or is no longer accessible.
+type RepliedContent struct {
+	// Message is the replied message, if it's in the same chat and is still accessible.
+	Message *Message
+
+	// External is the information of the replied message, if it's from another chat or forum topic.
+	External *ExternalReplyInfo
+
+	// Quote is the quoted part of the replied message, if any.
+	Quote *TextQuote
+
+	// Inaccessible is true if the replied message is deleted or is otherwise inaccessible to the bot.
+	Inaccessible bool
+
+	// Chat is the chat of the replied message; it's nil for the external replies from private chats and basic groups.
+	Chat *Chat
+
+	// MessageId is the identifier of the replied message; it's zero if it's unknown.
+	MessageId int64
+}
+
+// RepliedContent returns what the message is replying to, or nil if it's not a reply.
+//
+// The implicit replies of the forum topic messages to the topic's creation message are not counted as replies.
+func (m *Message) RepliedContent() *RepliedContent {
+	content := &RepliedContent{Quote: m.Quote}
+
+	switch {
+	case m.ReplyToMessage != nil:
+		if m.IsTopicMessage && m.ReplyToMessage.ForumTopicCreated != nil {
+			return nil
+		}
+
+		content.Chat = &m.ReplyToMessage.Chat
+		content.MessageId = m.ReplyToMessage.MessageId
+
+		if m.ReplyToMessage.IsInaccessible() {
+			content.Inaccessible = true
+		} else {
+			content.Message = m.ReplyToMessage
+		}
+
+	case m.ExternalReply != nil:
+		content.External = m.ExternalReply
+		content.Chat = m.ExternalReply.Chat
+		content.MessageId = m.ExternalReply.MessageId
+
+	default:
+		return nil
+	}
+
+	return content
+}
+
+// QuoteText returns the quoted text and its entities, or the text (or caption) of the
+// replied message and its entities if nothing is quoted.
+func (c *RepliedContent) QuoteText() (text string, entities []*MessageEntity) {
+	switch {
+	case c.Quote != nil:
+		return c.Quote.Text, c.Quote.Entities
+	case c.Message != nil && c.Message.Text != "":
+		return c.Message.Text, c.Message.Entities
+	case c.Message != nil:
+		return c.Message.Caption, c.Message.CaptionEntities
+	}
+
+	return "", nil
+}
+
+// Origin returns the origin of the replied message if it's from another chat, or nil.
+func (c *RepliedContent) Origin() MessageOrigin {
+	if c.External == nil {
+		return nil
+	}
+	return c.External.Origin
+}
+
+// IsInaccessible returns true if the message is deleted or is otherwise inaccessible to the bot;
+// telegram sends such messages with only the chat and message id, and a zero date.
+func (m *Message) IsInaccessible() bool { return m.Date == 0 }
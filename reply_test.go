@@ -0,0 +1,53 @@
+package tgo
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRepliedContent(t *testing.T) {
+	tests := map[string]struct {
+		raw          string
+		isReply      bool
+		inaccessible bool
+		messageID    int64
+		quote        string
+	}{
+		"not reply":    {raw: `{"message_id":2,"date":1,"chat":{"id":1,"type":"private"}}`},
+		"normal":       {raw: `{"message_id":2,"date":1,"chat":{"id":1,"type":"private"},"reply_to_message":{"message_id":1,"date":1,"chat":{"id":1,"type":"private"},"text":"hello"}}`, isReply: true, messageID: 1, quote: "hello"},
+		"inaccessible": {raw: `{"message_id":2,"date":1,"chat":{"id":1,"type":"private"},"reply_to_message":{"message_id":1,"date":0,"chat":{"id":1,"type":"private"}}}`, isReply: true, inaccessible: true, messageID: 1},
+		"topic":        {raw: `{"message_id":2,"date":1,"chat":{"id":1,"type":"supergroup"},"is_topic_message":true,"reply_to_message":{"message_id":1,"date":1,"chat":{"id":1,"type":"supergroup"},"forum_topic_created":{"name":"t","icon_color":1}}}`},
+		"external": {
+			raw:     `{"message_id":2,"date":1,"chat":{"id":1,"type":"private"},"external_reply":{"origin":{"type":"channel","date":1,"chat":{"id":-100,"type":"channel"},"message_id":7},"chat":{"id":-100,"type":"channel"},"message_id":7},"quote":{"text":"hel","position":0}}`,
+			isReply: true, messageID: 7, quote: "hel",
+		},
+	}
+
+	for name, test := range tests {
+		msg := &Message{}
+		if err := json.Unmarshal([]byte(test.raw), msg); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		content := msg.RepliedContent()
+		if (content != nil) != test.isReply {
+			t.Errorf("%s: got reply %v, want %v", name, content != nil, test.isReply)
+			continue
+		} else if content == nil {
+			continue
+		}
+
+		if content.Inaccessible != test.inaccessible || content.MessageId != test.messageID {
+			t.Errorf("%s: unexpected content: %+v", name, content)
+		}
+		if text, _ := content.QuoteText(); text != test.quote {
+			t.Errorf("%s: got quote %q, want %q", name, text, test.quote)
+		}
+	}
+
+	msg := &Message{}
+	json.Unmarshal([]byte(tests["external"].raw), msg)
+	if origin, ok := msg.RepliedContent().Origin().(*MessageOriginChannel); !ok || origin.MessageId != 7 {
+		t.Errorf("unexpected origin: %#v", msg.RepliedContent().Origin())
+	}
+}
@@ -0,0 +1,168 @@
+// Package responder picks, renders, and sends canned responses for the bots which answer the
+// triggers with one of many weighted replies, without repeating themselves in a chat.
+package responder
+
+import (
+	"math/rand"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/routers/message"
+)
+
+// Response is a weighted response template. The template is a text/template, executed with Data.
+type Response struct {
+	Template string
+	Weight   int // the relative chance of being picked; it defaults to 1
+
+	tmpl *template.Template
+}
+
+// Data is what the response templates are executed with.
+type Data struct {
+	Message *tgo.Message
+	From    *tgo.User
+	Chat    *tgo.Chat
+	Matches []string // the capture groups of the RegexCapture filter which the trigger passed, if any
+}
+
+// Options configures a responder. The zero value is valid and uses the defaults.
+type Options struct {
+	// NoRepeat is the number of the last responses in a chat which are not picked again; it's capped
+	// so there's always a response to pick. Zero allows repeating the last response.
+	NoRepeat int
+
+	// Reply sends the responses as replies to the triggering messages, instead of plain messages.
+	Reply bool
+
+	// Rand is the source of the picks; it defaults to one seeded by the current time.
+	Rand *rand.Rand
+}
+
+// Responder picks one of its responses at random by their weights.
+type Responder struct {
+	name      string
+	opts      Options
+	responses []Response
+
+	randMut sync.Mutex
+}
+
+// New returns a responder of the responses. The name is the key which the last responses of each
+// chat are kept under in the chat's session, so it must be unique among the bot's responders.
+//
+// It panics if there's no response or a template can't be parsed.
+func New(name string, opts Options, responses ...Response) *Responder {
+	if len(responses) == 0 {
+		panic("responder: " + name + " has no responses")
+	}
+
+	if opts.Rand == nil {
+		opts.Rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	if opts.NoRepeat >= len(responses) {
+		opts.NoRepeat = len(responses) - 1
+	}
+
+	r := &Responder{name: name, opts: opts, responses: make([]Response, len(responses))}
+	for i, response := range responses {
+		if response.Weight <= 0 {
+			response.Weight = 1
+		}
+		response.tmpl = template.Must(template.New(name).Parse(response.Template))
+
+		r.responses[i] = response
+	}
+
+	return r
+}
+
+// history is the indexes of the last responses picked in a chat, the last one at the end.
+type history struct {
+	mut    sync.Mutex
+	picked []int
+}
+
+// Pick picks a response, other than the last ones of the session, and records it in the session.
+func (r *Responder) Pick(session *sync.Map) *Response {
+	value, _ := session.LoadOrStore("responder."+r.name, &history{})
+	h := value.(*history)
+
+	h.mut.Lock()
+	defer h.mut.Unlock()
+
+	recent := make(map[int]bool, len(h.picked))
+	for _, i := range h.picked {
+		recent[i] = true
+	}
+
+	var total int
+	for i, response := range r.responses {
+		if !recent[i] {
+			total += response.Weight
+		}
+	}
+
+	r.randMut.Lock()
+	n := r.opts.Rand.Intn(total)
+	r.randMut.Unlock()
+
+	picked := 0
+	for i, response := range r.responses {
+		if recent[i] {
+			continue
+		} else if n -= response.Weight; n < 0 {
+			picked = i
+			break
+		}
+	}
+
+	if r.opts.NoRepeat > 0 {
+		h.picked = append(h.picked, picked)
+		if len(h.picked) > r.opts.NoRepeat {
+			h.picked = h.picked[len(h.picked)-r.opts.NoRepeat:]
+		}
+	}
+
+	return &r.responses[picked]
+}
+
+// Render executes the response's template with the data.
+func (resp *Response) Render(data Data) (string, error) {
+	var text strings.Builder
+	err := resp.tmpl.Execute(&text, data)
+	return text.String(), err
+}
+
+// Respond picks a response for the message's chat, renders it, and sends it into the chat.
+func (r *Responder) Respond(ctx *message.Context) (*tgo.Message, error) {
+	response := r.Pick(ctx.Bot.GetSession(ctx.Chat.Id))
+
+	text, err := response.Render(Data{Message: ctx.Message, From: ctx.From, Chat: &ctx.Chat, Matches: ctx.Matches()})
+	if err != nil {
+		return nil, err
+	}
+
+	if r.opts.Reply {
+		return ctx.Reply(&tgo.SendMessage{Text: text})
+	}
+	return ctx.Send(&tgo.SendMessage{Text: text})
+}
+
+// Handler returns a message handler which responds to the messages, and passes the errors to
+// onError if it's not nil.
+func (r *Responder) Handler(onError func(ctx *message.Context, err error)) message.Handler {
+	return func(ctx *message.Context) {
+		if _, err := r.Respond(ctx); err != nil && onError != nil {
+			onError(ctx, err)
+		}
+	}
+}
+
+// Register adds a route to the router, which responds to the messages passing the trigger.
+func (r *Responder) Register(router *message.Router, trigger tgo.Filter, middlewares ...message.Middleware) {
+	router.Handle(trigger, r.Handler(nil), middlewares...)
+}
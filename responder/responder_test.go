@@ -0,0 +1,74 @@
+package responder_test
+
+import (
+	"math/rand"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/filters"
+	"github.com/haashemi/tgo/responder"
+	"github.com/haashemi/tgo/routers/message"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestPick(t *testing.T) {
+	r := responder.New("greet", responder.Options{NoRepeat: 2, Rand: rand.New(rand.NewSource(1))},
+		responder.Response{Template: "hi", Weight: 100},
+		responder.Response{Template: "hello"},
+		responder.Response{Template: "hey"},
+	)
+
+	var session sync.Map
+	for round := 0; round < 20; round++ {
+		seen := make(map[*responder.Response]bool)
+		for i := 0; i < 3; i++ {
+			response := r.Pick(&session)
+			if seen[response] {
+				t.Fatalf("round %d: %q is repeated within the window", round, response.Template)
+			}
+			seen[response] = true
+		}
+	}
+
+	// without the window, the weights win.
+	heavy := responder.New("heavy", responder.Options{Rand: rand.New(rand.NewSource(1))},
+		responder.Response{Template: "common", Weight: 1000},
+		responder.Response{Template: "rare"},
+	)
+
+	var common int
+	for i := 0; i < 100; i++ {
+		if heavy.Pick(&session).Template == "common" {
+			common++
+		}
+	}
+	if common < 90 {
+		t.Errorf("the heavy response is picked %d times of 100", common)
+	}
+}
+
+func TestRespond(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	bot := server.Bot(tgo.Options{})
+
+	router := message.NewRouter()
+	responder.New("echo", responder.Options{Reply: true},
+		responder.Response{Template: "hi {{ .From.FirstName }}, you said {{ index .Matches 1 }}"},
+	).Register(router, filters.RegexCapture(regexp.MustCompile(`^say (\w+)$`)))
+
+	upd := &tgo.Update{Message: &tgo.Message{MessageId: 5, Text: "say cheese", From: &tgo.User{FirstName: "Ali"}, Chat: tgo.Chat{Id: 7}}}
+	defer tgo.ForgetUpdate(upd)
+
+	if !router.HandleUpdate(bot, upd) {
+		t.Fatal("trigger is not handled")
+	}
+
+	calls := server.Calls()
+	if len(calls) != 1 || calls[0].Params["text"] != "hi Ali, you said cheese" || calls[0].Params["reply_to_message_id"] != float64(5) {
+		t.Errorf("unexpected calls: %+v", calls)
+	}
+}
@@ -0,0 +1,106 @@
+package business
+
+import (
+	"sync"
+
+	"github.com/haashemi/tgo"
+)
+
+type Context struct {
+	// Message contains the raw received message, if the update is a new or edited business message.
+	*tgo.Message
+
+	// Connection contains the raw received connection, if the update is a business connection.
+	Connection *tgo.BusinessConnection
+
+	// Deleted contains the raw received deleted messages, if the update is about them.
+	Deleted *tgo.BusinessMessagesDeleted
+
+	// Update is the update which the business data is received in.
+	Update *tgo.Update
+
+	// Bot is the bot instance which got the update, acting on behalf of the business account,
+	// so its calls carry the update's business_connection_id.
+	Bot *tgo.Bot
+
+	// Storage contains an in-context storage used for middlewares to pass some data
+	// to the next middleware or even the handler.
+	Storage sync.Map
+}
+
+// Set stores the value for the update under the key; see tgo.SetUpdateValue.
+func (ctx *Context) Set(key string, value any) { tgo.SetUpdateValue(ctx.Update, key, value) }
+
+// Get returns the value stored for the update under the key, by Set or by the filters.
+func (ctx *Context) Get(key string) (value any, ok bool) { return tgo.GetUpdateValue(ctx.Update, key) }
+
+// ConnectionID returns the identifier of the business connection which the update belongs to.
+func (ctx *Context) ConnectionID() string {
+	switch {
+	case ctx.Message != nil:
+		return ctx.BusinessConnectionId
+	case ctx.Connection != nil:
+		return ctx.Connection.Id
+	}
+	return ctx.Deleted.BusinessConnectionId
+}
+
+// IsEdited returns true if the update is an edited business message.
+func (ctx *Context) IsEdited() bool { return ctx.Update.EditedBusinessMessage != nil }
+
+// ChatID returns the identifier of the business account's chat which the update belongs to;
+// for the connections, it's the private chat with the account's user.
+func (ctx *Context) ChatID() int64 {
+	switch {
+	case ctx.Message != nil:
+		return ctx.Chat.Id
+	case ctx.Connection != nil:
+		return ctx.Connection.UserChatId
+	}
+	return ctx.Deleted.Chat.Id
+}
+
+// Session returns the session storage of the message's sender, or of the chat if there's no sender.
+func (ctx *Context) Session() *sync.Map {
+	if ctx.Message != nil && ctx.From != nil {
+		return ctx.Bot.GetSession(ctx.From.Id)
+	} else if ctx.Connection != nil {
+		return ctx.Bot.GetSession(ctx.Connection.User.Id)
+	}
+
+	return ctx.Bot.GetSession(ctx.ChatID())
+}
+
+// UserData returns the data bag of the message's sender, or of the business account's user.
+func (ctx *Context) UserData() *tgo.Data {
+	if ctx.Message != nil && ctx.From != nil {
+		return ctx.Bot.UserData(ctx.From.Id)
+	} else if ctx.Connection != nil {
+		return ctx.Bot.UserData(ctx.Connection.User.Id)
+	}
+	return ctx.Bot.UserData(ctx.ChatID())
+}
+
+// ChatData returns the data bag of the current chat.
+func (ctx *Context) ChatData() *tgo.Data { return ctx.Bot.ChatData(ctx.ChatID()) }
+
+// Send sends a message into the current chat on behalf of the business account, with the preferred ParseMode.
+// It will set the target ChatId if not set.
+func (ctx *Context) Send(msg tgo.Sendable) (*tgo.Message, error) {
+	if msg.GetChatID() == nil {
+		msg.SetChatID(ctx.ChatID())
+	}
+
+	return ctx.Bot.Send(msg)
+}
+
+// Reply replies to the current message on behalf of the business account, with the preferred ParseMode.
+// It will pass/override the ChatId and ReplyToMessageId field.
+func (ctx *Context) Reply(msg tgo.Replyable) (*tgo.Message, error) {
+	msg.SetChatID(ctx.ChatID())
+	if ctx.Message != nil {
+		msg.SetReplyToMessageId(ctx.MessageId)
+	}
+
+	return ctx.Bot.Send(msg)
+}
@@ -0,0 +1,103 @@
+package business
+
+import (
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/filters"
+)
+
+type Handler func(ctx *Context)
+
+type Middleware func(ctx *Context) (ok bool)
+
+type Route struct {
+	filter      tgo.Filter
+	middlewares []Middleware
+	handler     Handler
+}
+
+type Router struct {
+	middlewares []Middleware
+	routes      []Route
+}
+
+// NewRouter returns a new business router, which handles the business_connection, business_message,
+// edited_business_message, and deleted_business_messages updates.
+func NewRouter(middlewares ...Middleware) *Router {
+	return &Router{
+		middlewares: middlewares,
+	}
+}
+
+// Handle adds a new route to the Router
+func (r *Router) Handle(filter tgo.Filter, handler Handler, middlewares ...Middleware) {
+	r.routes = append(r.routes, Route{filter: filter, middlewares: middlewares, handler: handler})
+}
+
+// OnMessage adds a new route for the new messages of the connected business accounts.
+func (r *Router) OnMessage(handler Handler, middlewares ...Middleware) {
+	r.Handle(filters.IsBusinessMessage(), handler, middlewares...)
+}
+
+// OnEditedMessage adds a new route for the edited messages of the connected business accounts.
+func (r *Router) OnEditedMessage(handler Handler, middlewares ...Middleware) {
+	r.Handle(filters.IsEditedBusinessMessage(), handler, middlewares...)
+}
+
+// OnDeleted adds a new route for the messages deleted from the connected business accounts.
+func (r *Router) OnDeleted(handler Handler, middlewares ...Middleware) {
+	r.Handle(filters.IsDeletedBusinessMessages(), handler, middlewares...)
+}
+
+// OnConnection adds a new route for the business accounts connecting to, disconnecting from,
+// or editing their connection with the bot.
+func (r *Router) OnConnection(handler Handler, middlewares ...Middleware) {
+	r.Handle(filters.IsBusinessConnection(), handler, middlewares...)
+}
+
+// Setup implements tgo.Router interface
+func (r *Router) Setup(bot *tgo.Bot) error { return nil }
+
+// UpdateTypes implements tgo.UpdateTypesRouter interface
+func (r *Router) UpdateTypes() []string {
+	return []string{"business_connection", "business_message", "edited_business_message", "deleted_business_messages"}
+}
+
+// HandleUpdate implements tgo.Router interface
+func (r *Router) HandleUpdate(bot *tgo.Bot, upd *tgo.Update) (used bool) {
+	ctx := &Context{Update: upd}
+	switch {
+	case upd.BusinessMessage != nil:
+		ctx.Message = upd.BusinessMessage
+	case upd.EditedBusinessMessage != nil:
+		ctx.Message = upd.EditedBusinessMessage
+	case upd.DeletedBusinessMessages != nil:
+		ctx.Deleted = upd.DeletedBusinessMessages
+	case upd.BusinessConnection != nil:
+		ctx.Connection = upd.BusinessConnection
+	default:
+		return false
+	}
+	ctx.Bot = bot.WithBusinessConnection(ctx.ConnectionID())
+
+	for _, route := range r.routes {
+		if !route.filter.Check(upd) {
+			continue
+		}
+
+		allMiddlewares := append(append([]Middleware(nil), r.middlewares...), route.middlewares...)
+		for _, middleware := range allMiddlewares {
+			if !middleware(ctx) {
+				// we used the update, but as the middleware is failed
+				// we'll stop the execution and return true as "update is used"
+				return true
+			}
+		}
+
+		route.handler(ctx)
+
+		// filters passed and we used this method, so it's used!
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,46 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+// make sure that *Router implements the tgo.Router correctly.
+var TestRouter tgo.Router = &Router{}
+
+var TestUpdateTypesRouter tgo.UpdateTypesRouter = &Router{}
+
+func TestReply(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	bot := server.Bot(tgo.Options{})
+
+	router := NewRouter()
+	router.OnMessage(func(ctx *Context) {
+		if _, err := ctx.Reply(&tgo.SendMessage{Text: "hi"}); err != nil {
+			t.Error(err)
+		}
+	})
+
+	upd := &tgo.Update{BusinessMessage: &tgo.Message{MessageId: 3, BusinessConnectionId: "conn", Chat: tgo.Chat{Id: 42}}}
+	if !router.HandleUpdate(bot, upd) {
+		t.Fatal("business message is not handled")
+	}
+	if router.HandleUpdate(bot, &tgo.Update{Message: &tgo.Message{}}) {
+		t.Error("regular message is handled")
+	}
+
+	calls := server.Calls()
+	if len(calls) != 1 || calls[0].Method != "sendMessage" {
+		t.Fatalf("unexpected calls: %+v", calls)
+	}
+	if id := calls[0].Params["business_connection_id"]; id != "conn" {
+		t.Errorf("unexpected business_connection_id: %v", id)
+	}
+	if id := calls[0].Params["chat_id"]; id != float64(42) {
+		t.Errorf("unexpected chat_id: %v", id)
+	}
+}
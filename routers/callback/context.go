@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/filters"
 	"github.com/haashemi/tgo/routers/message"
 )
 
@@ -12,6 +13,9 @@ type Context struct {
 	// CallbackQuery contains the raw received query
 	*tgo.CallbackQuery
 
+	// Update is the update which the query is received in.
+	Update *tgo.Update
+
 	// Bot is the bot instance which got the update.
 	Bot *tgo.Bot
 
@@ -20,12 +24,29 @@ type Context struct {
 	Storage sync.Map
 }
 
+// Set stores the value for the update under the key; see tgo.SetUpdateValue.
+func (ctx *Context) Set(key string, value any) { tgo.SetUpdateValue(ctx.Update, key, value) }
+
+// Get returns the value stored for the update under the key, by Set or by the filters.
+func (ctx *Context) Get(key string) (value any, ok bool) { return tgo.GetUpdateValue(ctx.Update, key) }
+
 // Session returns the user's session storage.
 // it will return the chat's session if user-id is zero.
 func (ctx *Context) Session() *sync.Map {
 	return ctx.Bot.GetSession(ctx.From.Id)
 }
 
+// UserData returns the data bag of the callback query sender.
+func (ctx *Context) UserData() *tgo.Data { return ctx.Bot.UserData(ctx.From.Id) }
+
+// ChatData returns the data bag of the query's message chat, or of the sender's private chat if it has none.
+func (ctx *Context) ChatData() *tgo.Data {
+	if ctx.Message != nil {
+		return ctx.Bot.ChatData(ctx.Message.Chat.Id)
+	}
+	return ctx.Bot.ChatData(ctx.From.Id)
+}
+
 // Send sends a message into the message's chat if exist, otherwise sends in the sender's chat, with the preferred ParseMode.
 // It will set the target ChatId if not set.
 func (ctx *Context) Send(msg tgo.Sendable) (*tgo.Message, error) {
@@ -55,6 +76,40 @@ func (ctx *Context) Ask(msg tgo.Sendable, timeout time.Duration) (question, answ
 	return question, answer, err
 }
 
+// Conversation returns a conversation with the callback query sender in the chat of the
+// query's message, or in their private chat if it has none.
+func (ctx *Context) Conversation() *tgo.Conversation {
+	chatID := ctx.From.Id
+	if ctx.Message != nil {
+		chatID = ctx.Message.Chat.Id
+	}
+
+	return ctx.Bot.Conversation(chatID, ctx.From.Id)
+}
+
+// EditOrSend edits the query's message to the text and keyboard, or sends them as a new message
+// if it can't be edited; see bot.EditOrSend. The inline messages are only edited.
+func (ctx *Context) EditOrSend(text string, markup *tgo.InlineKeyboardMarkup) (*tgo.Message, error) {
+	if ctx.Message == nil {
+		if err := ctx.Bot.ShrinkCallbackData(markup); err != nil {
+			return nil, err
+		}
+
+		_, err := ctx.Bot.EditMessageText(&tgo.EditMessageText{
+			InlineMessageId: ctx.InlineMessageId,
+			Text:            text,
+			ParseMode:       ctx.Bot.DefaultParseMode,
+			ReplyMarkup:     markup,
+		})
+		if tgo.IsMessageNotModifiedErr(err) {
+			err = nil
+		}
+		return nil, err
+	}
+
+	return ctx.Bot.EditOrSend(tgo.ID(ctx.Message.Chat.Id), ctx.Message.MessageId, text, markup)
+}
+
 // Answer answers to the sent callback query.
 // it fills the CallbackQueryId field by default.
 func (ctx *Context) Answer(options *tgo.AnswerCallbackQuery) error {
@@ -63,3 +118,30 @@ func (ctx *Context) Answer(options *tgo.AnswerCallbackQuery) error {
 	_, err := ctx.Bot.AnswerCallbackQuery(options)
 	return err
 }
+
+// T translates the key into the sender's language using the bot's translator.
+func (ctx *Context) T(key string, args ...any) string {
+	return ctx.Bot.Translate(ctx.From.LanguageCode, key, args...)
+}
+
+// Matches returns the capture groups of the RegexCapture filter which the update passed, or nil.
+// The first one is the whole match.
+func (ctx *Context) Matches() []string { return filters.Matches(ctx.Update) }
+
+// NamedMatch returns the text of the named capture group of the RegexCapture filter which the update passed.
+func (ctx *Context) NamedMatch(name string) string { return filters.NamedMatches(ctx.Update)[name] }
+
+// OpenGame answers the game's callback query by opening the game's url for the user.
+func (ctx *Context) OpenGame(url string) error {
+	return ctx.Answer(&tgo.AnswerCallbackQuery{Url: url})
+}
+
+// SetGameScore sets the score of the user in the game's message which the query is from.
+func (ctx *Context) SetGameScore(score int64, force bool) error {
+	return ctx.Bot.SetCallbackGameScore(ctx.CallbackQuery, score, force)
+}
+
+// GameHighScores returns the high scores of the game's message which the query is from, around the user.
+func (ctx *Context) GameHighScores() ([]*tgo.GameHighScore, error) {
+	return ctx.Bot.GetCallbackGameHighScores(ctx.CallbackQuery)
+}
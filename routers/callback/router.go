@@ -1,6 +1,9 @@
 package callback
 
-import "github.com/haashemi/tgo"
+import (
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/filters"
+)
 
 type Handler func(ctx *Context)
 
@@ -10,6 +13,7 @@ type Route struct {
 	filter      tgo.Filter
 	middlewares []Middleware
 	handler     Handler
+	router      *Router
 }
 
 type Router struct {
@@ -29,9 +33,27 @@ func (r *Router) Handle(filter tgo.Filter, handler Handler, middlewares ...Middl
 	r.routes = append(r.routes, Route{filter: filter, middlewares: middlewares, handler: handler})
 }
 
+// OnGameCallback adds a new route for the callback queries of the games' buttons, with one of
+// the passed short names if there's any. Answer them with ctx.OpenGame.
+func (r *Router) OnGameCallback(handler Handler, shortNames ...string) {
+	r.Handle(filters.GameCallback(shortNames...), handler)
+}
+
+// Topic returns a sub-router whose routes only handle the updates of the forum topic with the
+// thread ID, after the router's own middlewares and the passed ones. It's checked in the order it's added.
+func (r *Router) Topic(threadID int64, middlewares ...Middleware) *Router {
+	sub := NewRouter(append(append([]Middleware(nil), r.middlewares...), middlewares...)...)
+	r.routes = append(r.routes, Route{filter: filters.TopicID(threadID), router: sub})
+
+	return sub
+}
+
 // Setup implements tgo.Router interface
 func (r *Router) Setup(bot *tgo.Bot) error { return nil }
 
+// UpdateTypes implements tgo.UpdateTypesRouter interface
+func (r *Router) UpdateTypes() []string { return []string{"callback_query"} }
+
 // HandleUpdate implements tgo.Router interface
 func (r *Router) HandleUpdate(bot *tgo.Bot, upd *tgo.Update) (used bool) {
 	if upd.CallbackQuery == nil {
@@ -41,9 +63,14 @@ func (r *Router) HandleUpdate(bot *tgo.Bot, upd *tgo.Update) (used bool) {
 	for _, route := range r.routes {
 		if !route.filter.Check(upd) {
 			continue
+		} else if route.router != nil {
+			if route.router.HandleUpdate(bot, upd) {
+				return true
+			}
+			continue
 		}
 
-		ctx := &Context{CallbackQuery: upd.CallbackQuery, Bot: bot}
+		ctx := &Context{CallbackQuery: upd.CallbackQuery, Update: upd, Bot: bot}
 
 		allMiddlewares := append(r.middlewares, route.middlewares...)
 		for _, middleware := range allMiddlewares {
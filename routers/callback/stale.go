@@ -0,0 +1,86 @@
+package callback
+
+import "github.com/haashemi/tgo"
+
+// DefaultStaleText is the callback answer of the stale keyboards by default.
+const DefaultStaleText = "This menu has expired."
+
+// StaleOptions configures how the callback queries of the stale keyboards are responded to.
+type StaleOptions struct {
+	// IsStale returns true if the state behind the query's keyboard no longer exists,
+	// such as an expired pagination or a finished form. It's required by StaleMiddleware.
+	IsStale func(ctx *Context) bool
+
+	// Text is shown to the user as the callback answer; it defaults to DefaultStaleText.
+	Text string
+
+	// EditText, if not empty, replaces the message's text as its terminal state.
+	// Otherwise, only its keyboard is changed.
+	EditText string
+
+	// Rerender, if not nil, returns a fresh keyboard to replace the stale one; the keyboard
+	// is removed if it, or itself, returns nil.
+	Rerender func(ctx *Context) *tgo.InlineKeyboardMarkup
+}
+
+// StaleMiddleware responds to the callback queries of the stale keyboards by RespondStale,
+// and stops them from reaching the handler.
+func StaleMiddleware(opts StaleOptions) Middleware {
+	return func(ctx *Context) (ok bool) {
+		if !opts.IsStale(ctx) {
+			return true
+		}
+
+		ctx.RespondStale(opts)
+		return false
+	}
+}
+
+// RespondStale answers the callback query with the options' text, and then edits the message
+// to its terminal state, with a fresh keyboard if the options can render one. opts.IsStale is ignored.
+func (ctx *Context) RespondStale(opts StaleOptions) error {
+	if opts.Text == "" {
+		opts.Text = DefaultStaleText
+	}
+
+	if err := ctx.Answer(&tgo.AnswerCallbackQuery{Text: opts.Text}); err != nil {
+		return err
+	}
+
+	var markup *tgo.InlineKeyboardMarkup
+	if opts.Rerender != nil {
+		markup = opts.Rerender(ctx)
+	}
+	if markup != nil {
+		if err := ctx.Bot.ShrinkCallbackData(markup); err != nil {
+			return err
+		}
+	}
+
+	var chatID tgo.ChatID
+	var messageID int64
+	if ctx.Message != nil {
+		chatID, messageID = tgo.ID(ctx.Message.Chat.Id), ctx.Message.MessageId
+	}
+
+	var err error
+	if opts.EditText != "" {
+		_, err = ctx.Bot.EditMessageText(&tgo.EditMessageText{
+			ChatId:          chatID,
+			MessageId:       messageID,
+			InlineMessageId: ctx.InlineMessageId,
+			Text:            opts.EditText,
+			ParseMode:       ctx.Bot.DefaultParseMode,
+			ReplyMarkup:     markup,
+		})
+	} else {
+		_, err = ctx.Bot.EditMessageReplyMarkup(&tgo.EditMessageReplyMarkup{
+			ChatId:          chatID,
+			MessageId:       messageID,
+			InlineMessageId: ctx.InlineMessageId,
+			ReplyMarkup:     markup,
+		})
+	}
+
+	return err
+}
@@ -0,0 +1,35 @@
+package callback
+
+import (
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/filters"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestStaleMiddleware(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	bot := server.Bot(tgo.Options{})
+
+	var handled bool
+	router := NewRouter(StaleMiddleware(StaleOptions{
+		IsStale:  func(ctx *Context) bool { return ctx.Data == "page:9" },
+		EditText: "Closed.",
+	}))
+	router.Handle(filters.True(), func(ctx *Context) { handled = true })
+
+	query := &tgo.CallbackQuery{Id: "1", Data: "page:9", Message: &tgo.Message{MessageId: 5, Chat: tgo.Chat{Id: 1}}}
+	router.HandleUpdate(bot, &tgo.Update{CallbackQuery: query})
+
+	if handled {
+		t.Fatal("stale query reached the handler")
+	}
+
+	calls := server.Calls()
+	if len(calls) != 2 || calls[0].Method != "answerCallbackQuery" || calls[1].Method != "editMessageText" || calls[1].Params["text"] != "Closed." {
+		t.Errorf("unexpected calls: %+v", calls)
+	}
+}
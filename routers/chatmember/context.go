@@ -0,0 +1,63 @@
+package chatmember
+
+import (
+	"sync"
+
+	"github.com/haashemi/tgo"
+)
+
+type Context struct {
+	// ChatMemberUpdated contains the raw received update
+	*tgo.ChatMemberUpdated
+
+	// Update is the update which the member change is received in.
+	Update *tgo.Update
+
+	// IsMine is true if the updated member is the bot itself.
+	IsMine bool
+
+	// Bot is the bot instance which got the update.
+	Bot *tgo.Bot
+
+	// Storage contains an in-context storage used for middlewares to pass some data
+	// to the next middleware or even the handler.
+	Storage sync.Map
+}
+
+// Set stores the value for the update under the key; see tgo.SetUpdateValue.
+func (ctx *Context) Set(key string, value any) { tgo.SetUpdateValue(ctx.Update, key, value) }
+
+// Get returns the value stored for the update under the key, by Set or by the filters.
+func (ctx *Context) Get(key string) (value any, ok bool) { return tgo.GetUpdateValue(ctx.Update, key) }
+
+// Session returns the session storage of the user who is updated.
+func (ctx *Context) Session() *sync.Map {
+	return ctx.Bot.GetSession(ctx.User().Id)
+}
+
+// UserData returns the data bag of the user who is updated.
+func (ctx *Context) UserData() *tgo.Data { return ctx.Bot.UserData(ctx.User().Id) }
+
+// ChatData returns the data bag of the chat.
+func (ctx *Context) ChatData() *tgo.Data { return ctx.Bot.ChatData(ctx.Chat.Id) }
+
+// User returns the user whose membership is updated.
+func (ctx *Context) User() *tgo.User {
+	return tgo.ChatMemberUser(ctx.NewChatMember)
+}
+
+// OldStatus returns the member's status before the change.
+func (ctx *Context) OldStatus() string { return tgo.ChatMemberStatus(ctx.OldChatMember) }
+
+// NewStatus returns the member's status after the change.
+func (ctx *Context) NewStatus() string { return tgo.ChatMemberStatus(ctx.NewChatMember) }
+
+// Send sends a message into the chat with the preferred ParseMode.
+// It will set the target ChatId if not set.
+func (ctx *Context) Send(msg tgo.Sendable) (*tgo.Message, error) {
+	if msg.GetChatID() == nil {
+		msg.SetChatID(ctx.Chat.Id)
+	}
+
+	return ctx.Bot.Send(msg)
+}
@@ -0,0 +1,85 @@
+package chatmember
+
+import (
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/filters"
+)
+
+type Handler func(ctx *Context)
+
+type Middleware func(ctx *Context) (ok bool)
+
+type Route struct {
+	filter      tgo.Filter
+	middlewares []Middleware
+	handler     Handler
+}
+
+type Router struct {
+	middlewares []Middleware
+	routes      []Route
+}
+
+// NewRouter returns a new chat member router, which handles both
+// the chat_member and my_chat_member updates.
+func NewRouter(middlewares ...Middleware) *Router {
+	return &Router{
+		middlewares: middlewares,
+	}
+}
+
+// Handle adds a new route to the Router
+func (r *Router) Handle(filter tgo.Filter, handler Handler, middlewares ...Middleware) {
+	r.routes = append(r.routes, Route{filter: filter, middlewares: middlewares, handler: handler})
+}
+
+// OnChatMember adds a new route for the status changes of the chat members.
+//
+// Note: the bot must be an administrator in the chat and explicitly specify "chat_member"
+// in the list of allowed updates to receive these updates.
+func (r *Router) OnChatMember(handler Handler, middlewares ...Middleware) {
+	r.Handle(filters.IsChatMember(), handler, middlewares...)
+}
+
+// OnMyChatMember adds a new route for the status changes of the bot itself.
+func (r *Router) OnMyChatMember(handler Handler, middlewares ...Middleware) {
+	r.Handle(filters.IsMyChatMember(), handler, middlewares...)
+}
+
+// Setup implements tgo.Router interface
+func (r *Router) Setup(bot *tgo.Bot) error { return nil }
+
+// UpdateTypes implements tgo.UpdateTypesRouter interface
+func (r *Router) UpdateTypes() []string { return []string{"chat_member", "my_chat_member"} }
+
+// HandleUpdate implements tgo.Router interface
+func (r *Router) HandleUpdate(bot *tgo.Bot, upd *tgo.Update) (used bool) {
+	ctx := &Context{ChatMemberUpdated: upd.ChatMember, Update: upd, Bot: bot}
+	if upd.MyChatMember != nil {
+		ctx = &Context{ChatMemberUpdated: upd.MyChatMember, Update: upd, Bot: bot, IsMine: true}
+	} else if upd.ChatMember == nil {
+		return false
+	}
+
+	for _, route := range r.routes {
+		if !route.filter.Check(upd) {
+			continue
+		}
+
+		allMiddlewares := append(append([]Middleware(nil), r.middlewares...), route.middlewares...)
+		for _, middleware := range allMiddlewares {
+			if !middleware(ctx) {
+				// we used the update, but as the middleware is failed
+				// we'll stop the execution and return true as "update is used"
+				return true
+			}
+		}
+
+		route.handler(ctx)
+
+		// filters passed and we used this method, so it's used!
+		return true
+	}
+
+	return false
+}
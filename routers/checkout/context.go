@@ -0,0 +1,49 @@
+package checkout
+
+import (
+	"sync"
+
+	"github.com/haashemi/tgo"
+)
+
+type Context struct {
+	// PreCheckoutQuery contains the raw received query
+	*tgo.PreCheckoutQuery
+
+	// Update is the update which the query is received in.
+	Update *tgo.Update
+
+	// Bot is the bot instance which got the update.
+	Bot *tgo.Bot
+
+	// Storage contains an in-context storage used for middlewares to pass some data
+	// to the next middleware or even the handler.
+	Storage sync.Map
+}
+
+// Set stores the value for the update under the key; see tgo.SetUpdateValue.
+func (ctx *Context) Set(key string, value any) { tgo.SetUpdateValue(ctx.Update, key, value) }
+
+// Get returns the value stored for the update under the key, by Set or by the filters.
+func (ctx *Context) Get(key string) (value any, ok bool) { return tgo.GetUpdateValue(ctx.Update, key) }
+
+// Session returns the user's session storage.
+func (ctx *Context) Session() *sync.Map {
+	return ctx.Bot.GetSession(ctx.From.Id)
+}
+
+// UserData returns the data bag of the user who is paying.
+func (ctx *Context) UserData() *tgo.Data { return ctx.Bot.UserData(ctx.From.Id) }
+
+// Approve tells telegram that the bot is ready to proceed with the order.
+// Telegram waits at most 10 seconds for the answer.
+func (ctx *Context) Approve() error {
+	_, err := ctx.Bot.AnswerPreCheckoutQuery(&tgo.AnswerPreCheckoutQuery{PreCheckoutQueryId: ctx.Id, Ok: true})
+	return err
+}
+
+// Decline cancels the checkout with the passed human readable reason, which is shown to the user.
+func (ctx *Context) Decline(reason string) error {
+	_, err := ctx.Bot.AnswerPreCheckoutQuery(&tgo.AnswerPreCheckoutQuery{PreCheckoutQueryId: ctx.Id, ErrorMessage: reason})
+	return err
+}
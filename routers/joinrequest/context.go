@@ -0,0 +1,62 @@
+package joinrequest
+
+import (
+	"sync"
+
+	"github.com/haashemi/tgo"
+)
+
+type Context struct {
+	// ChatJoinRequest contains the raw received request
+	*tgo.ChatJoinRequest
+
+	// Update is the update which the request is received in.
+	Update *tgo.Update
+
+	// Bot is the bot instance which got the update.
+	Bot *tgo.Bot
+
+	// Storage contains an in-context storage used for middlewares to pass some data
+	// to the next middleware or even the handler.
+	Storage sync.Map
+}
+
+// Set stores the value for the update under the key; see tgo.SetUpdateValue.
+func (ctx *Context) Set(key string, value any) { tgo.SetUpdateValue(ctx.Update, key, value) }
+
+// Get returns the value stored for the update under the key, by Set or by the filters.
+func (ctx *Context) Get(key string) (value any, ok bool) { return tgo.GetUpdateValue(ctx.Update, key) }
+
+// Session returns the user's session storage.
+func (ctx *Context) Session() *sync.Map {
+	return ctx.Bot.GetSession(ctx.From.Id)
+}
+
+// UserData returns the data bag of the user who has requested to join.
+func (ctx *Context) UserData() *tgo.Data { return ctx.Bot.UserData(ctx.From.Id) }
+
+// ChatData returns the data bag of the chat.
+func (ctx *Context) ChatData() *tgo.Data { return ctx.Bot.ChatData(ctx.Chat.Id) }
+
+// Approve approves the user's request to join the chat.
+func (ctx *Context) Approve() error {
+	_, err := ctx.Bot.ApproveChatJoinRequest(&tgo.ApproveChatJoinRequest{ChatId: tgo.ID(ctx.Chat.Id), UserId: ctx.From.Id})
+	return err
+}
+
+// Decline declines the user's request to join the chat.
+func (ctx *Context) Decline() error {
+	_, err := ctx.Bot.DeclineChatJoinRequest(&tgo.DeclineChatJoinRequest{ChatId: tgo.ID(ctx.Chat.Id), UserId: ctx.From.Id})
+	return err
+}
+
+// SendToUser sends a message to the user in their private chat, which is allowed for 5 minutes after
+// the join request is sent, even if the user has never started the bot.
+// It will set the target ChatId if not set.
+func (ctx *Context) SendToUser(msg tgo.Sendable) (*tgo.Message, error) {
+	if msg.GetChatID() == nil {
+		msg.SetChatID(ctx.UserChatId)
+	}
+
+	return ctx.Bot.Send(msg)
+}
@@ -0,0 +1,153 @@
+package message
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/haashemi/tgo"
+)
+
+// FloodAction is what AntiFlood does with the senders who flood a chat, besides dropping their messages.
+type FloodAction int
+
+const (
+	FloodDrop FloodAction = iota // only drops the messages
+	FloodWarn                    // replies to the first dropped message with a warning
+	FloodMute                    // mutes the sender; the channels are banned instead, as they can't be muted
+)
+
+// AntiFloodOptions configures AntiFlood. The zero value is valid and uses the defaults.
+type AntiFloodOptions struct {
+	// Limit is the number of the messages which a sender may send to a chat in a Window; it defaults to 5.
+	Limit int
+
+	// Window is the sliding window which the messages are counted in; it defaults to 5 seconds.
+	Window time.Duration
+
+	// Action is done at the first message beyond the limit; the messages are dropped until
+	// the sender's rate is back under the limit.
+	Action FloodAction
+
+	// MuteDuration is how long FloodMute mutes the senders for; it defaults to 5 minutes.
+	//
+	// Note: telegram considers durations shorter than 30 seconds as forever.
+	MuteDuration time.Duration
+
+	// Warning is the FloodWarn's reply; it defaults to an English text, and it's translated by ctx.T.
+	Warning string
+
+	// OnFlood, if not nil, is called after the Action with the number of the sender's messages
+	// in the window, for each dropped message; use it for your own punishments or logging.
+	OnFlood func(ctx *Context, count int)
+}
+
+// floodKey identifies a sender in a chat.
+type floodKey struct{ chatID, senderID int64 }
+
+// floodState is the times of a sender's recent messages.
+type floodState struct {
+	times    []time.Time
+	punished bool // the Action is done for the current flood
+}
+
+// AntiFlood returns a middleware which counts the messages of each sender in each chat over a sliding
+// window, and drops the ones beyond the limit, doing the Action for the first one of them:
+//
+//	router := message.NewRouter(message.AntiFlood(message.AntiFloodOptions{Limit: 5, Action: message.FloodMute}))
+//
+// The counters are kept in memory, and the idle senders are forgotten after a window.
+func AntiFlood(opts AntiFloodOptions) Middleware {
+	if opts.Limit <= 0 {
+		opts.Limit = 5
+	}
+	if opts.Window <= 0 {
+		opts.Window = 5 * time.Second
+	}
+	if opts.MuteDuration <= 0 {
+		opts.MuteDuration = 5 * time.Minute
+	}
+	if opts.Warning == "" {
+		opts.Warning = "Please slow down."
+	}
+
+	var mut sync.Mutex
+	senders := make(map[floodKey]*floodState)
+	lastSweep := time.Now()
+
+	return func(ctx *Context) (ok bool) {
+		chatID, senderID := tgo.GetChatAndSenderID(ctx.Message)
+		now := time.Now()
+
+		mut.Lock()
+		// the idle senders are swept at most once per window, so the map doesn't grow forever.
+		if now.Sub(lastSweep) >= opts.Window {
+			for key, state := range senders {
+				if now.Sub(state.times[len(state.times)-1]) >= opts.Window {
+					delete(senders, key)
+				}
+			}
+			lastSweep = now
+		}
+
+		key := floodKey{chatID, senderID}
+		state, exists := senders[key]
+		if !exists {
+			state = &floodState{}
+			senders[key] = state
+		}
+
+		// the messages out of the window are dropped from the front, as the times are in order.
+		start := 0
+		for start < len(state.times) && now.Sub(state.times[start]) >= opts.Window {
+			start++
+		}
+		state.times = append(state.times[start:], now)
+
+		count := len(state.times)
+		if count <= opts.Limit {
+			state.punished = false
+			mut.Unlock()
+			return true
+		}
+
+		punish := !state.punished
+		state.punished = true
+		mut.Unlock()
+
+		if punish {
+			floodPunish(ctx, opts)
+		}
+		if opts.OnFlood != nil {
+			opts.OnFlood(ctx, count)
+		}
+		return false
+	}
+}
+
+// floodPunish does the Action to the message's sender.
+func floodPunish(ctx *Context, opts AntiFloodOptions) {
+	var err error
+
+	switch opts.Action {
+	case FloodWarn:
+		_, err = ctx.Reply(&tgo.SendMessage{Text: ctx.T(opts.Warning)})
+
+	case FloodMute:
+		if ctx.Chat.Type != "supergroup" {
+			return
+		}
+
+		err = ctx.MuteSender(opts.MuteDuration)
+		if errors.Is(err, ErrSenderChatCantBeMuted) {
+			err = ctx.BanSender(0)
+		} else if errors.Is(err, ErrAnonymousSender) {
+			// the anonymous administrators can't be punished, but their messages are still dropped.
+			err = nil
+		}
+	}
+
+	if err != nil {
+		ctx.Bot.Logger().Log(tgo.LevelError, "failed to punish the flooding sender", "chat_id", ctx.Chat.Id, "error", err)
+	}
+}
@@ -0,0 +1,61 @@
+package message
+
+import (
+	"testing"
+	"time"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/filters"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestAntiFlood(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	bot := server.Bot(tgo.Options{})
+
+	var handled, flooded int
+	router := NewRouter(AntiFlood(AntiFloodOptions{
+		Limit:   2,
+		Window:  50 * time.Millisecond,
+		Action:  FloodMute,
+		OnFlood: func(ctx *Context, count int) { flooded = count },
+	}))
+	router.Handle(filters.True(), func(ctx *Context) { handled++ })
+
+	send := func(userID int64) {
+		router.HandleUpdate(bot, &tgo.Update{Message: &tgo.Message{
+			Chat: tgo.Chat{Id: -1, Type: "supergroup"},
+			From: &tgo.User{Id: userID},
+		}})
+	}
+
+	for i := 0; i < 4; i++ {
+		send(1)
+	}
+	send(2)
+
+	if handled != 3 {
+		t.Errorf("expected 3 messages to be handled, got %d", handled)
+	} else if flooded != 4 {
+		t.Errorf("expected the flood to be reported at the 4th message, got %d", flooded)
+	}
+
+	var mutes int
+	for _, call := range server.Calls() {
+		if call.Method == "restrictChatMember" {
+			mutes++
+		}
+	}
+	if mutes != 1 {
+		t.Errorf("expected the sender to be muted once, got %d", mutes)
+	}
+
+	// the sender is let in again after the window.
+	time.Sleep(60 * time.Millisecond)
+	send(1)
+	if handled != 4 {
+		t.Errorf("expected the message after the window to be handled, got %d handled", handled)
+	}
+}
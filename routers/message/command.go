@@ -0,0 +1,86 @@
+package message
+
+import (
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/filters"
+)
+
+// Command adds a route handling the /name command, and declares the command with the description in
+// the bot's Commands registry once the router is added to the bot, to be shown in the command menu by
+// bot.PublishCommands:
+//
+//	router.Command("help", "Show the help", handleHelp).Translate("fa", "نمایش راهنما")
+//	router.Command("ban", "Ban the replied member", handleBan).Scopes = []tgo.BotCommandScope{tgo.ScopeAllChatAdministrators()}
+//
+// An empty description keeps the command out of the menus. In the groups, the commands addressed
+// to the other bots, such as /help@other_bot, aren't handled. The returned command may be changed
+// until the router is added to the bot.
+func (r *Router) Command(name, description string, handler Handler, middlewares ...Middleware) *tgo.Command {
+	cmd := &tgo.Command{Name: name, Description: description, Category: r.category}
+
+	// the bot's username is known once the router is set up, which addresses the filter to it.
+	r.addRoute(Route{filter: filters.Command(name, ""), middlewares: middlewares, handler: handler, command: cmd})
+	return cmd
+}
+
+// Category sets the category of the commands declared afterwards by the router's Command, and its
+// new sub-routers', which they're listed under by the help; see tgo.Commands.Help.
+func (r *Router) Category(name string) *Router {
+	r.category = name
+	return r
+}
+
+// Help adds the /help command of the description, replying the help of the bot's commands below the
+// header; see HelpHandler.
+func (r *Router) Help(description, header string, middlewares ...Middleware) *tgo.Command {
+	return r.Command("help", description, HelpHandler(tgo.HelpOptions{Header: header}), middlewares...)
+}
+
+// HelpHandler returns a handler replying the help of the bot's commands by the options, formatted by
+// tgo.ParseModeHTML. It's in the sender's language, and translated by the bot's translator, unless
+// the options have their own.
+func HelpHandler(opts tgo.HelpOptions) Handler {
+	return func(ctx *Context) {
+		opts := opts
+		if opts.LanguageCode == "" && ctx.From != nil {
+			opts.LanguageCode = ctx.From.LanguageCode
+		}
+		if opts.Translate == nil {
+			opts.Translate = func(languageCode, key string) string { return ctx.Bot.Translate(languageCode, key) }
+		}
+
+		if _, err := ctx.Reply(&tgo.SendMessage{Text: ctx.Bot.Commands().Help(opts), ParseMode: tgo.ParseModeHTML}); err != nil {
+			ctx.Bot.Logger().Log(tgo.LevelError, "failed to reply the help", "chat_id", ctx.Chat.Id, "error", err)
+		}
+	}
+}
+
+// hasCommands reports whether the router, or any of its sub-routers, has a command route.
+func (r *Router) hasCommands() bool {
+	for _, route := range r.getRoutes() {
+		if route.command != nil || (route.router != nil && route.router.hasCommands()) {
+			return true
+		}
+	}
+	return false
+}
+
+// setupCommands addresses the command routes of the router, and of its sub-routers, to the bot's
+// username, and declares their commands in the bot's registry.
+func (r *Router) setupCommands(bot *tgo.Bot, username string) {
+	r.routesMut.Lock()
+	defer r.routesMut.Unlock()
+
+	routes := append([]Route(nil), r.routes...)
+	for i, route := range routes {
+		if route.router != nil {
+			route.router.setupCommands(bot, username)
+		} else if route.command != nil {
+			routes[i].filter = filters.Command(route.command.Name, username)
+			if route.command.Description != "" {
+				bot.Commands().AddCommand(route.command)
+			}
+		}
+	}
+	r.routes = routes
+}
@@ -0,0 +1,96 @@
+package message
+
+import (
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestCommand(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	bot := server.Bot(tgo.Options{})
+
+	var handled int
+	router := NewRouter()
+	router.Command("help", "Show the help", func(ctx *Context) { handled++ })
+	router.Topic(5).Command("secret", "", func(ctx *Context) { handled++ })
+
+	if err := bot.AddRouter(router); err != nil {
+		t.Fatal(err)
+	}
+
+	send := func(text string, threadID int64) {
+		router.HandleUpdate(bot, &tgo.Update{Message: &tgo.Message{
+			Chat:            tgo.Chat{Id: -1, Type: "supergroup", IsForum: true},
+			From:            &tgo.User{Id: 1},
+			Text:            text,
+			MessageThreadId: threadID,
+			IsTopicMessage:  threadID != 0,
+		}})
+	}
+
+	send("/help", 0)
+	send("/help@test_bot now", 0)
+	send("/help@other_bot", 0)
+	send("/secret", 5)
+	if handled != 3 {
+		t.Errorf("expected 3 commands to be handled, got %d", handled)
+	}
+
+	list := bot.Commands().List()
+	if len(list) != 1 || list[0].Name != "help" {
+		t.Fatalf("unexpected registered commands %v", list)
+	}
+
+	if err := bot.PublishCommands(); err != nil {
+		t.Fatal(err)
+	}
+
+	var published bool
+	for _, call := range server.Calls() {
+		if call.Method == "setMyCommands" {
+			commands := call.Params["commands"].([]any)
+			published = len(commands) == 1 && commands[0].(map[string]any)["command"] == "help"
+		}
+	}
+	if !published {
+		t.Error("the help command isn't published")
+	}
+}
+
+func TestHelp(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	bot := server.Bot(tgo.Options{})
+
+	router := NewRouter()
+	router.Help("Show the help", "Commands:")
+	router.Category("Admin").Command("ban", "Ban the replied member", func(ctx *Context) {})
+
+	if err := bot.AddRouter(router); err != nil {
+		t.Fatal(err)
+	}
+
+	router.HandleUpdate(bot, &tgo.Update{Message: &tgo.Message{
+		MessageId: 1,
+		Chat:      tgo.Chat{Id: 1, Type: "private"},
+		From:      &tgo.User{Id: 1},
+		Text:      "/help",
+	}})
+
+	var reply tgotest.Call
+	for _, call := range server.Calls() {
+		if call.Method == "sendMessage" {
+			reply = call
+		}
+	}
+
+	want := "Commands:\n\n/help — Show the help\n\n<b>Admin</b>\n/ban — Ban the replied member"
+	if reply.Params["text"] != want || reply.Params["parse_mode"] != "HTML" {
+		t.Errorf("unexpected help reply %v", reply.Params)
+	}
+}
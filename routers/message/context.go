@@ -1,24 +1,48 @@
 package message
 
 import (
+	"errors"
 	"sync"
 	"time"
 
 	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/filters"
 )
 
 type Context struct {
 	// Message contains the raw received message
 	*tgo.Message
 
+	// Update is the update which the message is received in.
+	Update *tgo.Update
+
 	// Bot is the bot instance which got the update.
 	Bot *tgo.Bot
 
 	// Storage contains an in-context storage used for middlewares to pass some data
 	// to the next middleware or even the handler.
 	Storage sync.Map
+
+	// next and stopped are set by Next and StopPropagation for the current route.
+	next, stopped bool
 }
 
+// Set stores the value for the update under the key; see tgo.SetUpdateValue.
+func (ctx *Context) Set(key string, value any) { tgo.SetUpdateValue(ctx.Update, key, value) }
+
+// Get returns the value stored for the update under the key, by Set or by the filters.
+func (ctx *Context) Get(key string) (value any, ok bool) { return tgo.GetUpdateValue(ctx.Update, key) }
+
+// Next makes the router go on matching the update once the current handler returns, so the next
+// matching route handles it too, or the bot's next routers if none does. Called by a middleware
+// returning false, it skips the route's handler, passing the update on as if the route didn't match.
+func (ctx *Context) Next() { ctx.next = true }
+
+// StopPropagation makes the router stop matching the update once the current handler or middleware
+// returns, even if Next was called before by the route's middlewares; it's the default after the
+// handler, and a sub-router's route stops the routes of its parents too.
+func (ctx *Context) StopPropagation() { ctx.stopped = true }
+
 // Session returns the user's session storage.
 // it will return the chat's session if user-id is zero.
 //
@@ -37,6 +61,15 @@ func (ctx *Context) Session() *sync.Map {
 	return ctx.Bot.GetSession(id)
 }
 
+// UserData returns the data bag of the message's sender; see Session for the anonymous senders.
+func (ctx *Context) UserData() *tgo.Data {
+	_, senderID := tgo.GetChatAndSenderID(ctx.Message)
+	return ctx.Bot.UserData(senderID)
+}
+
+// ChatData returns the data bag of the current chat.
+func (ctx *Context) ChatData() *tgo.Data { return ctx.Bot.ChatData(ctx.Chat.Id) }
+
 // String returns the message's text or media caption
 func (m *Context) String() string {
 	if m.Text != "" {
@@ -47,10 +80,14 @@ func (m *Context) String() string {
 }
 
 // Send sends a message into the current chat with the preferred ParseMode.
-// It will set the target ChatId if not set.
+// It will set the target ChatId if not set, along with the forum topic of the current message.
 func (ctx *Context) Send(msg tgo.Sendable) (*tgo.Message, error) {
 	if msg.GetChatID() == nil {
 		msg.SetChatID(ctx.Chat.Id)
+
+		if ctx.IsTopicMessage {
+			tgo.SetMessageThreadID(msg, ctx.MessageThreadId)
+		}
 	}
 
 	return ctx.Bot.Send(msg)
@@ -65,6 +102,56 @@ func (ctx *Context) Reply(msg tgo.Replyable) (*tgo.Message, error) {
 	return ctx.Bot.Send(msg)
 }
 
+// ReplyEphemeral replies the text to the current message, and deletes the reply after the ttl by the
+// bot's scheduler, such as for the temporary notifications in the groups; see bot.DeleteAfter.
+// The reply isn't sent if the scheduler isn't started.
+func (ctx *Context) ReplyEphemeral(text string, ttl time.Duration) (*tgo.Message, error) {
+	if ctx.Bot.Scheduler() == nil {
+		return nil, tgo.ErrNoScheduler
+	}
+
+	reply, err := ctx.Reply(&tgo.SendMessage{Text: text})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = ctx.Bot.DeleteAfter(tgo.ID(reply.Chat.Id), reply.MessageId, ttl)
+	return reply, err
+}
+
+// CopyTo copies the current message to the chat, without a link to it.
+func (ctx *Context) CopyTo(chatID tgo.ChatID) (*tgo.MessageId, error) {
+	return ctx.Bot.CopyMessage(&tgo.CopyMessage{ChatId: chatID, FromChatId: tgo.ID(ctx.Chat.Id), MessageId: ctx.MessageId})
+}
+
+// ForwardTo forwards the current message to the chat.
+func (ctx *Context) ForwardTo(chatID tgo.ChatID) (*tgo.Message, error) {
+	return ctx.Bot.ForwardMessage(&tgo.ForwardMessage{ChatId: chatID, FromChatId: tgo.ID(ctx.Chat.Id), MessageId: ctx.MessageId})
+}
+
+// Pin pins the current message without notifying the chat's members; see bot.Pin for the loud one.
+func (ctx *Context) Pin() error { return ctx.Bot.PinSilently(tgo.ID(ctx.Chat.Id), ctx.MessageId) }
+
+// Unpin unpins the current message.
+func (ctx *Context) Unpin() error { return ctx.Bot.Unpin(tgo.ID(ctx.Chat.Id), ctx.MessageId) }
+
+// WithTyping shows the typing action in the current chat, and its forum topic, while the work runs;
+// see bot.WithChatAction.
+func (ctx *Context) WithTyping(work func() error) error {
+	return ctx.WithChatAction(tgo.ChatActionTyping, work)
+}
+
+// WithChatAction shows the chat action, such as tgo.ChatActionUploadPhoto, in the current chat,
+// and its forum topic, while the work runs; see bot.WithChatAction.
+func (ctx *Context) WithChatAction(action string, work func() error) error {
+	var threadID int64
+	if ctx.IsTopicMessage {
+		threadID = ctx.MessageThreadId
+	}
+
+	return ctx.Bot.WithChatAction(tgo.ID(ctx.Chat.Id), threadID, action, work)
+}
+
 // Ask asks a question from the message's sender and waits for the passed timeout for their response.
 func (ctx *Context) Ask(msg tgo.Sendable, timeout time.Duration) (question, answer *Context, err error) {
 	cid, sid := tgo.GetChatAndSenderID(ctx.Message)
@@ -77,8 +164,94 @@ func (ctx *Context) Ask(msg tgo.Sendable, timeout time.Duration) (question, answ
 	return question, answer, err
 }
 
+// Conversation returns a conversation with the message's sender in the current chat,
+// whose questions are sent to the forum topic of the current message.
+func (ctx *Context) Conversation() *tgo.Conversation {
+	conv := ctx.Bot.Conversation(tgo.GetChatAndSenderID(ctx.Message))
+	if ctx.IsTopicMessage {
+		conv.ThreadID = ctx.MessageThreadId
+	}
+	return conv
+}
+
 // Delete deletes the received message.
 func (ctx *Context) Delete() error {
 	_, err := ctx.Bot.DeleteMessage(&tgo.DeleteMessage{ChatId: tgo.ID(ctx.Chat.Id), MessageId: ctx.MessageId})
 	return err
 }
+
+// React sets the emojis, such as "👍", as the bot's reactions on the received message; no emojis removes them.
+func (ctx *Context) React(emojis ...string) error {
+	return ctx.Bot.React(tgo.ID(ctx.Chat.Id), ctx.MessageId, emojis...)
+}
+
+// ErrAnonymousSender is returned by the moderation helpers when the message's sender can't be
+// identified, as it's sent by an anonymous administrator on behalf of the group itself.
+var ErrAnonymousSender = errors.New("message: the sender is an anonymous administrator")
+
+// ErrSenderChatCantBeMuted is returned by MuteSender for the messages sent on behalf of a channel,
+// as the channels can only be banned.
+var ErrSenderChatCantBeMuted = errors.New("message: the sender chat can't be muted")
+
+// BanSender bans the message's sender from the chat for the passed duration, or forever if it's zero.
+// If the message is sent on behalf of a channel, the channel is banned forever instead.
+// It returns ErrAnonymousSender for the messages of the anonymous administrators.
+func (ctx *Context) BanSender(duration time.Duration) error {
+	if ctx.SenderChat != nil {
+		if ctx.SenderChat.Id == ctx.Chat.Id {
+			return ErrAnonymousSender
+		}
+
+		_, err := ctx.Bot.BanChatSenderChat(&tgo.BanChatSenderChat{ChatId: tgo.ID(ctx.Chat.Id), SenderChatId: ctx.SenderChat.Id})
+		return err
+	}
+
+	return ctx.Bot.BanUser(tgo.ID(ctx.Chat.Id), ctx.From.Id, duration)
+}
+
+// MuteSender prevents the message's sender from sending anything to the chat for the passed duration, or forever if it's zero.
+// It returns ErrAnonymousSender for the messages of the anonymous administrators, and ErrSenderChatCantBeMuted
+// for the ones sent on behalf of a channel; use BanSender for them instead.
+func (ctx *Context) MuteSender(duration time.Duration) error {
+	if ctx.SenderChat != nil {
+		if ctx.SenderChat.Id == ctx.Chat.Id {
+			return ErrAnonymousSender
+		}
+		return ErrSenderChatCantBeMuted
+	}
+
+	return ctx.Bot.MuteUser(tgo.ID(ctx.Chat.Id), ctx.From.Id, duration)
+}
+
+// UnrestrictUser lifts all of the user's restrictions in the chat.
+func (ctx *Context) UnrestrictUser(userID int64) error {
+	return ctx.Bot.UnrestrictUser(tgo.ID(ctx.Chat.Id), userID)
+}
+
+// T translates the key into the sender's language using the bot's translator.
+func (ctx *Context) T(key string, args ...any) string {
+	var language string
+	if ctx.From != nil {
+		language = ctx.From.LanguageCode
+	}
+
+	return ctx.Bot.Translate(language, key, args...)
+}
+
+// ReplyTemplate replies to the current message with the translation of the key in the sender's language.
+func (ctx *Context) ReplyTemplate(key string, args ...any) (*tgo.Message, error) {
+	return ctx.Reply(&tgo.SendMessage{Text: ctx.T(key, args...)})
+}
+
+// Matches returns the capture groups of the RegexCapture filter which the update passed, or nil.
+// The first one is the whole match.
+func (ctx *Context) Matches() []string { return filters.Matches(ctx.Update) }
+
+// NamedMatch returns the text of the named capture group of the RegexCapture filter which the update passed.
+func (ctx *Context) NamedMatch(name string) string { return filters.NamedMatches(ctx.Update)[name] }
+
+// StartParam returns the deep-link parameter, without the prefix, of the StartPayload filter which the update passed.
+func (ctx *Context) StartParam() string { return filters.StartParam(ctx.Update) }
+
+// IsChannelPost returns true if the message is a new or edited channel post; see Router.HandleChannelPosts.
+func (ctx *Context) IsChannelPost() bool { return ctx.Update != nil && ctx.Update.IsChannelPost() }
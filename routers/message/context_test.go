@@ -0,0 +1,37 @@
+package message
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/filters"
+)
+
+func TestContextValues(t *testing.T) {
+	parsed := filters.NewFilter(func(update *tgo.Update) bool {
+		tgo.SetUpdateValue(update, "args", strings.Fields(update.Message.Text)[1:])
+		return true
+	})
+
+	var args []string
+	router := NewRouter(func(ctx *Context) bool {
+		ctx.Set("checked", true)
+		return true
+	})
+	router.Handle(parsed, func(ctx *Context) {
+		if checked, _ := ctx.Get("checked"); checked != true {
+			t.Error("the middleware's value isn't passed to the handler")
+		}
+		value, _ := ctx.Get("args")
+		args, _ = value.([]string)
+	})
+
+	update := &tgo.Update{Message: &tgo.Message{Text: "/ban 42 spam"}}
+	defer tgo.ForgetUpdate(update)
+	router.HandleUpdate(nil, update)
+
+	if strings.Join(args, ",") != "42,spam" {
+		t.Errorf("expected the filter's args to be passed to the handler, got %q", args)
+	}
+}
@@ -0,0 +1,95 @@
+package message
+
+import (
+	"errors"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/filters"
+)
+
+// ErrEmptyRouteName is returned for the named routes and groups without a name.
+var ErrEmptyRouteName = errors.New("message: the route's name is empty")
+
+// HandleNamed adds a new route of the name to the Router, or replaces the route of the name in its
+// place; it may be removed by Remove at any time, such as for the feature-flagged behaviors:
+//
+//	router.HandleNamed("promo", filters.Text("/promo"), handlePromo)
+//	// once the promotion is over:
+//	router.Remove("promo")
+//
+// The routes may be added, replaced, and removed while the updates are being handled; the ones
+// being handled aren't affected. It fails with ErrEmptyRouteName if the name is empty.
+func (r *Router) HandleNamed(name string, filter tgo.Filter, handler Handler, middlewares ...Middleware) error {
+	return r.putRoute(Route{filter: filter, middlewares: middlewares, handler: handler, name: name})
+}
+
+// Group returns a sub-router of the name, whose routes handle the updates after the router's own
+// middlewares and the passed ones; all of them may be removed by Remove at once. It's checked in the
+// order it's added, and replaces the route or group of the name in its place. It fails with
+// ErrEmptyRouteName if the name is empty.
+func (r *Router) Group(name string, middlewares ...Middleware) (*Router, error) {
+	sub := NewRouter(append(append([]Middleware(nil), r.middlewares...), middlewares...)...)
+	sub.category = r.category
+
+	if err := r.putRoute(Route{filter: filters.True(), router: sub, name: name}); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// Remove removes the route or the group of the name, and reports whether there was one.
+func (r *Router) Remove(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	r.routesMut.Lock()
+	defer r.routesMut.Unlock()
+
+	for i, route := range r.routes {
+		if route.name == name {
+			r.routes = append(append(make([]Route, 0, len(r.routes)-1), r.routes[:i]...), r.routes[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Has reports whether the router has a route or a group of the name.
+func (r *Router) Has(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for _, route := range r.getRoutes() {
+		if route.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// putRoute replaces the route of the same name by the route, keeping its priority, or adds it if
+// there's none, at once.
+func (r *Router) putRoute(route Route) error {
+	if route.name == "" {
+		return ErrEmptyRouteName
+	}
+
+	r.routesMut.Lock()
+	defer r.routesMut.Unlock()
+
+	for i, old := range r.routes {
+		if old.name == route.name {
+			route.priority = old.priority
+
+			routes := append([]Route(nil), r.routes...)
+			routes[i] = route
+			r.routes = routes
+			return nil
+		}
+	}
+
+	r.insertRoute(route)
+	return nil
+}
@@ -0,0 +1,96 @@
+package message
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/filters"
+)
+
+func TestNamedRoutes(t *testing.T) {
+	var handled string
+	record := func(name string) Handler { return func(ctx *Context) { handled = name } }
+
+	router := NewRouter()
+	router.HandleNamed("promo", filters.True(), record("promo"))
+	router.Handle(filters.True(), record("fallback"))
+
+	handle := func() string {
+		handled = ""
+		router.HandleUpdate(nil, &tgo.Update{Message: &tgo.Message{}})
+		return handled
+	}
+
+	if got := handle(); got != "promo" {
+		t.Fatalf("expected the promo route to handle the update, got %q", got)
+	}
+
+	router.HandleNamed("promo", filters.True(), record("new promo"))
+	if got := handle(); got != "new promo" {
+		t.Fatalf("expected the replaced route to handle the update in its place, got %q", got)
+	}
+
+	if !router.Remove("promo") || router.Remove("promo") || router.Has("promo") {
+		t.Fatal("the route isn't removed once")
+	}
+	if got := handle(); got != "fallback" {
+		t.Fatalf("expected the fallback route to handle the update, got %q", got)
+	}
+
+	group, err := router.Group("beta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	group.Handle(filters.True(), record("beta"))
+	if got := handle(); got != "fallback" {
+		t.Fatalf("expected the group added after the fallback not to handle the update, got %q", got)
+	}
+
+	if err := router.HandleNamed("", filters.True(), record("unnamed")); !errors.Is(err, ErrEmptyRouteName) {
+		t.Fatalf("the empty name is accepted with %v", err)
+	}
+	if router.Remove("") {
+		t.Fatal("the empty name removed an unnamed route")
+	}
+	if got := handle(); got != "fallback" {
+		t.Fatalf("expected the fallback route to be kept, got %q", got)
+	}
+
+	// the routes may change while the updates are being handled.
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			router.HandleUpdate(nil, &tgo.Update{Message: &tgo.Message{}})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			router.HandleNamed("flag", filters.False(), func(ctx *Context) {})
+			router.Remove("flag")
+		}
+	}()
+	wg.Wait()
+}
+
+func TestHandleNamedConcurrently(t *testing.T) {
+	router := NewRouter()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			router.HandleNamed("flag", filters.True(), func(ctx *Context) {})
+		}()
+	}
+	wg.Wait()
+
+	if n := len(router.getRoutes()); n != 1 {
+		t.Errorf("expected a single route of the name, got %d", n)
+	}
+}
@@ -0,0 +1,53 @@
+package message
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/filters"
+)
+
+func TestPriorityAndPropagation(t *testing.T) {
+	var handled []string
+	record := func(name string) Handler { return func(ctx *Context) { handled = append(handled, name) } }
+
+	router := NewRouter()
+	router.Handle(filters.True(), record("first"))
+	router.HandlePriority(10, filters.True(), func(ctx *Context) {
+		handled = append(handled, "audit")
+		ctx.Next()
+	})
+	router.HandlePriority(5, filters.True(), record("skipped"), func(ctx *Context) bool {
+		ctx.Next()
+		return false
+	})
+	router.Handle(filters.True(), record("second"))
+
+	handle := func() bool {
+		handled = nil
+		return router.HandleUpdate(nil, &tgo.Update{Message: &tgo.Message{Text: "hi"}})
+	}
+
+	if !handle() || strings.Join(handled, ",") != "audit,first" {
+		t.Errorf("unexpected handlers %q", handled)
+	}
+
+	// a route passing the update with nothing after it leaves the update to the bot's next routers.
+	passing := NewRouter()
+	passing.Handle(filters.True(), func(ctx *Context) { ctx.Next() })
+	if passing.HandleUpdate(nil, &tgo.Update{Message: &tgo.Message{}}) {
+		t.Error("the passed update is used")
+	}
+
+	// stopping the propagation overrides the middleware's Next.
+	stopping := NewRouter(func(ctx *Context) bool {
+		ctx.Next()
+		return true
+	})
+	stopping.Handle(filters.True(), func(ctx *Context) { ctx.StopPropagation() })
+	stopping.Handle(filters.True(), func(ctx *Context) { t.Error("the stopped update is handled again") })
+	if !stopping.HandleUpdate(nil, &tgo.Update{Message: &tgo.Message{}}) {
+		t.Error("the stopped update isn't used")
+	}
+}
@@ -1,6 +1,11 @@
 package message
 
-import "github.com/haashemi/tgo"
+import (
+	"sync"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/filters"
+)
 
 type Handler func(ctx *Context)
 
@@ -10,11 +15,20 @@ type Route struct {
 	filter      tgo.Filter
 	middlewares []Middleware
 	handler     Handler
+	router      *Router
+	command     *tgo.Command
+	priority    int
+	name        string
 }
 
 type Router struct {
-	middlewares []Middleware
-	routes      []Route
+	middlewares  []Middleware
+	channelPosts bool
+	category     string
+
+	// routes are replaced, not changed in place, so the updates being handled keep their own.
+	routes    []Route
+	routesMut sync.RWMutex
 }
 
 // NewRouter returns a new message router
@@ -24,41 +38,166 @@ func NewRouter(middlewares ...Middleware) *Router {
 	}
 }
 
+// HandleChannelPosts makes the router handle the new and edited channel posts too, through the
+// same routes as the messages; use ctx.IsChannelPost() or the filters to tell them apart.
+func (r *Router) HandleChannelPosts() *Router {
+	r.channelPosts = true
+	return r
+}
+
 // Handle adds a new route to the Router
 func (r *Router) Handle(filter tgo.Filter, handler Handler, middlewares ...Middleware) {
-	r.routes = append(r.routes, Route{filter: filter, middlewares: middlewares, handler: handler})
+	r.addRoute(Route{filter: filter, middlewares: middlewares, handler: handler})
+}
+
+// HandlePriority adds a new route of the priority to the Router. The routes are checked by their
+// priorities, the highest first, and the ones of the same priority in the order they're added; the
+// routes added by Handle, Command, and Topic have the zero priority.
+func (r *Router) HandlePriority(priority int, filter tgo.Filter, handler Handler, middlewares ...Middleware) {
+	r.addRoute(Route{filter: filter, middlewares: middlewares, handler: handler, priority: priority})
+}
+
+// addRoute adds the route after the ones of its priority and the higher ones.
+func (r *Router) addRoute(route Route) {
+	r.routesMut.Lock()
+	defer r.routesMut.Unlock()
+
+	r.insertRoute(route)
+}
+
+// insertRoute adds the route as addRoute, with the routes already locked.
+func (r *Router) insertRoute(route Route) {
+	i := len(r.routes)
+	for i > 0 && r.routes[i-1].priority < route.priority {
+		i--
+	}
+
+	routes := make([]Route, 0, len(r.routes)+1)
+	routes = append(append(append(routes, r.routes[:i]...), route), r.routes[i:]...)
+	r.routes = routes
+}
+
+// getRoutes returns the current routes, which mustn't be changed.
+func (r *Router) getRoutes() []Route {
+	r.routesMut.RLock()
+	defer r.routesMut.RUnlock()
+	return r.routes
+}
+
+// OnMessagePinned adds a route handling the service messages about a message pinned in the chat,
+// which is ctx.PinnedMessage.
+func (r *Router) OnMessagePinned(handler Handler, middlewares ...Middleware) {
+	r.Handle(filters.MessagePinned(), handler, middlewares...)
+}
+
+// Topic returns a sub-router whose routes only handle the updates of the forum topic with the
+// thread ID, after the router's own middlewares and the passed ones. It's checked in the order it's added.
+func (r *Router) Topic(threadID int64, middlewares ...Middleware) *Router {
+	sub := NewRouter(append(append([]Middleware(nil), r.middlewares...), middlewares...)...)
+	sub.category = r.category
+	r.addRoute(Route{filter: filters.TopicID(threadID), router: sub})
+
+	return sub
 }
 
 // Setup implements tgo.Router interface
-func (r *Router) Setup(bot *tgo.Bot) error { return nil }
+func (r *Router) Setup(bot *tgo.Bot) error {
+	if !r.hasCommands() {
+		return nil
+	}
 
-// HandleUpdate implements tgo.Router interface
+	me, err := bot.Me()
+	if err != nil {
+		return err
+	}
+
+	r.setupCommands(bot, me.Username)
+	return nil
+}
+
+// UpdateTypes implements tgo.UpdateTypesRouter interface
+func (r *Router) UpdateTypes() []string {
+	if r.channelPosts {
+		return []string{"message", "channel_post", "edited_channel_post"}
+	}
+	return []string{"message"}
+}
+
+// HandleUpdate implements tgo.Router interface. The update is handled by the first matching route,
+// unless its handler or middlewares call ctx.Next; then the next matching routes handle it too, and
+// if none of them stops it, the update is left to the bot's next routers.
 func (r *Router) HandleUpdate(bot *tgo.Bot, upd *tgo.Update) (used bool) {
-	if upd.Message == nil {
+	msg := upd.Message
+	if r.channelPosts && upd.IsChannelPost() {
+		msg = upd.EffectiveMessage()
+	}
+
+	if msg == nil {
 		return false
 	}
 
-	for _, route := range r.routes {
-		if !route.filter.Check(upd) {
+	// the matching routes share the context, so their middlewares may pass the data along.
+	ctx := &Context{Message: msg, Update: upd, Bot: bot}
+	return r.handle(ctx) == stopped
+}
+
+// propagation is the result of matching an update against the routes.
+type propagation int
+
+const (
+	unmatched propagation = iota // no route has handled the update
+	passed                       // the update is handled, and passed to the next matching routes by ctx.Next
+	stopped                      // the update is handled, and not passed to any other routes
+)
+
+// handle matches the update of the context against the routes, including the ones of the sub-routers.
+func (r *Router) handle(ctx *Context) propagation {
+	result := unmatched
+
+	for _, route := range r.getRoutes() {
+		if !route.filter.Check(ctx.Update) {
+			continue
+		} else if route.router != nil {
+			switch route.router.handle(ctx) {
+			case stopped:
+				return stopped
+			case passed:
+				result = passed
+			}
 			continue
 		}
 
-		ctx := &Context{Message: upd.Message, Bot: bot}
+		ctx.next, ctx.stopped = false, false
 
-		allMiddlewares := append(r.middlewares, route.middlewares...)
-		for _, middleware := range allMiddlewares {
-			if !middleware(ctx) {
-				// we used the update, but as the middleware is failed
-				// we'll stop the execution and return true as "update is used"
-				return true
+		if !r.runRoute(ctx, route) {
+			// we used the update, but as the middleware is failed
+			// we'll stop the execution and return true as "update is used",
+			// unless the middleware has passed it to the next routes.
+			if ctx.next && !ctx.stopped {
+				continue
 			}
+			return stopped
 		}
 
-		route.handler(ctx)
-
 		// filters passed and we used this method, so it's used!
-		return true
+		if !ctx.next || ctx.stopped {
+			return stopped
+		}
+		result = passed
+	}
+
+	return result
+}
+
+// runRoute runs the router's and the route's middlewares, and the route's handler if they all pass.
+func (r *Router) runRoute(ctx *Context, route Route) (ok bool) {
+	allMiddlewares := append(append([]Middleware(nil), r.middlewares...), route.middlewares...)
+	for _, middleware := range allMiddlewares {
+		if !middleware(ctx) {
+			return false
+		}
 	}
 
-	return false
+	route.handler(ctx)
+	return true
 }
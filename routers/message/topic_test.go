@@ -0,0 +1,23 @@
+package message
+
+import (
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/filters"
+)
+
+func TestTopic(t *testing.T) {
+	var handled []string
+
+	router := NewRouter()
+	router.Topic(7).Handle(filters.True(), func(ctx *Context) { handled = append(handled, "topic") })
+	router.Handle(filters.True(), func(ctx *Context) { handled = append(handled, "general") })
+
+	router.HandleUpdate(nil, &tgo.Update{Message: &tgo.Message{IsTopicMessage: true, MessageThreadId: 7}})
+	router.HandleUpdate(nil, &tgo.Update{Message: &tgo.Message{IsTopicMessage: true, MessageThreadId: 8}})
+
+	if len(handled) != 2 || handled[0] != "topic" || handled[1] != "general" {
+		t.Errorf("unexpected handlers: %q", handled)
+	}
+}
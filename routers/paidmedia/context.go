@@ -0,0 +1,47 @@
+package paidmedia
+
+import (
+	"sync"
+
+	"github.com/haashemi/tgo"
+)
+
+type Context struct {
+	// PaidMediaPurchased contains the raw received purchase, with the buyer in From
+	// and the bot-specified payload in PaidMediaPayload.
+	*tgo.PaidMediaPurchased
+
+	// Update is the update which the purchase is received in.
+	Update *tgo.Update
+
+	// Bot is the bot instance which got the update.
+	Bot *tgo.Bot
+
+	// Storage contains an in-context storage used for middlewares to pass some data
+	// to the next middleware or even the handler.
+	Storage sync.Map
+}
+
+// Set stores the value for the update under the key; see tgo.SetUpdateValue.
+func (ctx *Context) Set(key string, value any) { tgo.SetUpdateValue(ctx.Update, key, value) }
+
+// Get returns the value stored for the update under the key, by Set or by the filters.
+func (ctx *Context) Get(key string) (value any, ok bool) { return tgo.GetUpdateValue(ctx.Update, key) }
+
+// Session returns the buyer's session storage.
+func (ctx *Context) Session() *sync.Map {
+	return ctx.Bot.GetSession(ctx.From.Id)
+}
+
+// UserData returns the data bag of the buyer.
+func (ctx *Context) UserData() *tgo.Data { return ctx.Bot.UserData(ctx.From.Id) }
+
+// Send sends a message to the buyer in their private chat, with the preferred ParseMode.
+// It will set the target ChatId if not set.
+func (ctx *Context) Send(msg tgo.Sendable) (*tgo.Message, error) {
+	if msg.GetChatID() == nil {
+		msg.SetChatID(ctx.From.Id)
+	}
+
+	return ctx.Bot.Send(msg)
+}
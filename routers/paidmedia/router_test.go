@@ -0,0 +1,8 @@
+package paidmedia
+
+import "github.com/haashemi/tgo"
+
+// make sure that *Router implements the tgo.Router correctly.
+var TestRouter tgo.Router = &Router{}
+
+var TestUpdateTypesRouter tgo.UpdateTypesRouter = &Router{}
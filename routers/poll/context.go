@@ -0,0 +1,81 @@
+package poll
+
+import (
+	"sync"
+
+	"github.com/haashemi/tgo"
+)
+
+type Context struct {
+	// Poll contains the raw received poll state, if the update is a poll.
+	Poll *tgo.Poll
+
+	// Answer contains the raw received answer, if the update is a poll answer.
+	Answer *tgo.PollAnswer
+
+	// Update is the update which the poll or answer is received in.
+	Update *tgo.Update
+
+	// Bot is the bot instance which got the update.
+	Bot *tgo.Bot
+
+	// Storage contains an in-context storage used for middlewares to pass some data
+	// to the next middleware or even the handler.
+	Storage sync.Map
+
+	router *Router
+}
+
+// Set stores the value for the update under the key; see tgo.SetUpdateValue.
+func (ctx *Context) Set(key string, value any) { tgo.SetUpdateValue(ctx.Update, key, value) }
+
+// Get returns the value stored for the update under the key, by Set or by the filters.
+func (ctx *Context) Get(key string) (value any, ok bool) { return tgo.GetUpdateValue(ctx.Update, key) }
+
+// PollID returns the identifier of the poll.
+func (ctx *Context) PollID() string {
+	if ctx.Poll != nil {
+		return ctx.Poll.Id
+	}
+	return ctx.Answer.PollId
+}
+
+// Session returns the voter's session storage, or nil if it's not a poll answer.
+func (ctx *Context) Session() *sync.Map {
+	if ctx.Answer == nil {
+		return nil
+	} else if ctx.Answer.User != nil {
+		return ctx.Bot.GetSession(ctx.Answer.User.Id)
+	}
+	return ctx.Bot.GetSession(ctx.Answer.VoterChat.Id)
+}
+
+// UserData returns the data bag of the voter, or of the voter chat for the anonymous votes.
+// It's nil for the polls.
+func (ctx *Context) UserData() *tgo.Data {
+	if ctx.Answer == nil {
+		return nil
+	} else if ctx.Answer.User != nil {
+		return ctx.Bot.UserData(ctx.Answer.User.Id)
+	}
+	return ctx.Bot.UserData(ctx.Answer.VoterChat.Id)
+}
+
+// Tally returns the number of the voters of each option. For the polls, it's the counts sent by telegram;
+// for the answers, it's counted from the answers received by the router from the poll's start.
+func (ctx *Context) Tally() []int64 {
+	if ctx.Poll != nil {
+		tally := make([]int64, len(ctx.Poll.Options))
+		for i, option := range ctx.Poll.Options {
+			tally[i] = option.VoterCount
+		}
+		return tally
+	}
+
+	return ctx.router.tally(ctx.Answer.PollId)
+}
+
+// Stop stops the poll, if it's sent by Router.SendPoll.
+func (ctx *Context) Stop() (*tgo.Poll, error) {
+	return ctx.router.StopPoll(ctx.Bot, ctx.PollID())
+}
@@ -0,0 +1,177 @@
+package poll
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/filters"
+)
+
+var ErrUnknownPoll = errors.New("poll is not sent by the router")
+
+type Handler func(ctx *Context)
+
+type Middleware func(ctx *Context) (ok bool)
+
+type Route struct {
+	filter      tgo.Filter
+	middlewares []Middleware
+	handler     Handler
+}
+
+type Router struct {
+	middlewares []Middleware
+	routes      []Route
+
+	mut   sync.Mutex
+	polls map[string]*trackedPoll
+}
+
+// trackedPoll is a poll sent by the router, with the answers received for it.
+type trackedPoll struct {
+	chatID    int64
+	messageID int64
+	options   int
+	answers   map[int64][]int64 // voter id to the chosen options
+}
+
+// NewRouter returns a new poll router, which handles both the poll and poll_answer updates.
+func NewRouter(middlewares ...Middleware) *Router {
+	return &Router{
+		middlewares: middlewares,
+		polls:       make(map[string]*trackedPoll),
+	}
+}
+
+// Handle adds a new route to the Router
+func (r *Router) Handle(filter tgo.Filter, handler Handler, middlewares ...Middleware) {
+	r.routes = append(r.routes, Route{filter: filter, middlewares: middlewares, handler: handler})
+}
+
+// OnPoll adds a new route for the state changes of the polls, such as their vote counts and closing.
+func (r *Router) OnPoll(handler Handler, middlewares ...Middleware) {
+	r.Handle(filters.IsPoll(), handler, middlewares...)
+}
+
+// OnPollAnswer adds a new route for the answers of the non-anonymous polls.
+func (r *Router) OnPollAnswer(handler Handler, middlewares ...Middleware) {
+	r.Handle(filters.IsPollAnswer(), handler, middlewares...)
+}
+
+// SendPoll sends the poll, and keeps track of it to be stopped and tallied by the contexts.
+// It's forgotten once it's closed.
+func (r *Router) SendPoll(bot *tgo.Bot, params *tgo.SendPoll) (*tgo.Message, error) {
+	msg, err := bot.Send(params)
+	if err != nil || msg.Poll == nil {
+		return msg, err
+	}
+
+	r.mut.Lock()
+	r.polls[msg.Poll.Id] = &trackedPoll{chatID: msg.Chat.Id, messageID: msg.MessageId, options: len(params.Options), answers: make(map[int64][]int64)}
+	r.mut.Unlock()
+
+	return msg, nil
+}
+
+// StopPoll stops the poll sent by SendPoll.
+func (r *Router) StopPoll(bot *tgo.Bot, pollID string) (*tgo.Poll, error) {
+	r.mut.Lock()
+	poll, ok := r.polls[pollID]
+	r.mut.Unlock()
+
+	if !ok {
+		return nil, ErrUnknownPoll
+	}
+
+	return bot.StopPoll(&tgo.StopPoll{ChatId: tgo.ID(poll.chatID), MessageId: poll.messageID})
+}
+
+func (r *Router) tally(pollID string) []int64 {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	poll, ok := r.polls[pollID]
+	if !ok {
+		return nil
+	}
+
+	tally := make([]int64, poll.options)
+	for _, options := range poll.answers {
+		for _, option := range options {
+			if option >= 0 && option < int64(len(tally)) {
+				tally[option]++
+			}
+		}
+	}
+
+	return tally
+}
+
+// track records the answer of, or forgets, the polls sent by the router.
+func (r *Router) track(upd *tgo.Update) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	if upd.Poll != nil && upd.Poll.IsClosed {
+		delete(r.polls, upd.Poll.Id)
+	} else if answer := upd.PollAnswer; answer != nil {
+		poll, ok := r.polls[answer.PollId]
+		if !ok {
+			return
+		}
+
+		var voter int64
+		if answer.User != nil {
+			voter = answer.User.Id
+		} else if answer.VoterChat != nil {
+			voter = answer.VoterChat.Id
+		}
+
+		// the answers with no options are the retractions.
+		if len(answer.OptionIds) == 0 {
+			delete(poll.answers, voter)
+		} else {
+			poll.answers[voter] = answer.OptionIds
+		}
+	}
+}
+
+// Setup implements tgo.Router interface
+func (r *Router) Setup(bot *tgo.Bot) error { return nil }
+
+// UpdateTypes implements tgo.UpdateTypesRouter interface
+func (r *Router) UpdateTypes() []string { return []string{"poll", "poll_answer"} }
+
+// HandleUpdate implements tgo.Router interface
+func (r *Router) HandleUpdate(bot *tgo.Bot, upd *tgo.Update) (used bool) {
+	if upd.Poll == nil && upd.PollAnswer == nil {
+		return false
+	}
+
+	r.track(upd)
+
+	for _, route := range r.routes {
+		if !route.filter.Check(upd) {
+			continue
+		}
+
+		ctx := &Context{Poll: upd.Poll, Answer: upd.PollAnswer, Update: upd, Bot: bot, router: r}
+
+		allMiddlewares := append(append([]Middleware(nil), r.middlewares...), route.middlewares...)
+		for _, middleware := range allMiddlewares {
+			if !middleware(ctx) {
+				// we used the update, but as the middleware is failed
+				// we'll stop the execution and return true as "update is used"
+				return true
+			}
+		}
+
+		route.handler(ctx)
+
+		// filters passed and we used this method, so it's used!
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,105 @@
+package reaction
+
+import (
+	"sync"
+
+	"github.com/haashemi/tgo"
+)
+
+type Context struct {
+	// Reaction contains the raw received reaction change, if the update is a message_reaction.
+	Reaction *tgo.MessageReactionUpdated
+
+	// Count contains the raw received reaction counts, if the update is a message_reaction_count.
+	Count *tgo.MessageReactionCountUpdated
+
+	// Update is the update which the reactions are received in.
+	Update *tgo.Update
+
+	// Bot is the bot instance which got the update.
+	Bot *tgo.Bot
+
+	// Storage contains an in-context storage used for middlewares to pass some data
+	// to the next middleware or even the handler.
+	Storage sync.Map
+}
+
+// Set stores the value for the update under the key; see tgo.SetUpdateValue.
+func (ctx *Context) Set(key string, value any) { tgo.SetUpdateValue(ctx.Update, key, value) }
+
+// Get returns the value stored for the update under the key, by Set or by the filters.
+func (ctx *Context) Get(key string) (value any, ok bool) { return tgo.GetUpdateValue(ctx.Update, key) }
+
+// Chat returns the chat of the reacted message.
+func (ctx *Context) Chat() *tgo.Chat {
+	if ctx.Reaction != nil {
+		return &ctx.Reaction.Chat
+	}
+	return &ctx.Count.Chat
+}
+
+// MessageID returns the identifier of the reacted message.
+func (ctx *Context) MessageID() int64 {
+	if ctx.Reaction != nil {
+		return ctx.Reaction.MessageId
+	}
+	return ctx.Count.MessageId
+}
+
+// Added returns the reactions which the user has added, or nil for the reaction counts.
+func (ctx *Context) Added() []tgo.ReactionType {
+	if ctx.Reaction == nil {
+		return nil
+	}
+	return ctx.Reaction.Added()
+}
+
+// Removed returns the reactions which the user has removed, or nil for the reaction counts.
+func (ctx *Context) Removed() []tgo.ReactionType {
+	if ctx.Reaction == nil {
+		return nil
+	}
+	return ctx.Reaction.Removed()
+}
+
+// Session returns the session storage of the user who reacted, or of the actor chat for the anonymous
+// reactions. It's the chat's session for the reaction counts.
+func (ctx *Context) Session() *sync.Map {
+	switch {
+	case ctx.Reaction != nil && ctx.Reaction.User != nil:
+		return ctx.Bot.GetSession(ctx.Reaction.User.Id)
+	case ctx.Reaction != nil && ctx.Reaction.ActorChat != nil:
+		return ctx.Bot.GetSession(ctx.Reaction.ActorChat.Id)
+	}
+	return ctx.Bot.GetSession(ctx.Chat().Id)
+}
+
+// UserData returns the data bag of the user who reacted, or of the actor chat for the anonymous
+// reactions. It's nil for the reaction counts.
+func (ctx *Context) UserData() *tgo.Data {
+	switch {
+	case ctx.Reaction != nil && ctx.Reaction.User != nil:
+		return ctx.Bot.UserData(ctx.Reaction.User.Id)
+	case ctx.Reaction != nil && ctx.Reaction.ActorChat != nil:
+		return ctx.Bot.UserData(ctx.Reaction.ActorChat.Id)
+	}
+	return nil
+}
+
+// ChatData returns the data bag of the reacted message's chat.
+func (ctx *Context) ChatData() *tgo.Data { return ctx.Bot.ChatData(ctx.Chat().Id) }
+
+// React sets the emojis as the bot's reactions on the reacted message; no emojis removes them.
+func (ctx *Context) React(emojis ...string) error {
+	return ctx.Bot.React(tgo.ID(ctx.Chat().Id), ctx.MessageID(), emojis...)
+}
+
+// Send sends a message into the chat of the reacted message with the preferred ParseMode.
+// It will set the target ChatId if not set.
+func (ctx *Context) Send(msg tgo.Sendable) (*tgo.Message, error) {
+	if msg.GetChatID() == nil {
+		msg.SetChatID(ctx.Chat().Id)
+	}
+
+	return ctx.Bot.Send(msg)
+}
@@ -0,0 +1,86 @@
+package reaction
+
+import (
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/filters"
+)
+
+type Handler func(ctx *Context)
+
+type Middleware func(ctx *Context) (ok bool)
+
+type Route struct {
+	filter      tgo.Filter
+	middlewares []Middleware
+	handler     Handler
+}
+
+type Router struct {
+	middlewares []Middleware
+	routes      []Route
+}
+
+// NewRouter returns a new reaction router, which handles both the message_reaction
+// and message_reaction_count updates.
+//
+// Note: the bot must be an administrator in the chat and explicitly specify them
+// in the list of allowed updates to receive these updates.
+func NewRouter(middlewares ...Middleware) *Router {
+	return &Router{
+		middlewares: middlewares,
+	}
+}
+
+// Handle adds a new route to the Router
+func (r *Router) Handle(filter tgo.Filter, handler Handler, middlewares ...Middleware) {
+	r.routes = append(r.routes, Route{filter: filter, middlewares: middlewares, handler: handler})
+}
+
+// OnMessageReaction adds a new route for the reaction changes of the users.
+func (r *Router) OnMessageReaction(handler Handler, middlewares ...Middleware) {
+	r.Handle(filters.IsMessageReaction(), handler, middlewares...)
+}
+
+// OnMessageReactionCount adds a new route for the reaction counts of the messages with anonymous reactions.
+func (r *Router) OnMessageReactionCount(handler Handler, middlewares ...Middleware) {
+	r.Handle(filters.IsMessageReactionCount(), handler, middlewares...)
+}
+
+// Setup implements tgo.Router interface
+func (r *Router) Setup(bot *tgo.Bot) error { return nil }
+
+// UpdateTypes implements tgo.UpdateTypesRouter interface
+func (r *Router) UpdateTypes() []string {
+	return []string{"message_reaction", "message_reaction_count"}
+}
+
+// HandleUpdate implements tgo.Router interface
+func (r *Router) HandleUpdate(bot *tgo.Bot, upd *tgo.Update) (used bool) {
+	if upd.MessageReaction == nil && upd.MessageReactionCount == nil {
+		return false
+	}
+
+	for _, route := range r.routes {
+		if !route.filter.Check(upd) {
+			continue
+		}
+
+		ctx := &Context{Reaction: upd.MessageReaction, Count: upd.MessageReactionCount, Update: upd, Bot: bot}
+
+		allMiddlewares := append(append([]Middleware(nil), r.middlewares...), route.middlewares...)
+		for _, middleware := range allMiddlewares {
+			if !middleware(ctx) {
+				// we used the update, but as the middleware is failed
+				// we'll stop the execution and return true as "update is used"
+				return true
+			}
+		}
+
+		route.handler(ctx)
+
+		// filters passed and we used this method, so it's used!
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,330 @@
+package tgo
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrNoScheduler    = errors.New("tgo: scheduler is not started; call bot.StartScheduler first")
+	ErrScheduleUpload = errors.New("tgo: messages with uploaded files can't be scheduled; use file ids or urls")
+)
+
+// Job is an API call scheduled to be made later.
+type Job struct {
+	ID       string          `json:"id"`
+	At       time.Time       `json:"at"`       // when the call should be made
+	Method   string          `json:"method"`   // the API method, such as "sendMessage"
+	Params   json.RawMessage `json:"params"`   // the JSON-encoded parameters of the method
+	Attempts int             `json:"attempts"` // the number of the failed attempts so far
+}
+
+// JobStore persists the scheduled jobs. Implement it to keep the jobs across the restarts;
+// see contrib/redisstore for a Redis one.
+type JobStore interface {
+	// Save adds the job, or replaces the one with the same ID.
+	Save(job *Job) error
+
+	// Due returns the jobs which should be run at now, the earliest first.
+	Due(now time.Time) ([]*Job, error)
+
+	// Delete removes the job; it's not an error if the job doesn't exist.
+	Delete(id string) error
+}
+
+// MemoryJobStore is an in-memory JobStore; the jobs are lost when the program exits.
+type MemoryJobStore struct {
+	mut  sync.Mutex
+	jobs map[string]*Job
+}
+
+// Save implements the JobStore interface.
+func (s *MemoryJobStore) Save(job *Job) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if s.jobs == nil {
+		s.jobs = make(map[string]*Job)
+	}
+
+	copied := *job
+	s.jobs[job.ID] = &copied
+	return nil
+}
+
+// Due implements the JobStore interface.
+func (s *MemoryJobStore) Due(now time.Time) ([]*Job, error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	var due []*Job
+	for _, job := range s.jobs {
+		if !job.At.After(now) {
+			copied := *job
+			due = append(due, &copied)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool { return due[i].At.Before(due[j].At) })
+	return due, nil
+}
+
+// Delete implements the JobStore interface.
+func (s *MemoryJobStore) Delete(id string) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	delete(s.jobs, id)
+	return nil
+}
+
+// SchedulerOptions configures the bot's scheduler. The zero value is valid and uses the defaults.
+type SchedulerOptions struct {
+	// Store persists the jobs; it defaults to a MemoryJobStore.
+	Store JobStore
+
+	// Interval is how often the store is checked for the due jobs; it defaults to 1 second.
+	Interval time.Duration
+
+	// MaxAttempts is the number of the times a job is tried before it's given up; it defaults to 3.
+	// Only the network errors, rate limits, and telegram's server errors are retried.
+	MaxAttempts int
+
+	// RetryDelay is the delay before the first retry, which is doubled for the next ones; it defaults to 10 seconds.
+	// The rate-limited jobs are retried after the duration telegram asks for instead.
+	RetryDelay time.Duration
+
+	// OnError, if not nil, is called with the jobs which are given up, and with the store's errors with a nil job.
+	// They're logged by default.
+	OnError func(job *Job, err error)
+}
+
+// Scheduler runs the jobs scheduled by bot.SendLater, bot.SendAfter and Schedule when they're due.
+type Scheduler struct {
+	api  *API
+	opts SchedulerOptions
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// StartScheduler starts the bot's scheduler in a new goroutine, which runs until its Stop method is called.
+// Start it once, before the handlers schedule any jobs.
+func (bot *Bot) StartScheduler(opts SchedulerOptions) *Scheduler {
+	if opts.Store == nil {
+		opts.Store = &MemoryJobStore{}
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = time.Second
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 3
+	}
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = 10 * time.Second
+	}
+
+	s := &Scheduler{api: bot.API, opts: opts, stop: make(chan struct{}), done: make(chan struct{})}
+	go s.run()
+
+	bot.schedulerMut.Lock()
+	bot.scheduler = s
+	bot.schedulerMut.Unlock()
+
+	return s
+}
+
+// Scheduler returns the bot's scheduler, or nil if it's not started.
+func (bot *Bot) Scheduler() *Scheduler {
+	bot.schedulerMut.RLock()
+	defer bot.schedulerMut.RUnlock()
+
+	return bot.scheduler
+}
+
+// SendLater schedules the message to be sent at the passed time, and returns the job's id.
+// The messages uploading files can't be scheduled, as they can't be persisted.
+func (bot *Bot) SendLater(msg Sendable, at time.Time) (string, error) {
+	s := bot.Scheduler()
+	if s == nil {
+		return "", ErrNoScheduler
+	}
+
+	if x, ok := msg.(interface{ getFiles() map[string]*InputFile }); ok && len(x.getFiles()) != 0 {
+		return "", ErrScheduleUpload
+	}
+
+	if x, ok := msg.(ParseModeSettable); ok && x.GetParseMode() == ParseModeNone {
+		x.SetParseMode(bot.DefaultParseMode)
+	}
+	if err := bot.shrinkSendable(msg); err != nil {
+		return "", err
+	}
+
+	return s.Schedule(sendableMethod(msg), msg, at)
+}
+
+// SendAfter schedules the message to be sent after the passed duration; see SendLater.
+func (bot *Bot) SendAfter(msg Sendable, d time.Duration) (string, error) {
+	return bot.SendLater(msg, time.Now().Add(d))
+}
+
+// DeleteAfter schedules the message to be deleted after the passed duration, such as the temporary
+// notifications in the groups, and returns the job's id. It's not an error if the message is already
+// deleted by then.
+func (bot *Bot) DeleteAfter(chatID ChatID, messageID int64, d time.Duration) (string, error) {
+	s := bot.Scheduler()
+	if s == nil {
+		return "", ErrNoScheduler
+	}
+
+	return s.Schedule("deleteMessage", &DeleteMessage{ChatId: chatID, MessageId: messageID}, time.Now().Add(d))
+}
+
+// CancelJob cancels the scheduled job by its id.
+func (bot *Bot) CancelJob(id string) error {
+	s := bot.Scheduler()
+	if s == nil {
+		return ErrNoScheduler
+	}
+
+	return s.Cancel(id)
+}
+
+// Schedule schedules the API method to be called with the JSON-encoded params at the passed time,
+// and returns the job's id.
+func (s *Scheduler) Schedule(method string, params any, at time.Time) (string, error) {
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return "", err
+	}
+
+	var id [16]byte
+	if _, err = rand.Read(id[:]); err != nil {
+		return "", err
+	}
+
+	job := &Job{ID: hex.EncodeToString(id[:]), At: at, Method: method, Params: encoded}
+	return job.ID, s.opts.Store.Save(job)
+}
+
+// Cancel cancels the scheduled job by its id.
+func (s *Scheduler) Cancel(id string) error { return s.opts.Store.Delete(id) }
+
+// Stop stops the scheduler and waits for the running jobs to finish. The remaining jobs are
+// kept in the store, to be run by the next scheduler using it.
+func (s *Scheduler) Stop() {
+	s.once.Do(func() { close(s.stop) })
+	<-s.done
+}
+
+func (s *Scheduler) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case now := <-ticker.C:
+			s.runDue(now)
+		}
+	}
+}
+
+// runDue runs the due jobs, and reschedules the ones which should be retried.
+func (s *Scheduler) runDue(now time.Time) {
+	jobs, err := s.opts.Store.Due(now)
+	if err != nil {
+		s.fail(nil, err)
+		return
+	}
+
+	for _, job := range jobs {
+		select {
+		case <-s.stop:
+			return
+		default:
+		}
+
+		_, err := callJson[json.RawMessage](s.api, job.Method, job.Params)
+		if err == nil || (job.Method == "deleteMessage" && errors.Is(err, ErrMessageToDeleteNotFound)) {
+			// the messages deleted by someone else are done too.
+			err = s.opts.Store.Delete(job.ID)
+		} else if delay, retry := s.retryDelay(job, err); retry {
+			job.Attempts++
+			job.At = time.Now().Add(delay)
+			s.api.log(LevelInfo, "scheduled job retry", "job", job.ID, "method", job.Method, "attempt", job.Attempts+1, "delay", delay)
+			if s.api.metrics != nil {
+				s.api.metrics.Retried(job.Method)
+			}
+			err = s.opts.Store.Save(job)
+		} else {
+			s.fail(job, err)
+			err = s.opts.Store.Delete(job.ID)
+		}
+
+		if err != nil {
+			s.fail(nil, err)
+		}
+	}
+}
+
+// retryDelay returns how long to wait before retrying the job, and whether it should be retried at all.
+func (s *Scheduler) retryDelay(job *Job, err error) (time.Duration, bool) {
+	return retryDelay(err, job.Attempts, s.opts.MaxAttempts, s.opts.RetryDelay)
+}
+
+// retryDelay returns how long to wait before retrying a call which has failed attempts times
+// by the err, and whether it should be retried at all. Only the network errors, rate limits,
+// and telegram's server errors are retried, after a delay starting from base which is doubled
+// for each attempt, or after the duration telegram asks for.
+func retryDelay(err error, attempts, maxAttempts int, base time.Duration) (time.Duration, bool) {
+	if attempts+1 >= maxAttempts {
+		return 0, false
+	}
+
+	// the messages to the muted chats are given up, rather than sent once the mute is over.
+	var muted *ChatMutedError
+	if errors.As(err, &muted) {
+		return 0, false
+	}
+
+	var flood *FloodError
+	if errors.As(err, &flood) && flood.RetryAfter > 0 {
+		return flood.RetryAfter, true
+	}
+
+	var tgErr *Error
+	if errors.As(err, &tgErr) && tgErr.ErrorCode < 500 {
+		return 0, false
+	}
+
+	return base << attempts, true
+}
+
+func (s *Scheduler) fail(job *Job, err error) {
+	if s.opts.OnError != nil {
+		s.opts.OnError(job, err)
+	} else if job != nil {
+		s.api.log(LevelError, "scheduled job failed", "job", job.ID, "method", job.Method, "attempts", job.Attempts+1, "error", err)
+	} else {
+		s.api.log(LevelError, "scheduler's store failed", "error", err)
+	}
+}
+
+// sendableMethod returns the API method of the sendable, such as "sendMessage" for *SendMessage.
+func sendableMethod(msg Sendable) string {
+	name := reflect.TypeOf(msg).Elem().Name()
+	return strings.ToLower(name[:1]) + name[1:]
+}
@@ -0,0 +1,98 @@
+package tgo_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestScheduler(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	// the first attempt fails with a server error, and gets retried.
+	var attempts int32
+	server.Handle("sendMessage", func(call tgotest.Call) (any, *tgo.Error) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			return nil, &tgo.Error{ErrorCode: 502, Description: "Bad Gateway"}
+		}
+		return map[string]any{"message_id": 1, "date": 1, "chat": map[string]any{"id": 1, "type": "private"}}, nil
+	})
+
+	bot := server.Bot(tgo.Options{})
+	store := &tgo.MemoryJobStore{}
+	scheduler := bot.StartScheduler(tgo.SchedulerOptions{Store: store, Interval: 5 * time.Millisecond, RetryDelay: time.Millisecond})
+	defer scheduler.Stop()
+
+	if _, err := bot.SendAfter(&tgo.SendMessage{ChatId: tgo.ID(1), Text: "reminder"}, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	canceled, _ := bot.SendAfter(&tgo.SendMessage{ChatId: tgo.ID(1), Text: "canceled"}, 20*time.Millisecond)
+	if err := bot.CancelJob(canceled); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(&attempts) < 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Errorf("got %d attempts, want 2", got)
+	}
+	if due, _ := store.Due(time.Now().Add(time.Hour)); len(due) != 0 {
+		t.Errorf("%d jobs are left in the store", len(due))
+	}
+}
+
+func TestDeleteAfter(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	deleted := make(chan float64, 2)
+	server.Handle("deleteMessage", func(call tgotest.Call) (any, *tgo.Error) {
+		deleted <- call.Params["message_id"].(float64)
+		if len(deleted) == 2 {
+			return nil, &tgo.Error{ErrorCode: 400, Description: "Bad Request: message to delete not found"}
+		}
+		return true, nil
+	})
+
+	bot := server.Bot(tgo.Options{})
+	if _, err := bot.DeleteAfter(tgo.ID(1), 10, 0); err != tgo.ErrNoScheduler {
+		t.Fatalf("got %v without a scheduler", err)
+	}
+
+	var failed int32
+	store := &tgo.MemoryJobStore{}
+	scheduler := bot.StartScheduler(tgo.SchedulerOptions{Store: store, Interval: 5 * time.Millisecond, OnError: func(job *tgo.Job, err error) {
+		atomic.AddInt32(&failed, 1)
+	}})
+	defer scheduler.Stop()
+
+	bot.DeleteAfter(tgo.ID(1), 10, 0)
+	bot.DeleteAfter(tgo.ID(1), 11, 10*time.Millisecond)
+
+	for _, want := range []float64{10, 11} {
+		select {
+		case id := <-deleted:
+			if id != want {
+				t.Fatalf("deleted the message %v, want %v", id, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("the message isn't deleted")
+		}
+	}
+
+	scheduler.Stop()
+	if atomic.LoadInt32(&failed) != 0 {
+		t.Error("the already deleted message failed the job")
+	} else if due, _ := store.Due(time.Now().Add(time.Hour)); len(due) != 0 {
+		t.Errorf("%d jobs are left in the store", len(due))
+	}
+}
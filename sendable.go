@@ -73,6 +73,7 @@ func (x *SendDocument) GetChatID() ChatID  { return x.ChatId }
 func (x *SendGame) GetChatID() ChatID      { return ID(x.ChatId) }
 func (x *SendInvoice) GetChatID() ChatID   { return x.ChatId }
 func (x *SendLocation) GetChatID() ChatID  { return x.ChatId }
+func (x *SendPaidMedia) GetChatID() ChatID { return x.ChatId }
 func (x *SendMessage) GetChatID() ChatID   { return x.ChatId }
 func (x *SendPhoto) GetChatID() ChatID     { return x.ChatId }
 func (x *SendPoll) GetChatID() ChatID      { return x.ChatId }
@@ -90,6 +91,7 @@ func (x *SendDocument) SetChatID(id int64)  { x.ChatId = ID(id) }
 func (x *SendGame) SetChatID(id int64)      { x.ChatId = id }
 func (x *SendInvoice) SetChatID(id int64)   { x.ChatId = ID(id) }
 func (x *SendLocation) SetChatID(id int64)  { x.ChatId = ID(id) }
+func (x *SendPaidMedia) SetChatID(id int64) { x.ChatId = ID(id) }
 func (x *SendMessage) SetChatID(id int64)   { x.ChatId = ID(id) }
 func (x *SendPhoto) SetChatID(id int64)     { x.ChatId = ID(id) }
 func (x *SendPoll) SetChatID(id int64)      { x.ChatId = ID(id) }
@@ -104,9 +106,10 @@ func (x *SendAudio) Send(api *API) (*Message, error)     { return api.SendAudio(
 func (x *SendContact) Send(api *API) (*Message, error)   { return api.SendContact(x) }
 func (x *SendDice) Send(api *API) (*Message, error)      { return api.SendDice(x) }
 func (x *SendDocument) Send(api *API) (*Message, error)  { return api.SendDocument(x) }
-func (x *SendGame) Send(api *API)                        { api.SendGame(x) }
+func (x *SendGame) Send(api *API) (*Message, error)      { return api.SendGame(x) }
 func (x *SendInvoice) Send(api *API) (*Message, error)   { return api.SendInvoice(x) }
 func (x *SendLocation) Send(api *API) (*Message, error)  { return api.SendLocation(x) }
+func (x *SendPaidMedia) Send(api *API) (*Message, error) { return api.SendPaidMedia(x) }
 func (x *SendMessage) Send(api *API) (*Message, error)   { return api.SendMessage(x) }
 func (x *SendPhoto) Send(api *API) (*Message, error)     { return api.SendPhoto(x) }
 func (x *SendPoll) Send(api *API) (*Message, error)      { return api.SendPoll(x) }
@@ -120,6 +123,7 @@ func (x *SendAnimation) GetParseMode() ParseMode { return x.ParseMode }
 func (x *SendAudio) GetParseMode() ParseMode     { return x.ParseMode }
 func (x *SendDocument) GetParseMode() ParseMode  { return x.ParseMode }
 func (x *SendMessage) GetParseMode() ParseMode   { return x.ParseMode }
+func (x *SendPaidMedia) GetParseMode() ParseMode { return x.ParseMode }
 func (x *SendPhoto) GetParseMode() ParseMode     { return x.ParseMode }
 func (x *SendVideo) GetParseMode() ParseMode     { return x.ParseMode }
 func (x *SendVoice) GetParseMode() ParseMode     { return x.ParseMode }
@@ -128,6 +132,7 @@ func (x *SendAnimation) SetParseMode(mode ParseMode) { x.ParseMode = mode }
 func (x *SendAudio) SetParseMode(mode ParseMode)     { x.ParseMode = mode }
 func (x *SendDocument) SetParseMode(mode ParseMode)  { x.ParseMode = mode }
 func (x *SendMessage) SetParseMode(mode ParseMode)   { x.ParseMode = mode }
+func (x *SendPaidMedia) SetParseMode(mode ParseMode) { x.ParseMode = mode }
 func (x *SendPhoto) SetParseMode(mode ParseMode)     { x.ParseMode = mode }
 func (x *SendVideo) SetParseMode(mode ParseMode)     { x.ParseMode = mode }
 func (x *SendVoice) SetParseMode(mode ParseMode)     { x.ParseMode = mode }
@@ -141,6 +146,7 @@ func (x *SendGame) SetReplyToMessageId(id int64)      { x.ReplyToMessageId = id
 func (x *SendInvoice) SetReplyToMessageId(id int64)   { x.ReplyToMessageId = id }
 func (x *SendLocation) SetReplyToMessageId(id int64)  { x.ReplyToMessageId = id }
 func (x *SendMessage) SetReplyToMessageId(id int64)   { x.ReplyToMessageId = id }
+func (x *SendPaidMedia) SetReplyToMessageId(id int64) { x.ReplyToMessageId = id }
 func (x *SendPhoto) SetReplyToMessageId(id int64)     { x.ReplyToMessageId = id }
 func (x *SendPoll) SetReplyToMessageId(id int64)      { x.ReplyToMessageId = id }
 func (x *SendSticker) SetReplyToMessageId(id int64)   { x.ReplyToMessageId = id }
@@ -150,12 +156,25 @@ func (x *SendVideoNote) SetReplyToMessageId(id int64) { x.ReplyToMessageId = id
 func (x *SendVoice) SetReplyToMessageId(id int64)     { x.ReplyToMessageId = id }
 
 // Send sends a message with the preferred ParseMode.
+//
+// If the bot is muted in the chat, it fails with a *ChatMutedError instead; see bot.MuteChat.
 func (b *Bot) Send(msg Sendable) (*Message, error) {
 	if x, ok := msg.(ParseModeSettable); ok {
 		if x.GetParseMode() == ParseModeNone {
 			x.SetParseMode(b.DefaultParseMode)
 		}
 	}
+	b.applyLinkPreview(msg)
+
+	if err := b.shrinkSendable(msg); err != nil {
+		return nil, err
+	}
+
+	cleanup, err := b.attachThumbnail(msg)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
 
 	return msg.Send(b.API)
 }
@@ -0,0 +1,146 @@
+package tgo
+
+import (
+	"context"
+	"sync"
+)
+
+// inflight tracks the pollers, webhook requests and handlers which are running, so bot.Shutdown can
+// wait for them. Once it's closed, no new pollers and webhook requests are let in.
+type inflight struct {
+	mut    sync.Mutex
+	n      int
+	idle   chan struct{}
+	closed bool
+}
+
+// enter adds a runner, unless it's closed.
+func (f *inflight) enter() bool {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+
+	if f.closed {
+		return false
+	}
+
+	f.add()
+	return true
+}
+
+// hold adds a runner even if it's closed; it's only called by the ones which have already entered,
+// such as the handlers of the updates fetched by a poller.
+func (f *inflight) hold() {
+	f.mut.Lock()
+	f.add()
+	f.mut.Unlock()
+}
+
+func (f *inflight) add() {
+	if f.n == 0 {
+		f.idle = make(chan struct{})
+	}
+	f.n++
+}
+
+func (f *inflight) done() {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+
+	if f.n--; f.n == 0 {
+		close(f.idle)
+	}
+}
+
+// close stops letting the new runners in, and waits for the running ones until the context is done.
+func (f *inflight) close(ctx context.Context) error {
+	f.mut.Lock()
+	f.closed = true
+	idle := f.idle
+	running := f.n != 0
+	f.mut.Unlock()
+
+	if !running {
+		return nil
+	}
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// OnShutdown registers the function to be called by bot.Shutdown after the handlers are done,
+// to flush the outgoing queues such as an outbox; it should return when the context is done.
+func (bot *Bot) OnShutdown(flush func(ctx context.Context) error) {
+	bot.shutdownMut.Lock()
+	bot.shutdownHooks = append(bot.shutdownHooks, flush)
+	bot.shutdownMut.Unlock()
+}
+
+// IsShuttingDown returns true if bot.Shutdown is called.
+func (bot *Bot) IsShuttingDown() bool {
+	select {
+	case <-bot.polling.Done():
+		return true
+	default:
+		return false
+	}
+}
+
+// Shutdown gracefully stops the bot:
+//
+//  1. The pollers stop fetching the updates and return, and the webhook handlers answer
+//     telegram with 503 Service Unavailable, so it sends the updates again later.
+//  2. It waits for the handlers of the updates which are already received.
+//  3. The dispatchers handle their queued updates, the scheduler is stopped, and the OnShutdown
+//     functions are called to flush their queues.
+//  4. If the handlers are all done, the offset of the polled updates is acknowledged to telegram,
+//     so they're not fetched again after a restart.
+//
+// It returns the context's error if it's done before all of it, leaving the rest running.
+func (bot *Bot) Shutdown(ctx context.Context) error {
+	bot.stopPolling()
+
+	if err := bot.inflight.close(ctx); err != nil {
+		return err
+	}
+
+	dispatchersDone := make(chan struct{})
+	go func() {
+		defer close(dispatchersDone)
+		bot.StopDispatchers()
+	}()
+
+	select {
+	case <-dispatchersDone:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if s := bot.Scheduler(); s != nil {
+		s.Stop()
+	}
+
+	bot.shutdownMut.Lock()
+	hooks := bot.shutdownHooks
+	bot.shutdownMut.Unlock()
+
+	var firstErr error
+	for _, flush := range hooks {
+		if err := flush(ctx); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if offset := bot.polledOffset.Load(); offset != 0 {
+		// fetching the updates from the offset confirms the ones before it; the fetched one is
+		// not confirmed yet, so it's fetched again after a restart.
+		if _, err := bot.GetUpdates(&GetUpdates{Offset: offset, Limit: 1}); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
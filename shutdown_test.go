@@ -0,0 +1,84 @@
+package tgo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+type slowRouter struct {
+	started chan struct{}
+	done    atomic.Bool
+}
+
+func (r *slowRouter) Setup(bot *tgo.Bot) error { return nil }
+
+func (r *slowRouter) HandleUpdate(bot *tgo.Bot, update *tgo.Update) bool {
+	close(r.started)
+	time.Sleep(50 * time.Millisecond)
+	r.done.Store(true)
+	return true
+}
+
+func TestShutdown(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	var polls atomic.Int64
+	server.Handle("getUpdates", func(call tgotest.Call) (any, *tgo.Error) {
+		if polls.Add(1) == 1 {
+			return []map[string]any{{"update_id": 5, "message": map[string]any{"message_id": 1, "date": 1, "chat": map[string]any{"id": 1, "type": "private"}}}}, nil
+		}
+		time.Sleep(20 * time.Millisecond)
+		return []any{}, nil
+	})
+
+	bot := server.Bot(tgo.Options{})
+	router := &slowRouter{started: make(chan struct{})}
+	bot.AddRouter(router)
+
+	polling := make(chan error, 1)
+	go func() { polling <- bot.StartPolling(1) }()
+
+	select {
+	case <-router.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the update is not handled")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := bot.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	} else if !router.done.Load() {
+		t.Fatal("shutdown didn't wait for the handler")
+	}
+
+	select {
+	case err := <-polling:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("polling didn't stop")
+	}
+
+	calls := server.Calls()
+	if ack := calls[len(calls)-1]; ack.Method != "getUpdates" || ack.Params["offset"] != float64(6) {
+		t.Fatalf("the offset is not acknowledged: %+v", ack)
+	}
+
+	recorder := httptest.NewRecorder()
+	bot.WebhookHandler(tgo.WebhookOptions{}).ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"update_id": 7}`)))
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Fatalf("the webhook accepted an update after shutdown with %d", recorder.Code)
+	}
+}
@@ -0,0 +1,134 @@
+package tgo
+
+import (
+	"fmt"
+	"log"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// SlowLog logs the handlers and API calls which take longer than their thresholds,
+// to find the latency culprits without a full-blown tracing.
+type SlowLog struct {
+	// Handler is the threshold of handling an update by the asks and routers; zero disables it.
+	Handler time.Duration
+
+	// Call is the threshold of the API calls; zero disables it.
+	Call time.Duration
+
+	// Logf is used to write the logs; it's log.Printf by default.
+	Logf func(format string, args ...any)
+}
+
+func (s *SlowLog) logf(format string, args ...any) {
+	if s.Logf != nil {
+		s.Logf(format, args...)
+	} else {
+		log.Printf(format, args...)
+	}
+}
+
+// handler logs the update if handling it took longer than the threshold.
+func (s *SlowLog) handler(update *Update, took time.Duration) {
+	if s.Handler == 0 || took < s.Handler {
+		return
+	}
+
+	s.logf("tgo: slow handler: %s took %s", describeUpdate(update), took)
+}
+
+// call logs the API call, and the stack of the handler which made it, if it took longer than the threshold.
+func (s *SlowLog) call(method string, took time.Duration) {
+	if s.Call == 0 || took < s.Call {
+		return
+	}
+
+	s.logf("tgo: slow call: %s took %s\n%s", method, took, callerStack())
+}
+
+// callerStack returns the stack of the current goroutine, without the frames of this package.
+func callerStack() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+
+	var stack strings.Builder
+	for {
+		frame, more := frames.Next()
+
+		pkg := frame.Function
+		if slash := strings.LastIndexByte(pkg, '/'); slash != -1 {
+			pkg = pkg[:slash+strings.IndexByte(pkg[slash:], '.')]
+		}
+
+		if pkg != "github.com/haashemi/tgo" && !strings.HasPrefix(pkg, "runtime") {
+			fmt.Fprintf(&stack, "\t%s\n\t\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		}
+
+		if !more {
+			return stack.String()
+		}
+	}
+}
+
+// describeUpdate returns a short human-readable summary of the update.
+func describeUpdate(update *Update) string {
+	describeMessage := func(kind string, msg *Message) string {
+		text := msg.Text
+		if text == "" {
+			text = msg.Caption
+		}
+		if len([]rune(text)) > 32 {
+			text = string([]rune(text)[:32]) + "…"
+		}
+
+		return fmt.Sprintf("update %d (%s %d in chat %d: %q)", update.UpdateId, kind, msg.MessageId, msg.Chat.Id, text)
+	}
+
+	switch {
+	case update.Message != nil:
+		return describeMessage("message", update.Message)
+	case update.EditedMessage != nil:
+		return describeMessage("edited message", update.EditedMessage)
+	case update.ChannelPost != nil:
+		return describeMessage("channel post", update.ChannelPost)
+	case update.EditedChannelPost != nil:
+		return describeMessage("edited channel post", update.EditedChannelPost)
+	case update.BusinessConnection != nil:
+		return fmt.Sprintf("update %d (business connection %s of %d)", update.UpdateId, update.BusinessConnection.Id, update.BusinessConnection.User.Id)
+	case update.BusinessMessage != nil:
+		return describeMessage("business message", update.BusinessMessage)
+	case update.EditedBusinessMessage != nil:
+		return describeMessage("edited business message", update.EditedBusinessMessage)
+	case update.DeletedBusinessMessages != nil:
+		return fmt.Sprintf("update %d (%d deleted business messages in chat %d)", update.UpdateId, len(update.DeletedBusinessMessages.MessageIds), update.DeletedBusinessMessages.Chat.Id)
+	case update.MessageReaction != nil:
+		return fmt.Sprintf("update %d (reaction to message %d in chat %d)", update.UpdateId, update.MessageReaction.MessageId, update.MessageReaction.Chat.Id)
+	case update.MessageReactionCount != nil:
+		return fmt.Sprintf("update %d (reaction count of message %d in chat %d)", update.UpdateId, update.MessageReactionCount.MessageId, update.MessageReactionCount.Chat.Id)
+	case update.CallbackQuery != nil:
+		return fmt.Sprintf("update %d (callback query from %d: %q)", update.UpdateId, update.CallbackQuery.From.Id, update.CallbackQuery.Data)
+	case update.InlineQuery != nil:
+		return fmt.Sprintf("update %d (inline query from %d: %q)", update.UpdateId, update.InlineQuery.From.Id, update.InlineQuery.Query)
+	case update.ChosenInlineResult != nil:
+		return fmt.Sprintf("update %d (chosen inline result from %d)", update.UpdateId, update.ChosenInlineResult.From.Id)
+	case update.ShippingQuery != nil:
+		return fmt.Sprintf("update %d (shipping query from %d)", update.UpdateId, update.ShippingQuery.From.Id)
+	case update.PreCheckoutQuery != nil:
+		return fmt.Sprintf("update %d (pre-checkout query from %d)", update.UpdateId, update.PreCheckoutQuery.From.Id)
+	case update.PurchasedPaidMedia != nil:
+		return fmt.Sprintf("update %d (purchased paid media by %d)", update.UpdateId, update.PurchasedPaidMedia.From.Id)
+	case update.Poll != nil:
+		return fmt.Sprintf("update %d (poll %s)", update.UpdateId, update.Poll.Id)
+	case update.PollAnswer != nil:
+		return fmt.Sprintf("update %d (poll answer for %s)", update.UpdateId, update.PollAnswer.PollId)
+	case update.MyChatMember != nil:
+		return fmt.Sprintf("update %d (my chat member in chat %d)", update.UpdateId, update.MyChatMember.Chat.Id)
+	case update.ChatMember != nil:
+		return fmt.Sprintf("update %d (chat member in chat %d)", update.UpdateId, update.ChatMember.Chat.Id)
+	case update.ChatJoinRequest != nil:
+		return fmt.Sprintf("update %d (join request from %d to chat %d)", update.UpdateId, update.ChatJoinRequest.From.Id, update.ChatJoinRequest.Chat.Id)
+	}
+
+	return fmt.Sprintf("update %d", update.UpdateId)
+}
@@ -0,0 +1,31 @@
+package tgo
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSlowLog(t *testing.T) {
+	var logs []string
+	slowLog := &SlowLog{Handler: time.Second, Logf: func(format string, args ...any) { logs = append(logs, fmt.Sprintf(format, args...)) }}
+
+	update := &Update{UpdateId: 7, Message: &Message{MessageId: 1, Chat: Chat{Id: 2}, Text: "hello"}}
+	slowLog.handler(update, time.Millisecond)
+	slowLog.call("sendMessage", time.Hour)
+	if len(logs) != 0 {
+		t.Fatalf("logged the fast or disabled ones: %q", logs)
+	}
+
+	slowLog.handler(update, 2*time.Second)
+	if len(logs) != 1 || !strings.Contains(logs[0], `update 7 (message 1 in chat 2: "hello")`) {
+		t.Errorf("unexpected logs: %q", logs)
+	}
+
+	slowLog.Call = time.Second
+	slowLog.call("sendMessage", 2*time.Second)
+	if len(logs) != 2 || !strings.Contains(logs[1], "sendMessage") || !strings.Contains(logs[1], "testing.tRunner") {
+		t.Errorf("unexpected logs: %q", logs)
+	}
+}
@@ -0,0 +1,93 @@
+package spam
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/haashemi/tgo"
+)
+
+// Verdict is how much a message looks like spam; the greater ones are worse.
+type Verdict int
+
+const (
+	Clean      Verdict = iota // the message is fine
+	Suspicious                // the message may be spam, such as for an administrator to look at
+	Spam                      // the message is spam
+)
+
+func (v Verdict) String() string {
+	switch v {
+	case Clean:
+		return "clean"
+	case Suspicious:
+		return "suspicious"
+	case Spam:
+		return "spam"
+	}
+	return "unknown"
+}
+
+// Classifier tells how much the messages look like spam.
+type Classifier interface {
+	Classify(msg *tgo.Message) Verdict
+}
+
+// ClassifierFunc is a function used as a Classifier, such as one calling an external service:
+//
+//	spam.ClassifierFunc(func(msg *tgo.Message) spam.Verdict {
+//		score, err := detector.Score(msg.Text)
+//		if err != nil || score < 0.5 {
+//			return spam.Clean
+//		}
+//		return spam.Spam
+//	})
+type ClassifierFunc func(msg *tgo.Message) Verdict
+
+// Classify implements the Classifier interface.
+func (f ClassifierFunc) Classify(msg *tgo.Message) Verdict { return f(msg) }
+
+// Keywords returns a Classifier giving the verdict to the messages whose text or caption contains
+// any of the keywords, case-insensitively, and Clean to the others.
+func Keywords(verdict Verdict, keywords ...string) Classifier {
+	lowered := make([]string, len(keywords))
+	for i, keyword := range keywords {
+		lowered[i] = strings.ToLower(keyword)
+	}
+
+	return ClassifierFunc(func(msg *tgo.Message) Verdict {
+		text := strings.ToLower(messageText(msg))
+		for _, keyword := range lowered {
+			if strings.Contains(text, keyword) {
+				return verdict
+			}
+		}
+		return Clean
+	})
+}
+
+// Patterns returns a Classifier giving the verdict to the messages whose text or caption matches
+// any of the patterns, and Clean to the others. It panics if any of the patterns is invalid.
+func Patterns(verdict Verdict, patterns ...string) Classifier {
+	compiled := make([]*regexp.Regexp, len(patterns))
+	for i, pattern := range patterns {
+		compiled[i] = regexp.MustCompile(pattern)
+	}
+
+	return ClassifierFunc(func(msg *tgo.Message) Verdict {
+		text := messageText(msg)
+		for _, re := range compiled {
+			if re.MatchString(text) {
+				return verdict
+			}
+		}
+		return Clean
+	})
+}
+
+func messageText(msg *tgo.Message) string {
+	if msg.Text != "" {
+		return msg.Text
+	}
+	return msg.Caption
+}
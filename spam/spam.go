@@ -0,0 +1,143 @@
+// Package spam moderates the messages of the groups by running them through pluggable classifiers,
+// such as keyword lists, regular expressions, or external services, and acting on their verdicts.
+package spam
+
+import (
+	"fmt"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/routers/message"
+)
+
+// Action is what a Filter does with the messages of a verdict. The actions may be combined, such as
+// Delete | Report, and they're done in the order of Report, Warn, and Delete.
+type Action int
+
+const (
+	Drop   Action = 1 << iota // stops the message from reaching the handlers
+	Delete                    // deletes the message; it implies Drop
+	Warn                      // warns the sender in the chat
+	Report                    // forwards the message to the ReportChat, with the verdict
+)
+
+// Options configures a Filter. The zero value is valid and uses the defaults, but it doesn't
+// classify anything without the Classifiers.
+type Options struct {
+	// Classifiers are run on the messages in order; the worst verdict among them is taken,
+	// and the rest aren't run once one of them gives Spam.
+	Classifiers []Classifier
+
+	// Actions are the actions done for the verdicts; it defaults to Report for Suspicious, and
+	// Delete | Report for Spam. The Report is ignored if there's no ReportChat.
+	Actions map[Verdict]Action
+
+	// ReportChat is where the reported messages are forwarded to, such as the administrators' channel.
+	ReportChat tgo.ChatID
+
+	// Warning is the Warn's text, formatted with the verdict; it defaults to an English text,
+	// and it's translated by ctx.T.
+	Warning string
+
+	// OnVerdict, if not nil, is called for the messages of a verdict other than Clean, after its actions.
+	OnVerdict func(ctx *message.Context, verdict Verdict)
+}
+
+// Filter runs the messages through the classifiers, and acts on the verdicts.
+type Filter struct {
+	opts Options
+}
+
+// New returns a Filter of the options.
+func New(opts Options) *Filter {
+	if opts.Actions == nil {
+		opts.Actions = map[Verdict]Action{Suspicious: Report, Spam: Delete | Report}
+	}
+	if opts.Warning == "" {
+		opts.Warning = "Your message looks like %s, so it's removed."
+	}
+
+	return &Filter{opts: opts}
+}
+
+// Classify returns the worst verdict of the classifiers for the message.
+func (f *Filter) Classify(msg *tgo.Message) Verdict {
+	worst := Clean
+	for _, classifier := range f.opts.Classifiers {
+		if verdict := classifier.Classify(msg); verdict > worst {
+			if worst = verdict; worst >= Spam {
+				break
+			}
+		}
+	}
+	return worst
+}
+
+// Middleware returns a message middleware which classifies the messages and acts on their verdicts,
+// stopping the ones to be dropped or deleted from reaching the handlers:
+//
+//	filter := spam.New(spam.Options{
+//		Classifiers: []spam.Classifier{spam.Keywords(spam.Spam, "free crypto"), spam.Patterns(spam.Suspicious, `t\.me/\w+`)},
+//		ReportChat:  tgo.ID(adminChannelID),
+//	})
+//	router := message.NewRouter(filter.Middleware())
+//
+// The bot must be an administrator of the groups, with the right to delete the messages.
+func (f *Filter) Middleware() message.Middleware {
+	return func(ctx *message.Context) (ok bool) {
+		verdict := f.Classify(ctx.Message)
+		if verdict == Clean {
+			return true
+		}
+
+		action := f.opts.Actions[verdict]
+		f.act(ctx, verdict, action)
+
+		if f.opts.OnVerdict != nil {
+			f.opts.OnVerdict(ctx, verdict)
+		}
+		return action&(Drop|Delete) == 0
+	}
+}
+
+// act does the actions to the message.
+func (f *Filter) act(ctx *message.Context, verdict Verdict, action Action) {
+	if action&Report != 0 && f.opts.ReportChat != nil {
+		if err := f.report(ctx, verdict); err != nil {
+			ctx.Bot.Logger().Log(tgo.LevelError, "failed to report the message", "chat_id", ctx.Chat.Id, "verdict", verdict, "error", err)
+		}
+	}
+
+	if action&Warn != 0 {
+		warning := &tgo.SendMessage{ChatId: tgo.ID(ctx.Chat.Id), MessageThreadId: ctx.MessageThreadId, Text: ctx.T(f.opts.Warning, ctx.T(verdict.String()))}
+		if action&Delete == 0 {
+			// the warning can't reply to the message which is going to be deleted.
+			warning.ReplyToMessageId = ctx.MessageId
+		}
+
+		if _, err := ctx.Bot.Send(warning); err != nil {
+			ctx.Bot.Logger().Log(tgo.LevelError, "failed to warn the sender", "chat_id", ctx.Chat.Id, "error", err)
+		}
+	}
+
+	if action&Delete != 0 {
+		if err := ctx.Delete(); err != nil {
+			ctx.Bot.Logger().Log(tgo.LevelError, "failed to delete the message", "chat_id", ctx.Chat.Id, "error", err)
+		}
+	}
+}
+
+// report forwards the message to the ReportChat, followed by its verdict and where it's from.
+func (f *Filter) report(ctx *message.Context, verdict Verdict) error {
+	forwarded, err := ctx.Bot.ForwardMessage(&tgo.ForwardMessage{ChatId: f.opts.ReportChat, FromChatId: tgo.ID(ctx.Chat.Id), MessageId: ctx.MessageId})
+	if err != nil {
+		return err
+	}
+
+	_, senderID := tgo.GetChatAndSenderID(ctx.Message)
+	_, err = ctx.Bot.Send(&tgo.SendMessage{
+		ChatId:           f.opts.ReportChat,
+		Text:             fmt.Sprintf("%s message from %d in %q (%d)", verdict, senderID, ctx.Chat.Title, ctx.Chat.Id),
+		ReplyToMessageId: forwarded.MessageId,
+	})
+	return err
+}
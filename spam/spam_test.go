@@ -0,0 +1,70 @@
+package spam
+
+import (
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/filters"
+	"github.com/haashemi/tgo/routers/message"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestClassify(t *testing.T) {
+	f := New(Options{Classifiers: []Classifier{
+		Patterns(Suspicious, `t\.me/\w+`),
+		Keywords(Spam, "Free Crypto"),
+	}})
+
+	tests := []struct {
+		text string
+		want Verdict
+	}{
+		{text: "hello", want: Clean},
+		{text: "join t.me/channel", want: Suspicious},
+		{text: "FREE CRYPTO at t.me/channel", want: Spam},
+	}
+
+	for _, tt := range tests {
+		if got := f.Classify(&tgo.Message{Text: tt.text}); got != tt.want {
+			t.Errorf("%q: expected %s, got %s", tt.text, tt.want, got)
+		}
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	server.Handle("forwardMessage", func(call tgotest.Call) (any, *tgo.Error) {
+		return map[string]any{"message_id": 5, "date": 1, "chat": map[string]any{"id": -100, "type": "channel"}}, nil
+	})
+
+	bot := server.Bot(tgo.Options{})
+
+	f := New(Options{Classifiers: []Classifier{Keywords(Spam, "spam")}, ReportChat: tgo.ID(-100)})
+
+	var handled int
+	router := message.NewRouter(f.Middleware())
+	router.Handle(filters.True(), func(ctx *message.Context) { handled++ })
+
+	for _, text := range []string{"hello", "buy spam"} {
+		router.HandleUpdate(bot, &tgo.Update{Message: &tgo.Message{
+			MessageId: 1,
+			Chat:      tgo.Chat{Id: -1, Type: "supergroup"},
+			From:      &tgo.User{Id: 1},
+			Text:      text,
+		}})
+	}
+
+	if handled != 1 {
+		t.Errorf("expected only the clean message to be handled, got %d", handled)
+	}
+
+	var methods []string
+	for _, call := range server.Calls() {
+		methods = append(methods, call.Method)
+	}
+	if len(methods) != 3 || methods[0] != "forwardMessage" || methods[1] != "sendMessage" || methods[2] != "deleteMessage" {
+		t.Errorf("expected the spam to be reported and deleted, got %v", methods)
+	}
+}
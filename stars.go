@@ -0,0 +1,67 @@
+package tgo
+
+// StarsCurrency is the currency code of Telegram Stars, which is used to pay for digital goods and services.
+const StarsCurrency = "XTR"
+
+// StarsInvoice returns an invoice of a digital product with the price of the passed amount of stars.
+// ChatId is left empty to be set by the Send methods or yourself.
+func StarsInvoice(title, description, payload string, amount int64) *SendInvoice {
+	return &SendInvoice{
+		Title:       title,
+		Description: description,
+		Payload:     payload,
+		// provider token must be empty for the payments in telegram stars.
+		ProviderToken: "",
+		Currency:      StarsCurrency,
+		Prices:        []*LabeledPrice{{Label: title, Amount: amount}},
+	}
+}
+
+// IsStars returns true if the payment is done in telegram stars.
+func (x *SuccessfulPayment) IsStars() bool { return x.Currency == StarsCurrency }
+
+// IsStars returns true if the checkout is going to be paid in telegram stars.
+func (x *PreCheckoutQuery) IsStars() bool { return x.Currency == StarsCurrency }
+
+// GetAllStarTransactions fetches all of the bot's star transactions page by page, in chronological order.
+func (api *API) GetAllStarTransactions() ([]*StarTransaction, error) {
+	const pageSize = 100
+
+	var transactions []*StarTransaction
+	for {
+		page, err := api.GetStarTransactions(&GetStarTransactions{Offset: int64(len(transactions)), Limit: pageSize})
+		if err != nil {
+			return transactions, err
+		}
+
+		transactions = append(transactions, page.Transactions...)
+		if len(page.Transactions) < pageSize {
+			return transactions, nil
+		}
+	}
+}
+
+// RefundStars refunds the successful payment of the user, made in telegram stars.
+func (api *API) RefundStars(userID int64, payment *SuccessfulPayment) error {
+	_, err := api.RefundStarPayment(&RefundStarPayment{
+		UserId:                  userID,
+		TelegramPaymentChargeId: payment.TelegramPaymentChargeId,
+	})
+	return err
+}
+
+// PaidPhoto returns the photo to be sent as a paid media.
+func PaidPhoto(photo *InputFile) *InputPaidMediaPhoto {
+	return &InputPaidMediaPhoto{Type: "photo", Media: photo}
+}
+
+// PaidVideo returns the video to be sent as a paid media.
+func PaidVideo(video *InputFile) *InputPaidMediaVideo {
+	return &InputPaidMediaVideo{Type: "video", Media: video}
+}
+
+// GiftUser sends the gift, one of the GetAvailableGifts, to the user with the optional text.
+func (api *API) GiftUser(userID int64, giftID, text string) error {
+	_, err := api.SendGift(&SendGift{UserId: userID, GiftId: giftID, Text: text})
+	return err
+}
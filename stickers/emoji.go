@@ -0,0 +1,117 @@
+package stickers
+
+import (
+	"unicode/utf16"
+
+	"github.com/haashemi/tgo"
+)
+
+// MaxCustomEmojiLookup is the number of the custom emojis which telegram looks up at once.
+const MaxCustomEmojiLookup = 200
+
+// CustomEmoji is a custom emoji used in a message.
+type CustomEmoji struct {
+	ID     string // the custom emoji's identifier
+	Text   string // the text which the custom emoji replaces, such as "👍"
+	Offset int64  // the offset of the text, in UTF-16 code units, in the text or caption of the message
+
+	// Sticker is the custom emoji's sticker, set by Resolve; it's nil if telegram doesn't know the emoji.
+	Sticker *tgo.Sticker
+}
+
+// CustomEmojis returns the custom emojis used in the message's text or caption, in order.
+func CustomEmojis(msg *tgo.Message) []*CustomEmoji {
+	text, entities := msg.Text, msg.Entities
+	if text == "" {
+		text, entities = msg.Caption, msg.CaptionEntities
+	}
+
+	var units []uint16
+	var emojis []*CustomEmoji
+
+	for _, entity := range entities {
+		if entity.Type != "custom_emoji" {
+			continue
+		}
+
+		// the offsets are in UTF-16 code units, so the text is encoded only if there's an emoji.
+		if units == nil {
+			units = utf16.Encode([]rune(text))
+		}
+
+		var emoji string
+		if end := entity.Offset + entity.Length; entity.Offset >= 0 && end <= int64(len(units)) {
+			emoji = string(utf16.Decode(units[entity.Offset:end]))
+		}
+		emojis = append(emojis, &CustomEmoji{ID: entity.CustomEmojiId, Text: emoji, Offset: entity.Offset})
+	}
+
+	return emojis
+}
+
+// GetCustomEmojiStickers returns the stickers of the custom emoji ids by their ids, looking them up in
+// batches of MaxCustomEmojiLookup; the repeated ids are looked up once, and the unknown ones are left out.
+func GetCustomEmojiStickers(bot *tgo.Bot, ids []string) (map[string]*tgo.Sticker, error) {
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	stickers := make(map[string]*tgo.Sticker, len(unique))
+	for len(unique) > 0 {
+		batch := unique
+		if len(batch) > MaxCustomEmojiLookup {
+			batch = batch[:MaxCustomEmojiLookup]
+		}
+		unique = unique[len(batch):]
+
+		found, err := bot.GetCustomEmojiStickers(&tgo.GetCustomEmojiStickers{CustomEmojiIds: batch})
+		if err != nil {
+			return nil, err
+		}
+		for _, sticker := range found {
+			stickers[sticker.CustomEmojiId] = sticker
+		}
+	}
+
+	return stickers, nil
+}
+
+// Resolve returns the custom emojis used in the message, with their stickers, such as for mirroring
+// them by their file ids:
+//
+//	emojis, err := stickers.Resolve(bot, msg)
+//	for _, emoji := range emojis {
+//		if emoji.Sticker != nil {
+//			bot.Send(&tgo.SendSticker{ChatId: tgo.ID(mirrorID), Sticker: tgo.FileFromID(emoji.Sticker.FileId)})
+//		}
+//	}
+func Resolve(bot *tgo.Bot, msg *tgo.Message) ([]*CustomEmoji, error) {
+	emojis := CustomEmojis(msg)
+	if len(emojis) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, len(emojis))
+	for i, emoji := range emojis {
+		ids[i] = emoji.ID
+	}
+
+	stickers, err := GetCustomEmojiStickers(bot, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, emoji := range emojis {
+		emoji.Sticker = stickers[emoji.ID]
+	}
+	return emojis, nil
+}
+
+// IsPremium reports whether the sticker is a premium one, which has a premium animation only
+// the premium users see.
+func IsPremium(sticker *tgo.Sticker) bool { return sticker.PremiumAnimation != nil }
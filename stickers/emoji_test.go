@@ -0,0 +1,43 @@
+package stickers
+
+import (
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestResolve(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	var lookups int
+	server.Handle("getCustomEmojiStickers", func(call tgotest.Call) (any, *tgo.Error) {
+		lookups++
+		return []map[string]any{{"file_id": "file1", "custom_emoji_id": "1", "type": "custom_emoji"}}, nil
+	})
+
+	bot := server.Bot(tgo.Options{})
+
+	// "𝔸" takes two UTF-16 code units, which shift the offsets of the emojis after it.
+	msg := &tgo.Message{Text: "𝔸 👍 🔥 👍", Entities: []*tgo.MessageEntity{
+		{Type: "bold", Offset: 0, Length: 2},
+		{Type: "custom_emoji", Offset: 3, Length: 2, CustomEmojiId: "1"},
+		{Type: "custom_emoji", Offset: 6, Length: 2, CustomEmojiId: "2"},
+		{Type: "custom_emoji", Offset: 9, Length: 2, CustomEmojiId: "1"},
+	}}
+
+	emojis, err := Resolve(bot, msg)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(emojis) != 3 || lookups != 1 {
+		t.Fatalf("expected 3 emojis in a lookup, got %d in %d", len(emojis), lookups)
+	}
+
+	if emojis[0].Text != "👍" || emojis[0].Sticker == nil || emojis[0].Sticker.FileId != "file1" {
+		t.Errorf("unexpected first emoji: %+v", emojis[0])
+	}
+	if emojis[1].Text != "🔥" || emojis[1].Sticker != nil {
+		t.Errorf("expected the unknown emoji to have no sticker, got %+v", emojis[1])
+	}
+}
@@ -0,0 +1,183 @@
+// Package stickers manages the bots' sticker sets: it builds and validates their stickers before
+// they're uploaded, and wraps the API methods creating and changing the sets.
+package stickers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/haashemi/tgo"
+)
+
+// The limits of the sticker sets and their stickers.
+const (
+	MaxSetStickers = 50 // of a new set
+	MaxEmojis      = 20 // of a sticker
+	MaxKeywords    = 20 // of a sticker
+)
+
+// Builder builds the list of the stickers of a set, validating each of them as it's added; the first
+// error is kept and returned by Build, so the calls can be chained:
+//
+//	list, err := stickers.NewBuilder(stickers.FormatStatic, stickers.TypeRegular).
+//		Add(tgo.FileFromReader("cat.png", cat), "🐱").Keywords("cat", "meow").
+//		Add(tgo.FileFromReader("dog.png", dog), "🐶").
+//		Build()
+type Builder struct {
+	format, stickerType string
+
+	stickers []*tgo.InputSticker
+	names    map[string]bool
+	err      error
+}
+
+// NewBuilder returns a Builder of the stickers of the format and the set type; an empty type is regular.
+func NewBuilder(format, stickerType string) *Builder {
+	if stickerType == "" {
+		stickerType = TypeRegular
+	}
+	return &Builder{format: format, stickerType: stickerType, names: make(map[string]bool)}
+}
+
+// Add adds the sticker of the file, associated with the emojis.
+func (b *Builder) Add(file *tgo.InputFile, emojis ...string) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	if len(emojis) == 0 || len(emojis) > MaxEmojis {
+		b.err = fmt.Errorf("stickers: %q has %d emojis, not 1 to %d", file.Value, len(emojis), MaxEmojis)
+		return b
+	}
+
+	// the uploaded files are attached by their names, so they must be unique.
+	if file.IsUploadable() {
+		if b.names[file.Value] {
+			b.err = fmt.Errorf("stickers: %q is added twice", file.Value)
+			return b
+		}
+		b.names[file.Value] = true
+	}
+
+	if b.err = Validate(file, b.format, b.stickerType); b.err != nil {
+		return b
+	}
+
+	b.stickers = append(b.stickers, &tgo.InputSticker{Sticker: file, EmojiList: emojis})
+	return b
+}
+
+// Keywords sets the search keywords of the last added sticker, for the regular and the custom emoji sets.
+func (b *Builder) Keywords(keywords ...string) *Builder {
+	if b.err != nil {
+		return b
+	} else if len(b.stickers) == 0 {
+		b.err = errors.New("stickers: the keywords are set before any sticker is added")
+		return b
+	}
+
+	sticker := b.stickers[len(b.stickers)-1]
+	if len(keywords) > MaxKeywords || len(strings.Join(keywords, "")) > 64 {
+		b.err = fmt.Errorf("stickers: %q has more than %d keywords or 64 characters of them", sticker.Sticker.Value, MaxKeywords)
+		return b
+	}
+
+	sticker.Keywords = keywords
+	return b
+}
+
+// Mask sets the position of the last added sticker on the faces, for the mask sets.
+func (b *Builder) Mask(position *tgo.MaskPosition) *Builder {
+	if b.err != nil {
+		return b
+	} else if len(b.stickers) == 0 {
+		b.err = errors.New("stickers: the mask position is set before any sticker is added")
+		return b
+	}
+
+	b.stickers[len(b.stickers)-1].MaskPosition = position
+	return b
+}
+
+// Build returns the stickers, or the first error of building them.
+func (b *Builder) Build() ([]*tgo.InputSticker, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	return b.stickers, nil
+}
+
+// SetName returns the name of the bot's set, which telegram requires to end in "_by_<bot's username>".
+func SetName(bot *tgo.Bot, name string) (string, error) {
+	me, err := bot.Me()
+	if err != nil {
+		return "", err
+	}
+
+	suffix := "_by_" + me.Username
+	if strings.HasSuffix(strings.ToLower(name), strings.ToLower(suffix)) {
+		return name, nil
+	}
+	return name + suffix, nil
+}
+
+// Upload validates the sticker file of the user and uploads it, to be added to their sets by its file id later.
+func Upload(bot *tgo.Bot, userID int64, file *tgo.InputFile, format string) (*tgo.File, error) {
+	if err := Validate(file, format, TypeRegular); err != nil {
+		return nil, err
+	}
+	return bot.UploadStickerFile(&tgo.UploadStickerFile{UserId: userID, Sticker: file, StickerFormat: format})
+}
+
+// CreateSet creates the user's set of the built stickers; the name gets the bot's suffix by SetName.
+// It returns the set's full name.
+func CreateSet(bot *tgo.Bot, userID int64, name, title string, builder *Builder) (string, error) {
+	list, err := builder.Build()
+	if err != nil {
+		return "", err
+	} else if len(list) == 0 || len(list) > MaxSetStickers {
+		return "", fmt.Errorf("stickers: a new set has %d stickers, not 1 to %d", len(list), MaxSetStickers)
+	}
+
+	if name, err = SetName(bot, name); err != nil {
+		return "", err
+	}
+
+	_, err = bot.CreateNewStickerSet(&tgo.CreateNewStickerSet{
+		UserId:        userID,
+		Name:          name,
+		Title:         title,
+		Stickers:      list,
+		StickerFormat: builder.format,
+		StickerType:   builder.stickerType,
+	})
+	return name, err
+}
+
+// AddToSet adds the built stickers to the user's set, in order.
+func AddToSet(bot *tgo.Bot, userID int64, name string, builder *Builder) error {
+	list, err := builder.Build()
+	if err != nil {
+		return err
+	}
+
+	for _, sticker := range list {
+		if _, err = bot.AddStickerToSet(&tgo.AddStickerToSet{UserId: userID, Name: name, Sticker: *sticker}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Move moves the sticker of the file id to the zero-based position in its set.
+func Move(bot *tgo.Bot, fileID string, position int) error {
+	_, err := bot.SetStickerPositionInSet(&tgo.SetStickerPositionInSet{Sticker: fileID, Position: int64(position)})
+	return err
+}
+
+// Delete deletes the sticker of the file id from its set.
+func Delete(bot *tgo.Bot, fileID string) error {
+	_, err := bot.DeleteStickerFromSet(&tgo.DeleteStickerFromSet{Sticker: fileID})
+	return err
+}
@@ -0,0 +1,86 @@
+package stickers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func pngFile(t *testing.T, name string, width, height int) *tgo.InputFile {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+	return tgo.FileFromReader(name, &buf)
+}
+
+// losslessWebP returns the header of a lossless .WEBP image of the dimensions.
+func losslessWebP(width, height int) []byte {
+	header := []byte("RIFF\x00\x00\x00\x00WEBPVP8L\x00\x00\x00\x00\x2f")
+	return binary.LittleEndian.AppendUint32(header, uint32(width-1)|uint32(height-1)<<14)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		file        *tgo.InputFile
+		format      string
+		stickerType string
+		valid       bool
+	}{
+		{name: "png", file: pngFile(t, "a.png", 512, 300), format: FormatStatic, valid: true},
+		{name: "png too wide", file: pngFile(t, "a.png", 600, 512), format: FormatStatic},
+		{name: "png without a 512 side", file: pngFile(t, "a.png", 300, 300), format: FormatStatic},
+		{name: "custom emoji", file: pngFile(t, "a.png", 100, 100), format: FormatStatic, stickerType: TypeCustomEmoji, valid: true},
+		{name: "webp", file: tgo.FileFromReader("a.webp", bytes.NewReader(losslessWebP(200, 512))), format: FormatStatic, valid: true},
+		{name: "webp too small", file: tgo.FileFromReader("a.webp", bytes.NewReader(losslessWebP(200, 200))), format: FormatStatic},
+		{name: "tgs", file: tgo.FileFromReader("a.tgs", strings.NewReader("\x1f\x8blottie")), format: FormatAnimated, valid: true},
+		{name: "tgs too large", file: tgo.FileFromReader("a.tgs", bytes.NewReader(append([]byte{0x1f, 0x8b}, make([]byte, MaxAnimatedSize)...))), format: FormatAnimated},
+		{name: "webm as tgs", file: tgo.FileFromReader("a.webm", strings.NewReader("\x1a\x45\xdf\xa3")), format: FormatAnimated},
+		{name: "webm", file: tgo.FileFromReader("a.webm", strings.NewReader("\x1a\x45\xdf\xa3")), format: FormatVideo, valid: true},
+		{name: "file id", file: tgo.FileFromID("CAACAg"), format: FormatVideo, valid: true},
+	}
+
+	for _, tt := range tests {
+		err := Validate(tt.file, tt.format, tt.stickerType)
+
+		var invalid *InvalidFileError
+		if tt.valid && err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+		} else if !tt.valid && !errors.As(err, &invalid) {
+			t.Errorf("%s: expected an InvalidFileError, got %v", tt.name, err)
+		}
+	}
+}
+
+func TestCreateSet(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	bot := server.Bot(tgo.Options{})
+
+	duplicate := NewBuilder(FormatStatic, "").Add(pngFile(t, "a.png", 512, 512), "🐱").Add(pngFile(t, "a.png", 512, 512), "🐶")
+	if _, err := duplicate.Build(); err == nil {
+		t.Error("expected the duplicate file names to be rejected")
+	}
+
+	builder := NewBuilder(FormatStatic, "").Add(pngFile(t, "cat.png", 512, 512), "🐱").Keywords("cat")
+	name, err := CreateSet(bot, 1, "animals", "Animals", builder)
+	if err != nil {
+		t.Fatal(err)
+	} else if name != "animals_by_test_bot" {
+		t.Errorf("expected the name to have the bot's suffix, got %q", name)
+	}
+
+	calls := server.Calls()
+	if call := calls[len(calls)-1]; call.Method != "createNewStickerSet" || call.Params["cat.png"] != "attach://cat.png" {
+		t.Errorf("expected the set to be created with the uploaded sticker, got %+v", call)
+	}
+}
@@ -0,0 +1,160 @@
+package stickers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image/png"
+	"io"
+
+	"github.com/haashemi/tgo"
+)
+
+// The formats of the stickers.
+const (
+	FormatStatic   = "static"   // .PNG or .WEBP images
+	FormatAnimated = "animated" // .TGS animations
+	FormatVideo    = "video"    // .WEBM videos
+)
+
+// The types of the sticker sets.
+const (
+	TypeRegular     = "regular"
+	TypeMask        = "mask"
+	TypeCustomEmoji = "custom_emoji"
+)
+
+// The size limits of the sticker files, by their format.
+const (
+	MaxStaticSize   = 512 << 10
+	MaxAnimatedSize = 64 << 10
+	MaxVideoSize    = 256 << 10
+)
+
+var maxSizes = map[string]int{FormatStatic: MaxStaticSize, FormatAnimated: MaxAnimatedSize, FormatVideo: MaxVideoSize}
+
+// InvalidFileError is returned for the sticker files which telegram would reject.
+type InvalidFileError struct {
+	Name   string // the name of the file
+	Format string // the format which the file is checked against
+	Reason string // why the file is invalid
+}
+
+func (e *InvalidFileError) Error() string {
+	return fmt.Sprintf("stickers: invalid %s sticker %q: %s", e.Format, e.Name, e.Reason)
+}
+
+// Validate checks the uploadable file against the format's requirements: its size, its file type, and for
+// the static ones, their dimensions, which must be 512 pixels on one side and at most 512 on the other,
+// or 100×100 for the custom emojis. The file is read into memory, as the stickers are small, and its
+// reader is replaced to be read again. The files on the telegram servers, by their id or url, aren't checked.
+//
+// The animations and videos aren't decoded, so their dimensions, durations, and frame rates are checked by telegram.
+func Validate(file *tgo.InputFile, format, stickerType string) error {
+	if file == nil || !file.IsUploadable() {
+		return nil
+	}
+
+	maxSize, ok := maxSizes[format]
+	if !ok {
+		return &InvalidFileError{Name: file.Value, Format: format, Reason: "unknown format"}
+	}
+
+	content, err := io.ReadAll(io.LimitReader(file.Reader, int64(maxSize)+1))
+	if err != nil {
+		return err
+	}
+	file.Reader = io.MultiReader(bytes.NewReader(content), file.Reader)
+
+	if len(content) > maxSize {
+		return &InvalidFileError{Name: file.Value, Format: format, Reason: fmt.Sprintf("larger than %d kB", maxSize>>10)}
+	}
+
+	var reason string
+	switch format {
+	case FormatStatic:
+		reason = validateImage(content, stickerType)
+	case FormatAnimated:
+		// the .TGS files are gzipped lottie animations.
+		if !bytes.HasPrefix(content, []byte{0x1f, 0x8b}) {
+			reason = "not a .TGS file"
+		}
+	case FormatVideo:
+		// the .WEBM files are EBML documents.
+		if !bytes.HasPrefix(content, []byte{0x1a, 0x45, 0xdf, 0xa3}) {
+			reason = "not a .WEBM file"
+		}
+	}
+
+	if reason != "" {
+		return &InvalidFileError{Name: file.Value, Format: format, Reason: reason}
+	}
+	return nil
+}
+
+// validateImage returns why the static sticker's image is invalid, or an empty string.
+func validateImage(content []byte, stickerType string) string {
+	var width, height int
+
+	switch {
+	case bytes.HasPrefix(content, []byte("\x89PNG\r\n\x1a\n")):
+		config, err := png.DecodeConfig(bytes.NewReader(content))
+		if err != nil {
+			return "broken .PNG file"
+		}
+		width, height = config.Width, config.Height
+
+	case len(content) >= 16 && string(content[:4]) == "RIFF" && string(content[8:12]) == "WEBP":
+		var ok bool
+		if width, height, ok = webpSize(content); !ok {
+			return "broken .WEBP file"
+		}
+
+	default:
+		return "not a .PNG or .WEBP file"
+	}
+
+	if stickerType == TypeCustomEmoji {
+		if width != 100 || height != 100 {
+			return fmt.Sprintf("%d×%d pixels, not 100×100", width, height)
+		}
+	} else if (width != 512 && height != 512) || width > 512 || height > 512 {
+		return fmt.Sprintf("%d×%d pixels, not 512 on one side and at most 512 on the other", width, height)
+	}
+
+	return ""
+}
+
+// webpSize returns the dimensions of the .WEBP image from the header of its first chunk.
+func webpSize(content []byte) (width, height int, ok bool) {
+	chunk := content[12:]
+
+	switch string(chunk[:4]) {
+	case "VP8 ": // lossy; the frame header follows the 3-byte frame tag and the 3-byte start code.
+		if len(chunk) < 18 {
+			return 0, 0, false
+		}
+		width = int(binary.LittleEndian.Uint16(chunk[14:]) & 0x3fff)
+		height = int(binary.LittleEndian.Uint16(chunk[16:]) & 0x3fff)
+
+	case "VP8L": // lossless; the 14-bit dimensions minus one follow the signature byte.
+		if len(chunk) < 13 || chunk[8] != 0x2f {
+			return 0, 0, false
+		}
+		bits := binary.LittleEndian.Uint32(chunk[9:])
+		width = int(bits&0x3fff) + 1
+		height = int(bits>>14&0x3fff) + 1
+
+	case "VP8X": // extended; the 24-bit canvas dimensions minus one follow the flags.
+		if len(chunk) < 18 {
+			return 0, 0, false
+		}
+		width = int(uint32(chunk[12])|uint32(chunk[13])<<8|uint32(chunk[14])<<16) + 1
+		height = int(uint32(chunk[15])|uint32(chunk[16])<<8|uint32(chunk[17])<<16) + 1
+
+	default:
+		return 0, 0, false
+	}
+
+	return width, height, true
+}
@@ -0,0 +1,135 @@
+package tgo
+
+import (
+	"strings"
+	"unicode/utf16"
+)
+
+// Ellipsis is appended to the texts truncated by TruncateWithEntities.
+const Ellipsis = "…"
+
+// UTF16Len returns the length of the text in UTF-16 code units, which telegram uses to measure
+// the texts and the entities' offsets and lengths.
+func UTF16Len(text string) int {
+	var length int
+	for _, r := range text {
+		length += len(utf16.Encode([]rune{r}))
+	}
+	return length
+}
+
+// TruncateWithEntities cuts the text (and its entities) to fit in max UTF-16 code units, including
+// the appended Ellipsis. It never splits a surrogate pair; the entities after the cut are dropped, and
+// the ones crossing it are shortened. The text and entities are returned as is if the text already fits.
+//
+// The passed entities are not modified.
+func TruncateWithEntities(text string, entities []*MessageEntity, max int) (string, []*MessageEntity) {
+	units := utf16.Encode([]rune(text))
+	if len(units) <= max {
+		return text, entities
+	}
+
+	cut := max - UTF16Len(Ellipsis)
+	if cut <= 0 {
+		return "", nil
+	}
+
+	// don't leave the first half of a surrogate pair behind.
+	if utf16.IsSurrogate(rune(units[cut-1])) && units[cut-1] < 0xDC00 {
+		cut--
+	}
+
+	// don't put the ellipsis after a whitespace.
+	head := strings.TrimRight(string(utf16.Decode(units[:cut])), " \t\n")
+	cut = UTF16Len(head)
+
+	var truncated []*MessageEntity
+	for _, entity := range entities {
+		if entity.Offset >= int64(cut) {
+			continue
+		}
+
+		e := *entity
+		if end := e.Offset + e.Length; end > int64(cut) {
+			e.Length = int64(cut) - e.Offset
+		}
+		truncated = append(truncated, &e)
+	}
+
+	return head + Ellipsis, truncated
+}
+
+// TextChunk is a part of a text split by SplitWithEntities, with its own entities.
+type TextChunk struct {
+	Text     string
+	Entities []*MessageEntity
+}
+
+// SplitWithEntities splits the text (and its entities) into the chunks of at most max UTF-16 code units.
+// Each chunk is split at its last paragraph break, line break, or space in its second half, which is
+// dropped; or at the max, but never in a surrogate pair. The entities crossing a split are split into
+// both of its chunks, so the formatting carries on in the next one.
+//
+// The passed entities are not modified.
+func SplitWithEntities(text string, entities []*MessageEntity, max int) []TextChunk {
+	if max <= 0 {
+		return nil
+	}
+
+	units := utf16.Encode([]rune(text))
+
+	var chunks []TextChunk
+	for start := 0; start < len(units); {
+		end, next := len(units), len(units)
+		if end-start > max {
+			end, next = splitPoint(units, start, start+max)
+		}
+
+		chunk := TextChunk{Text: string(utf16.Decode(units[start:end]))}
+		for _, entity := range entities {
+			from, to := entity.Offset, entity.Offset+entity.Length
+			if from < int64(start) {
+				from = int64(start)
+			}
+			if to > int64(end) {
+				to = int64(end)
+			}
+
+			if from < to {
+				e := *entity
+				e.Offset, e.Length = from-int64(start), to-from
+				chunk.Entities = append(chunk.Entities, &e)
+			}
+		}
+
+		if chunk.Text != "" {
+			chunks = append(chunks, chunk)
+		}
+		start = next
+	}
+
+	return chunks
+}
+
+// splitSeparators are where the texts are split, the preferred ones first.
+var splitSeparators = [][]uint16{{'\n', '\n'}, {'\n'}, {' '}}
+
+// splitPoint returns where the chunk of the units from the start is split before the limit,
+// and where the next chunk starts.
+func splitPoint(units []uint16, start, limit int) (end, next int) {
+	half := start + (limit-start)/2
+
+	for _, sep := range splitSeparators {
+		for i := limit - len(sep); i > start && i >= half; i-- {
+			if units[i] == sep[0] && (len(sep) == 1 || units[i+1] == sep[1]) {
+				return i, i + len(sep)
+			}
+		}
+	}
+
+	// don't leave the first half of a surrogate pair behind, unless it's all that fits.
+	if utf16.IsSurrogate(rune(units[limit-1])) && units[limit-1] < 0xDC00 && limit-1 > start {
+		limit--
+	}
+	return limit, limit
+}
@@ -0,0 +1,85 @@
+package tgo
+
+import "testing"
+
+func TestTruncateWithEntities(t *testing.T) {
+	// "😀" is two UTF-16 code units.
+	text := "hello 😀 world"
+	entities := []*MessageEntity{
+		{Type: "bold", Offset: 0, Length: 5},
+		{Type: "italic", Offset: 6, Length: 8},
+		{Type: "code", Offset: 9, Length: 5},
+	}
+
+	tests := []struct {
+		max      int
+		text     string
+		entities []MessageEntity
+	}{
+		{max: 100, text: text, entities: []MessageEntity{{Type: "bold", Length: 5}, {Type: "italic", Offset: 6, Length: 8}, {Type: "code", Offset: 9, Length: 5}}},
+		{max: 8, text: "hello…", entities: []MessageEntity{{Type: "bold", Length: 5}}},
+		{max: 9, text: "hello 😀…", entities: []MessageEntity{{Type: "bold", Length: 5}, {Type: "italic", Offset: 6, Length: 2}}},
+		{max: 12, text: "hello 😀 wo…", entities: []MessageEntity{{Type: "bold", Length: 5}, {Type: "italic", Offset: 6, Length: 5}, {Type: "code", Offset: 9, Length: 2}}},
+		{max: 1, text: "", entities: nil},
+	}
+
+	for _, test := range tests {
+		gotText, gotEntities := TruncateWithEntities(text, entities, test.max)
+		if gotText != test.text {
+			t.Errorf("max %d: got text %q, want %q", test.max, gotText, test.text)
+		}
+
+		if UTF16Len(gotText) > test.max {
+			t.Errorf("max %d: got text of %d units", test.max, UTF16Len(gotText))
+		}
+
+		if len(gotEntities) != len(test.entities) {
+			t.Errorf("max %d: got %d entities, want %d", test.max, len(gotEntities), len(test.entities))
+			continue
+		}
+		for i, e := range gotEntities {
+			if *e != test.entities[i] {
+				t.Errorf("max %d: entity %d: got %+v, want %+v", test.max, i, *e, test.entities[i])
+			}
+		}
+	}
+
+	if entities[1].Length != 8 {
+		t.Error("the passed entities are modified")
+	}
+}
+
+func TestSplitWithEntities(t *testing.T) {
+	text := "first line\nsecond 😀 line"
+	entities := []*MessageEntity{{Type: "bold", Offset: 6, Length: 11}}
+
+	chunks := SplitWithEntities(text, entities, 12)
+	want := []TextChunk{
+		{Text: "first line", Entities: []*MessageEntity{{Type: "bold", Offset: 6, Length: 4}}},
+		{Text: "second 😀", Entities: []*MessageEntity{{Type: "bold", Offset: 0, Length: 6}}},
+		{Text: "line"},
+	}
+
+	if len(chunks) != len(want) {
+		t.Fatalf("got %d chunks, want %d: %+v", len(chunks), len(want), chunks)
+	}
+	for i, chunk := range chunks {
+		if chunk.Text != want[i].Text || len(chunk.Entities) != len(want[i].Entities) {
+			t.Fatalf("chunk %d: got %q with %d entities, want %q with %d", i, chunk.Text, len(chunk.Entities), want[i].Text, len(want[i].Entities))
+		}
+		for j, e := range chunk.Entities {
+			if *e != *want[i].Entities[j] {
+				t.Errorf("chunk %d: got entity %+v, want %+v", i, *e, *want[i].Entities[j])
+			}
+		}
+	}
+
+	// without a separator, it's split at the max, but not in the surrogate pair.
+	if chunks := SplitWithEntities("ab😀", nil, 3); len(chunks) != 2 || chunks[0].Text != "ab" || chunks[1].Text != "😀" {
+		t.Fatalf("unexpected hard split %+v", chunks)
+	}
+
+	if entities[0].Length != 11 {
+		t.Fatal("the passed entities are modified")
+	}
+}
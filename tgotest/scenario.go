@@ -0,0 +1,291 @@
+package tgotest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/haashemi/tgo"
+)
+
+var update = flag.Bool("tgotest.update", false, "rewrite the golden scenario files with the actual calls")
+
+// Step is an incoming update and the calls the bot is expected to make while handling it.
+type Step struct {
+	Update json.RawMessage
+	Calls  []ExpectedCall
+}
+
+// ExpectedCall is an outgoing call; string values of its Params may contain placeholders:
+//
+//	{{any}}   matches anything.
+//	{{$name}} matches anything the first time, and the same value on the next occurrences of $name.
+type ExpectedCall struct {
+	Method string
+	Params json.RawMessage
+}
+
+// Scenario is a scripted conversation with the bot.
+//
+// In the scenario files, lines starting with ">" are the incoming updates, and lines starting with "<"
+// are the calls which are expected to be made after the last update, in the form of "method {params}".
+// Empty lines and lines starting with "#" are ignored.
+//
+//	# the bot should greet the user back.
+//	> {"message":{"message_id":1,"chat":{"id":1,"type":"private"},"text":"hi"}}
+//	< sendMessage {"chat_id":1,"text":"hi {{any}}!"}
+type Scenario struct {
+	Steps []Step
+}
+
+// ParseScenario parses a scenario from its text form.
+func ParseScenario(data []byte) (*Scenario, error) {
+	scenario := &Scenario{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, 1<<20)
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case text == "", strings.HasPrefix(text, "#"):
+			continue
+
+		case strings.HasPrefix(text, ">"):
+			raw := json.RawMessage(strings.TrimSpace(text[1:]))
+			if !json.Valid(raw) {
+				return nil, fmt.Errorf("line %d: invalid update", line)
+			}
+			scenario.Steps = append(scenario.Steps, Step{Update: raw})
+
+		case strings.HasPrefix(text, "<"):
+			if len(scenario.Steps) == 0 {
+				return nil, fmt.Errorf("line %d: expected call before any update", line)
+			}
+
+			method, params, _ := strings.Cut(strings.TrimSpace(text[1:]), " ")
+			if params = strings.TrimSpace(params); params == "" {
+				params = "{}"
+			}
+			if !json.Valid([]byte(params)) {
+				return nil, fmt.Errorf("line %d: invalid params", line)
+			}
+
+			step := &scenario.Steps[len(scenario.Steps)-1]
+			step.Calls = append(step.Calls, ExpectedCall{Method: method, Params: json.RawMessage(params)})
+
+		default:
+			return nil, fmt.Errorf("line %d: unknown line", line)
+		}
+	}
+
+	return scenario, scanner.Err()
+}
+
+// Format returns the scenario in its text form.
+func (s *Scenario) Format() []byte {
+	buf := bytes.NewBuffer(nil)
+
+	for index, step := range s.Steps {
+		if index != 0 {
+			buf.WriteByte('\n')
+		}
+
+		fmt.Fprintf(buf, "> %s\n", step.Update)
+		for _, call := range step.Calls {
+			fmt.Fprintf(buf, "< %s %s\n", call.Method, call.Params)
+		}
+	}
+
+	return buf.Bytes()
+}
+
+// RunScenario feeds the updates of the scenario file to the bot one by one, and compares the calls
+// received by the server with the expected ones. The bot must be created by server.Bot.
+//
+// Run the tests with the -tgotest.update flag to rewrite the file with the actual calls.
+func RunScenario(t testing.TB, server *Server, bot *tgo.Bot, filename string) {
+	t.Helper()
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenario, err := ParseScenario(data)
+	if err != nil {
+		t.Fatalf("%s: %v", filename, err)
+	}
+
+	actual := &Scenario{}
+	vars := map[string]any{}
+
+	for index, step := range scenario.Steps {
+		upd := &tgo.Update{}
+		if err = json.Unmarshal(step.Update, upd); err != nil {
+			t.Fatalf("%s: step %d: %v", filename, index+1, err)
+		}
+		if upd.UpdateId == 0 {
+			upd.UpdateId = int64(index + 1)
+		}
+
+		server.Reset()
+		bot.HandleUpdate(upd)
+		calls := server.Reset()
+
+		actualStep := Step{Update: step.Update}
+		for _, call := range calls {
+			params, _ := json.Marshal(call.Params)
+			actualStep.Calls = append(actualStep.Calls, ExpectedCall{Method: call.Method, Params: params})
+		}
+		actual.Steps = append(actual.Steps, actualStep)
+
+		if *update {
+			continue
+		}
+
+		if len(calls) != len(step.Calls) {
+			t.Errorf("%s: step %d: got %d calls, want %d\n%s", filename, index+1, len(calls), len(step.Calls), diffCalls(step.Calls, actualStep.Calls))
+			continue
+		}
+
+		for i, want := range step.Calls {
+			var wantParams any
+			json.Unmarshal(want.Params, &wantParams)
+
+			if calls[i].Method != want.Method || !match(wantParams, normalize(calls[i].Params), vars) {
+				t.Errorf("%s: step %d: call %d mismatch\n%s", filename, index+1, i+1, diffCalls(step.Calls, actualStep.Calls))
+				break
+			}
+		}
+	}
+
+	if *update {
+		if err = os.WriteFile(filename, actual.Format(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// diffCalls returns the expected and actual calls in the scenario's form.
+func diffCalls(want, got []ExpectedCall) string {
+	buf := bytes.NewBuffer(nil)
+
+	buf.WriteString("want:\n")
+	for _, call := range want {
+		fmt.Fprintf(buf, "\t< %s %s\n", call.Method, call.Params)
+	}
+
+	buf.WriteString("got:\n")
+	for _, call := range got {
+		fmt.Fprintf(buf, "\t< %s %s\n", call.Method, call.Params)
+	}
+
+	return buf.String()
+}
+
+// normalize makes the params comparable with the decoded expected params.
+func normalize(params map[string]any) (normalized any) {
+	data, _ := json.Marshal(params)
+	json.Unmarshal(data, &normalized)
+	return normalized
+}
+
+var placeholderRegex = regexp.MustCompile(`\{\{(any|\$[A-Za-z0-9_]+)\}\}`)
+
+// match reports whether the got value matches the want value with its placeholders.
+func match(want, got any, vars map[string]any) bool {
+	switch want := want.(type) {
+	case map[string]any:
+		gotMap, ok := got.(map[string]any)
+		if !ok || len(gotMap) != len(want) {
+			return false
+		}
+
+		for key, value := range want {
+			if gotValue, ok := gotMap[key]; !ok || !match(value, gotValue, vars) {
+				return false
+			}
+		}
+		return true
+
+	case []any:
+		gotSlice, ok := got.([]any)
+		if !ok || len(gotSlice) != len(want) {
+			return false
+		}
+
+		for i := range want {
+			if !match(want[i], gotSlice[i], vars) {
+				return false
+			}
+		}
+		return true
+
+	case string:
+		if !placeholderRegex.MatchString(want) {
+			return want == got
+		}
+		return matchPlaceholders(want, got, vars)
+	}
+
+	return reflect.DeepEqual(want, got)
+}
+
+// matchPlaceholders matches the got value with the want string which contains placeholders.
+func matchPlaceholders(want string, got any, vars map[string]any) bool {
+	// a single placeholder may match a value of any type.
+	if placeholder := placeholderRegex.FindString(want); placeholder == want {
+		name := strings.Trim(want, "{}")
+		if name == "any" {
+			return true
+		}
+
+		if value, ok := vars[name]; ok {
+			return reflect.DeepEqual(value, got)
+		}
+		vars[name] = got
+		return true
+	}
+
+	gotString, ok := got.(string)
+	if !ok {
+		return false
+	}
+
+	var names []string
+	pattern := "^"
+	last := 0
+	for _, loc := range placeholderRegex.FindAllStringSubmatchIndex(want, -1) {
+		pattern += regexp.QuoteMeta(want[last:loc[0]]) + "(.*?)"
+		names = append(names, want[loc[2]:loc[3]])
+		last = loc[1]
+	}
+	pattern += regexp.QuoteMeta(want[last:]) + "$"
+
+	matches := regexp.MustCompile(pattern).FindStringSubmatch(gotString)
+	if matches == nil {
+		return false
+	}
+
+	for i, name := range names {
+		if name == "any" {
+			continue
+		}
+
+		if value, ok := vars[name]; ok && value != matches[i+1] {
+			return false
+		}
+		vars[name] = matches[i+1]
+	}
+
+	return true
+}
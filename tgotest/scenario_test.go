@@ -0,0 +1,27 @@
+package tgotest
+
+import (
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/filters"
+	"github.com/haashemi/tgo/routers/message"
+)
+
+func TestRunScenario(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	bot := server.Bot(tgo.Options{DefaultParseMode: tgo.ParseModeHTML})
+
+	mr := message.NewRouter()
+	mr.Handle(filters.Command("start", "test_bot"), func(ctx *message.Context) {
+		ctx.Reply(&tgo.SendMessage{Text: "Hi <i>" + ctx.From.FirstName + "</i>!"})
+	})
+	mr.Handle(filters.IsMessage(), func(ctx *message.Context) {
+		ctx.Send(&tgo.SendMessage{Text: ctx.String()})
+	})
+	bot.AddRouter(mr)
+
+	RunScenario(t, server, bot, "testdata/greet.scenario")
+}
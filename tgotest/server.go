@@ -0,0 +1,199 @@
+// Package tgotest provides a fake telegram bot API server and a scenario runner
+// to test the bots end-to-end, without talking to telegram.
+package tgotest
+
+import (
+	"encoding/json"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/haashemi/tgo"
+)
+
+// Token is the bot token used by the bots created by Server.Bot.
+const Token = "123456:TEST"
+
+// Call is an API call received by the Server.
+type Call struct {
+	Method string
+	Params map[string]any
+}
+
+// Responder returns the result of a call, or the telegram error to fail the call with.
+type Responder func(call Call) (result any, err *tgo.Error)
+
+// Server is a fake telegram bot API server which records the calls and answers them
+// using the registered responders, or reasonable defaults if there's none.
+type Server struct {
+	*httptest.Server
+
+	mut        sync.Mutex
+	calls      []Call
+	responders map[string]Responder
+	messageID  int64
+}
+
+// NewServer starts and returns a new Server. Close it when you're done.
+func NewServer() *Server {
+	s := &Server{responders: make(map[string]Responder)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Bot returns a new bot which sends its API calls to the server.
+func (s *Server) Bot(opts tgo.Options) *tgo.Bot {
+	opts.Host = s.URL
+	if opts.Client == nil {
+		opts.Client = s.Client()
+	}
+
+	return tgo.NewBot(Token, opts)
+}
+
+// Handle registers the responder of the method, replacing the default one.
+func (s *Server) Handle(method string, responder Responder) {
+	s.mut.Lock()
+	s.responders[method] = responder
+	s.mut.Unlock()
+}
+
+// Calls returns the calls received since the last Reset.
+func (s *Server) Calls() []Call {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	return append([]Call(nil), s.calls...)
+}
+
+// Reset forgets the received calls, and returns them.
+func (s *Server) Reset() []Call {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	calls := s.calls
+	s.calls = nil
+	return calls
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	call := Call{Method: path.Base(r.URL.Path), Params: map[string]any{}}
+
+	mediaType, mediaParams, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/json":
+		// the body of the methods without payload (such as getMe) is "null".
+		json.NewDecoder(r.Body).Decode(&call.Params)
+		if call.Params == nil {
+			call.Params = map[string]any{}
+		}
+
+	case "multipart/form-data":
+		form, err := multipart.NewReader(r.Body, mediaParams["boundary"]).ReadForm(32 << 20)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		for key, values := range form.Value {
+			call.Params[key] = decodeFormValue(values[0])
+		}
+		for key, files := range form.File {
+			call.Params[key] = "attach://" + files[0].Filename
+		}
+	}
+
+	s.mut.Lock()
+	s.calls = append(s.calls, call)
+	responder, ok := s.responders[call.Method]
+	s.mut.Unlock()
+
+	if !ok {
+		responder = s.defaultResponder
+	}
+
+	result, tgErr := responder(call)
+	if tgErr != nil {
+		json.NewEncoder(w).Encode(map[string]any{"ok": false, "error_code": tgErr.ErrorCode, "description": tgErr.Description, "parameters": tgErr.Parameters})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": result})
+}
+
+// defaultResponder answers the message sending, editing, and forwarding methods with a synthesized message,
+// the copying ones with their message ids, getMe with a test bot, getUpdates and getMyCommands with empty lists, and
+// everything else with true.
+func (s *Server) defaultResponder(call Call) (any, *tgo.Error) {
+	switch {
+	case call.Method == "getMe":
+		return tgo.User{Id: 123456, IsBot: true, FirstName: "Test", Username: "test_bot"}, nil
+
+	case call.Method == "getUpdates", call.Method == "getMyCommands":
+		return []any{}, nil
+
+	case call.Method == "sendChatAction":
+		return true, nil
+
+	case call.Method == "copyMessage":
+		return map[string]any{"message_id": s.nextMessageID()}, nil
+
+	case call.Method == "copyMessages", call.Method == "forwardMessages":
+		ids, _ := call.Params["message_ids"].([]any)
+		copies := make([]any, len(ids))
+		for i := range ids {
+			copies[i] = map[string]any{"message_id": s.nextMessageID()}
+		}
+		return copies, nil
+
+	case strings.HasPrefix(call.Method, "send"), strings.HasPrefix(call.Method, "edit"), call.Method == "forwardMessage":
+		msg := map[string]any{"date": time.Now().Unix(), "chat": map[string]any{"id": call.Params["chat_id"], "type": "private"}}
+
+		if id, ok := call.Params["message_id"]; ok && call.Method != "forwardMessage" {
+			msg["message_id"] = id
+		} else {
+			msg["message_id"] = s.nextMessageID()
+		}
+
+		for _, key := range []string{"text", "caption", "reply_markup"} {
+			if value, ok := call.Params[key]; ok {
+				msg[key] = value
+			}
+		}
+
+		return msg, nil
+	}
+
+	return true, nil
+}
+
+// nextMessageID returns the id of the next synthesized message.
+func (s *Server) nextMessageID() int64 {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	s.messageID++
+	return s.messageID
+}
+
+// decodeFormValue decodes the JSON-serialized multipart values (objects, arrays, numbers, and booleans)
+// to keep them comparable with the ones sent as JSON. The numbers which don't fit a float64 are kept
+// as strings, as they're the string ids of the text fields, such as the message effects.
+func decodeFormValue(value string) any {
+	var decoded any
+	if err := json.Unmarshal([]byte(value), &decoded); err == nil {
+		if number, isNumber := decoded.(float64); isNumber && strconv.FormatFloat(number, 'f', -1, 64) != value {
+			return value
+		} else if _, isString := decoded.(string); !isString {
+			return decoded
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,158 @@
+package tgo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// ThumbnailProvider generates the thumbnails of the uploaded videos, animations, and documents.
+type ThumbnailProvider interface {
+	// Thumbnail returns the JPEG thumbnail of the file at the path, at most 320×320 and 200 kB,
+	// or nil if it has none, such as for the documents which aren't images or videos.
+	Thumbnail(ctx context.Context, info MediaInfo, path string) ([]byte, error)
+}
+
+// ThumbnailProviderFunc is a function which implements the ThumbnailProvider interface.
+type ThumbnailProviderFunc func(ctx context.Context, info MediaInfo, path string) ([]byte, error)
+
+// Thumbnail implements the ThumbnailProvider interface.
+func (f ThumbnailProviderFunc) Thumbnail(ctx context.Context, info MediaInfo, path string) ([]byte, error) {
+	return f(ctx, info, path)
+}
+
+// FFmpegThumbnailer is a ThumbnailProvider taking a frame of the videos and images by ffmpeg.
+type FFmpegThumbnailer struct {
+	// Binary is the path of the ffmpeg binary; it defaults to "ffmpeg", looked up in the PATH.
+	Binary string
+
+	// At is the time of the frame; it's the first frame of the shorter videos.
+	At time.Duration
+}
+
+// Thumbnail implements the ThumbnailProvider interface.
+func (f FFmpegThumbnailer) Thumbnail(ctx context.Context, info MediaInfo, path string) ([]byte, error) {
+	binary := f.Binary
+	if binary == "" {
+		binary = "ffmpeg"
+	}
+
+	for _, at := range []time.Duration{f.At, 0} {
+		var stdout, stderr bytes.Buffer
+
+		cmd := exec.CommandContext(ctx, binary, "-v", "error", "-ss", fmt.Sprintf("%.3f", at.Seconds()), "-i", path,
+			"-frames:v", "1", "-vf", "scale=320:320:force_original_aspect_ratio=decrease", "-q:v", "5", "-f", "mjpeg", "pipe:1")
+		cmd.Stdout, cmd.Stderr = &stdout, &stderr
+
+		if err := cmd.Run(); err != nil {
+			return nil, fmt.Errorf("tgo: ffmpeg failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+		} else if stdout.Len() > 0 {
+			return stdout.Bytes(), nil
+		}
+
+		// there's no frame at the time, as the video is shorter, so the first one is taken.
+		if at == 0 {
+			break
+		}
+	}
+
+	return nil, nil
+}
+
+// WithThumbnails returns a copy of the bot which generates the thumbnails of the videos, animations,
+// and documents uploaded by bot.Send without one, such as for a single send call; see Options.Thumbnails
+// for all of them:
+//
+//	bot.WithThumbnails(tgo.FFmpegThumbnailer{At: time.Second}).Send(&tgo.SendVideo{
+//		ChatId: tgo.ID(chatID),
+//		Video:  tgo.FileFromReader("movie.mp4", file),
+//	})
+func (bot *Bot) WithThumbnails(provider ThumbnailProvider) *Bot {
+	clone := *bot
+	api := *bot.API
+	api.thumbnails = provider
+	clone.API = &api
+	return &clone
+}
+
+// attachThumbnail generates the thumbnail of the message's uploaded file, if it has none, and returns
+// the function cleaning up after the message is sent. The failed thumbnails are logged and skipped,
+// as the messages are fine without them.
+func (bot *Bot) attachThumbnail(msg Sendable) (cleanup func(), err error) {
+	cleanup = func() {}
+	if bot.thumbnails == nil {
+		return cleanup, nil
+	}
+
+	var method, field string
+	var file, thumbnail **InputFile
+
+	switch x := msg.(type) {
+	case *SendVideo:
+		method, field, file, thumbnail = "sendVideo", "video", &x.Video, &x.Thumbnail
+	case *SendAnimation:
+		method, field, file, thumbnail = "sendAnimation", "animation", &x.Animation, &x.Thumbnail
+	case *SendDocument:
+		method, field, file, thumbnail = "sendDocument", "document", &x.Document, &x.Thumbnail
+	default:
+		return cleanup, nil
+	}
+
+	if *file == nil || !(*file).IsUploadable() || *thumbnail != nil {
+		return cleanup, nil
+	}
+
+	path, cleanup, err := spoolUpload(*file)
+	if err != nil {
+		return cleanup, err
+	}
+
+	info := MediaInfo{Method: method, Field: field, Name: (*file).Value}
+	jpeg, err := bot.thumbnails.Thumbnail(bot.Context(), info, path)
+	if err != nil {
+		bot.log(LevelWarn, "failed to generate the thumbnail", "method", method, "name", info.Name, "error", err)
+	} else if len(jpeg) > 0 {
+		*thumbnail = FileFromReader("thumbnail.jpg", bytes.NewReader(jpeg))
+	}
+
+	return cleanup, nil
+}
+
+// spoolUpload returns the path of the uploaded file for the thumbnail providers. The files which aren't
+// on the disk, such as the network streams, are spooled to a temporary file, which then replaces
+// their reader until the cleanup.
+func spoolUpload(file *InputFile) (path string, cleanup func(), err error) {
+	cleanup = func() {}
+
+	if f, ok := file.Reader.(*os.File); ok {
+		if stat, err := f.Stat(); err == nil && stat.Mode().IsRegular() {
+			return f.Name(), cleanup, nil
+		}
+	}
+
+	temp, err := os.CreateTemp("", "tgo-upload-*")
+	if err != nil {
+		return "", cleanup, err
+	}
+
+	cleanup = func() {
+		temp.Close()
+		os.Remove(temp.Name())
+	}
+
+	if _, err = io.Copy(temp, file.Reader); err != nil {
+		cleanup()
+		return "", func() {}, err
+	} else if _, err = temp.Seek(0, io.SeekStart); err != nil {
+		cleanup()
+		return "", func() {}, err
+	}
+
+	file.Reader = temp
+	return temp.Name(), cleanup, nil
+}
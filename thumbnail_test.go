@@ -0,0 +1,41 @@
+package tgo_test
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestThumbnails(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	var spooled string
+	provider := tgo.ThumbnailProviderFunc(func(ctx context.Context, info tgo.MediaInfo, path string) ([]byte, error) {
+		content, err := os.ReadFile(path)
+		if err != nil || string(content) != "mp4" || info.Field != "video" {
+			t.Errorf("unexpected file %+v at %q: %q, %v", info, path, content, err)
+		}
+
+		spooled = path
+		return []byte("jpeg"), nil
+	})
+
+	bot := server.Bot(tgo.Options{Thumbnails: provider})
+
+	if _, err := bot.Send(&tgo.SendVideo{ChatId: tgo.ID(1), Video: tgo.FileFromReader("movie.mp4", strings.NewReader("mp4"))}); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := server.Calls()
+	if len(calls) != 1 || calls[0].Params["thumbnail"] != "attach://thumbnail.jpg" || calls[0].Params["video"] != "attach://movie.mp4" {
+		t.Errorf("expected the video to be sent with its thumbnail, got %+v", calls)
+	}
+	if _, err := os.Stat(spooled); !os.IsNotExist(err) {
+		t.Errorf("expected the spooled file to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+package tgo
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// Tracer traces the handling of the updates and the API calls made while handling them.
+// Use the contrib/tgootel module to trace them with OpenTelemetry.
+type Tracer interface {
+	// StartUpdate starts the span of handling the update, and returns the context carrying it.
+	// The returned end function is called after the routers are done with the update.
+	StartUpdate(ctx context.Context, update *Update) (spanCtx context.Context, end func())
+
+	// StartCall starts the span of an API call as a child of the context's span. The chatID is the
+	// call's chat_id parameter, such as "42" or "@channel", or empty if it has none.
+	StartCall(ctx context.Context, method, chatID string) (end func(err error))
+}
+
+// WithContext returns a copy of the api whose calls are traced as the children of the context's span.
+func (api *API) WithContext(ctx context.Context) *API {
+	clone := *api
+	clone.ctx = ctx
+	return &clone
+}
+
+// Context returns the context of the api, which carries the span of the update which is being handled
+// if there's an Options.Tracer; it's context.Background() by default.
+func (api *API) Context() context.Context {
+	if api.ctx == nil {
+		return context.Background()
+	}
+	return api.ctx
+}
+
+// WithContext returns a copy of the bot whose API calls are traced as the children of the context's span,
+// such as the ones made in a goroutine after the handler is returned.
+// The copy shares everything else, such as the routers and sessions, with the bot.
+func (bot *Bot) WithContext(ctx context.Context) *Bot {
+	clone := *bot
+	clone.API = bot.API.WithContext(ctx)
+	return &clone
+}
+
+// traceCall starts the span of the API call, if there's a tracer.
+func (api *API) traceCall(method string, chatID func() string) func(err error) {
+	if api.tracer == nil {
+		return func(error) {}
+	}
+	return api.tracer.StartCall(api.Context(), method, chatID())
+}
+
+// payloadChatID returns the json-encoded ChatId field of the payload, or an empty string if it has none.
+func payloadChatID(payload any) string {
+	value := reflect.ValueOf(payload)
+	for value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return ""
+	}
+
+	field := value.FieldByName("ChatId")
+	if !field.IsValid() || field.IsZero() {
+		return ""
+	}
+
+	encoded, _ := json.Marshal(field.Interface())
+	return strings.Trim(string(encoded), `"`)
+}
@@ -0,0 +1,56 @@
+package tgo_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+type spanKey struct{}
+
+type recordingTracer struct {
+	mut   sync.Mutex
+	spans []string
+}
+
+func (r *recordingTracer) record(span string) {
+	r.mut.Lock()
+	r.spans = append(r.spans, span)
+	r.mut.Unlock()
+}
+
+func (r *recordingTracer) StartUpdate(ctx context.Context, update *tgo.Update) (context.Context, func()) {
+	r.record("update " + update.Type())
+	return context.WithValue(ctx, spanKey{}, "update"), func() { r.record("end update") }
+}
+
+func (r *recordingTracer) StartCall(ctx context.Context, method, chatID string) func(err error) {
+	parent, _ := ctx.Value(spanKey{}).(string)
+	r.record(parent + "/" + method + " " + chatID)
+	return func(err error) {}
+}
+
+func TestTracer(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	tracer := &recordingTracer{}
+	bot := server.Bot(tgo.Options{Tracer: tracer})
+	bot.AddRouter(&echoRouter{})
+
+	bot.HandleUpdate(&tgo.Update{Message: &tgo.Message{Chat: tgo.Chat{Id: 42}, Text: "hi"}})
+	bot.SendMessage(&tgo.SendMessage{ChatId: tgo.Username("@channel"), Text: "outside"})
+
+	expected := []string{"update message", "update/sendMessage 42", "end update", "/sendMessage @channel"}
+	if len(tracer.spans) != len(expected) {
+		t.Fatalf("expected the spans %q, got %q", expected, tracer.spans)
+	}
+	for i := range expected {
+		if tracer.spans[i] != expected[i] {
+			t.Fatalf("expected the spans %q, got %q", expected, tracer.spans)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package tgo
+
+import "fmt"
+
+// Translator translates the texts into the users' languages, such as *i18n.Bundle.
+type Translator interface {
+	Translate(language, key string, args ...any) string
+}
+
+// Translate translates the key into the language using the bot's translator. Without a translator,
+// it returns the key itself, formatted with the args if there's any.
+func (bot *Bot) Translate(language, key string, args ...any) string {
+	if bot.translator != nil {
+		return bot.translator.Translate(language, key, args...)
+	}
+
+	if len(args) != 0 {
+		return fmt.Sprintf(key, args...)
+	}
+	return key
+}
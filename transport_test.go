@@ -0,0 +1,60 @@
+package tgo_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/haashemi/tgo"
+)
+
+func TestProxyTransport(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the proxy gets the absolute url of the request.
+		proxied = r.URL.String()
+		json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": map[string]any{"id": 1, "is_bot": true}})
+	}))
+	defer proxy.Close()
+
+	transport, err := tgo.ProxyTransport(proxy.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bot := tgo.NewBot("123:TOKEN", tgo.Options{Host: "http://telegram.invalid", Transport: transport})
+	if _, err = bot.GetMe(); err != nil {
+		t.Fatal(err)
+	} else if proxied != "http://telegram.invalid/bot123:TOKEN/getMe" {
+		t.Fatalf("the proxy got %q", proxied)
+	}
+
+	if _, err = tgo.ProxyTransport("mtproto://127.0.0.1:443"); err == nil {
+		t.Fatal("the unsupported proxy scheme is accepted")
+	}
+}
+
+func TestTestEnvironment(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": map[string]any{"id": 1, "is_bot": true}})
+	}))
+	defer server.Close()
+
+	bot := tgo.NewBot("123:TOKEN", tgo.Options{Host: server.URL, TestEnvironment: true})
+	if _, err := bot.GetMe(); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := bot.Download("photos/file_0.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if len(paths) != 2 || paths[0] != "/bot123:TOKEN/test/getMe" || paths[1] != "/file/bot123:TOKEN/test/photos/file_0.jpg" {
+		t.Fatalf("the test environment is called by %v", paths)
+	}
+}
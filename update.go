@@ -0,0 +1,87 @@
+package tgo
+
+// EffectiveMessage returns the message of the update, whether it's a new or edited message,
+// channel post, or business message, or nil if the update is not about a message.
+func (u *Update) EffectiveMessage() *Message {
+	switch {
+	case u.Message != nil:
+		return u.Message
+	case u.EditedMessage != nil:
+		return u.EditedMessage
+	case u.ChannelPost != nil:
+		return u.ChannelPost
+	case u.EditedChannelPost != nil:
+		return u.EditedChannelPost
+	case u.BusinessMessage != nil:
+		return u.BusinessMessage
+	case u.EditedBusinessMessage != nil:
+		return u.EditedBusinessMessage
+	}
+
+	return nil
+}
+
+// IsChannelPost returns true if the update is a new or edited channel post.
+func (u *Update) IsChannelPost() bool { return u.ChannelPost != nil || u.EditedChannelPost != nil }
+
+// IsBusiness returns true if the update is about a connected business account, or its messages.
+func (u *Update) IsBusiness() bool {
+	return u.BusinessConnection != nil || u.BusinessMessage != nil ||
+		u.EditedBusinessMessage != nil || u.DeletedBusinessMessages != nil
+}
+
+// IsEdited returns true if the update is an edited message, channel post, or business message.
+func (u *Update) IsEdited() bool {
+	return u.EditedMessage != nil || u.EditedChannelPost != nil || u.EditedBusinessMessage != nil
+}
+
+// Type returns the type of the update as named in the allowed_updates, such as "message" or
+// "callback_query", or an empty string if it's unknown to tgo.
+func (u *Update) Type() string {
+	switch {
+	case u.Message != nil:
+		return "message"
+	case u.EditedMessage != nil:
+		return "edited_message"
+	case u.ChannelPost != nil:
+		return "channel_post"
+	case u.EditedChannelPost != nil:
+		return "edited_channel_post"
+	case u.BusinessConnection != nil:
+		return "business_connection"
+	case u.BusinessMessage != nil:
+		return "business_message"
+	case u.EditedBusinessMessage != nil:
+		return "edited_business_message"
+	case u.DeletedBusinessMessages != nil:
+		return "deleted_business_messages"
+	case u.MessageReaction != nil:
+		return "message_reaction"
+	case u.MessageReactionCount != nil:
+		return "message_reaction_count"
+	case u.InlineQuery != nil:
+		return "inline_query"
+	case u.ChosenInlineResult != nil:
+		return "chosen_inline_result"
+	case u.CallbackQuery != nil:
+		return "callback_query"
+	case u.ShippingQuery != nil:
+		return "shipping_query"
+	case u.PreCheckoutQuery != nil:
+		return "pre_checkout_query"
+	case u.PurchasedPaidMedia != nil:
+		return "purchased_paid_media"
+	case u.Poll != nil:
+		return "poll"
+	case u.PollAnswer != nil:
+		return "poll_answer"
+	case u.MyChatMember != nil:
+		return "my_chat_member"
+	case u.ChatMember != nil:
+		return "chat_member"
+	case u.ChatJoinRequest != nil:
+		return "chat_join_request"
+	}
+
+	return ""
+}
@@ -0,0 +1,127 @@
+package tgo
+
+import "sync"
+
+// updateValues contains the values stored for the updates which are being handled, keyed by the update.
+var updateValues = struct {
+	mut    sync.Mutex
+	stores map[*Update]*updateStore
+}{stores: make(map[*Update]*updateStore)}
+
+// updateStore is the values of an update, and the number of its handlings which are still running.
+type updateStore struct {
+	values   sync.Map
+	handlers int
+}
+
+// loadUpdateStore returns the values of the update, or creates them if create is true.
+func loadUpdateStore(update *Update, create bool) *updateStore {
+	updateValues.mut.Lock()
+	defer updateValues.mut.Unlock()
+
+	store, ok := updateValues.stores[update]
+	if !ok && create {
+		store = &updateStore{}
+		updateValues.stores[update] = store
+	}
+	return store
+}
+
+// holdUpdate keeps the values of the update until the matching releaseUpdate, so a handling, such as
+// a nested bot's or a dispatcher's, doesn't forget the values of the others which are still running.
+func holdUpdate(update *Update) {
+	updateValues.mut.Lock()
+	defer updateValues.mut.Unlock()
+
+	store, ok := updateValues.stores[update]
+	if !ok {
+		store = &updateStore{}
+		updateValues.stores[update] = store
+	}
+	store.handlers++
+}
+
+// releaseUpdate ends a handling of the update held by holdUpdate, and forgets its values if it was the last one.
+func releaseUpdate(update *Update) {
+	updateValues.mut.Lock()
+	defer updateValues.mut.Unlock()
+
+	if store, ok := updateValues.stores[update]; ok {
+		if store.handlers--; store.handlers <= 0 {
+			delete(updateValues.stores, update)
+		}
+	}
+}
+
+// SetUpdateValue stores the value for the update under the key, so the filters can pass what
+// they've already computed to the middlewares and handlers of the same update. The routers'
+// contexts read and store them by their Get and Set methods, which unlike their Storage are
+// shared between the filters, the middlewares, and the handlers, and between the routers.
+//
+// The values are kept until the last bot.HandleUpdate of the update returns, including the nested
+// bots' and the dispatchers', or ForgetUpdate is called for the update.
+func SetUpdateValue(update *Update, key string, value any) {
+	loadUpdateStore(update, true).values.Store(key, value)
+}
+
+// GetUpdateValue returns the value stored for the update under the key.
+func GetUpdateValue(update *Update, key string) (value any, ok bool) {
+	store := loadUpdateStore(update, false)
+	if store == nil {
+		return nil, false
+	}
+
+	return store.values.Load(key)
+}
+
+// ForgetUpdate removes all of the values stored for the update. They're removed by bot.HandleUpdate
+// once the update is handled, but you have to call it yourself if you're passing the updates to
+// the routers directly.
+func ForgetUpdate(update *Update) {
+	updateValues.mut.Lock()
+	delete(updateValues.stores, update)
+	updateValues.mut.Unlock()
+}
+
+// DeleteUpdateValue removes the value stored for the update under the key.
+func DeleteUpdateValue(update *Update, key string) {
+	if store := loadUpdateStore(update, false); store != nil {
+		store.values.Delete(key)
+	}
+}
+
+// UpdateValue returns the value stored for the update under the key, if it's of the type T.
+func UpdateValue[T any](update *Update, key string) (value T, ok bool) {
+	stored, ok := GetUpdateValue(update, key)
+	if !ok {
+		return value, false
+	}
+
+	value, ok = stored.(T)
+	return value, ok
+}
+
+// ComputeUpdateValue returns the value stored for the update under the key, or computes and stores
+// it, so the expensive ones, such as the database lookups, are done once for the filters, middlewares,
+// and handlers of the update:
+//
+//	user, err := tgo.ComputeUpdateValue(update, "user", func() (*User, error) { return db.User(senderID) })
+//
+// The failures aren't stored, so the next callers compute the value again. The concurrent callers
+// may compute it at once, but they all get the same stored value.
+func ComputeUpdateValue[T any](update *Update, key string, compute func() (T, error)) (T, error) {
+	if value, ok := UpdateValue[T](update, key); ok {
+		return value, nil
+	}
+
+	value, err := compute()
+	if err != nil {
+		return value, err
+	}
+
+	stored, _ := loadUpdateStore(update, true).values.LoadOrStore(key, value)
+	if typed, ok := stored.(T); ok {
+		return typed, nil
+	}
+	return value, nil
+}
@@ -0,0 +1,81 @@
+package tgo_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/haashemi/tgo"
+)
+
+func TestComputeUpdateValue(t *testing.T) {
+	update := &tgo.Update{}
+	defer tgo.ForgetUpdate(update)
+
+	calls := 0
+	compute := func() (int, error) {
+		calls++
+		return 42, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		if value, err := tgo.ComputeUpdateValue(update, "answer", compute); err != nil || value != 42 {
+			t.Fatalf("got %v, %v", value, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("computed the value %d times, want once", calls)
+	}
+
+	if _, ok := tgo.UpdateValue[string](update, "answer"); ok {
+		t.Error("got the int value as a string")
+	}
+
+	tgo.DeleteUpdateValue(update, "answer")
+	if _, ok := tgo.GetUpdateValue(update, "answer"); ok {
+		t.Error("the deleted value is still stored")
+	}
+
+	if _, err := tgo.ComputeUpdateValue(update, "failed", func() (int, error) { return 0, errors.New("failed") }); err == nil {
+		t.Error("the failure isn't returned")
+	} else if _, ok := tgo.GetUpdateValue(update, "failed"); ok {
+		t.Error("the failure is stored")
+	}
+}
+
+type valueRouter struct{ handle func(update *tgo.Update) }
+
+func (r *valueRouter) Setup(bot *tgo.Bot) error { return nil }
+
+func (r *valueRouter) HandleUpdate(bot *tgo.Bot, update *tgo.Update) bool {
+	r.handle(update)
+	return true
+}
+
+func TestUpdateValuesOfHandlings(t *testing.T) {
+	inner := tgo.NewBot("token", tgo.Options{})
+	inner.AddRouter(&valueRouter{handle: func(update *tgo.Update) { tgo.SetUpdateValue(update, "inner", true) }})
+
+	outer := tgo.NewBot("token", tgo.Options{})
+	outer.AddDispatcher(inner, tgo.DispatcherOptions{})
+
+	var kept bool
+	outer.AddRouter(&valueRouter{handle: func(update *tgo.Update) {
+		tgo.SetUpdateValue(update, "outer", true)
+
+		// the nested bot finishes its handling meanwhile, which mustn't forget the outer one's values.
+		outer.StopDispatchers()
+		_, kept = tgo.GetUpdateValue(update, "outer")
+	}})
+
+	update := &tgo.Update{UpdateId: 1, Message: &tgo.Message{Chat: tgo.Chat{Id: 1}}}
+	outer.HandleUpdate(update)
+
+	if !kept {
+		t.Error("the nested bot has forgotten the outer bot's values")
+	}
+	for _, key := range []string{"inner", "outer"} {
+		if _, ok := tgo.GetUpdateValue(update, key); ok {
+			t.Errorf("the %s value is kept after all the handlings", key)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package tgo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	// MaxUploadSize is the size limit of the uploaded files on the public bot API server.
+	MaxUploadSize = 50 << 20
+
+	// MaxLocalUploadSize is the size limit of the uploaded files on a locally hosted bot API server.
+	MaxLocalUploadSize = 2000 << 20
+
+	// DefaultUploadChunkSize is the size of the chunks which the uploaded files are read in by default.
+	DefaultUploadChunkSize = 512 << 10
+)
+
+// FileTooLargeError is returned by bot.UploadFile for the files beyond the upload size limit.
+type FileTooLargeError struct {
+	Name  string // the name of the file
+	Size  int64  // the size of the file
+	Limit int64  // the limit which the file is beyond
+}
+
+func (e *FileTooLargeError) Error() string {
+	return fmt.Sprintf("tgo: file %q of %d bytes is beyond the upload limit of %d bytes", e.Name, e.Size, e.Limit)
+}
+
+// UploadProgress is the progress of an uploaded file.
+type UploadProgress struct {
+	MediaInfo
+	Sent  int64 // the bytes read from the file so far
+	Total int64 // the size of the file, or -1 if it's not known
+}
+
+// UploadOptions configures bot.UploadFile. The zero value is valid and uses the defaults.
+type UploadOptions struct {
+	// ChunkSize is the size of the chunks which the files are read in; it defaults to DefaultUploadChunkSize.
+	ChunkSize int
+
+	// Progress, if not nil, is called after each chunk of the files is read. It's called in the
+	// goroutine writing the request, so it should return quickly, such as by only storing the progress.
+	Progress func(progress UploadProgress)
+
+	// MaxSize is the size limit of the files; it defaults to MaxUploadSize on the public bot API server,
+	// and to MaxLocalUploadSize on the others. The files of unknown sizes aren't checked.
+	MaxSize int64
+}
+
+type uploadOptionsKey struct{}
+
+// UploadFile sends the message, such as a *SendDocument or a *SendVideo, reading its files in chunks,
+// reporting their progress, and stopping the upload once the context is done:
+//
+//	file, _ := os.Open("movie.mp4")
+//	defer file.Close()
+//
+//	bot.UploadFile(ctx, &tgo.SendVideo{ChatId: tgo.ID(chatID), Video: tgo.FileFromReader("movie.mp4", file)}, tgo.UploadOptions{
+//		Progress: func(p tgo.UploadProgress) { log.Printf("%s: %d/%d", p.Name, p.Sent, p.Total) },
+//	})
+//
+// The files are streamed, so even the ones of a few gigabytes on a locally hosted bot API server aren't
+// kept in memory. Their sizes are known from the *os.File, the readers with a Len method such as
+// *bytes.Reader, and the io.Seeker ones; it fails with a *FileTooLargeError if they're beyond the limit.
+//
+// Note that telegram has no resumable uploads, so the cancelled or failed ones start over when retried.
+func (bot *Bot) UploadFile(ctx context.Context, msg Sendable, opts UploadOptions) (*Message, error) {
+	return bot.WithContext(bot.uploadContext(ctx, opts)).Send(msg)
+}
+
+// UploadMediaGroup is like UploadFile, but for the albums; the progress of each of their files
+// is reported separately, with its attachment name as the Field.
+func (bot *Bot) UploadMediaGroup(ctx context.Context, group *SendMediaGroup, opts UploadOptions) ([]*Message, error) {
+	return bot.API.WithContext(bot.uploadContext(ctx, opts)).SendMediaGroup(group)
+}
+
+// uploadContext returns the context carrying the options, with their defaults.
+func (bot *Bot) uploadContext(ctx context.Context, opts UploadOptions) context.Context {
+	if opts.ChunkSize <= 0 {
+		opts.ChunkSize = DefaultUploadChunkSize
+	}
+	if opts.MaxSize <= 0 {
+		opts.MaxSize = MaxUploadSize
+		if bot.host != TelegramHost {
+			opts.MaxSize = MaxLocalUploadSize
+		}
+	}
+
+	return context.WithValue(ctx, uploadOptionsKey{}, &opts)
+}
+
+// trackUpload wraps the file's reader to be read in chunks and report its progress,
+// if it's uploaded by bot.UploadFile.
+func (api *API) trackUpload(info MediaInfo, r io.Reader) (io.Reader, error) {
+	ctx := api.Context()
+
+	opts, ok := ctx.Value(uploadOptionsKey{}).(*UploadOptions)
+	if !ok {
+		return r, nil
+	}
+
+	total, known := readerSize(r)
+	if !known {
+		total = -1
+	} else if total > opts.MaxSize {
+		return nil, &FileTooLargeError{Name: info.Name, Size: total, Limit: opts.MaxSize}
+	}
+
+	return &uploadReader{ctx: ctx, r: r, opts: opts, progress: UploadProgress{MediaInfo: info, Total: total}}, nil
+}
+
+// readerSize returns the number of the bytes left in the reader, if it's known.
+func readerSize(r io.Reader) (int64, bool) {
+	switch x := r.(type) {
+	case interface{ Len() int }:
+		return int64(x.Len()), true
+
+	case *os.File:
+		stat, err := x.Stat()
+		if err != nil || !stat.Mode().IsRegular() {
+			return 0, false
+		}
+
+		offset, err := x.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return 0, false
+		}
+		return stat.Size() - offset, true
+
+	case io.Seeker:
+		offset, err := x.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return 0, false
+		}
+
+		end, err := x.Seek(0, io.SeekEnd)
+		if err != nil {
+			return 0, false
+		} else if _, err = x.Seek(offset, io.SeekStart); err != nil {
+			return 0, false
+		}
+		return end - offset, true
+	}
+
+	return 0, false
+}
+
+// uploadReader reads an uploaded file in chunks, reporting its progress after each one.
+type uploadReader struct {
+	ctx      context.Context
+	r        io.Reader
+	opts     *UploadOptions
+	progress UploadProgress
+}
+
+func (u *uploadReader) Read(p []byte) (int, error) {
+	if len(p) > u.opts.ChunkSize {
+		p = p[:u.opts.ChunkSize]
+	}
+	return u.read(p, u.r.Read)
+}
+
+// WriteTo implements the io.WriterTo interface, so io.Copy reads the file in whole chunks
+// rather than in its own smaller buffer.
+func (u *uploadReader) WriteTo(w io.Writer) (written int64, err error) {
+	chunk := make([]byte, u.opts.ChunkSize)
+
+	// the chunks are filled up, as the readers may return fewer bytes at once, such as the network ones.
+	fill := func(p []byte) (int, error) { return io.ReadFull(u.r, p) }
+
+	for {
+		n, rerr := u.read(chunk, fill)
+		if n > 0 {
+			m, werr := w.Write(chunk[:n])
+			written += int64(m)
+			if werr != nil {
+				return written, werr
+			}
+		}
+
+		if rerr == io.EOF || rerr == io.ErrUnexpectedEOF {
+			return written, nil
+		} else if rerr != nil {
+			return written, rerr
+		}
+	}
+}
+
+// read reads a chunk unless the context is done, and reports the progress.
+func (u *uploadReader) read(p []byte, read func(p []byte) (int, error)) (int, error) {
+	if err := u.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	n, err := read(p)
+	if n > 0 {
+		u.progress.Sent += int64(n)
+		if u.opts.Progress != nil {
+			u.opts.Progress(u.progress)
+		}
+	}
+	return n, err
+}
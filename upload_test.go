@@ -0,0 +1,50 @@
+package tgo_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestUploadFile(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	bot := server.Bot(tgo.Options{})
+	content := bytes.Repeat([]byte("x"), 1<<20)
+
+	var chunks int
+	var last tgo.UploadProgress
+	_, err := bot.UploadFile(context.Background(), &tgo.SendDocument{ChatId: tgo.ID(1), Document: tgo.FileFromReader("big.bin", bytes.NewReader(content))}, tgo.UploadOptions{
+		ChunkSize: 64 << 10,
+		Progress:  func(p tgo.UploadProgress) { chunks, last = chunks+1, p },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if chunks != 16 {
+		t.Errorf("expected 16 chunks, got %d", chunks)
+	} else if last.Sent != last.Total || last.Total != int64(len(content)) || last.Field != "document" {
+		t.Errorf("unexpected progress: %+v", last)
+	}
+
+	_, err = bot.UploadFile(context.Background(), &tgo.SendDocument{ChatId: tgo.ID(1), Document: tgo.FileFromReader("big.bin", bytes.NewReader(content))}, tgo.UploadOptions{MaxSize: 1 << 10})
+
+	var tooLarge *tgo.FileTooLargeError
+	if !errors.As(err, &tooLarge) || tooLarge.Size != int64(len(content)) {
+		t.Errorf("expected a FileTooLargeError, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = bot.UploadFile(ctx, &tgo.SendDocument{ChatId: tgo.ID(1), Document: tgo.FileFromReader("big.bin", bytes.NewReader(content))}, tgo.UploadOptions{})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the upload to be cancelled, got %v", err)
+	}
+}
@@ -0,0 +1,166 @@
+package tgo
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WatchdogOptions configures the health checks of a Watchdog. The zero thresholds disable their checks,
+// except for getMe failing, which is always alerted.
+type WatchdogOptions struct {
+	// Interval is the duration between the checks; it defaults to 1 minute.
+	Interval time.Duration
+
+	// MaxGetMeLatency is the maximum time which a getMe call may take.
+	MaxGetMeLatency time.Duration
+
+	// MaxUpdateAge is the maximum time since the last update passed to bot.HandleUpdate.
+	MaxUpdateAge time.Duration
+
+	// MaxQueueDepth is the maximum number of the updates waiting in the dispatchers' queues.
+	MaxQueueDepth int
+
+	// Cooldown is the minimum duration between two alerts of the same check; it defaults to 15 minutes.
+	Cooldown time.Duration
+
+	// Notify, if not nil, is used to send the alerts instead of bot.NotifyOwner.
+	Notify func(text string) error
+}
+
+// Watchdog periodically checks the bot's end-to-end health, and alerts the owners when it's degraded.
+type Watchdog struct {
+	bot  *Bot
+	opts WatchdogOptions
+
+	mut       sync.Mutex
+	lastAlert map[string]time.Time
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+// NewWatchdog returns a Watchdog of the bot; call its Start method to start checking.
+func NewWatchdog(bot *Bot, opts WatchdogOptions) *Watchdog {
+	if opts.Interval <= 0 {
+		opts.Interval = time.Minute
+	}
+	if opts.Cooldown <= 0 {
+		opts.Cooldown = 15 * time.Minute
+	}
+	if opts.Notify == nil {
+		opts.Notify = bot.NotifyOwner
+	}
+
+	return &Watchdog{bot: bot, opts: opts, lastAlert: make(map[string]time.Time)}
+}
+
+// Start runs the checks every interval in a new goroutine, until Stop is called.
+// Calling it on a started watchdog does nothing.
+func (w *Watchdog) Start() {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+
+	if w.stop != nil {
+		return
+	}
+	w.stop, w.done = make(chan struct{}), make(chan struct{})
+
+	go w.run(w.stop, w.done)
+}
+
+// Stop stops the checks, and waits for the running one to finish.
+func (w *Watchdog) Stop() {
+	w.mut.Lock()
+	stop, done := w.stop, w.done
+	w.stop, w.done = nil, nil
+	w.mut.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+func (w *Watchdog) run(stop, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			w.Check()
+		}
+	}
+}
+
+// Check runs the checks once, alerts the breached ones which are not in their cool-down,
+// and returns the problems found, whether they're alerted or not.
+func (w *Watchdog) Check() []string {
+	var kinds, problems []string
+	report := func(kind, format string, args ...any) {
+		kinds = append(kinds, kind)
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	start := time.Now()
+	if _, err := w.bot.GetMe(); err != nil {
+		report("getme", "getMe failed: %v", err)
+	} else if took := time.Since(start); w.opts.MaxGetMeLatency > 0 && took > w.opts.MaxGetMeLatency {
+		report("getme", "getMe took %s", took.Round(time.Millisecond))
+	}
+
+	if w.opts.MaxUpdateAge > 0 {
+		if last := w.bot.LastUpdateTime(); !last.IsZero() && time.Since(last) > w.opts.MaxUpdateAge {
+			report("updates", "no updates since %s", time.Since(last).Round(time.Second))
+		}
+	}
+
+	if depth := w.bot.DispatchQueueLen(); w.opts.MaxQueueDepth > 0 && depth > w.opts.MaxQueueDepth {
+		report("queue", "%d updates are waiting in the dispatchers' queues", depth)
+	}
+
+	var alerts []string
+	now := time.Now()
+
+	w.mut.Lock()
+	for i, kind := range kinds {
+		if last, ok := w.lastAlert[kind]; ok && now.Sub(last) < w.opts.Cooldown {
+			continue
+		}
+		w.lastAlert[kind] = now
+		alerts = append(alerts, problems[i])
+	}
+	w.mut.Unlock()
+
+	if len(alerts) != 0 {
+		w.opts.Notify("⚠️ Watchdog:\n" + strings.Join(alerts, "\n"))
+	}
+
+	return problems
+}
+
+// NotifyOwner sends the text to all of the bot's owners, and returns the first error, if any.
+func (bot *Bot) NotifyOwner(text string) error {
+	var firstErr error
+
+	for _, owner := range bot.owners {
+		if _, err := bot.SendMessage(&SendMessage{ChatId: ID(owner), Text: text}); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// LastUpdateTime returns the time of the last update passed to bot.HandleUpdate, or the zero time if there's none.
+func (bot *Bot) LastUpdateTime() time.Time {
+	if nano := bot.lastUpdate.Load(); nano != 0 {
+		return time.Unix(0, nano)
+	}
+	return time.Time{}
+}
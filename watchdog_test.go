@@ -0,0 +1,38 @@
+package tgo_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestWatchdog(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	bot := server.Bot(tgo.Options{Owners: []int64{1, 2}})
+	bot.HandleUpdate(&tgo.Update{UpdateId: 1})
+
+	watchdog := tgo.NewWatchdog(bot, tgo.WatchdogOptions{MaxUpdateAge: time.Nanosecond, Cooldown: time.Hour})
+
+	if problems := watchdog.Check(); len(problems) != 1 || !strings.HasPrefix(problems[0], "no updates since") {
+		t.Fatalf("unexpected problems: %q", problems)
+	}
+	if problems := watchdog.Check(); len(problems) != 1 {
+		t.Fatalf("unexpected problems: %q", problems)
+	}
+
+	// both owners are alerted once; the second check is in the cool-down.
+	var alerts int
+	for _, call := range server.Calls() {
+		if call.Method == "sendMessage" {
+			alerts++
+		}
+	}
+	if alerts != 2 {
+		t.Errorf("got %d alerts, want 2", alerts)
+	}
+}
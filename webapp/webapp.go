@@ -0,0 +1,169 @@
+// Package webapp validates and parses the data which telegram passes to the Web Apps (Mini Apps).
+//
+// see https://core.telegram.org/bots/webapps#validating-data-received-via-the-mini-app
+package webapp
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/haashemi/tgo"
+)
+
+var (
+	ErrMissingHash = errors.New("webapp: init data has no hash")
+	ErrInvalidHash = errors.New("webapp: init data hash is invalid")
+	ErrExpired     = errors.New("webapp: init data is expired")
+)
+
+// User contains the data of a Web App user.
+type User struct {
+	Id                    int64  `json:"id"`
+	IsBot                 bool   `json:"is_bot,omitempty"`
+	FirstName             string `json:"first_name"`
+	LastName              string `json:"last_name,omitempty"`
+	Username              string `json:"username,omitempty"`
+	LanguageCode          string `json:"language_code,omitempty"`
+	IsPremium             bool   `json:"is_premium,omitempty"`
+	AddedToAttachmentMenu bool   `json:"added_to_attachment_menu,omitempty"`
+	AllowsWriteToPm       bool   `json:"allows_write_to_pm,omitempty"`
+	PhotoUrl              string `json:"photo_url,omitempty"`
+}
+
+// Chat contains the data of the chat which the Web App is opened in, from the attachment menu.
+type Chat struct {
+	Id       int64  `json:"id"`
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Username string `json:"username,omitempty"`
+	PhotoUrl string `json:"photo_url,omitempty"`
+}
+
+// InitData is the parsed data which is passed to the Web App by telegram.
+type InitData struct {
+	QueryId      string
+	User         *User
+	Receiver     *User
+	Chat         *Chat
+	ChatType     string
+	ChatInstance string
+	StartParam   string
+	CanSendAfter time.Duration
+	AuthDate     time.Time
+	Hash         string
+}
+
+// Validate checks the init data's signature with the bot token and parses it.
+// It also returns ErrExpired if the data is older than maxAge, if maxAge is not zero.
+func Validate(initData, botToken string, maxAge time.Duration) (*InitData, error) {
+	values, err := url.ParseQuery(initData)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := values.Get("hash")
+	if hash == "" {
+		return nil, ErrMissingHash
+	}
+
+	secretKey := hmacSHA256([]byte("WebAppData"), []byte(botToken))
+	expected := hex.EncodeToString(hmacSHA256(secretKey, []byte(dataCheckString(values))))
+	if !hmac.Equal([]byte(expected), []byte(hash)) {
+		return nil, ErrInvalidHash
+	}
+
+	data, err := parse(values)
+	if err != nil {
+		return nil, err
+	}
+
+	if maxAge != 0 && time.Since(data.AuthDate) > maxAge {
+		return nil, ErrExpired
+	}
+
+	return data, nil
+}
+
+// Parse parses the init data without validating it.
+// Never trust its result unless you've validated it in some other way.
+func Parse(initData string) (*InitData, error) {
+	values, err := url.ParseQuery(initData)
+	if err != nil {
+		return nil, err
+	}
+
+	return parse(values)
+}
+
+// Answer sends the result of a Web App query in the name of the user who opened the Web App.
+func Answer(api *tgo.API, data *InitData, result tgo.InlineQueryResult) (*tgo.SentWebAppMessage, error) {
+	return api.AnswerWebAppQuery(&tgo.AnswerWebAppQuery{WebAppQueryId: data.QueryId, Result: result})
+}
+
+func parse(values url.Values) (*InitData, error) {
+	data := &InitData{
+		QueryId:      values.Get("query_id"),
+		ChatType:     values.Get("chat_type"),
+		ChatInstance: values.Get("chat_instance"),
+		StartParam:   values.Get("start_param"),
+		Hash:         values.Get("hash"),
+	}
+
+	for key, target := range map[string]any{"user": &data.User, "receiver": &data.Receiver, "chat": &data.Chat} {
+		if raw := values.Get(key); raw != "" {
+			if err := json.Unmarshal([]byte(raw), target); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	authDate, err := parseInt(values.Get("auth_date"))
+	if err != nil {
+		return nil, err
+	}
+	data.AuthDate = time.Unix(authDate, 0)
+
+	canSendAfter, err := parseInt(values.Get("can_send_after"))
+	if err != nil {
+		return nil, err
+	}
+	data.CanSendAfter = time.Duration(canSendAfter) * time.Second
+
+	return data, nil
+}
+
+// dataCheckString returns all of the received fields except the hash, sorted alphabetically
+// in the format of key=<value>, with a line feed character as separator.
+func dataCheckString(values url.Values) string {
+	pairs := make([]string, 0, len(values))
+	for key := range values {
+		if key != "hash" {
+			pairs = append(pairs, key+"="+values.Get(key))
+		}
+	}
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, "\n")
+}
+
+// parseInt parses the raw value as an integer, which may be empty for the optional fields.
+func parseInt(raw string) (int64, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(raw, 10, 64)
+}
+
+func hmacSHA256(key, data []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(data)
+	return h.Sum(nil)
+}
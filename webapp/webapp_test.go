@@ -0,0 +1,36 @@
+package webapp
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+const (
+	testToken    = "123456:TEST"
+	testInitData = "query_id=AAHdF6IQAAAAAN0XohDhrOrc&user=%7B%22id%22%3A279058397%2C%22first_name%22%3A%22Vladislav%22%2C%22username%22%3A%22vdkfrost%22%2C%22language_code%22%3A%22ru%22%2C%22is_premium%22%3Atrue%7D&auth_date=1662771648&hash=32082613e63cf253a10e56b2e6bff01102682bded7c45ded19779b5196a28cba"
+)
+
+func TestValidate(t *testing.T) {
+	data, err := Validate(testInitData, testToken, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if data.User == nil || data.User.Id != 279058397 || !data.User.IsPremium {
+		t.Errorf("unexpected user: %+v", data.User)
+	}
+	if data.QueryId != "AAHdF6IQAAAAAN0XohDhrOrc" || data.AuthDate.Unix() != 1662771648 {
+		t.Errorf("unexpected data: %+v", data)
+	}
+
+	if _, err = Validate(testInitData, "654321:TEST", 0); !errors.Is(err, ErrInvalidHash) {
+		t.Errorf("validated with the wrong token: %v", err)
+	}
+	if _, err = Validate(testInitData, testToken, time.Hour); !errors.Is(err, ErrExpired) {
+		t.Errorf("validated the expired data: %v", err)
+	}
+	if _, err = Validate("auth_date=1662771648", testToken, 0); !errors.Is(err, ErrMissingHash) {
+		t.Errorf("validated the data without hash: %v", err)
+	}
+}
@@ -0,0 +1,244 @@
+package tgo
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const (
+	// DefaultWebhookMaxBodySize is the default maximum size of a webhook request's body, in bytes.
+	DefaultWebhookMaxBodySize int64 = 1 << 20
+
+	// DefaultWebhookMaxJSONDepth is the default maximum nesting depth of a webhook request's body.
+	DefaultWebhookMaxJSONDepth = 64
+)
+
+// TelegramNetworks are the IP ranges which telegram sends the webhook requests from.
+//
+// see https://core.telegram.org/bots/webhooks#the-short-version
+var TelegramNetworks = []string{"149.154.160.0/20", "91.108.4.0/22"}
+
+// IPFilter reports whether an IP address is in one of its allowed networks.
+// Its networks can be refreshed at any time using Update, even while it's in use.
+type IPFilter struct {
+	mut      sync.RWMutex
+	networks []*net.IPNet
+}
+
+// NewIPFilter returns a new IPFilter which allows the passed CIDRs.
+// It allows the TelegramNetworks if no CIDRs are passed.
+func NewIPFilter(cidrs ...string) (*IPFilter, error) {
+	filter := &IPFilter{}
+	if err := filter.Update(cidrs...); err != nil {
+		return nil, err
+	}
+
+	return filter, nil
+}
+
+// Update replaces the allowed networks with the passed CIDRs.
+// It falls back to the TelegramNetworks if no CIDRs are passed.
+func (f *IPFilter) Update(cidrs ...string) error {
+	if len(cidrs) == 0 {
+		cidrs = TelegramNetworks
+	}
+
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			return err
+		}
+		networks = append(networks, network)
+	}
+
+	f.mut.Lock()
+	f.networks = networks
+	f.mut.Unlock()
+
+	return nil
+}
+
+// Allowed returns true if the ip is in one of the allowed networks.
+func (f *IPFilter) Allowed(ip net.IP) bool {
+	f.mut.RLock()
+	defer f.mut.RUnlock()
+
+	for _, network := range f.networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WebhookOptions are the guards applied by the webhook handler on every request.
+type WebhookOptions struct {
+	// SecretToken, if not empty, must match the X-Telegram-Bot-Api-Secret-Token header
+	// of the requests. It should be the same secret_token you've passed to SetWebhook.
+	SecretToken string
+
+	// MaxBodySize is the maximum allowed size of the request's body in bytes.
+	// Defaults to DefaultWebhookMaxBodySize, and a negative value disables the limit.
+	MaxBodySize int64
+
+	// MaxJSONDepth is the maximum allowed nesting depth of the request's body.
+	// Defaults to DefaultWebhookMaxJSONDepth, and a negative value disables the limit.
+	MaxJSONDepth int
+
+	// IPFilter, if not nil, rejects the requests coming from its disallowed networks.
+	IPFilter *IPFilter
+
+	// ForwardedForHeader is the header which your reverse proxy puts the client's IP in,
+	// such as "X-Forwarded-For". The last address of the header is used, if it's set.
+	ForwardedForHeader string
+
+	// Queue, if not nil, receives the raw updates instead of bot.HandleUpdate, to be handled
+	// by the workers consuming it with bot.ConsumeQueue. The updates which can't be pushed
+	// are answered with 503 Service Unavailable, so telegram sends them again later.
+	Queue Queue
+}
+
+type webhookHandler struct {
+	bot  *Bot
+	opts WebhookOptions
+}
+
+// WebhookHandler returns a http.Handler which receives the updates sent by telegram
+// and passes them to bot.HandleUpdate, after they've passed the guards in opts.
+func (bot *Bot) WebhookHandler(opts WebhookOptions) http.Handler {
+	if opts.MaxBodySize == 0 {
+		opts.MaxBodySize = DefaultWebhookMaxBodySize
+	}
+	if opts.MaxJSONDepth == 0 {
+		opts.MaxJSONDepth = DefaultWebhookMaxJSONDepth
+	}
+
+	return &webhookHandler{bot: bot, opts: opts}
+}
+
+func (h *webhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if h.opts.IPFilter != nil {
+		ip := net.ParseIP(h.remoteIP(r))
+		if ip == nil || !h.opts.IPFilter.Allowed(ip) {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+	}
+
+	if h.opts.SecretToken != "" {
+		token := r.Header.Get("X-Telegram-Bot-Api-Secret-Token")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(h.opts.SecretToken)) != 1 {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+	}
+
+	body := io.Reader(r.Body)
+	if h.opts.MaxBodySize > 0 {
+		body = http.MaxBytesReader(w, r.Body, h.opts.MaxBodySize)
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	if h.opts.MaxJSONDepth > 0 && jsonDepth(data) > h.opts.MaxJSONDepth {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	update := &Update{}
+	if err = json.Unmarshal(data, update); err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	// telegram sends the update again later if it's not accepted while shutting down.
+	if !h.bot.inflight.enter() {
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+
+	if h.opts.Queue != nil {
+		err = h.opts.Queue.Push(r.Context(), data)
+		h.bot.inflight.done()
+
+		if err != nil {
+			h.bot.log(LevelError, "failed to queue the update", "update", update.UpdateId, "error", err)
+			http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		}
+		return
+	}
+
+	// telegram only waits for the response, so we shouldn't make it wait for our handlers.
+	h.bot.spawn(func() {
+		defer h.bot.inflight.done()
+		h.bot.HandleUpdate(update)
+	})
+}
+
+// remoteIP returns the request's client IP address, without its port.
+func (h *webhookHandler) remoteIP(r *http.Request) string {
+	if h.opts.ForwardedForHeader != "" {
+		if header := r.Header.Get(h.opts.ForwardedForHeader); header != "" {
+			addresses := strings.Split(header, ",")
+			return strings.TrimSpace(addresses[len(addresses)-1])
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// jsonDepth returns the maximum nesting depth of objects and arrays in data.
+// It doesn't validate the data, json.Unmarshal will do it.
+func jsonDepth(data []byte) (maxDepth int) {
+	var depth int
+	var inString, escaped bool
+
+	for _, c := range data {
+		switch {
+		case escaped:
+			escaped = false
+		case inString && c == '\\':
+			escaped = true
+		case c == '"':
+			inString = !inString
+		case inString:
+			// characters inside strings doesn't matter.
+		case c == '{', c == '[':
+			depth++
+			if depth > maxDepth {
+				maxDepth = depth
+			}
+		case c == '}', c == ']':
+			depth--
+		}
+	}
+
+	return maxDepth
+}
@@ -0,0 +1,173 @@
+package tgo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// DefaultWebhookMuxPrefix is the path prefix which the WebhookMux serves the bots under by default.
+const DefaultWebhookMuxPrefix = "/bot/"
+
+// TokenHash returns a hash of the bot token which is safe to be used in the webhook URLs,
+// as the token itself shouldn't be exposed in the access logs of the proxies.
+func TokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:16])
+}
+
+// WebhookMuxOptions configures a WebhookMux. The zero value is valid and uses the defaults.
+type WebhookMuxOptions struct {
+	// Prefix is the path prefix which the bots are served under; it defaults to DefaultWebhookMuxPrefix.
+	Prefix string
+
+	// ByBotID serves the bots under their ids, the part of their tokens before the colon, instead of
+	// their token hashes. The ids are public, so set a SecretToken in the bots' WebhookOptions.
+	ByBotID bool
+
+	// Resolve, if not nil, is called for the requests of the bots which are not added, to add them on
+	// their first update, such as loading them from a database on a platform of many bots. The key is
+	// the bot's id or token hash. It returns a nil bot if there's none, which is answered by 404;
+	// its errors are answered by 503, so telegram sends the update again later.
+	//
+	// The concurrent requests of the same key share a single Resolve call.
+	Resolve func(key string) (bot *Bot, opts WebhookOptions, err error)
+}
+
+// WebhookMux is a http.Handler which serves the webhooks of many bots behind one HTTP server,
+// routing the requests of <prefix><token-hash> (or <prefix><bot-id>) to the bot of that key.
+// The bots can be added and removed at runtime.
+type WebhookMux struct {
+	opts WebhookMuxOptions
+
+	mut       sync.RWMutex
+	handlers  map[string]http.Handler
+	resolving map[string]*resolveCall
+}
+
+// resolveCall is a Resolve call which is being made, until done is closed.
+type resolveCall struct {
+	done    chan struct{}
+	handler http.Handler
+	err     error
+}
+
+// NewWebhookMux returns an empty WebhookMux serving the bots under the prefix, or DefaultWebhookMuxPrefix if it's empty.
+func NewWebhookMux(prefix string) *WebhookMux {
+	return NewWebhookMuxWithOptions(WebhookMuxOptions{Prefix: prefix})
+}
+
+// NewWebhookMuxWithOptions returns an empty WebhookMux with the options.
+func NewWebhookMuxWithOptions(opts WebhookMuxOptions) *WebhookMux {
+	if opts.Prefix == "" {
+		opts.Prefix = DefaultWebhookMuxPrefix
+	}
+	if !strings.HasSuffix(opts.Prefix, "/") {
+		opts.Prefix += "/"
+	}
+
+	return &WebhookMux{opts: opts, handlers: make(map[string]http.Handler), resolving: make(map[string]*resolveCall)}
+}
+
+// key returns the key which the bot is served under.
+func (m *WebhookMux) key(bot *Bot) string {
+	if m.opts.ByBotID {
+		id, _, _ := strings.Cut(bot.token, ":")
+		return id
+	}
+	return TokenHash(bot.token)
+}
+
+// Add starts serving the bot's webhook with its own guards, replacing the existing one with the same token.
+// It returns the path which the bot is served on, to be appended to the public URL passed to SetWebhook.
+func (m *WebhookMux) Add(bot *Bot, opts WebhookOptions) (path string) {
+	key := m.key(bot)
+
+	m.mut.Lock()
+	m.handlers[key] = bot.WebhookHandler(opts)
+	m.mut.Unlock()
+
+	return m.opts.Prefix + key
+}
+
+// Remove stops serving the bot's webhook. The requests of removed bots are answered with 404,
+// unless there's a Resolve to add them again.
+func (m *WebhookMux) Remove(bot *Bot) {
+	m.mut.Lock()
+	delete(m.handlers, m.key(bot))
+	m.mut.Unlock()
+}
+
+// Path returns the path which the bot is (or will be) served on.
+func (m *WebhookMux) Path(bot *Bot) string { return m.opts.Prefix + m.key(bot) }
+
+// Len returns the number of the bots being served.
+func (m *WebhookMux) Len() int {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	return len(m.handlers)
+}
+
+// ServeHTTP implements http.Handler.
+func (m *WebhookMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, m.opts.Prefix)
+	if !strings.HasPrefix(r.URL.Path, m.opts.Prefix) || key == "" || strings.Contains(key, "/") {
+		http.NotFound(w, r)
+		return
+	}
+
+	m.mut.RLock()
+	handler, ok := m.handlers[key]
+	m.mut.RUnlock()
+
+	if !ok && m.opts.Resolve != nil {
+		var err error
+		if handler, err = m.resolve(key); err != nil {
+			http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+			return
+		}
+	}
+
+	if handler == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// resolve adds the bot of the key by the Resolve option, and returns its handler, or nil if there's none.
+func (m *WebhookMux) resolve(key string) (http.Handler, error) {
+	m.mut.Lock()
+	if handler, ok := m.handlers[key]; ok {
+		m.mut.Unlock()
+		return handler, nil
+	} else if call, ok := m.resolving[key]; ok {
+		m.mut.Unlock()
+		<-call.done
+		return call.handler, call.err
+	}
+
+	call := &resolveCall{done: make(chan struct{})}
+	m.resolving[key] = call
+	m.mut.Unlock()
+
+	bot, opts, err := m.opts.Resolve(key)
+	if err == nil && bot != nil {
+		call.handler = bot.WebhookHandler(opts)
+	}
+	call.err = err
+
+	m.mut.Lock()
+	delete(m.resolving, key)
+	if call.handler != nil {
+		m.handlers[key] = call.handler
+	}
+	m.mut.Unlock()
+
+	close(call.done)
+	return call.handler, call.err
+}
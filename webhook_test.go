@@ -0,0 +1,171 @@
+package tgo
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestJSONDepth(t *testing.T) {
+	tests := map[string]int{
+		`1`:                        0,
+		`{}`:                       1,
+		`{"a":[1,{"b":2}]}`:        3,
+		`{"a":"{[{[{["}`:           1,
+		`{"a":"\"{[","b":[[]]}`:    3,
+		`[[[[[]]]]],{"x":{"y":1}}`: 5,
+	}
+
+	for data, want := range tests {
+		if got := jsonDepth([]byte(data)); got != want {
+			t.Errorf("jsonDepth(%s) = %d, want %d", data, got, want)
+		}
+	}
+}
+
+func TestIPFilter(t *testing.T) {
+	filter, err := NewIPFilter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !filter.Allowed(net.ParseIP("149.154.167.220")) {
+		t.Error("telegram's address is not allowed")
+	}
+	if filter.Allowed(net.ParseIP("8.8.8.8")) {
+		t.Error("non-telegram address is allowed")
+	}
+
+	if err = filter.Update("8.8.8.0/24"); err != nil {
+		t.Fatal(err)
+	}
+	if !filter.Allowed(net.ParseIP("8.8.8.8")) {
+		t.Error("refreshed network is not allowed")
+	}
+}
+
+func TestWebhookHandlerGuards(t *testing.T) {
+	bot := NewBot("", Options{})
+	filter, _ := NewIPFilter()
+
+	handler := bot.WebhookHandler(WebhookOptions{
+		SecretToken:  "secret",
+		MaxBodySize:  64,
+		MaxJSONDepth: 3,
+		IPFilter:     filter,
+	})
+
+	tests := []struct {
+		name, remoteAddr, secret, body string
+		want                           int
+	}{
+		{"ok", "149.154.167.220:443", "secret", `{"update_id":1}`, http.StatusOK},
+		{"bad ip", "8.8.8.8:443", "secret", `{"update_id":1}`, http.StatusForbidden},
+		{"bad secret", "149.154.167.220:443", "wrong", `{"update_id":1}`, http.StatusUnauthorized},
+		{"too large", "149.154.167.220:443", "secret", `{"update_id":1,"x":"` + strings.Repeat("a", 64) + `"}`, http.StatusRequestEntityTooLarge},
+		{"too deep", "149.154.167.220:443", "secret", `{"a":{"b":{"c":{}}}}`, http.StatusBadRequest},
+		{"invalid", "149.154.167.220:443", "secret", `{"update_id":`, http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(test.body))
+		r.RemoteAddr = test.remoteAddr
+		r.Header.Set("X-Telegram-Bot-Api-Secret-Token", test.secret)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		if w.Code != test.want {
+			t.Errorf("%s: got status %d, want %d", test.name, w.Code, test.want)
+		}
+	}
+}
+
+func TestWebhookMux(t *testing.T) {
+	mux := NewWebhookMux("")
+	first, second := NewBot("1:A", Options{}), NewBot("2:B", Options{})
+
+	firstPath := mux.Add(first, WebhookOptions{SecretToken: "first"})
+	secondPath := mux.Add(second, WebhookOptions{SecretToken: "second"})
+	if firstPath == secondPath || strings.Contains(firstPath, "1:A") {
+		t.Fatalf("unexpected paths: %q, %q", firstPath, secondPath)
+	}
+
+	tests := []struct {
+		name, path, secret string
+		want               int
+	}{
+		{"first", firstPath, "first", http.StatusOK},
+		{"second", secondPath, "second", http.StatusOK},
+		{"secret of another bot", secondPath, "first", http.StatusUnauthorized},
+		{"unknown bot", "/bot/unknown", "first", http.StatusNotFound},
+		{"nested path", firstPath + "/x", "first", http.StatusNotFound},
+	}
+
+	serve := func(path, secret string) int {
+		r := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"update_id":1}`))
+		r.Header.Set("X-Telegram-Bot-Api-Secret-Token", secret)
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	for _, test := range tests {
+		if got := serve(test.path, test.secret); got != test.want {
+			t.Errorf("%s: got status %d, want %d", test.name, got, test.want)
+		}
+	}
+
+	mux.Remove(first)
+	if got := serve(firstPath, "first"); got != http.StatusNotFound || mux.Len() != 1 {
+		t.Errorf("removed bot is still served: status %d, %d bots", got, mux.Len())
+	}
+}
+
+func TestWebhookMuxResolve(t *testing.T) {
+	bots := map[string]*Bot{"7": NewBot("7:G", Options{})}
+
+	var resolved atomic.Int64
+	mux := NewWebhookMuxWithOptions(WebhookMuxOptions{
+		ByBotID: true,
+		Resolve: func(key string) (*Bot, WebhookOptions, error) {
+			resolved.Add(1)
+			if key == "8" {
+				return nil, WebhookOptions{}, errors.New("database is down")
+			}
+			return bots[key], WebhookOptions{SecretToken: "secret"}, nil
+		},
+	})
+
+	serve := func(path string) int {
+		r := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"update_id":1}`))
+		r.Header.Set("X-Telegram-Bot-Api-Secret-Token", "secret")
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	if path := mux.Path(bots["7"]); path != "/bot/7" {
+		t.Fatalf("unexpected path %q", path)
+	}
+
+	for _, test := range []struct {
+		path string
+		want int
+	}{{"/bot/7", http.StatusOK}, {"/bot/7", http.StatusOK}, {"/bot/8", http.StatusServiceUnavailable}, {"/bot/9", http.StatusNotFound}} {
+		if got := serve(test.path); got != test.want {
+			t.Errorf("%s: got status %d, want %d", test.path, got, test.want)
+		}
+	}
+
+	// the resolved bot is kept, so it's resolved once; the missing ones are tried again.
+	if n := resolved.Load(); n != 3 || mux.Len() != 1 {
+		t.Errorf("got %d resolves and %d bots, want 3 and 1", n, mux.Len())
+	}
+}
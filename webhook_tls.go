@@ -0,0 +1,162 @@
+package tgo
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// WebhookTLSOptions configures bot.StartWebhookTLS.
+type WebhookTLSOptions struct {
+	// WebhookOptions are the guards of the webhook handler. Its SecretToken is passed to setWebhook too.
+	WebhookOptions
+
+	// URL is the public HTTPS URL of the webhook, such as "https://203.0.113.7:8443/bot". Telegram
+	// only sends the webhooks to the ports 443, 80, 88, and 8443. The updates are served on its path.
+	URL string
+
+	// Addr is the address to listen on; it defaults to the port of the URL on all interfaces.
+	Addr string
+
+	// CertFile and KeyFile are the PEM files of the certificate and its key. If they're empty,
+	// a self-signed certificate is generated for the host of the URL on every start.
+	CertFile, KeyFile string
+
+	// SelfSigned reports that the certificate of the CertFile is self-signed, so it's uploaded to telegram.
+	// The generated ones are always uploaded.
+	SelfSigned bool
+
+	// AllowedUpdates, MaxConnections, and DropPendingUpdates are passed to setWebhook.
+	AllowedUpdates     []string
+	MaxConnections     int64
+	DropPendingUpdates bool
+}
+
+// StartWebhookTLS serves the webhook over HTTPS by itself, for the deployments without a reverse proxy.
+// It sets the bot's webhook to the URL, uploading the certificate if it's self-signed, and serves
+// the updates until bot.Shutdown is called; then it returns nil.
+func (bot *Bot) StartWebhookTLS(opts WebhookTLSOptions) error {
+	webhookURL, err := url.Parse(opts.URL)
+	if err != nil {
+		return err
+	} else if webhookURL.Scheme != "https" {
+		return errors.New("tgo: webhook url must be https")
+	}
+
+	if opts.Addr == "" {
+		port := webhookURL.Port()
+		if port == "" {
+			port = "443"
+		}
+		opts.Addr = ":" + port
+	}
+
+	var certPEM, keyPEM []byte
+	if opts.CertFile == "" && opts.KeyFile == "" {
+		if certPEM, keyPEM, err = GenerateSelfSignedCert(webhookURL.Hostname(), 10*365*24*time.Hour); err != nil {
+			return err
+		}
+		opts.SelfSigned = true
+	} else if certPEM, err = os.ReadFile(opts.CertFile); err != nil {
+		return err
+	} else if keyPEM, err = os.ReadFile(opts.KeyFile); err != nil {
+		return err
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return err
+	}
+
+	// it listens before setting the webhook, so telegram isn't pointed to an address which fails to serve.
+	listener, err := net.Listen("tcp", opts.Addr)
+	if err != nil {
+		return err
+	}
+
+	path := webhookURL.Path
+	if path == "" {
+		path = "/"
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(path, bot.WebhookHandler(opts.WebhookOptions))
+
+	server := &http.Server{
+		Handler:           mux,
+		TLSConfig:         &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12},
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	// it's registered before serving, so the server is stopped even if the bot is shut down meanwhile.
+	bot.OnShutdown(func(ctx context.Context) error { return server.Shutdown(ctx) })
+
+	setWebhook := &SetWebhook{
+		Url:                opts.URL,
+		AllowedUpdates:     opts.AllowedUpdates,
+		MaxConnections:     opts.MaxConnections,
+		DropPendingUpdates: opts.DropPendingUpdates,
+		SecretToken:        opts.SecretToken,
+	}
+	if opts.SelfSigned {
+		setWebhook.Certificate = FileFromReader("certificate.pem", bytes.NewReader(certPEM))
+	}
+
+	if _, err = bot.SetWebhook(setWebhook); err != nil {
+		listener.Close()
+		return err
+	}
+
+	if err = server.ServeTLS(listener, "", ""); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// GenerateSelfSignedCert returns a new self-signed certificate for the host, which is an IP address
+// or a domain name, and its RSA key, in PEM. It's valid for the duration from now.
+func GenerateSelfSignedCert(host string, validFor time.Duration) (certPEM, keyPEM []byte, err error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: host},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(validFor),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		template.IPAddresses = []net.IP{ip}
+	} else {
+		template.DNSNames = []string{host}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return certPEM, keyPEM, nil
+}
@@ -0,0 +1,85 @@
+package tgo_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/haashemi/tgo"
+	"github.com/haashemi/tgo/tgotest"
+)
+
+func TestGenerateSelfSignedCert(t *testing.T) {
+	for _, host := range []string{"203.0.113.7", "bot.example.com"} {
+		certPEM, keyPEM, err := tgo.GenerateSelfSignedCert(host, time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		pair, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cert, err := x509.ParseCertificate(pair.Certificate[0])
+		if err != nil {
+			t.Fatal(err)
+		} else if err = cert.VerifyHostname(host); err != nil {
+			t.Errorf("%s: %v", host, err)
+		}
+	}
+}
+
+func TestStartWebhookTLS(t *testing.T) {
+	server := tgotest.NewServer()
+	defer server.Close()
+
+	setWebhook := make(chan tgotest.Call, 1)
+	server.Handle("setWebhook", func(call tgotest.Call) (any, *tgo.Error) {
+		setWebhook <- call
+		return true, nil
+	})
+
+	bot := server.Bot(tgo.Options{})
+
+	serving := make(chan error, 1)
+	go func() {
+		serving <- bot.StartWebhookTLS(tgo.WebhookTLSOptions{
+			WebhookOptions: tgo.WebhookOptions{SecretToken: "secret"},
+			URL:            "https://203.0.113.7:8443/hook",
+			Addr:           "127.0.0.1:0",
+		})
+	}()
+
+	select {
+	case call := <-setWebhook:
+		if call.Params["url"] != "https://203.0.113.7:8443/hook" || call.Params["secret_token"] != "secret" {
+			t.Errorf("unexpected params: %v", call.Params)
+		}
+		if call.Params["certificate"] != "attach://certificate.pem" {
+			t.Errorf("the certificate is not uploaded: %v", call.Params["certificate"])
+		}
+	case err := <-serving:
+		t.Fatal(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the webhook is not set")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := bot.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-serving:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the server is not stopped")
+	}
+}